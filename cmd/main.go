@@ -1,17 +1,37 @@
 package main
 
 import (
+	"context"
 	"database/sql"
+	"flag"
 	_ "github.com/AlecSmith96/dating-api/docs"
 	"github.com/AlecSmith96/dating-api/internal/adapters"
+	"github.com/AlecSmith96/dating-api/internal/adapters/memory"
 	"github.com/AlecSmith96/dating-api/internal/drivers"
+	"github.com/AlecSmith96/dating-api/internal/entities"
+	"github.com/AlecSmith96/dating-api/internal/usecases"
 	_ "github.com/lib/pq"
 	"log/slog"
 	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+	_ "time/tzdata" // the service runs from scratch, which has no timezone database for calculating user ages
 )
 
 const (
-	gooseDir = "./db/goose"
+	gooseDir                   = "./db/goose"
+	eventLogPruneInterval      = 5 * time.Minute
+	desirabilityUpdateInterval = time.Minute
+	desirabilityBatchSize      = 500
+	// recommendationEvaluationK is how many recommendations each user is scored on when evaluating the recommender, and
+	// recommendationHoldout the fraction of each users latest swipes that are held out from it
+	recommendationEvaluationK = 10
+	recommendationHoldout     = 0.2
+	// daily picks are generated soon after each users local midnight
+	dailyPicksInterval  = 15 * time.Minute
+	dailyPicksBatchSize = 100
 )
 
 // @title dating-api
@@ -22,26 +42,264 @@ const (
 // @name Authorization
 
 func main() {
+	recomputeDesirability := flag.Bool("recompute-desirability", false, "recompute every users desirability score by replaying all swipes, then exit")
+	evaluateRecommendations := flag.Bool("evaluate-recommendations", false, "report the recommenders precision@k against each users latest swipes, then exit")
+	flag.Parse()
+
 	conf, err := adapters.NewConfig()
 	if err != nil {
 		slog.Error("reading in config", "err", err)
 		os.Exit(1)
 	}
 
-	db, err := sql.Open("postgres", conf.DatabaseConnectionString)
+	logHandler, err := adapters.NewLogHandler(os.Stderr, conf.LogLevel, conf.LogFormat)
+	if err != nil {
+		slog.Error("creating logger", "err", err)
+		os.Exit(1)
+	}
+	slog.SetDefault(slog.New(logHandler))
+
+	metrics := adapters.NewPrometheusMetrics()
+
+	// db is only opened for postgres storage
+	var db *sql.DB
+	var storage adapters.Storage
+	switch conf.Storage {
+	case adapters.StoragePostgres:
+		if conf.DatabaseConnectionString == "" {
+			slog.Error("DATABASE_CONNECTION_STRING is required for postgres storage")
+			os.Exit(1)
+		}
+
+		db, err = sql.Open("postgres", conf.DatabaseConnectionString)
+		if err != nil {
+			slog.Error("connecting to postgres", "err", err)
+			os.Exit(1)
+		}
+
+		err = metrics.RegisterDB(db, "postgres")
+		if err != nil {
+			slog.Error("registering database metrics", "err", err)
+			os.Exit(1)
+		}
+
+		postgresAdapter := adapters.NewPostgresAdapter(db, conf.JwtExpiryMillis, conf.JwtSecretKey, metrics)
+		err = postgresAdapter.PerformDataMigration(gooseDir)
+		if err != nil {
+			slog.Error("performing data migration", "err", err)
+			os.Exit(1)
+		}
+		storage = postgresAdapter
+	case adapters.StorageMemory:
+		slog.Warn("in-memory storage is enabled, nothing will be kept when the service stops")
+		storage = memory.NewAdapter(conf.JwtExpiryMillis, conf.JwtSecretKey)
+	default:
+		slog.Error("unknown storage", "storage", conf.Storage)
+		os.Exit(1)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	if *recomputeDesirability {
+		replayed, err := usecases.RecomputeDesirability(ctx, storage, desirabilityBatchSize)
+		if err != nil {
+			slog.Error("recomputing desirability", "err", err)
+			os.Exit(1)
+		}
+
+		slog.Info("recomputed desirability", "swipes", replayed)
+		closeDB(db)
+		return
+	}
+
+	if *evaluateRecommendations {
+		swipes, err := storage.GetSwipeHistory(ctx)
+		if err != nil {
+			slog.Error("getting swipe history", "err", err)
+			os.Exit(1)
+		}
+
+		precision, users := usecases.EvaluateRecommendations(swipes, recommendationEvaluationK, recommendationHoldout)
+		slog.Info("evaluated recommendations", "k", recommendationEvaluationK, "holdout", recommendationHoldout, "users", users, "precision", precision)
+		closeDB(db)
+		return
+	}
+
+	// tracing is set up after the offline commands so that they don't write out spans
+	tracerProvider, err := adapters.NewTracerProvider(ctx, conf.OtlpEndpoint, conf.TraceFile)
+	if err != nil {
+		slog.Error("setting up tracing", "err", err)
+		os.Exit(1)
+	}
+
+	// the workers have their own context so that they keep running while in-flight requests are drained
+	workersCtx, stopWorkers := context.WithCancel(context.Background())
+	var workers sync.WaitGroup
+	startWorker := func(worker func(ctx context.Context)) {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			worker(workersCtx)
+		}()
+	}
+
+	eventLogRetention := time.Duration(conf.EventLogRetentionMinutes) * time.Minute
+	startWorker(func(ctx context.Context) {
+		usecases.RunEventLogPruner(ctx, storage, eventLogRetention, eventLogPruneInterval)
+	})
+	startWorker(func(ctx context.Context) {
+		usecases.RunDesirabilityUpdater(ctx, storage, desirabilityBatchSize, desirabilityUpdateInterval)
+	})
+	if conf.RecommendationMixInterval > 0 {
+		recommendationRefreshInterval := time.Duration(conf.RecommendationRefreshMinutes) * time.Minute
+		startWorker(func(ctx context.Context) {
+			usecases.RunRecommender(ctx, storage, conf.RecommendationsPerUser, recommendationRefreshInterval)
+		})
+	}
+
+	swipeRewindWindow := time.Duration(conf.SwipeRewindWindowSeconds) * time.Second
+	var billingProviders []usecases.BillingProvider
+	if conf.LocalBillingWebhookSecret != "" {
+		slog.Warn("local billing provider is enabled")
+		billingProviders = append(billingProviders, adapters.NewLocalBillingProvider(conf.LocalBillingWebhookSecret))
+	}
+
+	boostDuration := time.Duration(conf.BoostDurationMinutes) * time.Minute
+	queryTimeout := time.Duration(conf.QueryTimeoutMillis) * time.Millisecond
+	rankingWeights := entities.RankingWeights{
+		Distance:        conf.RankingWeightDistance,
+		AgeFit:          conf.RankingWeightAgeFit,
+		SharedInterests: conf.RankingWeightSharedInterests,
+		RecentActivity:  conf.RankingWeightRecentActivity,
+		Boost:           conf.RankingWeightBoost,
+		Popularity:      conf.RankingWeightPopularity,
+		Reciprocal:      conf.RankingWeightReciprocal,
+		DesirabilityFit: conf.RankingWeightDesirabilityFit,
+	}
+	ranker, err := usecases.NewRanker(conf.DiscoveryRanker, rankingWeights)
 	if err != nil {
-		slog.Error("connecting to postgres", "err", err)
+		slog.Error("creating discovery ranker", "err", err)
 		os.Exit(1)
 	}
+	if conf.RecommendationMixInterval > 0 {
+		ranker = usecases.NewRecommendationMixer(ranker, conf.RecommendationMixInterval)
+	}
+	slog.Info("discovery ranker", "ranker", ranker.Name(), "weights", ranker.Weights())
 
-	postgresAdapter := adapters.NewPostgresAdapter(db, conf.JwtExpiryMillis, conf.JwtSecretKey)
-	err = postgresAdapter.PerformDataMigration(gooseDir)
+	// daily picks are curated on compatibility alone, so boosts don't count towards them
+	picksWeights := rankingWeights
+	picksWeights.Boost = 0
+	startWorker(func(ctx context.Context) {
+		usecases.RunDailyPicksGenerator(ctx, storage, storage, usecases.NewWeightedLinearRanker(picksWeights), conf.DailyPicksPerUser, dailyPicksBatchSize, dailyPicksInterval)
+	})
+
+	migrationVersion, err := adapters.GetLatestMigrationVersion(gooseDir)
 	if err != nil {
-		slog.Error("performing data migration", "err", err)
+		slog.Error("getting latest migration version", "err", err)
 		os.Exit(1)
 	}
 
-	router := drivers.NewRouter(postgresAdapter, postgresAdapter, postgresAdapter, postgresAdapter, postgresAdapter)
+	drainingCtx, startDraining := context.WithCancel(context.Background())
+	streamsCtx, stopStreams := context.WithCancel(context.Background())
+
+	router := drivers.NewRouter(drivers.RouterDeps{
+		UserCreator:               storage,
+		UserAuthenticator:         storage,
+		JwtProcessor:              storage,
+		UserDiscoverer:            storage,
+		SwipeRegister:             storage,
+		TxManager:                 storage,
+		EventStreamer:             storage,
+		EventRecorder:             storage,
+		UserBlocker:               storage,
+		UserReporter:              storage,
+		RoleChecker:               storage,
+		ModerationQueue:           storage,
+		SwipeRewinder:             storage,
+		SwipeRewindWindow:         swipeRewindWindow,
+		LikesReceivedLister:       storage,
+		Entitlements:              storage,
+		SubscriptionManager:       storage,
+		BillingProviders:          billingProviders,
+		BoostManager:              storage,
+		BoostDuration:             boostDuration,
+		Ranker:                    ranker,
+		DailyPicksLister:          storage,
+		DiscoveryPreferencesSaver: storage,
+		QuestionBank:              storage,
+		QuestionAnswerer:          storage,
+		QueryTimeout:              queryTimeout,
+		Shutdown:                  streamsCtx.Done(),
+		ReadinessChecker:          storage,
+		MigrationVersion:          migrationVersion,
+		Draining:                  drainingCtx.Done(),
+		MetricsRecorder:           metrics,
+		MetricsHandler:            metrics.Handler(),
+	})
+
+	server := drivers.NewServer(conf.ListenAddress, router, drivers.ServerTimeouts{
+		ReadHeader: time.Duration(conf.ReadHeaderTimeoutSeconds) * time.Second,
+		Read:       time.Duration(conf.ReadTimeoutSeconds) * time.Second,
+		Write:      time.Duration(conf.WriteTimeoutSeconds) * time.Second,
+		Idle:       time.Duration(conf.IdleTimeoutSeconds) * time.Second,
+	}, conf.TLSCertFile, conf.TLSKeyFile, stopStreams)
+
+	serverErr := make(chan error, 1)
+	go func() {
+		slog.Info("listening", "address", conf.ListenAddress, "tls", conf.TLSCertFile != "" && conf.TLSKeyFile != "")
+		serverErr <- server.ListenAndServe()
+	}()
+
+	exitCode := 0
+	select {
+	case <-ctx.Done():
+		slog.Info("shutting down")
+	case err = <-serverErr:
+		slog.Error("serving requests", "err", err)
+		exitCode = 1
+	}
+
+	// report that the service isn't ready and give load balancers time to notice before no longer taking requests
+	startDraining()
+	if exitCode == 0 {
+		time.Sleep(time.Duration(conf.ShutdownDelaySeconds) * time.Second)
+	}
+
+	// stop taking requests and let the in-flight ones finish before stopping the workers, the database is closed last
+	// once nothing is using it
+	err = server.Shutdown(time.Duration(conf.ShutdownTimeoutSeconds) * time.Second)
+	if err != nil {
+		slog.Error("draining requests", "err", err)
+	}
+
+	stopWorkers()
+	workers.Wait()
+	slog.Info("stopped background workers")
+
+	closeDB(db)
+
+	// flush the spans of the drained requests
+	err = tracerProvider.Shutdown(context.Background())
+	if err != nil {
+		slog.Error("flushing traces", "err", err)
+	}
+
+	os.Exit(exitCode)
+}
+
+// closeDB is a function that closes the database connection pool once the service has stopped using it, there is no
+// pool to close for in-memory storage
+func closeDB(db *sql.DB) {
+	if db == nil {
+		return
+	}
+
+	err := db.Close()
+	if err != nil {
+		slog.Error("closing database", "err", err)
+		return
+	}
 
-	router.Run(":8080")
+	slog.Info("closed database")
 }
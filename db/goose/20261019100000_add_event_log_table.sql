@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS event_log(
+    id            BIGSERIAL PRIMARY KEY,
+    user_id       uuid      REFERENCES platform_user(id) NOT NULL,
+    actor_user_id uuid      REFERENCES platform_user(id),
+    event_type    TEXT      NOT NULL,
+    payload       JSONB     NOT NULL DEFAULT '{}',
+    created_at    TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS event_log_user_id_id_idx ON event_log(user_id, id);
+CREATE INDEX IF NOT EXISTS event_log_created_at_idx ON event_log(created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE event_log;
+-- +goose StatementEnd
@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS user_block(
+    id              uuid      DEFAULT gen_random_uuid() PRIMARY KEY,
+    blocker_user_id uuid      REFERENCES platform_user(id) NOT NULL,
+    blocked_user_id uuid      REFERENCES platform_user(id) NOT NULL,
+    created_at      TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE (blocker_user_id, blocked_user_id)
+);
+
+CREATE INDEX IF NOT EXISTS user_block_blocked_user_id_idx ON user_block(blocked_user_id);
+
+CREATE TABLE IF NOT EXISTS user_report(
+    id               uuid      DEFAULT gen_random_uuid() PRIMARY KEY,
+    reporter_user_id uuid      REFERENCES platform_user(id) NOT NULL,
+    reported_user_id uuid      REFERENCES platform_user(id) NOT NULL,
+    reason           TEXT      NOT NULL CHECK (reason IN ('spam', 'harassment', 'inappropriate_content', 'fake_profile', 'scam', 'underage', 'other')),
+    details          TEXT      NOT NULL DEFAULT '',
+    status           TEXT      NOT NULL DEFAULT 'open',
+    created_at       TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- the moderation queue reads open reports oldest first
+CREATE INDEX IF NOT EXISTS user_report_status_created_at_idx ON user_report(status, created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE user_report;
+DROP TABLE user_block;
+-- +goose StatementEnd
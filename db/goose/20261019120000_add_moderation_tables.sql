@@ -0,0 +1,79 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS user_role(
+    user_id uuid REFERENCES platform_user(id) PRIMARY KEY,
+    role    TEXT NOT NULL CHECK (role IN ('user', 'moderator', 'admin'))
+);
+
+CREATE TABLE IF NOT EXISTS moderation_case(
+    id                    uuid      DEFAULT gen_random_uuid() PRIMARY KEY,
+    subject_user_id       uuid      REFERENCES platform_user(id) NOT NULL,
+    report_id             uuid      REFERENCES user_report(id),
+    status                TEXT      NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'in_review', 'actioned', 'dismissed')),
+    assigned_moderator_id uuid      REFERENCES platform_user(id),
+    created_at            TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at            TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS moderation_case_status_created_at_idx ON moderation_case(status, created_at);
+
+-- every existing report becomes an open case, the case now owns the status of the report
+INSERT INTO moderation_case (subject_user_id, report_id, status, created_at, updated_at)
+SELECT reported_user_id, id, status, created_at, created_at FROM user_report;
+
+ALTER TABLE user_report DROP COLUMN status;
+
+CREATE TABLE IF NOT EXISTS moderation_audit(
+    id            uuid      DEFAULT gen_random_uuid() PRIMARY KEY,
+    case_id       uuid      REFERENCES moderation_case(id) NOT NULL,
+    actor_user_id uuid      REFERENCES platform_user(id) NOT NULL,
+    action        TEXT      NOT NULL,
+    details       JSONB     NOT NULL DEFAULT '{}',
+    created_at    TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS moderation_audit_case_id_idx ON moderation_audit(case_id, created_at);
+
+CREATE OR REPLACE FUNCTION prevent_moderation_audit_change() RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'moderation_audit is append only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER moderation_audit_no_update_or_delete
+    BEFORE UPDATE OR DELETE ON moderation_audit
+    FOR EACH ROW EXECUTE FUNCTION prevent_moderation_audit_change();
+
+CREATE TRIGGER moderation_audit_no_truncate
+    BEFORE TRUNCATE ON moderation_audit
+    FOR EACH STATEMENT EXECUTE FUNCTION prevent_moderation_audit_change();
+
+CREATE TABLE IF NOT EXISTS user_sanction(
+    id            uuid      DEFAULT gen_random_uuid() PRIMARY KEY,
+    user_id       uuid      REFERENCES platform_user(id) NOT NULL,
+    case_id       uuid      REFERENCES moderation_case(id),
+    sanction_type TEXT      NOT NULL CHECK (sanction_type IN ('warn', 'suspend', 'ban')),
+    starts_at     TIMESTAMP NOT NULL DEFAULT NOW(),
+    -- a NULL end is permanent
+    ends_at       TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS user_sanction_user_id_idx ON user_sanction(user_id);
+
+ALTER TABLE token ADD COLUMN revoked_at TIMESTAMP;
+
+-- add test data
+INSERT INTO user_role (user_id, role) SELECT id, 'admin' FROM platform_user WHERE email = 'admin';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE token DROP COLUMN revoked_at;
+DROP TABLE user_sanction;
+DROP TABLE moderation_audit;
+DROP FUNCTION prevent_moderation_audit_change;
+ALTER TABLE user_report ADD COLUMN status TEXT NOT NULL DEFAULT 'open';
+UPDATE user_report ur SET status = mc.status FROM moderation_case mc WHERE mc.report_id = ur.id;
+DROP TABLE moderation_case;
+DROP TABLE user_role;
+-- +goose StatementEnd
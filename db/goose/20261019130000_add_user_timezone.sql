@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE platform_user ADD COLUMN timezone TEXT NOT NULL DEFAULT 'UTC';
+
+-- ages are calculated on the users local calendar date, this must match entities.AgeOn
+CREATE FUNCTION platform_user_age(date_of_birth TIMESTAMP, timezone TEXT) RETURNS INT AS $$
+    SELECT DATE_PART('year', AGE((NOW() AT TIME ZONE timezone)::date, date_of_birth::date))::INT;
+$$ LANGUAGE sql STABLE;
+
+CREATE FUNCTION enforce_minimum_age() RETURNS TRIGGER AS $$
+BEGIN
+    IF platform_user_age(NEW.date_of_birth, NEW.timezone) < 18 THEN
+        RAISE EXCEPTION 'platform_user must be at least 18' USING ERRCODE = 'check_violation';
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER platform_user_minimum_age BEFORE INSERT OR UPDATE OF date_of_birth, timezone ON platform_user
+    FOR EACH ROW EXECUTE FUNCTION enforce_minimum_age();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER platform_user_minimum_age ON platform_user;
+DROP FUNCTION enforce_minimum_age();
+DROP FUNCTION platform_user_age(TIMESTAMP, TEXT);
+ALTER TABLE platform_user DROP COLUMN timezone;
+-- +goose StatementEnd
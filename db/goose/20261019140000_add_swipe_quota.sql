@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS entitlement_tier(
+    tier              TEXT PRIMARY KEY,
+    -- a NULL limit means the tier has unlimited positive swipes
+    daily_swipe_limit INT CHECK (daily_swipe_limit > 0)
+);
+
+INSERT INTO entitlement_tier (tier, daily_swipe_limit) VALUES ('free', 100), ('premium', NULL);
+
+ALTER TABLE platform_user ADD COLUMN tier TEXT NOT NULL DEFAULT 'free' REFERENCES entitlement_tier(tier);
+
+-- quota_date is the users local date, so each user's quota resets at their own midnight
+CREATE TABLE IF NOT EXISTS swipe_quota(
+    user_id    uuid REFERENCES platform_user(id) NOT NULL,
+    quota_date DATE NOT NULL,
+    used       INT  NOT NULL,
+    PRIMARY KEY (user_id, quota_date)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE swipe_quota;
+ALTER TABLE platform_user DROP COLUMN tier;
+DROP TABLE entitlement_tier;
+-- +goose StatementEnd
@@ -0,0 +1,36 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE user_swipe ADD COLUMN swipe_type TEXT CHECK (swipe_type IN ('like', 'pass', 'superlike'));
+UPDATE user_swipe SET swipe_type = CASE WHEN positive_preference THEN 'like' ELSE 'pass' END;
+ALTER TABLE user_swipe ALTER COLUMN swipe_type SET NOT NULL;
+ALTER TABLE user_swipe DROP COLUMN positive_preference;
+
+-- used to find the users that super liked someone when building their discovery queue
+CREATE INDEX user_swipe_superlike_idx ON user_swipe (swiped_user_id) WHERE swipe_type = 'superlike';
+
+-- a NULL limit means the tier has unlimited super likes
+ALTER TABLE entitlement_tier ADD COLUMN weekly_superlike_limit INT CHECK (weekly_superlike_limit > 0);
+UPDATE entitlement_tier SET weekly_superlike_limit = 1 WHERE tier = 'free';
+UPDATE entitlement_tier SET weekly_superlike_limit = 5 WHERE tier = 'premium';
+
+-- likes and super likes have separate quotas, quota_date is the first local day of the quota period
+ALTER TABLE swipe_quota ADD COLUMN swipe_type TEXT NOT NULL DEFAULT 'like';
+ALTER TABLE swipe_quota DROP CONSTRAINT swipe_quota_pkey;
+ALTER TABLE swipe_quota ADD PRIMARY KEY (user_id, swipe_type, quota_date);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM swipe_quota WHERE swipe_type != 'like';
+ALTER TABLE swipe_quota DROP CONSTRAINT swipe_quota_pkey;
+ALTER TABLE swipe_quota ADD PRIMARY KEY (user_id, quota_date);
+ALTER TABLE swipe_quota DROP COLUMN swipe_type;
+
+ALTER TABLE entitlement_tier DROP COLUMN weekly_superlike_limit;
+
+DROP INDEX user_swipe_superlike_idx;
+ALTER TABLE user_swipe ADD COLUMN positive_preference BOOLEAN;
+UPDATE user_swipe SET positive_preference = swipe_type != 'pass';
+ALTER TABLE user_swipe ALTER COLUMN positive_preference SET NOT NULL;
+ALTER TABLE user_swipe DROP COLUMN swipe_type;
+-- +goose StatementEnd
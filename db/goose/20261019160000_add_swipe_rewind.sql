@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+-- existing swipes have no timestamp and so can't be rewound
+ALTER TABLE user_swipe ADD COLUMN created_at TIMESTAMP;
+ALTER TABLE user_swipe ALTER COLUMN created_at SET DEFAULT NOW();
+CREATE INDEX user_swipe_owner_created_at_idx ON user_swipe (owner_user_id, created_at DESC);
+
+ALTER TABLE entitlement_tier ADD COLUMN can_rewind BOOLEAN NOT NULL DEFAULT FALSE;
+UPDATE entitlement_tier SET can_rewind = TRUE WHERE tier = 'premium';
+
+CREATE TABLE IF NOT EXISTS swipe_rewind(
+    id             BIGSERIAL PRIMARY KEY,
+    user_id        uuid      REFERENCES platform_user(id) NOT NULL,
+    swiped_user_id uuid      REFERENCES platform_user(id) NOT NULL,
+    swipe_type     TEXT      NOT NULL,
+    swiped_at      TIMESTAMP NOT NULL,
+    rewound_at     TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX swipe_rewind_user_id_idx ON swipe_rewind (user_id, rewound_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE swipe_rewind;
+ALTER TABLE entitlement_tier DROP COLUMN can_rewind;
+DROP INDEX user_swipe_owner_created_at_idx;
+ALTER TABLE user_swipe DROP COLUMN created_at;
+-- +goose StatementEnd
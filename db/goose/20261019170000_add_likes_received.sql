@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE entitlement_tier ADD COLUMN can_see_likes BOOLEAN NOT NULL DEFAULT FALSE;
+UPDATE entitlement_tier SET can_see_likes = TRUE WHERE tier = 'premium';
+
+CREATE INDEX user_swipe_swiped_user_id_idx ON user_swipe (swiped_user_id, owner_user_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX user_swipe_swiped_user_id_idx;
+ALTER TABLE entitlement_tier DROP COLUMN can_see_likes;
+-- +goose StatementEnd
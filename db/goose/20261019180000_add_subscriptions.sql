@@ -0,0 +1,61 @@
+-- +goose Up
+-- +goose StatementBegin
+-- entitlement tiers are the plans users can subscribe to, rank orders them when a user is entitled to more than one
+ALTER TABLE entitlement_tier ADD COLUMN rank INT NOT NULL DEFAULT 0;
+UPDATE entitlement_tier SET rank = 2 WHERE tier = 'premium';
+INSERT INTO entitlement_tier (tier, daily_swipe_limit, weekly_superlike_limit, can_rewind, can_see_likes, rank)
+VALUES ('plus', NULL, 3, TRUE, FALSE, 1);
+
+CREATE TABLE IF NOT EXISTS user_subscription(
+    id                       uuid      DEFAULT gen_random_uuid() PRIMARY KEY,
+    user_id                  uuid      REFERENCES platform_user(id) NOT NULL,
+    tier                     TEXT      REFERENCES entitlement_tier(tier) NOT NULL,
+    status                   TEXT      NOT NULL CHECK (status IN ('trialing', 'active', 'past_due', 'cancelled', 'expired')),
+    provider                 TEXT      NOT NULL,
+    provider_subscription_id TEXT      NOT NULL,
+    current_period_end       TIMESTAMP NOT NULL,
+    -- the time of the latest billing event applied, older events arriving late are ignored
+    last_event_at            TIMESTAMP NOT NULL,
+    created_at               TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at               TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE (provider, provider_subscription_id)
+);
+
+CREATE INDEX IF NOT EXISTS user_subscription_user_id_idx ON user_subscription(user_id);
+
+-- every billing event received, so redelivered webhooks are only applied once
+CREATE TABLE IF NOT EXISTS billing_event(
+    provider        TEXT      NOT NULL,
+    event_id        TEXT      NOT NULL,
+    subscription_id uuid      REFERENCES user_subscription(id),
+    payload         JSONB     NOT NULL,
+    received_at     TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (provider, event_id)
+);
+
+-- user_entitlement is the highest ranked of the tier set on the user and the tiers of their subscriptions that are
+-- paid up. Cancelled and past due subscriptions keep their tier until the end of the current period.
+CREATE VIEW user_entitlement AS
+SELECT pu.id AS user_id, et.tier, et.daily_swipe_limit, et.weekly_superlike_limit, et.can_rewind, et.can_see_likes
+FROM platform_user pu
+CROSS JOIN LATERAL (
+    SELECT et.*
+    FROM entitlement_tier et
+    WHERE et.tier = pu.tier OR et.tier IN (
+        SELECT us.tier FROM user_subscription us
+        WHERE us.user_id = pu.id AND us.status != 'expired' AND us.current_period_end > NOW()
+    )
+    ORDER BY et.rank DESC
+    LIMIT 1
+) et;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP VIEW user_entitlement;
+DROP TABLE billing_event;
+DROP TABLE user_subscription;
+UPDATE platform_user SET tier = 'free' WHERE tier = 'plus';
+DELETE FROM entitlement_tier WHERE tier = 'plus';
+ALTER TABLE entitlement_tier DROP COLUMN rank;
+-- +goose StatementEnd
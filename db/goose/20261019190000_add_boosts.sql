@@ -0,0 +1,65 @@
+-- +goose Up
+-- +goose StatementBegin
+-- the number of boosts a user can start in any 30 days
+ALTER TABLE entitlement_tier ADD COLUMN monthly_boost_limit INT NOT NULL DEFAULT 0;
+UPDATE entitlement_tier SET monthly_boost_limit = 1 WHERE tier = 'plus';
+UPDATE entitlement_tier SET monthly_boost_limit = 4 WHERE tier = 'premium';
+
+CREATE OR REPLACE VIEW user_entitlement AS
+SELECT pu.id AS user_id, et.tier, et.daily_swipe_limit, et.weekly_superlike_limit, et.can_rewind, et.can_see_likes, et.monthly_boost_limit
+FROM platform_user pu
+CROSS JOIN LATERAL (
+    SELECT et.*
+    FROM entitlement_tier et
+    WHERE et.tier = pu.tier OR et.tier IN (
+        SELECT us.tier FROM user_subscription us
+        WHERE us.user_id = pu.id AND us.status != 'expired' AND us.current_period_end > NOW()
+    )
+    ORDER BY et.rank DESC
+    LIMIT 1
+) et;
+
+CREATE TABLE IF NOT EXISTS user_boost(
+    id         uuid      DEFAULT gen_random_uuid() PRIMARY KEY,
+    user_id    uuid      REFERENCES platform_user(id) NOT NULL,
+    starts_at  TIMESTAMP NOT NULL DEFAULT NOW(),
+    ends_at    TIMESTAMP NOT NULL,
+    -- the number of times the profile was shown in discovery while the boost was active
+    views      INT       NOT NULL DEFAULT 0,
+    CHECK (ends_at > starts_at)
+);
+
+CREATE INDEX IF NOT EXISTS user_boost_user_id_starts_at_idx ON user_boost(user_id, starts_at);
+CREATE INDEX IF NOT EXISTS user_boost_ends_at_idx ON user_boost(ends_at);
+
+-- the number of times each profile is shown in discovery per day, the baseline boosts are compared against
+CREATE TABLE IF NOT EXISTS profile_view_daily(
+    user_id   uuid REFERENCES platform_user(id) NOT NULL,
+    view_date DATE NOT NULL,
+    views     INT  NOT NULL DEFAULT 0,
+    PRIMARY KEY (user_id, view_date)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE profile_view_daily;
+DROP TABLE user_boost;
+
+DROP VIEW user_entitlement;
+CREATE VIEW user_entitlement AS
+SELECT pu.id AS user_id, et.tier, et.daily_swipe_limit, et.weekly_superlike_limit, et.can_rewind, et.can_see_likes
+FROM platform_user pu
+CROSS JOIN LATERAL (
+    SELECT et.*
+    FROM entitlement_tier et
+    WHERE et.tier = pu.tier OR et.tier IN (
+        SELECT us.tier FROM user_subscription us
+        WHERE us.user_id = pu.id AND us.status != 'expired' AND us.current_period_end > NOW()
+    )
+    ORDER BY et.rank DESC
+    LIMIT 1
+) et;
+
+ALTER TABLE entitlement_tier DROP COLUMN monthly_boost_limit;
+-- +goose StatementEnd
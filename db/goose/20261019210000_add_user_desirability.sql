@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+-- swipes are applied to the swiped users desirability in the background, in the order they were made
+ALTER TABLE user_swipe ADD COLUMN desirability_applied BOOLEAN NOT NULL DEFAULT FALSE;
+CREATE INDEX IF NOT EXISTS user_swipe_desirability_pending_idx ON user_swipe(created_at, id) WHERE NOT desirability_applied;
+
+-- the hidden score discovery uses to pair users with similar scores, users without a row have the default score
+CREATE TABLE IF NOT EXISTS user_desirability(
+    user_id    uuid             REFERENCES platform_user(id) PRIMARY KEY,
+    score      DOUBLE PRECISION NOT NULL,
+    -- the number of swipes on the user the score has been updated from
+    swipes     INT              NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP        NOT NULL DEFAULT NOW()
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE user_desirability;
+DROP INDEX user_swipe_desirability_pending_idx;
+ALTER TABLE user_swipe DROP COLUMN desirability_applied;
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+-- the ranked candidates the recommender precomputes for each user from the swipe history, replaced on every run
+CREATE TABLE IF NOT EXISTS recommendation(
+    user_id           uuid             REFERENCES platform_user(id) NOT NULL,
+    candidate_user_id uuid             REFERENCES platform_user(id) NOT NULL,
+    score             DOUBLE PRECISION NOT NULL,
+    -- the candidates position in the users recommendations, starting at 1
+    rank              INT              NOT NULL CHECK (rank > 0),
+    created_at        TIMESTAMP        NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, candidate_user_id)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE recommendation;
+-- +goose StatementEnd
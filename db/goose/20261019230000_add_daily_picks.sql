@@ -0,0 +1,67 @@
+-- +goose Up
+-- +goose StatementBegin
+-- the filters a user wants their candidates to match outside of a discovery request, a NULL age has no limit
+CREATE TABLE IF NOT EXISTS discovery_preference(
+    user_id           uuid      REFERENCES platform_user(id) PRIMARY KEY,
+    min_age           INT,
+    max_age           INT,
+    preferred_genders TEXT[]    NOT NULL DEFAULT '{}',
+    updated_at        TIMESTAMP NOT NULL DEFAULT NOW(),
+    CHECK (min_age IS NULL OR max_age IS NULL OR min_age <= max_age)
+);
+
+-- the picks generated for a user on their local pick_date, they expire at the users next local midnight. A set is
+-- recorded even when no candidates were found, so each user is only picked for once a day.
+CREATE TABLE IF NOT EXISTS daily_pick_set(
+    user_id    uuid        REFERENCES platform_user(id) NOT NULL,
+    pick_date  DATE        NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMP   NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, pick_date)
+);
+
+CREATE TABLE IF NOT EXISTS daily_pick(
+    user_id           uuid             NOT NULL,
+    pick_date         DATE             NOT NULL,
+    candidate_user_id uuid             REFERENCES platform_user(id) NOT NULL,
+    rank              INT              NOT NULL CHECK (rank > 0),
+    score             DOUBLE PRECISION NOT NULL,
+    -- how the user swiped on the candidate while the pick was live, tracked separately from user_swipe to measure
+    -- the quality of picks
+    swipe_type        TEXT,
+    swiped_at         TIMESTAMP,
+    PRIMARY KEY (user_id, pick_date, candidate_user_id),
+    FOREIGN KEY (user_id, pick_date) REFERENCES daily_pick_set(user_id, pick_date) ON DELETE CASCADE
+);
+
+CREATE FUNCTION record_daily_pick_swipe() RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        UPDATE daily_pick dp SET swipe_type = NEW.swipe_type, swiped_at = NOW()
+        FROM daily_pick_set ds
+        WHERE dp.user_id = NEW.owner_user_id AND dp.candidate_user_id = NEW.swiped_user_id
+        AND ds.user_id = dp.user_id AND ds.pick_date = dp.pick_date AND ds.expires_at > NOW();
+        RETURN NEW;
+    END IF;
+
+    -- a rewound swipe no longer counts as the result of the pick
+    UPDATE daily_pick dp SET swipe_type = NULL, swiped_at = NULL
+    FROM daily_pick_set ds
+    WHERE dp.user_id = OLD.owner_user_id AND dp.candidate_user_id = OLD.swiped_user_id
+    AND ds.user_id = dp.user_id AND ds.pick_date = dp.pick_date AND ds.expires_at > NOW();
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER user_swipe_daily_pick AFTER INSERT OR DELETE ON user_swipe
+    FOR EACH ROW EXECUTE FUNCTION record_daily_pick_swipe();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER user_swipe_daily_pick ON user_swipe;
+DROP FUNCTION record_daily_pick_swipe();
+DROP TABLE daily_pick;
+DROP TABLE daily_pick_set;
+DROP TABLE discovery_preference;
+-- +goose StatementEnd
@@ -0,0 +1,38 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS question(
+    id         uuid      DEFAULT gen_random_uuid() PRIMARY KEY,
+    text       TEXT      NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- the possible answers to each question, in the order they are shown
+CREATE TABLE IF NOT EXISTS question_answer(
+    id          uuid DEFAULT gen_random_uuid() PRIMARY KEY,
+    question_id uuid REFERENCES question(id) ON DELETE CASCADE NOT NULL,
+    text        TEXT NOT NULL,
+    position    INT  NOT NULL,
+    UNIQUE (question_id, position)
+);
+
+-- how each user answered a question, the answers they would accept from someone else and how much they care
+CREATE TABLE IF NOT EXISTS user_question_answer(
+    user_id             uuid      REFERENCES platform_user(id) NOT NULL,
+    question_id         uuid      REFERENCES question(id) ON DELETE CASCADE NOT NULL,
+    answer_id           uuid      REFERENCES question_answer(id) ON DELETE CASCADE NOT NULL,
+    accepted_answer_ids uuid[]    NOT NULL DEFAULT '{}',
+    importance          TEXT      NOT NULL CHECK (importance IN ('irrelevant', 'a_little', 'somewhat', 'very', 'mandatory')),
+    updated_at          TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, question_id)
+);
+
+CREATE INDEX IF NOT EXISTS user_question_answer_question_id_idx ON user_question_answer(question_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE user_question_answer;
+DROP TABLE question_answer;
+DROP TABLE question;
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+-- the ranked users the pages of a users latest discover request are taken from, a new first page replaces it
+CREATE TABLE IF NOT EXISTS discovery_snapshot(
+    id              uuid      DEFAULT gen_random_uuid() PRIMARY KEY,
+    user_id         uuid      REFERENCES platform_user(id) NOT NULL UNIQUE,
+    ranked_user_ids uuid[]    NOT NULL,
+    created_at      TIMESTAMP NOT NULL DEFAULT NOW()
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE discovery_snapshot;
+-- +goose StatementEnd
@@ -15,9 +15,135 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/login": {
+        "/admin/moderation/cases": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Gets the moderation cases with the provided status, oldest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "moderation"
+                ],
+                "summary": "Get the moderation queue",
+                "parameters": [
+                    {
+                        "enum": [
+                            "open",
+                            "in_review",
+                            "actioned",
+                            "dismissed"
+                        ],
+                        "type": "string",
+                        "description": "The status of the cases to return",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "The maximum number of cases to return",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.ModerationCasesResponseBody"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.ProblemResponseBody"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.ProblemResponseBody"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.ProblemResponseBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/moderation/cases/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Gets a moderation case and its audit history",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "moderation"
+                ],
+                "summary": "Get a moderation case",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The id of the case",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.ModerationCaseResponseBody"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.ProblemResponseBody"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.ProblemResponseBody"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.ProblemResponseBody"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.ProblemResponseBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/moderation/cases/{id}/actions": {
             "post": {
-                "description": "Logs in a user with the provided credentials",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Warns, suspends or bans the user a case is about and marks the case as actioned. Suspending or banning\na user revokes all of their tokens.",
                 "consumes": [
                     "application/json"
                 ],
@@ -25,17 +151,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "moderation"
                 ],
-                "summary": "Login a user",
+                "summary": "Take a moderation action",
                 "parameters": [
                     {
-                        "description": "Login User Request Body",
-                        "name": "user",
+                        "type": "string",
+                        "description": "The id of the case",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Moderation Action Request Body",
+                        "name": "action",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/usecases.LoginUserRequestBody"
+                            "$ref": "#/definitions/usecases.ModerationActionRequestBody"
                         }
                     }
                 ],
@@ -43,57 +176,126 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/usecases.LoginUserResponseBody"
+                            "$ref": "#/definitions/usecases.ModerationCaseResponseBody"
                         }
                     },
                     "400": {
-                        "description": "Bad Request"
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.ProblemResponseBody"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.ProblemResponseBody"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.ProblemResponseBody"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.ProblemResponseBody"
+                        }
                     },
                     "500": {
-                        "description": "Internal Server Error"
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.ProblemResponseBody"
+                        }
                     }
                 }
             }
         },
-        "/user/create": {
+        "/admin/moderation/cases/{id}/assign": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Generates a new user record based on fake data",
+                "description": "Assigns a moderation case to a moderator, moving open cases into review",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "moderation"
+                ],
+                "summary": "Assign a moderation case",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The id of the case",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Assign Moderation Case Request Body",
+                        "name": "assignment",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/usecases.AssignModerationCaseRequestBody"
+                        }
+                    }
                 ],
-                "summary": "Create a new user",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/usecases.CreateUserResponseBody"
+                            "$ref": "#/definitions/usecases.ModerationCaseResponseBody"
                         }
                     },
                     "400": {
-                        "description": "Bad Request"
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.ProblemResponseBody"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.ProblemResponseBody"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.ProblemResponseBody"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.ProblemResponseBody"
+                        }
                     },
                     "500": {
-                        "description": "Internal Server Error"
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.ProblemResponseBody"
+                        }
                     }
                 }
             }
         },
-        "/user/discover": {
-            "get": {
+        "/admin/moderation/cases/{id}/status": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Gets a filterable list of new users",
+                "description": "Moves a moderation case between open, in_review and dismissed",
                 "consumes": [
                     "application/json"
                 ],
@@ -101,17 +303,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "moderation"
                 ],
-                "summary": "Discover new users",
+                "summary": "Update the status of a moderation case",
                 "parameters": [
                     {
-                        "description": "Discover Potential Matches Request Body",
-                        "name": "user",
+                        "type": "string",
+                        "description": "The id of the case",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update Moderation Case Status Request Body",
+                        "name": "status",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/usecases.DiscoverPotentialMatchesRequestBody"
+                            "$ref": "#/definitions/usecases.UpdateModerationCaseStatusRequestBody"
                         }
                     }
                 ],
@@ -119,26 +328,85 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/usecases.DiscoverPotentialMatchesResponseBody"
+                            "$ref": "#/definitions/usecases.ModerationCaseResponseBody"
                         }
                     },
                     "400": {
-                        "description": "Bad Request"
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.ProblemResponseBody"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.ProblemResponseBody"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.ProblemResponseBody"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.ProblemResponseBody"
+                        }
                     },
                     "500": {
-                        "description": "Internal Server Error"
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.ProblemResponseBody"
+                        }
                     }
                 }
             }
         },
-        "/user/swipe": {
+        "/admin/questions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Gets every question in the question bank, oldest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "questions"
+                ],
+                "summary": "Get the question bank",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.QuestionsResponseBody"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.ProblemResponseBody"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.ProblemResponseBody"
+                        }
+                    }
+                }
+            },
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Provides a swipe result on a user",
+                "description": "Adds a question with between 2 and 6 possible answers to the question bank",
                 "consumes": [
                     "application/json"
                 ],
@@ -146,162 +414,1929 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "questions"
                 ],
-                "summary": "Swipe on a user",
+                "summary": "Create a question",
                 "parameters": [
                     {
-                        "description": "Swipe User Request Body",
-                        "name": "user",
+                        "description": "Question Request Body",
+                        "name": "question",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/usecases.SwipeUserRequestBody"
+                            "$ref": "#/definitions/usecases.QuestionRequestBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.QuestionResponseBody"
                         }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.ProblemResponseBody"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.ProblemResponseBody"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.ProblemResponseBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/questions/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Gets a question from the question bank",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "questions"
+                ],
+                "summary": "Get a question",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The id of the question",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/usecases.SwipeUserResponseBody"
+                            "$ref": "#/definitions/usecases.QuestionResponseBody"
                         }
                     },
                     "400": {
-                        "description": "Bad Request"
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.ProblemResponseBody"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.ProblemResponseBody"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.ProblemResponseBody"
+                        }
                     },
                     "500": {
-                        "description": "Internal Server Error"
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.ProblemResponseBody"
+                        }
                     }
                 }
-            }
-        }
-    },
-    "definitions": {
-        "usecases.CreateUserResponseBody": {
-            "description": "Response body for the newly created user",
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replaces the text and answers of a question. Once users have answered the question its answers can\nonly be reworded, keeping the same number of answers in the same order.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "questions"
+                ],
+                "summary": "Update a question",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The id of the question",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Question Request Body",
+                        "name": "question",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/usecases.QuestionRequestBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.QuestionResponseBody"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.ProblemResponseBody"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.ProblemResponseBody"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.ProblemResponseBody"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.ProblemResponseBody"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.ProblemResponseBody"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deletes a question from the question bank along with every users answer to it",
+                "tags": [
+                    "questions"
+                ],
+                "summary": "Delete a question",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The id of the question",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.ProblemResponseBody"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.ProblemResponseBody"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.ProblemResponseBody"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.ProblemResponseBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/billing/webhooks/{provider}": {
+            "post": {
+                "description": "Applies a subscription change sent by a billing provider. The request must be signed by the provider,\nredelivered events are acknowledged without being applied again and events older than the last one\napplied to the subscription are ignored.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "billing"
+                ],
+                "summary": "Receive a billing webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The name of the billing provider",
+                        "name": "provider",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.ProblemResponseBody"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.ProblemResponseBody"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.ProblemResponseBody"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.ProblemResponseBody"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.ProblemResponseBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/login": {
+            "post": {
+                "description": "Logs in a user with the provided credentials",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Login a user",
+                "parameters": [
+                    {
+                        "description": "Login User Request Body",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/usecases.LoginUserRequestBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.LoginUserResponseBody"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.ProblemResponseBody"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.ProblemResponseBody"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.ProblemResponseBody"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.ProblemResponseBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/block/{id}": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Blocks a user, removing the pair from each others discovery, matches and events in both directions",
+                "tags": [
+                    "safety"
+                ],
+                "summary": "Block a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The id of the user to block",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.ProblemResponseBody"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.ProblemResponseBody"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.ProblemResponseBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/boost": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Ranks the requesting users profile higher in other users discovery for the length of the boost. Each\nplan includes a number of boosts in any 30 days, and only one boost can be active at a time.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Boost the requesting users profile",
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.BoostResponseBody"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.ProblemResponseBody"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.ProblemResponseBody"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.ProblemResponseBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/boosts": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Gets the requesting users boosts, most recent first, with the views and likes each produced. Extra views\nand likes are measured against the profiles rate over the week before the boost.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get boost reports",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.BoostReportsResponseBody"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.ProblemResponseBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/create": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Generates a new user record based on fake data. Generated users are always at least 18 years old.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Create a new user",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.CreateUserResponseBody"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.ProblemResponseBody"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.ProblemResponseBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/discover": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Gets a filterable list of new users ranked by the discovery ranker, users that have super liked the\nrequesting user are listed first. Each user has their compatibility with the requesting user from the\nquestions both have answered, set minCompatibility to only list users at least that compatible. Admins\ncan set debug to see the ranker, its weights and each users score.\nThe filters used to be sent as a request body, which is still accepted for now but is deprecated as many\nclients and proxies drop the body of GET requests. When a body is sent its filters are used instead of\nthe filter query parameters.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Discover new users",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "The minimum age of the users, at least 18",
+                        "name": "minAge",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "The maximum age of the users, at least 18 and minAge",
+                        "name": "maxAge",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "enum": [
+                                "male",
+                                "female"
+                            ],
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "The genders of the users, repeated for each gender",
+                        "name": "gender",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "The minimum compatibility percentage of the users",
+                        "name": "minCompatibility",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "The furthest away in miles the users can be",
+                        "name": "maxDistance",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "The maximum number of users to return",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The nextCursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Deprecated, use the query parameters instead",
+                        "name": "user",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.DiscoverPotentialMatchesRequestBody"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include how the users were ranked, admins only",
+                        "name": "debug",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.DiscoverPotentialMatchesResponseBody"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.ProblemResponseBody"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.ProblemResponseBody"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.ProblemResponseBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/entitlements": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Gets the tier of the requesting user, the features it includes and how many likes and super likes they\nhave left. The tier is the highest of the users paid up subscriptions, or free.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get entitlements",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.EntitlementsResponseBody"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.ProblemResponseBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/events": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Opens a Server-Sent Events stream of the users events (new matches, profile likes, super likes and\nmoderation warnings). Reconnecting clients can resume from the last event they received by providing the\nLast-Event-ID header, or the lastEventId query parameter for clients that are unable to set headers.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "events"
+                ],
+                "summary": "Stream real-time events",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The id of the last event received",
+                        "name": "Last-Event-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The id of the last event received",
+                        "name": "lastEventId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.EventResponseBody"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.ProblemResponseBody"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.ProblemResponseBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/likes/received": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists the users that have liked or super liked the requesting user that they haven't swiped on yet.\nUsers on a plan that includes it get the profiles of those users, other users get the total and blurred\nplaceholders.\nSwiping on a user from the list through /user/swipe matches as normal and removes them from the list.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get likes received",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "The maximum number of likes to return",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "The number of likes to skip",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.LikesReceivedResponseBody"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.ProblemResponseBody"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.ProblemResponseBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/picks/today": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists the small set of candidates picked for the requesting user today by compatibility, from their\nsaved preferences. Picks are generated once a day and expire at the users local midnight, picks the\nuser has swiped on or that have been blocked since are left out.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get today's picks",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.TodaysPicksResponseBody"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.ProblemResponseBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/preferences": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Saves the filters the requesting users daily picks are generated with, replacing any saved before.\nDiscovery requests still use the filters sent with them.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Save discovery preferences",
+                "parameters": [
+                    {
+                        "description": "Discovery Preferences Request Body",
+                        "name": "preferences",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/usecases.DiscoveryPreferencesRequestBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.ProblemResponseBody"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.ProblemResponseBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/questions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists every question in the question bank along with how the requesting user answered it",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "questions"
+                ],
+                "summary": "Get questions",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.UserQuestionsResponseBody"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.ProblemResponseBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/questions/{id}/answer": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Records the requesting users own answer to a question, the answers they would accept from someone else\nand how much they care, replacing any earlier answer. Answers are used to work out the compatibility\nof users in discovery.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "questions"
+                ],
+                "summary": "Answer a question",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The id of the question",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Answer Question Request Body",
+                        "name": "answer",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/usecases.AnswerQuestionRequestBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.ProblemResponseBody"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.ProblemResponseBody"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.ProblemResponseBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/report/{id}": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Reports a user for review by a moderator",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "safety"
+                ],
+                "summary": "Report a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The id of the user to report",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Report User Request Body",
+                        "name": "report",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/usecases.ReportUserRequestBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.ReportUserResponseBody"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.ProblemResponseBody"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.ProblemResponseBody"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.ProblemResponseBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/swipe": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Provides a swipe result on a user. Likes use one swipe from the users daily quota, which resets at midnight\nin the users timezone, and super likes use one from their weekly quota, which resets at midnight on monday.\nThe quota the swipe used (the daily quota for passes) is returned in the X-RateLimit headers unless the\nusers tier is unlimited. Super liked users are notified and shown the sender first when discovering.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Swipe on a user",
+                "parameters": [
+                    {
+                        "description": "Swipe User Request Body",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/usecases.SwipeUserRequestBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.SwipeUserResponseBody"
+                        },
+                        "headers": {
+                            "X-RateLimit-Limit": {
+                                "type": "integer",
+                                "description": "The number of swipes allowed in the quota period"
+                            },
+                            "X-RateLimit-Remaining": {
+                                "type": "integer",
+                                "description": "The number of swipes left in the quota period"
+                            },
+                            "X-RateLimit-Reset": {
+                                "type": "integer",
+                                "description": "The unix time the quota resets"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.ProblemResponseBody"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.ProblemResponseBody"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.ProblemResponseBody"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.ProblemResponseBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/swipe/rewind": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Undoes the users most recent swipe if it was made within the rewind window and hasn't resulted in a\nmatch, returning the swiped user to discovery. Rewinding is only available on plans that include it and doesn't\nreturn likes or super likes to the users quota.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Rewind the last swipe",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.RewindSwipeResponseBody"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.ProblemResponseBody"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.ProblemResponseBody"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.ProblemResponseBody"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.ProblemResponseBody"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "usecases.AnswerQuestionRequestBody": {
+            "description": "the users own answer, the answers they would accept from someone else and how much they care",
+            "type": "object",
+            "required": [
+                "answerId",
+                "importance"
+            ],
+            "properties": {
+                "acceptedAnswerIds": {
+                    "description": "AcceptedAnswerIDs are the ids of the answers the user would accept, required unless the question is irrelevant",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "answerId": {
+                    "description": "AnswerID is the id of the users own answer",
+                    "type": "string"
+                },
+                "importance": {
+                    "description": "Importance is how much the user cares about the answer someone else gives",
+                    "type": "string",
+                    "enum": [
+                        "irrelevant",
+                        "a_little",
+                        "somewhat",
+                        "very",
+                        "mandatory"
+                    ]
+                }
+            }
+        },
+        "usecases.AnswerResponseBody": {
+            "description": "a possible answer to a question",
+            "type": "object",
+            "properties": {
+                "id": {
+                    "description": "ID is the id of the answer",
+                    "type": "string"
+                },
+                "text": {
+                    "description": "Text is the answer",
+                    "type": "string"
+                }
+            }
+        },
+        "usecases.AssignModerationCaseRequestBody": {
+            "description": "the moderator to assign the case to",
+            "type": "object",
+            "properties": {
+                "moderatorId": {
+                    "description": "ModeratorID is the id of the moderator to assign, defaults to the requesting moderator",
+                    "type": "string"
+                }
+            }
+        },
+        "usecases.BoostQuotaResponseBody": {
+            "description": "the boosts the user can start in any 30 days",
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "description": "Limit is the number of boosts the users plan includes in any 30 days",
+                    "type": "integer"
+                },
+                "remaining": {
+                    "description": "Remaining is the number of boosts the user can start now",
+                    "type": "integer"
+                }
+            }
+        },
+        "usecases.BoostReportResponseBody": {
+            "description": "how a boost performed, compared to the week before it",
+            "type": "object",
+            "properties": {
+                "active": {
+                    "description": "Active is true while the boost is running",
+                    "type": "boolean"
+                },
+                "boostsRemaining": {
+                    "description": "BoostsRemaining is the number of boosts the user has left, it is only returned when starting a boost",
+                    "type": "integer"
+                },
+                "endsAt": {
+                    "description": "EndsAt is when the boost ends",
+                    "type": "string"
+                },
+                "extraLikes": {
+                    "description": "ExtraLikes is the number of likes above the profiles usual rate over the week before the boost",
+                    "type": "integer"
+                },
+                "extraViews": {
+                    "description": "ExtraViews is the number of views above the profiles usual rate over the week before the boost",
+                    "type": "integer"
+                },
+                "id": {
+                    "description": "ID is the id of the boost",
+                    "type": "string"
+                },
+                "likes": {
+                    "description": "Likes is the number of likes the profile got during the boost",
+                    "type": "integer"
+                },
+                "startsAt": {
+                    "description": "StartsAt is when the boost started",
+                    "type": "string"
+                },
+                "views": {
+                    "description": "Views is the number of times the profile was shown in discovery during the boost",
+                    "type": "integer"
+                }
+            }
+        },
+        "usecases.BoostReportsResponseBody": {
+            "description": "the users boosts and how they performed, most recent first",
+            "type": "object",
+            "properties": {
+                "boosts": {
+                    "description": "Boosts is the list of the users boosts",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/usecases.BoostReportResponseBody"
+                    }
+                }
+            }
+        },
+        "usecases.BoostResponseBody": {
+            "description": "a period where the users profile is ranked higher in discovery",
+            "type": "object",
+            "properties": {
+                "boostsRemaining": {
+                    "description": "BoostsRemaining is the number of boosts the user has left, it is only returned when starting a boost",
+                    "type": "integer"
+                },
+                "endsAt": {
+                    "description": "EndsAt is when the boost ends",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "ID is the id of the boost",
+                    "type": "string"
+                },
+                "startsAt": {
+                    "description": "StartsAt is when the boost started",
+                    "type": "string"
+                }
+            }
+        },
+        "usecases.CreateUserResponseBody": {
+            "description": "Response body for the newly created user",
+            "type": "object",
+            "properties": {
+                "age": {
+                    "description": "Age the generated age for the user",
+                    "type": "integer"
+                },
+                "email": {
+                    "description": "Email the generated email for the user",
+                    "type": "string"
+                },
+                "gender": {
+                    "description": "Gender the generated gender for the user",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "ID the generated id for the user",
+                    "type": "string"
+                },
+                "interests": {
+                    "description": "Interests the generated interests for the user",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "location": {
+                    "description": "Location the generated location for the user",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/usecases.Location"
+                        }
+                    ]
+                },
+                "name": {
+                    "description": "Name the generated name for the user",
+                    "type": "string"
+                },
+                "password": {
+                    "description": "Password the generated password for the user",
+                    "type": "string"
+                },
+                "timezone": {
+                    "description": "Timezone the generated timezone for the user",
+                    "type": "string"
+                }
+            }
+        },
+        "usecases.DailyPickResponseBody": {
+            "description": "a user picked for the requesting user, swipe on it through /user/swipe",
+            "type": "object",
+            "properties": {
+                "age": {
+                    "description": "Age is the age of the user",
+                    "type": "integer"
+                },
+                "distanceFromMe": {
+                    "description": "DistanceFromMe is the distance between the users measured in miles",
+                    "type": "number"
+                },
+                "gender": {
+                    "description": "Gender is the gender of the user",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "ID is the id of the user",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "Name is the name of the user",
+                    "type": "string"
+                }
+            }
+        },
+        "usecases.DiscoverPotentialMatchesRequestBody": {
+            "description": "the request body for the discover endpoint, deprecated in favour of the query parameters",
+            "type": "object",
+            "properties": {
+                "pageInfo": {
+                    "description": "PageInfo represents the filter information for the request",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/usecases.PageInfo"
+                        }
+                    ]
+                }
+            }
+        },
+        "usecases.DiscoverPotentialMatchesResponseBody": {
+            "description": "the response body for the discover endpoint",
+            "type": "object",
+            "properties": {
+                "debug": {
+                    "description": "Debug is how the users were ranked, it is only returned to admins that request it",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/usecases.DiscoveryDebugResponseBody"
+                        }
+                    ]
+                },
+                "nextCursor": {
+                    "description": "NextCursor is the cursor of the next page, it is omitted on the last page",
+                    "type": "string"
+                },
+                "users": {
+                    "description": "Users is the returned list of all users matching the filter criteria",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/usecases.UserResponseBody"
+                    }
+                }
+            }
+        },
+        "usecases.DiscoveryDebugResponseBody": {
+            "description": "the ranker that ordered the users and the weight of each signal",
+            "type": "object",
+            "properties": {
+                "ranker": {
+                    "description": "Ranker is the name of the ranker",
+                    "type": "string"
+                },
+                "weights": {
+                    "description": "Weights is how much each signal counts towards a users score, it is omitted for rankers that don't weight signals",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/usecases.RankingSignalsResponseBody"
+                        }
+                    ]
+                }
+            }
+        },
+        "usecases.DiscoveryPreferencesRequestBody": {
+            "description": "the users discovery preferences, an age of 0 has no limit and no genders includes every gender",
+            "type": "object",
+            "properties": {
+                "maxAge": {
+                    "description": "MaxAge is the maximum age of picked users",
+                    "type": "integer",
+                    "minimum": 18
+                },
+                "minAge": {
+                    "description": "MinAge is the minimum age of picked users",
+                    "type": "integer",
+                    "minimum": 18
+                },
+                "preferredGenders": {
+                    "description": "PreferredGenders is an array of genders to pick users from",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "usecases.EntitlementsResponseBody": {
+            "description": "the tier of the requesting user, the features it includes and their remaining swipes",
+            "type": "object",
+            "properties": {
+                "boosts": {
+                    "description": "Boosts is the users boost allowance",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/usecases.BoostQuotaResponseBody"
+                        }
+                    ]
+                },
+                "canRewind": {
+                    "description": "CanRewind is true when the user can rewind their last swipe",
+                    "type": "boolean"
+                },
+                "canSeeLikes": {
+                    "description": "CanSeeLikes is true when the user can see the profiles of the users that liked them",
+                    "type": "boolean"
+                },
+                "likes": {
+                    "description": "Likes is the users daily like quota",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/usecases.SwipeQuotaResponseBody"
+                        }
+                    ]
+                },
+                "subscription": {
+                    "description": "Subscription is the users latest subscription, it is omitted if they have never subscribed",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/usecases.SubscriptionResponseBody"
+                        }
+                    ]
+                },
+                "superLikes": {
+                    "description": "SuperLikes is the users weekly super like quota",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/usecases.SwipeQuotaResponseBody"
+                        }
+                    ]
+                },
+                "tier": {
+                    "description": "Tier is the tier the user is on, one of free, plus or premium",
+                    "type": "string"
+                }
+            }
+        },
+        "usecases.EventResponseBody": {
+            "description": "the data of an event written to the event stream, the event id and type are sent as the SSE id and event fields",
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "description": "CreatedAt is the time the event occurred",
+                    "type": "string"
+                },
+                "payload": {
+                    "description": "Payload is the event specific data",
+                    "type": "object"
+                },
+                "type": {
+                    "description": "Type is the type of the event, one of new_match, profile_liked, super_liked or moderation_warning",
+                    "type": "string"
+                }
+            }
+        },
+        "usecases.FieldErrorResponseBody": {
+            "description": "the field of the request that is invalid and the rule it broke",
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "Code is the rule the field broke, such as required or max",
+                    "type": "string"
+                },
+                "field": {
+                    "description": "Field is the name of the field, such as pageInfo.minAge",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Message describes the rule the field broke",
+                    "type": "string"
+                }
+            }
+        },
+        "usecases.LikesReceivedResponseBody": {
+            "description": "the users that have liked the requesting user that they haven't swiped on yet, super likes first",
+            "type": "object",
+            "properties": {
+                "likes": {
+                    "description": "Likes is the page of likes, they are blurred unless the requesting users plan includes seeing who liked them",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/usecases.ReceivedLikeResponseBody"
+                    }
+                },
+                "nextOffset": {
+                    "description": "NextOffset is the offset of the next page, it is omitted on the last page",
+                    "type": "integer"
+                },
+                "total": {
+                    "description": "Total is the number of users that have liked the requesting user",
+                    "type": "integer"
+                }
+            }
+        },
+        "usecases.Location": {
+            "type": "object",
+            "properties": {
+                "latitude": {
+                    "description": "Latitude the generated latitude for the users location",
+                    "type": "number"
+                },
+                "longitude": {
+                    "description": "Longitude the generated longitude for the users location",
+                    "type": "number"
+                }
+            }
+        },
+        "usecases.LoginUserRequestBody": {
+            "description": "the login information for the user",
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "description": "Email represents the email of the user to log in as",
+                    "type": "string"
+                },
+                "password": {
+                    "description": "Password represents the password of the user to log in as",
+                    "type": "string"
+                }
+            }
+        },
+        "usecases.LoginUserResponseBody": {
+            "description": "the newly issued JWT for the logged in user",
+            "type": "object",
+            "properties": {
+                "token": {
+                    "description": "Token represents the JWT issued for the logged in user",
+                    "type": "string"
+                }
+            }
+        },
+        "usecases.ModerationActionRequestBody": {
+            "description": "the action to take against the user the case is about",
+            "type": "object",
+            "required": [
+                "action"
+            ],
+            "properties": {
+                "action": {
+                    "description": "Action is the action to take",
+                    "type": "string",
+                    "enum": [
+                        "warn",
+                        "suspend",
+                        "ban"
+                    ]
+                },
+                "durationHours": {
+                    "description": "DurationHours is how long to suspend the user for, required when suspending",
+                    "type": "integer",
+                    "maximum": 8760
+                },
+                "note": {
+                    "description": "Note is the reasoning of the moderator, it is written to the audit history",
+                    "type": "string",
+                    "maxLength": 2000
+                }
+            }
+        },
+        "usecases.ModerationAuditEntryResponseBody": {
+            "description": "an immutable record of a change made to a case",
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "Action is the change that was made",
+                    "type": "string"
+                },
+                "actorUserId": {
+                    "description": "ActorUserID is the id of the moderator that made the change",
+                    "type": "string"
+                },
+                "createdAt": {
+                    "description": "CreatedAt is when the change was made",
+                    "type": "string"
+                },
+                "details": {
+                    "description": "Details is the data of the change",
+                    "type": "object"
+                }
+            }
+        },
+        "usecases.ModerationCaseResponseBody": {
+            "description": "a case in the moderation queue",
+            "type": "object",
+            "properties": {
+                "assignedModeratorId": {
+                    "description": "AssignedModeratorID is the id of the moderator working the case",
+                    "type": "string"
+                },
+                "audit": {
+                    "description": "Audit is the history of the case, it is only returned when getting a single case",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/usecases.ModerationAuditEntryResponseBody"
+                    }
+                },
+                "createdAt": {
+                    "description": "CreatedAt is when the case was opened",
+                    "type": "string"
+                },
+                "details": {
+                    "description": "Details is the free text given in the report",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "ID is the id of the case",
+                    "type": "string"
+                },
+                "reason": {
+                    "description": "Reason is the reason given in the report",
+                    "type": "string"
+                },
+                "reportId": {
+                    "description": "ReportID is the id of the report that opened the case",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "Status is the status of the case",
+                    "type": "string"
+                },
+                "subjectUserId": {
+                    "description": "SubjectUserID is the id of the user the case is about",
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "description": "UpdatedAt is when the case was last changed",
+                    "type": "string"
+                }
+            }
+        },
+        "usecases.ModerationCasesResponseBody": {
+            "description": "the cases in the moderation queue, oldest first",
+            "type": "object",
+            "properties": {
+                "cases": {
+                    "description": "Cases is the list of cases matching the filter",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/usecases.ModerationCaseResponseBody"
+                    }
+                }
+            }
+        },
+        "usecases.PageInfo": {
+            "description": "the filter information for the request",
+            "type": "object",
+            "properties": {
+                "maxAge": {
+                    "description": "MaxAge is the maximum age of any users returned in the list",
+                    "type": "integer",
+                    "minimum": 18
+                },
+                "minAge": {
+                    "description": "MinAge is the minimum age of any users returned in the list",
+                    "type": "integer",
+                    "minimum": 18
+                },
+                "minCompatibility": {
+                    "description": "MinCompatibility is the minimum compatibility percentage of any users returned in the list, users without\nquestions answered in common are left out when it is set",
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 0
+                },
+                "preferredGenders": {
+                    "description": "PreferredGenders is an array of genders to include in the list",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "usecases.ProblemResponseBody": {
+            "description": "a problem details object describing why the request failed",
             "type": "object",
             "properties": {
-                "age": {
-                    "description": "Age the generated age for the user",
-                    "type": "integer"
-                },
-                "email": {
-                    "description": "Email the generated email for the user",
+                "code": {
+                    "description": "Code is a stable identifier for the problem that clients can rely on",
                     "type": "string"
                 },
-                "gender": {
-                    "description": "Gender the generated gender for the user",
+                "detail": {
+                    "description": "Detail is a description of the problem that is safe to show to users",
                     "type": "string"
                 },
-                "id": {
-                    "description": "ID the generated id for the user",
+                "errors": {
+                    "description": "Errors are the reasons each of the invalid fields of the request is invalid",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/usecases.FieldErrorResponseBody"
+                    }
+                },
+                "instance": {
+                    "description": "Instance is the path of the request that failed",
                     "type": "string"
                 },
-                "location": {
-                    "description": "Location the generated location for the user",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/usecases.Location"
-                        }
-                    ]
+                "status": {
+                    "description": "Status is the http status of the response",
+                    "type": "integer"
                 },
-                "name": {
-                    "description": "Name the generated name for the user",
+                "title": {
+                    "description": "Title is the description of the status",
                     "type": "string"
                 },
-                "password": {
-                    "description": "Password the generated password for the user",
+                "type": {
+                    "description": "Type is always about:blank, the code identifies the problem instead",
                     "type": "string"
                 }
             }
         },
-        "usecases.DiscoverPotentialMatchesRequestBody": {
-            "description": "the request body for the discover endpoint",
+        "usecases.QuestionRequestBody": {
+            "description": "the question and its possible answers, in the order they are shown",
             "type": "object",
+            "required": [
+                "answers",
+                "text"
+            ],
             "properties": {
-                "pageInfo": {
-                    "description": "PageInfo represents the filter information for the request",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/usecases.PageInfo"
-                        }
-                    ]
+                "answers": {
+                    "description": "Answers are the possible answers to the question",
+                    "type": "array",
+                    "maxItems": 6,
+                    "minItems": 2,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "text": {
+                    "description": "Text is the question",
+                    "type": "string",
+                    "maxLength": 500
                 }
             }
         },
-        "usecases.DiscoverPotentialMatchesResponseBody": {
-            "description": "the response body for the discover endpoint",
+        "usecases.QuestionResponseBody": {
+            "description": "a question and its possible answers",
             "type": "object",
             "properties": {
-                "users": {
-                    "description": "Users is the returned list of all users matching the filter criteria",
+                "answers": {
+                    "description": "Answers are the possible answers to the question, in the order they are shown",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/usecases.UserResponseBody"
+                        "$ref": "#/definitions/usecases.AnswerResponseBody"
                     }
+                },
+                "createdAt": {
+                    "description": "CreatedAt is when the question was added",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "ID is the id of the question",
+                    "type": "string"
+                },
+                "text": {
+                    "description": "Text is the question",
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "description": "UpdatedAt is when the question was last changed",
+                    "type": "string"
                 }
             }
         },
-        "usecases.Location": {
+        "usecases.QuestionsResponseBody": {
+            "description": "every question in the question bank, oldest first",
             "type": "object",
             "properties": {
-                "latitude": {
-                    "description": "Latitude the generated latitude for the users location",
+                "questions": {
+                    "description": "Questions are the questions in the question bank",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/usecases.QuestionResponseBody"
+                    }
+                }
+            }
+        },
+        "usecases.RankingResponseBody": {
+            "description": "the users score and how they scored on each signal",
+            "type": "object",
+            "properties": {
+                "recommendationRank": {
+                    "description": "RecommendationRank is the users position in the recommendations from the swipe history, it is omitted if they\nweren't recommended",
+                    "type": "integer"
+                },
+                "score": {
+                    "description": "Score is the weighted sum of the signals, higher scores are listed first",
                     "type": "number"
                 },
-                "longitude": {
-                    "description": "Longitude the generated longitude for the users location",
+                "signals": {
+                    "description": "Signals is how the user scored on each signal, from 0 to 1",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/usecases.RankingSignalsResponseBody"
+                        }
+                    ]
+                }
+            }
+        },
+        "usecases.RankingSignalsResponseBody": {
+            "description": "a value for each of the signals users are ranked on",
+            "type": "object",
+            "properties": {
+                "ageFit": {
+                    "description": "AgeFit is how close the users age is to the middle of the requested age range",
+                    "type": "number"
+                },
+                "boost": {
+                    "description": "Boost is whether the user has an active boost",
+                    "type": "number"
+                },
+                "desirabilityFit": {
+                    "description": "DesirabilityFit is how close the users desirability is to the requesting users",
+                    "type": "number"
+                },
+                "distance": {
+                    "description": "Distance is how close the user is",
+                    "type": "number"
+                },
+                "popularity": {
+                    "description": "Popularity is how often the user is liked",
+                    "type": "number"
+                },
+                "recentActivity": {
+                    "description": "RecentActivity is how recently the user last swiped",
+                    "type": "number"
+                },
+                "reciprocal": {
+                    "description": "Reciprocal is how likely the user is to like the requesting user back",
+                    "type": "number"
+                },
+                "sharedInterests": {
+                    "description": "SharedInterests is how many interests the users have in common",
                     "type": "number"
                 }
             }
         },
-        "usecases.LoginUserRequestBody": {
-            "description": "the login information for the user",
+        "usecases.ReceivedLikeResponseBody": {
+            "description": "a user that has liked the requesting user, only blurred is set for blurred likes",
             "type": "object",
-            "required": [
-                "email",
-                "password"
-            ],
             "properties": {
-                "email": {
-                    "description": "Email represents the email of the user to log in as",
+                "age": {
+                    "description": "Age is the age of the user",
+                    "type": "integer"
+                },
+                "blurred": {
+                    "description": "Blurred is true when the profile of the user is hidden",
+                    "type": "boolean"
+                },
+                "gender": {
+                    "description": "Gender is the gender of the user",
                     "type": "string"
                 },
-                "password": {
-                    "description": "Password represents the password of the user to log in as",
+                "likedAt": {
+                    "description": "LikedAt is when the user liked the requesting user, it is omitted for older likes",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "Name is the name of the user",
+                    "type": "string"
+                },
+                "superLike": {
+                    "description": "SuperLike is true when the user super liked the requesting user",
+                    "type": "boolean"
+                },
+                "userId": {
+                    "description": "UserID is the id of the user, swipe on it through /user/swipe to like back or pass",
                     "type": "string"
                 }
             }
         },
-        "usecases.LoginUserResponseBody": {
-            "description": "the newly issued JWT for the logged in user",
+        "usecases.ReportUserRequestBody": {
+            "description": "the reason for reporting a user",
             "type": "object",
+            "required": [
+                "reason"
+            ],
             "properties": {
-                "token": {
-                    "description": "Token represents the JWT issued for the logged in user",
-                    "type": "string"
+                "details": {
+                    "description": "Details is an optional free text description of the problem",
+                    "type": "string",
+                    "maxLength": 2000
+                },
+                "reason": {
+                    "description": "Reason is the category of the report",
+                    "type": "string",
+                    "enum": [
+                        "spam",
+                        "harassment",
+                        "inappropriate_content",
+                        "fake_profile",
+                        "scam",
+                        "underage",
+                        "other"
+                    ]
                 }
             }
         },
-        "usecases.PageInfo": {
-            "description": "the filter information for the request",
+        "usecases.ReportUserResponseBody": {
+            "description": "the report that has been added to the moderation queue",
             "type": "object",
             "properties": {
-                "maxAge": {
-                    "description": "MaxAge is the maximum age of any users returned in the list",
-                    "type": "integer"
-                },
-                "minAge": {
-                    "description": "MinAge is the minimum age of any users returned in the list",
-                    "type": "integer"
+                "reportId": {
+                    "description": "ReportID is the id of the report",
+                    "type": "string"
                 },
-                "preferredGenders": {
-                    "description": "PreferredGenders is an array of genders to include in the list",
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "status": {
+                    "description": "Status is the moderation status of the report",
+                    "type": "string"
                 }
             }
         },
@@ -319,6 +2354,64 @@ const docTemplate = `{
                 }
             }
         },
+        "usecases.RewindSwipeResponseBody": {
+            "description": "the swipe that was undone, the swiped user will be shown in discovery again",
+            "type": "object",
+            "properties": {
+                "swipeType": {
+                    "description": "SwipeType the type of the swipe that was undone, one of like, pass or superlike",
+                    "type": "string"
+                },
+                "swipedAt": {
+                    "description": "SwipedAt the time the swipe was made",
+                    "type": "string"
+                },
+                "userId": {
+                    "description": "UserID the id of the user that was swiped on",
+                    "type": "string"
+                }
+            }
+        },
+        "usecases.SubscriptionResponseBody": {
+            "description": "a subscription to a paid tier",
+            "type": "object",
+            "properties": {
+                "currentPeriodEnd": {
+                    "description": "CurrentPeriodEnd is when the current billing period ends",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "Status is the status of the subscription, one of trialing, active, past_due, cancelled or expired",
+                    "type": "string"
+                },
+                "tier": {
+                    "description": "Tier is the tier subscribed to",
+                    "type": "string"
+                }
+            }
+        },
+        "usecases.SwipeQuotaResponseBody": {
+            "description": "the swipes the user has left, limit and remaining are omitted when the quota is unlimited",
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "description": "Limit is the number of swipes allowed in the quota period",
+                    "type": "integer"
+                },
+                "remaining": {
+                    "description": "Remaining is the number of swipes left in the quota period",
+                    "type": "integer"
+                },
+                "resetsAt": {
+                    "description": "ResetsAt is when the quota resets",
+                    "type": "string"
+                },
+                "unlimited": {
+                    "description": "Unlimited is true when the users tier has no limit",
+                    "type": "boolean"
+                }
+            }
+        },
         "usecases.SwipeUserRequestBody": {
             "description": "the swipe result on a user",
             "type": "object",
@@ -328,9 +2421,12 @@ const docTemplate = `{
             ],
             "properties": {
                 "preference": {
-                    "description": "Preference the preference for the user",
+                    "description": "Preference the type of swipe, one of like, pass or superlike. YES and NO are still accepted as like and pass",
                     "type": "string",
                     "enum": [
+                        "like",
+                        "pass",
+                        "superlike",
                         "YES",
                         "yes",
                         "NO",
@@ -357,6 +2453,104 @@ const docTemplate = `{
                 }
             }
         },
+        "usecases.TodaysPicksResponseBody": {
+            "description": "the candidates picked for the requesting user today that they haven't swiped on yet",
+            "type": "object",
+            "properties": {
+                "expiresAt": {
+                    "description": "ExpiresAt is when the picks expire, at the requesting users next local midnight. It is omitted when there are no\npicks.",
+                    "type": "string"
+                },
+                "picks": {
+                    "description": "Picks are the picked users, best first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/usecases.DailyPickResponseBody"
+                    }
+                }
+            }
+        },
+        "usecases.UpdateModerationCaseStatusRequestBody": {
+            "description": "the new status of the case, cases are marked as actioned by taking an action",
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "status": {
+                    "description": "Status is the new status of the case",
+                    "type": "string",
+                    "enum": [
+                        "open",
+                        "in_review",
+                        "dismissed"
+                    ]
+                }
+            }
+        },
+        "usecases.UserAnswerResponseBody": {
+            "description": "the users own answer, the answers they would accept and how much they care",
+            "type": "object",
+            "properties": {
+                "acceptedAnswerIds": {
+                    "description": "AcceptedAnswerIDs are the ids of the answers the user would accept",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "answerId": {
+                    "description": "AnswerID is the id of the users own answer",
+                    "type": "string"
+                },
+                "importance": {
+                    "description": "Importance is how much the user cares about the answer someone else gives",
+                    "type": "string"
+                }
+            }
+        },
+        "usecases.UserQuestionResponseBody": {
+            "description": "a question, its possible answers and the requesting users answer",
+            "type": "object",
+            "properties": {
+                "answers": {
+                    "description": "Answers are the possible answers to the question, in the order they are shown",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/usecases.AnswerResponseBody"
+                    }
+                },
+                "id": {
+                    "description": "ID is the id of the question",
+                    "type": "string"
+                },
+                "myAnswer": {
+                    "description": "MyAnswer is how the requesting user answered, it is omitted if they haven't",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/usecases.UserAnswerResponseBody"
+                        }
+                    ]
+                },
+                "text": {
+                    "description": "Text is the question",
+                    "type": "string"
+                }
+            }
+        },
+        "usecases.UserQuestionsResponseBody": {
+            "description": "every question in the question bank with the requesting users answer",
+            "type": "object",
+            "properties": {
+                "questions": {
+                    "description": "Questions are the questions in the question bank, oldest first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/usecases.UserQuestionResponseBody"
+                    }
+                }
+            }
+        },
         "usecases.UserResponseBody": {
             "description": "a user matching the filter criteria",
             "type": "object",
@@ -365,6 +2559,10 @@ const docTemplate = `{
                     "description": "Age is the age of the user",
                     "type": "integer"
                 },
+                "compatibility": {
+                    "description": "Compatibility is the percentage match of the users answers to questions, it is omitted if the users have no\nquestions answered in common",
+                    "type": "integer"
+                },
                 "distanceFromMe": {
                     "description": "DistanceFromMe is the distance between the users measured in miles",
                     "type": "number"
@@ -380,6 +2578,18 @@ const docTemplate = `{
                 "name": {
                     "description": "Name is the name of the user",
                     "type": "string"
+                },
+                "ranking": {
+                    "description": "Ranking is how the user was ranked, it is only returned to admins that request it",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/usecases.RankingResponseBody"
+                        }
+                    ]
+                },
+                "superLikedMe": {
+                    "description": "SuperLikedMe is true when the user has super liked the requesting user",
+                    "type": "boolean"
                 }
             }
         }
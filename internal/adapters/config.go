@@ -5,9 +5,55 @@ import (
 )
 
 type Config struct {
-	DatabaseConnectionString string `yaml:"database-connection-string" env:"DATABASE_CONNECTION_STRING" env-required:"true"`
+	// ListenAddress is the address the server listens on, it serves TLS when both the certificate and key files are set
+	ListenAddress            string `yaml:"listen-address" env:"LISTEN_ADDRESS" env-default:":8080"`
+	TLSCertFile              string `yaml:"tls-cert-file" env:"TLS_CERT_FILE"`
+	TLSKeyFile               string `yaml:"tls-key-file" env:"TLS_KEY_FILE"`
+	ReadHeaderTimeoutSeconds int    `yaml:"read-header-timeout-seconds" env:"READ_HEADER_TIMEOUT_SECONDS" env-default:"5"`
+	ReadTimeoutSeconds       int    `yaml:"read-timeout-seconds" env:"READ_TIMEOUT_SECONDS" env-default:"15"`
+	WriteTimeoutSeconds      int    `yaml:"write-timeout-seconds" env:"WRITE_TIMEOUT_SECONDS" env-default:"30"`
+	IdleTimeoutSeconds       int    `yaml:"idle-timeout-seconds" env:"IDLE_TIMEOUT_SECONDS" env-default:"120"`
+	// ShutdownDelaySeconds is how long the service reports that it isn't ready before it stops taking requests, giving
+	// load balancers time to stop sending it new ones
+	ShutdownDelaySeconds int `yaml:"shutdown-delay-seconds" env:"SHUTDOWN_DELAY_SECONDS" env-default:"5"`
+	// ShutdownTimeoutSeconds is how long in-flight requests are given to finish once the service is asked to stop
+	ShutdownTimeoutSeconds int `yaml:"shutdown-timeout-seconds" env:"SHUTDOWN_TIMEOUT_SECONDS" env-default:"20"`
+	// Storage is either postgres or memory, in-memory storage is empty whenever the service starts so it must only be
+	// used to run the service locally. DatabaseConnectionString is required for postgres.
+	Storage                  string `yaml:"storage" env:"STORAGE" env-default:"postgres"`
+	DatabaseConnectionString string `yaml:"database-connection-string" env:"DATABASE_CONNECTION_STRING"`
 	JwtExpiryMillis          int    `yaml:"jwt-expiry-millis" env:"JWT_EXPIRY_MILLIS" env-required:"true"`
 	JwtSecretKey             string `yaml:"jwt-secret-key" env:"JWT_SECRET_KEY" env-required:"true"`
+	EventLogRetentionMinutes int    `yaml:"event-log-retention-minutes" env:"EVENT_LOG_RETENTION_MINUTES" env-default:"1440"`
+	SwipeRewindWindowSeconds int    `yaml:"swipe-rewind-window-seconds" env:"SWIPE_REWIND_WINDOW_SECONDS" env-default:"300"`
+	BoostDurationMinutes     int    `yaml:"boost-duration-minutes" env:"BOOST_DURATION_MINUTES" env-default:"30"`
+	// DiscoveryRanker is the name of the ranker that orders discovery, the weights are used by the weighted-linear ranker
+	DiscoveryRanker              string  `yaml:"discovery-ranker" env:"DISCOVERY_RANKER" env-default:"weighted-linear"`
+	RankingWeightDistance        float64 `yaml:"ranking-weight-distance" env:"RANKING_WEIGHT_DISTANCE" env-default:"1"`
+	RankingWeightAgeFit          float64 `yaml:"ranking-weight-age-fit" env:"RANKING_WEIGHT_AGE_FIT" env-default:"0.5"`
+	RankingWeightSharedInterests float64 `yaml:"ranking-weight-shared-interests" env:"RANKING_WEIGHT_SHARED_INTERESTS" env-default:"0.75"`
+	RankingWeightRecentActivity  float64 `yaml:"ranking-weight-recent-activity" env:"RANKING_WEIGHT_RECENT_ACTIVITY" env-default:"0.5"`
+	RankingWeightBoost           float64 `yaml:"ranking-weight-boost" env:"RANKING_WEIGHT_BOOST" env-default:"2"`
+	RankingWeightPopularity      float64 `yaml:"ranking-weight-popularity" env:"RANKING_WEIGHT_POPULARITY" env-default:"0.5"`
+	RankingWeightReciprocal      float64 `yaml:"ranking-weight-reciprocal" env:"RANKING_WEIGHT_RECIPROCAL" env-default:"0.75"`
+	RankingWeightDesirabilityFit float64 `yaml:"ranking-weight-desirability-fit" env:"RANKING_WEIGHT_DESIRABILITY_FIT" env-default:"0.75"`
+	// RecommendationMixInterval is how often a recommended candidate is mixed into discovery, every third place by
+	// default, and 0 turns recommendations off
+	RecommendationMixInterval    int `yaml:"recommendation-mix-interval" env:"RECOMMENDATION_MIX_INTERVAL" env-default:"3"`
+	RecommendationsPerUser       int `yaml:"recommendations-per-user" env:"RECOMMENDATIONS_PER_USER" env-default:"100"`
+	RecommendationRefreshMinutes int `yaml:"recommendation-refresh-minutes" env:"RECOMMENDATION_REFRESH_MINUTES" env-default:"60"`
+	DailyPicksPerUser            int `yaml:"daily-picks-per-user" env:"DAILY_PICKS_PER_USER" env-default:"5"`
+	// QueryTimeoutMillis is how long a request can spend running queries before it is cancelled with a 504
+	QueryTimeoutMillis int `yaml:"query-timeout-millis" env:"QUERY_TIMEOUT_MILLIS" env-default:"5000"`
+	// LogLevel is one of debug, info, warn or error, and LogFormat either json or text
+	LogLevel  string `yaml:"log-level" env:"LOG_LEVEL" env-default:"info"`
+	LogFormat string `yaml:"log-format" env:"LOG_FORMAT" env-default:"json"`
+	// OtlpEndpoint is the url of the collector that traces are exported to, when it isn't set they are written to
+	// TraceFile, or stdout if that isn't set either
+	OtlpEndpoint string `yaml:"otlp-endpoint" env:"OTEL_EXPORTER_OTLP_ENDPOINT"`
+	TraceFile    string `yaml:"trace-file" env:"TRACE_FILE"`
+	// LocalBillingWebhookSecret enables the local billing provider when it is set, it must not be set in production
+	LocalBillingWebhookSecret string `yaml:"local-billing-webhook-secret" env:"LOCAL_BILLING_WEBHOOK_SECRET"`
 }
 
 func NewConfig() (*Config, error) {
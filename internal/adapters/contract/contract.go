@@ -0,0 +1,859 @@
+// Package contract is a test suite that every storage adapter must pass, so that the service behaves the same whichever
+// storage it is started with.
+package contract
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"github.com/AlecSmith96/dating-api/internal/adapters"
+	"github.com/AlecSmith96/dating-api/internal/entities"
+	"github.com/google/uuid"
+	. "github.com/onsi/gomega"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Run is a function that runs every contract test against storage created by newStorage, each test is given its own
+// storage holding only the seeded admin user
+func Run(t *testing.T, newStorage func(t *testing.T) adapters.Storage) {
+	tests := []struct {
+		name string
+		test func(g *WithT, storage adapters.Storage)
+	}{
+		{"CreateUser", testCreateUser},
+		{"LoginUser", testLoginUser},
+		{"JWT", testJWT},
+		{"DiscoverNewUsers", testDiscoverNewUsers},
+		{"DiscoverySnapshots", testDiscoverySnapshots},
+		{"RegisterSwipe", testRegisterSwipe},
+		{"RegisterSwipe_ConcurrentQuota", testRegisterSwipeConcurrentQuota},
+		{"IsMatch", testIsMatch},
+		{"RewindLastSwipe", testRewindLastSwipe},
+		{"LikesReceived", testLikesReceived},
+		{"BlockUser", testBlockUser},
+		{"Moderation", testModeration},
+		{"Entitlements", testEntitlements},
+		{"Boosts", testBoosts},
+		{"Events", testEvents},
+		{"Questions", testQuestions},
+		{"DailyPicks", testDailyPicks},
+		{"Desirability", testDesirability},
+		{"WithinTx", testWithinTx},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.test(NewWithT(t), newStorage(t))
+		})
+	}
+}
+
+// newUser is a function that returns an adult user with the name and gender, their email is the same as their name
+func newUser(name, gender string) *entities.User {
+	return &entities.User{
+		Email:       name,
+		Password:    "password",
+		Name:        name,
+		Gender:      gender,
+		DateOfBirth: time.Date(1995, time.March, 14, 0, 0, 0, 0, time.UTC),
+		Location:    entities.Location{Latitude: 51.5072, Longitude: -0.1276},
+		Timezone:    "Europe/London",
+		Interests:   []string{"hiking"},
+	}
+}
+
+// createUser is a function that creates a user returned by newUser
+func createUser(g *WithT, storage adapters.Storage, name, gender string) *entities.User {
+	user, err := storage.CreateUser(context.Background(), newUser(name, gender))
+	g.Expect(err).ToNot(HaveOccurred())
+
+	return user
+}
+
+// swipe is a function that registers a swipe that is expected to succeed
+func swipe(g *WithT, storage adapters.Storage, owner, swiped *entities.User, swipeType entities.SwipeType) {
+	_, err := storage.RegisterSwipe(context.Background(), owner.ID, swiped.ID, swipeType)
+	g.Expect(err).ToNot(HaveOccurred())
+}
+
+// subscribe is a function that gives the user an active subscription to the tier
+func subscribe(g *WithT, storage adapters.Storage, user *entities.User, tier entities.Tier) *entities.Subscription {
+	subscription, err := storage.ApplyBillingEvent(context.Background(), billingEvent(user, tier, entities.SubscriptionStatusActive, time.Now()))
+	g.Expect(err).ToNot(HaveOccurred())
+
+	return subscription
+}
+
+// billingEvent is a function that returns a new event for the users subscription
+func billingEvent(user *entities.User, tier entities.Tier, status entities.SubscriptionStatus, occurredAt time.Time) *entities.BillingEvent {
+	return &entities.BillingEvent{
+		ID:                     uuid.NewString(),
+		Provider:               "local",
+		ProviderSubscriptionID: "sub-" + user.ID.String(),
+		UserID:                 user.ID,
+		Tier:                   tier,
+		Status:                 status,
+		CurrentPeriodEnd:       time.Now().Add(30 * 24 * time.Hour),
+		OccurredAt:             occurredAt,
+		Payload:                json.RawMessage(`{}`),
+	}
+}
+
+// discoveredIDs is a function that returns the ids of the users discovery returns for the owner
+func discoveredIDs(g *WithT, storage adapters.Storage, owner *entities.User, pageInfo entities.PageInfo) []uuid.UUID {
+	users, err := storage.DiscoverNewUsers(context.Background(), owner.ID, pageInfo)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	ids := make([]uuid.UUID, 0, len(users))
+	for _, user := range users {
+		ids = append(ids, user.ID)
+	}
+
+	return ids
+}
+
+// admin is a function that logs in as the admin user seeded by the migrations
+func admin(g *WithT, storage adapters.Storage) *entities.User {
+	user, err := storage.LoginUser(context.Background(), "admin", "admin")
+	g.Expect(err).ToNot(HaveOccurred())
+
+	return user
+}
+
+// ban is a function that bans the user through a report actioned by the admin
+func ban(g *WithT, storage adapters.Storage, user *entities.User) {
+	ctx := context.Background()
+	moderator := admin(g, storage)
+
+	report, err := storage.ReportUser(ctx, &entities.Report{
+		ReporterUserID: moderator.ID,
+		ReportedUserID: user.ID,
+		Reason:         entities.ReportReasonScam,
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	_, err = storage.AssignModerationCase(ctx, report.CaseID, moderator.ID, moderator.ID)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	_, err = storage.ApplyModerationAction(ctx, &entities.ModerationAction{
+		CaseID:          report.CaseID,
+		ModeratorUserID: moderator.ID,
+		Action:          entities.ModerationActionBan,
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+}
+
+func testCreateUser(g *WithT, storage adapters.Storage) {
+	ctx := context.Background()
+
+	user := createUser(g, storage, "alice", "female")
+	g.Expect(user.ID).ToNot(Equal(uuid.Nil))
+	g.Expect(user.Name).To(Equal("alice"))
+	g.Expect(user.Interests).To(Equal([]string{"hiking"}))
+
+	_, err := storage.CreateUser(ctx, &entities.User{
+		Email:       "young",
+		Password:    "password",
+		Name:        "young",
+		Gender:      "male",
+		DateOfBirth: time.Now().AddDate(-17, 0, 0),
+		Timezone:    "UTC",
+	})
+	g.Expect(err).To(MatchError(entities.ErrUserUnderage))
+
+	_, err = storage.CreateUser(ctx, &entities.User{
+		Email:       "lost",
+		Password:    "password",
+		Name:        "lost",
+		Gender:      "male",
+		DateOfBirth: time.Date(1990, time.January, 1, 0, 0, 0, 0, time.UTC),
+		Timezone:    "Not/AZone",
+	})
+	g.Expect(err).To(HaveOccurred())
+}
+
+func testLoginUser(g *WithT, storage adapters.Storage) {
+	ctx := context.Background()
+	user := createUser(g, storage, "alice", "female")
+
+	loggedIn, err := storage.LoginUser(ctx, "alice", "password")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(loggedIn.ID).To(Equal(user.ID))
+
+	_, err = storage.LoginUser(ctx, "alice", "wrong")
+	g.Expect(err).To(MatchError(entities.ErrUserNotFound))
+
+	adminUser := admin(g, storage)
+	role, err := storage.GetUserRole(ctx, adminUser.ID)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(role).To(Equal(entities.RoleAdmin))
+
+	role, err = storage.GetUserRole(ctx, user.ID)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(role).To(Equal(entities.RoleUser))
+}
+
+func testJWT(g *WithT, storage adapters.Storage) {
+	ctx := context.Background()
+	user := createUser(g, storage, "alice", "female")
+
+	token, err := storage.IssueJWT(ctx, user.ID)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(token.UserID).To(Equal(user.ID))
+
+	userID, err := storage.ValidateJwtForUser(ctx, token.Value)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(userID).To(Equal(user.ID))
+
+	_, err = storage.ValidateJwtForUser(ctx, "not-a-token")
+	g.Expect(err).To(MatchError(sql.ErrNoRows))
+
+	_, err = storage.IssueJWT(ctx, uuid.New())
+	g.Expect(err).To(MatchError(sql.ErrNoRows))
+}
+
+func testDiscoverNewUsers(g *WithT, storage adapters.Storage) {
+	ctx := context.Background()
+	owner := createUser(g, storage, "owner", "male")
+	swiped := createUser(g, storage, "swiped", "female")
+	blocked := createUser(g, storage, "blocked", "female")
+	man := createUser(g, storage, "man", "male")
+	woman := createUser(g, storage, "woman", "female")
+	farAwayUser := newUser("far-away", "male")
+	farAwayUser.Location = entities.Location{Latitude: 53.4808, Longitude: -2.2426}
+	farAway, err := storage.CreateUser(ctx, farAwayUser)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	banned := createUser(g, storage, "banned", "female")
+
+	swipe(g, storage, owner, swiped, entities.SwipeTypePass)
+	g.Expect(storage.BlockUser(ctx, blocked.ID, owner.ID)).To(Succeed())
+	ban(g, storage, banned)
+
+	ids := discoveredIDs(g, storage, owner, entities.PageInfo{})
+	g.Expect(ids).To(ContainElements(man.ID, woman.ID))
+	g.Expect(ids).ToNot(ContainElements(owner.ID))
+	g.Expect(ids).ToNot(ContainElements(swiped.ID))
+	g.Expect(ids).ToNot(ContainElements(blocked.ID))
+	g.Expect(ids).ToNot(ContainElement(banned.ID))
+
+	g.Expect(discoveredIDs(g, storage, owner, entities.PageInfo{PreferredGenders: []string{"female"}})).To(Equal([]uuid.UUID{woman.ID}))
+	g.Expect(discoveredIDs(g, storage, owner, entities.PageInfo{MinAge: 90})).To(BeEmpty())
+	g.Expect(discoveredIDs(g, storage, owner, entities.PageInfo{UserIDs: []uuid.UUID{man.ID, swiped.ID}})).To(Equal([]uuid.UUID{man.ID}))
+	g.Expect(discoveredIDs(g, storage, owner, entities.PageInfo{UserIDs: []uuid.UUID{}})).To(BeEmpty())
+
+	nearbyIDs := discoveredIDs(g, storage, owner, entities.PageInfo{MaxDistance: 50, Origin: owner.Location})
+	g.Expect(nearbyIDs).To(ContainElements(man.ID, woman.ID))
+	g.Expect(nearbyIDs).ToNot(ContainElement(farAway.ID))
+	g.Expect(discoveredIDs(g, storage, owner, entities.PageInfo{MaxDistance: 200, Origin: owner.Location})).To(ContainElement(farAway.ID))
+
+	// the most recently active users are kept when the users are limited
+	swipe(g, storage, woman, man, entities.SwipeTypePass)
+	g.Expect(discoveredIDs(g, storage, owner, entities.PageInfo{Limit: 1})).To(Equal([]uuid.UUID{woman.ID}))
+
+	users, err := storage.DiscoverNewUsers(ctx, man.ID, entities.PageInfo{PreferredGenders: []string{"male"}})
+	g.Expect(err).ToNot(HaveOccurred())
+	for _, user := range users {
+		if user.ID == owner.ID {
+			g.Expect(user.SwipesGiven).To(Equal(1))
+			g.Expect(user.LikesGiven).To(Equal(0))
+			g.Expect(user.SharedInterests).To(Equal(1))
+			g.Expect(user.Desirability).To(Equal(entities.DefaultDesirability))
+		}
+	}
+
+	location, err := storage.GetUsersLocation(ctx, owner.ID)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(*location).To(Equal(owner.Location))
+
+	_, err = storage.GetUsersLocation(ctx, uuid.New())
+	g.Expect(err).To(MatchError(sql.ErrNoRows))
+}
+
+func testDiscoverySnapshots(g *WithT, storage adapters.Storage) {
+	ctx := context.Background()
+	owner := createUser(g, storage, "owner", "male")
+	other := createUser(g, storage, "other", "female")
+	rankedUserIDs := []uuid.UUID{other.ID, uuid.New()}
+
+	snapshot, err := storage.SaveDiscoverySnapshot(ctx, owner.ID, rankedUserIDs)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(snapshot.UserID).To(Equal(owner.ID))
+	g.Expect(snapshot.RankedUserIDs).To(Equal(rankedUserIDs))
+
+	stored, err := storage.GetDiscoverySnapshot(ctx, owner.ID, snapshot.ID, time.Hour)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(stored.ID).To(Equal(snapshot.ID))
+	g.Expect(stored.RankedUserIDs).To(Equal(rankedUserIDs))
+
+	_, err = storage.GetDiscoverySnapshot(ctx, other.ID, snapshot.ID, time.Hour)
+	g.Expect(err).To(MatchError(entities.ErrSnapshotNotFound))
+
+	time.Sleep(10 * time.Millisecond)
+	_, err = storage.GetDiscoverySnapshot(ctx, owner.ID, snapshot.ID, time.Millisecond)
+	g.Expect(err).To(MatchError(entities.ErrSnapshotNotFound))
+
+	// a new snapshot replaces the old one, so the old ones cursors stop working
+	replacement, err := storage.SaveDiscoverySnapshot(ctx, owner.ID, []uuid.UUID{other.ID})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(replacement.ID).ToNot(Equal(snapshot.ID))
+
+	_, err = storage.GetDiscoverySnapshot(ctx, owner.ID, snapshot.ID, time.Hour)
+	g.Expect(err).To(MatchError(entities.ErrSnapshotNotFound))
+
+	stored, err = storage.GetDiscoverySnapshot(ctx, owner.ID, replacement.ID, time.Hour)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(stored.RankedUserIDs).To(Equal([]uuid.UUID{other.ID}))
+
+	_, err = storage.SaveDiscoverySnapshot(ctx, uuid.New(), rankedUserIDs)
+	g.Expect(err).To(MatchError(entities.ErrTargetUserNotFound))
+}
+
+func testRegisterSwipe(g *WithT, storage adapters.Storage) {
+	ctx := context.Background()
+	owner := createUser(g, storage, "owner", "male")
+	first := createUser(g, storage, "first", "female")
+	second := createUser(g, storage, "second", "female")
+	blocker := createUser(g, storage, "blocker", "female")
+
+	quota, err := storage.RegisterSwipe(ctx, owner.ID, first.ID, entities.SwipeTypeLike)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(quota.Tier).To(Equal(entities.TierFree))
+	g.Expect(quota.SwipeType).To(Equal(entities.SwipeTypeLike))
+	g.Expect(quota.Limit).To(Equal(100))
+	g.Expect(quota.Used).To(Equal(1))
+
+	quota, err = storage.RegisterSwipe(ctx, owner.ID, second.ID, entities.SwipeTypeSuperLike)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(quota.SwipeType).To(Equal(entities.SwipeTypeSuperLike))
+	g.Expect(quota.Limit).To(Equal(1))
+	g.Expect(quota.Used).To(Equal(1))
+
+	quota, err = storage.RegisterSwipe(ctx, owner.ID, blocker.ID, entities.SwipeTypeSuperLike)
+	g.Expect(err).To(MatchError(entities.ErrSwipeQuotaExceeded))
+	g.Expect(quota.Remaining()).To(Equal(0))
+
+	_, err = storage.RegisterSwipe(ctx, owner.ID, uuid.New(), entities.SwipeTypePass)
+	g.Expect(err).To(MatchError(entities.ErrTargetUserNotFound))
+
+	g.Expect(storage.BlockUser(ctx, blocker.ID, owner.ID)).To(Succeed())
+	_, err = storage.RegisterSwipe(ctx, owner.ID, blocker.ID, entities.SwipeTypePass)
+	g.Expect(err).To(MatchError(entities.ErrUserBlocked))
+}
+
+func testRegisterSwipeConcurrentQuota(g *WithT, storage adapters.Storage) {
+	owner := createUser(g, storage, "owner", "male")
+	targets := make([]*entities.User, 10)
+	for i := range targets {
+		targets[i] = createUser(g, storage, "target"+string(rune('a'+i)), "female")
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, len(targets))
+	for i, target := range targets {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, errs[i] = storage.RegisterSwipe(context.Background(), owner.ID, target.ID, entities.SwipeTypeSuperLike)
+		}()
+	}
+	wg.Wait()
+
+	succeeded := 0
+	for _, err := range errs {
+		if err == nil {
+			succeeded++
+			continue
+		}
+		g.Expect(err).To(MatchError(entities.ErrSwipeQuotaExceeded))
+	}
+	g.Expect(succeeded).To(Equal(1))
+}
+
+func testIsMatch(g *WithT, storage adapters.Storage) {
+	ctx := context.Background()
+	alice := createUser(g, storage, "alice", "female")
+	bob := createUser(g, storage, "bob", "male")
+
+	swipe(g, storage, alice, bob, entities.SwipeTypeLike)
+	match, err := storage.IsMatch(ctx, alice.ID, bob.ID)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(match).To(BeNil())
+
+	swipe(g, storage, bob, alice, entities.SwipeTypeSuperLike)
+	match, err = storage.IsMatch(ctx, bob.ID, alice.ID)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(match).ToNot(BeNil())
+	g.Expect(match.OwnerUserID).To(Equal(bob.ID))
+	g.Expect(match.MatchedUserID).To(Equal(alice.ID))
+}
+
+func testRewindLastSwipe(g *WithT, storage adapters.Storage) {
+	ctx := context.Background()
+	alice := createUser(g, storage, "alice", "female")
+	bob := createUser(g, storage, "bob", "male")
+	carl := createUser(g, storage, "carl", "male")
+
+	swipe(g, storage, alice, carl, entities.SwipeTypePass)
+	rewound, err := storage.RewindLastSwipe(ctx, alice.ID, time.Hour)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(rewound.SwipedUserID).To(Equal(carl.ID))
+	g.Expect(rewound.Type).To(Equal(entities.SwipeTypePass))
+	g.Expect(discoveredIDs(g, storage, alice, entities.PageInfo{})).To(ContainElement(carl.ID))
+
+	_, err = storage.RewindLastSwipe(ctx, alice.ID, time.Hour)
+	g.Expect(err).To(MatchError(entities.ErrNoSwipeToRewind))
+
+	swipe(g, storage, bob, alice, entities.SwipeTypeLike)
+	swipe(g, storage, alice, bob, entities.SwipeTypeLike)
+	_, err = storage.IsMatch(ctx, alice.ID, bob.ID)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	_, err = storage.RewindLastSwipe(ctx, alice.ID, time.Hour)
+	g.Expect(err).To(MatchError(entities.ErrSwipeMatched))
+}
+
+func testLikesReceived(g *WithT, storage adapters.Storage) {
+	ctx := context.Background()
+	liked := createUser(g, storage, "liked", "female")
+	liker := createUser(g, storage, "liker", "male")
+	superLiker := createUser(g, storage, "superliker", "male")
+	passer := createUser(g, storage, "passer", "male")
+	banned := createUser(g, storage, "banned", "male")
+
+	swipe(g, storage, superLiker, liked, entities.SwipeTypeSuperLike)
+	swipe(g, storage, liker, liked, entities.SwipeTypeLike)
+	swipe(g, storage, passer, liked, entities.SwipeTypePass)
+	swipe(g, storage, banned, liked, entities.SwipeTypeLike)
+	ban(g, storage, banned)
+
+	count, err := storage.CountLikesReceived(ctx, liked.ID)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(count).To(Equal(2))
+
+	likes, err := storage.GetLikesReceived(ctx, liked.ID, 10, 0)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(likes).To(HaveLen(2))
+	g.Expect(likes[0].UserID).To(Equal(superLiker.ID))
+	g.Expect(likes[0].SwipeType).To(Equal(entities.SwipeTypeSuperLike))
+	g.Expect(likes[1].UserID).To(Equal(liker.ID))
+	g.Expect(likes[1].Name).To(Equal("liker"))
+
+	likes, err = storage.GetLikesReceived(ctx, liked.ID, 10, 2)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(likes).To(BeEmpty())
+}
+
+func testBlockUser(g *WithT, storage adapters.Storage) {
+	ctx := context.Background()
+	alice := createUser(g, storage, "alice", "female")
+	bob := createUser(g, storage, "bob", "male")
+
+	swipe(g, storage, alice, bob, entities.SwipeTypeLike)
+	swipe(g, storage, bob, alice, entities.SwipeTypeLike)
+	match, err := storage.IsMatch(ctx, bob.ID, alice.ID)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(match).ToNot(BeNil())
+
+	g.Expect(storage.BlockUser(ctx, alice.ID, bob.ID)).To(Succeed())
+	g.Expect(storage.BlockUser(ctx, alice.ID, bob.ID)).To(Succeed())
+
+	match, err = storage.IsMatch(ctx, bob.ID, alice.ID)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(match).To(BeNil())
+
+	_, err = storage.RewindLastSwipe(ctx, bob.ID, time.Hour)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	g.Expect(storage.BlockUser(ctx, alice.ID, uuid.New())).To(MatchError(entities.ErrTargetUserNotFound))
+}
+
+func testModeration(g *WithT, storage adapters.Storage) {
+	ctx := context.Background()
+	moderator := admin(g, storage)
+	reporter := createUser(g, storage, "reporter", "female")
+	reported := createUser(g, storage, "reported", "male")
+
+	token, err := storage.IssueJWT(ctx, reported.ID)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	report, err := storage.ReportUser(ctx, &entities.Report{
+		ReporterUserID: reporter.ID,
+		ReportedUserID: reported.ID,
+		Reason:         entities.ReportReasonScam,
+		Details:        "asked for money",
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(report.Status).To(Equal(entities.ModerationCaseStatusOpen))
+
+	cases, err := storage.GetModerationCases(ctx, entities.ModerationCaseStatusOpen, 10)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(cases).To(HaveLen(1))
+	g.Expect(cases[0].ID).To(Equal(report.CaseID))
+	g.Expect(cases[0].SubjectUserID).To(Equal(reported.ID))
+	g.Expect(cases[0].ReportReason).To(Equal(entities.ReportReasonScam))
+	g.Expect(cases[0].ReportDetails).To(Equal("asked for money"))
+
+	_, err = storage.AssignModerationCase(ctx, report.CaseID, reporter.ID, moderator.ID)
+	g.Expect(err).To(MatchError(entities.ErrNotAModerator))
+
+	_, err = storage.GetModerationCase(ctx, uuid.New())
+	g.Expect(err).To(MatchError(entities.ErrCaseNotFound))
+
+	moderationCase, err := storage.AssignModerationCase(ctx, report.CaseID, moderator.ID, moderator.ID)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(moderationCase.Status).To(Equal(entities.ModerationCaseStatusInReview))
+	g.Expect(moderationCase.AssignedModeratorID).To(Equal(uuid.NullUUID{UUID: moderator.ID, Valid: true}))
+
+	moderationCase, err = storage.ApplyModerationAction(ctx, &entities.ModerationAction{
+		CaseID:          report.CaseID,
+		ModeratorUserID: moderator.ID,
+		Action:          entities.ModerationActionBan,
+		Note:            "scammer",
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(moderationCase.Status).To(Equal(entities.ModerationCaseStatusActioned))
+
+	_, err = storage.UpdateModerationCaseStatus(ctx, report.CaseID, entities.ModerationCaseStatusDismissed, moderator.ID)
+	g.Expect(err).To(MatchError(entities.ErrInvalidTransition))
+
+	audit, err := storage.GetModerationAudit(ctx, report.CaseID)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(audit).To(HaveLen(2))
+	g.Expect(audit[0].Action).To(Equal("assigned"))
+	g.Expect(audit[1].Action).To(Equal("action_taken"))
+
+	_, err = storage.ValidateJwtForUser(ctx, token.Value)
+	g.Expect(err).To(MatchError(entities.ErrUserBanned))
+
+	_, err = storage.LoginUser(ctx, "reported", "password")
+	g.Expect(err).To(MatchError(entities.ErrUserBanned))
+}
+
+func testEntitlements(g *WithT, storage adapters.Storage) {
+	ctx := context.Background()
+	user := createUser(g, storage, "alice", "female")
+
+	entitlements, err := storage.GetEntitlements(ctx, user.ID)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(entitlements.Tier).To(Equal(entities.TierFree))
+	g.Expect(entitlements.Subscription).To(BeNil())
+	g.Expect(entitlements.CanSeeLikes).To(BeFalse())
+	g.Expect(entitlements.LikeQuota.Limit).To(Equal(100))
+	g.Expect(entitlements.SuperLikeQuota.Limit).To(Equal(1))
+
+	event := billingEvent(user, entities.TierPremium, entities.SubscriptionStatusActive, time.Now())
+	subscription, err := storage.ApplyBillingEvent(ctx, event)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(subscription.Tier).To(Equal(entities.TierPremium))
+	g.Expect(subscription.Status).To(Equal(entities.SubscriptionStatusActive))
+
+	_, err = storage.ApplyBillingEvent(ctx, event)
+	g.Expect(err).To(MatchError(entities.ErrDuplicateEvent))
+
+	stale := billingEvent(user, entities.TierPremium, entities.SubscriptionStatusExpired, time.Now().Add(-time.Hour))
+	subscription, err = storage.ApplyBillingEvent(ctx, stale)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(subscription.Status).To(Equal(entities.SubscriptionStatusActive))
+
+	entitlements, err = storage.GetEntitlements(ctx, user.ID)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(entitlements.Tier).To(Equal(entities.TierPremium))
+	g.Expect(entitlements.Subscription.ID).To(Equal(subscription.ID))
+	g.Expect(entitlements.CanRewind).To(BeTrue())
+	g.Expect(entitlements.CanSeeLikes).To(BeTrue())
+	g.Expect(entitlements.LikeQuota.Unlimited).To(BeTrue())
+	g.Expect(entitlements.SuperLikeQuota.Limit).To(Equal(5))
+	g.Expect(entitlements.BoostLimit).To(Equal(4))
+
+	_, err = storage.ApplyBillingEvent(ctx, billingEvent(&entities.User{ID: uuid.New()}, entities.TierPlus, entities.SubscriptionStatusActive, time.Now()))
+	g.Expect(err).To(MatchError(entities.ErrTargetUserNotFound))
+
+	_, err = storage.GetEntitlements(ctx, uuid.New())
+	g.Expect(err).To(MatchError(sql.ErrNoRows))
+}
+
+func testBoosts(g *WithT, storage adapters.Storage) {
+	ctx := context.Background()
+	user := createUser(g, storage, "alice", "female")
+	viewer := createUser(g, storage, "bob", "male")
+
+	_, err := storage.StartBoost(ctx, user.ID, 30*time.Minute)
+	g.Expect(err).To(MatchError(entities.ErrNoBoostsLeft))
+
+	subscribe(g, storage, user, entities.TierPlus)
+	boost, err := storage.StartBoost(ctx, user.ID, 30*time.Minute)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(boost.UserID).To(Equal(user.ID))
+	g.Expect(boost.EndsAt.Sub(boost.StartsAt)).To(Equal(30 * time.Minute))
+
+	_, err = storage.StartBoost(ctx, user.ID, 30*time.Minute)
+	g.Expect(err).To(MatchError(entities.ErrBoostActive))
+
+	g.Expect(storage.RecordProfileViews(ctx, []uuid.UUID{user.ID, viewer.ID})).To(Succeed())
+	g.Expect(storage.RecordProfileViews(ctx, []uuid.UUID{user.ID})).To(Succeed())
+	g.Expect(storage.RecordProfileViews(ctx, []uuid.UUID{user.ID, uuid.New()})).To(HaveOccurred())
+	swipe(g, storage, viewer, user, entities.SwipeTypeLike)
+
+	reports, err := storage.GetBoostReports(ctx, user.ID)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(reports).To(HaveLen(1))
+	g.Expect(reports[0].ID).To(Equal(boost.ID))
+	g.Expect(reports[0].Views).To(Equal(2))
+	g.Expect(reports[0].Likes).To(Equal(1))
+
+	entitlements, err := storage.GetEntitlements(ctx, user.ID)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(entitlements.BoostsRemaining()).To(Equal(0))
+}
+
+func testEvents(g *WithT, storage adapters.Storage) {
+	ctx := context.Background()
+	user := createUser(g, storage, "alice", "female")
+	actor := createUser(g, storage, "bob", "male")
+	blocked := createUser(g, storage, "carl", "male")
+
+	latest, err := storage.GetLatestEventID(ctx, user.ID)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(latest).To(BeZero())
+
+	for _, actorUser := range []*entities.User{actor, blocked} {
+		err = storage.RecordEvent(ctx, &entities.Event{
+			UserID:      user.ID,
+			ActorUserID: actorUser.ID,
+			Type:        entities.EventTypeProfileLiked,
+			Payload:     json.RawMessage(`{"likedBy":"` + actorUser.ID.String() + `"}`),
+		})
+		g.Expect(err).ToNot(HaveOccurred())
+	}
+	g.Expect(storage.BlockUser(ctx, user.ID, blocked.ID)).To(Succeed())
+
+	err = storage.RecordEvent(ctx, &entities.Event{UserID: uuid.New(), ActorUserID: actor.ID, Type: entities.EventTypeNewMatch, Payload: json.RawMessage(`{}`)})
+	g.Expect(err).To(HaveOccurred())
+
+	events, err := storage.GetEventsSince(ctx, user.ID, 0)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(events).To(HaveLen(1))
+	g.Expect(events[0].ActorUserID).To(Equal(actor.ID))
+	g.Expect(events[0].Type).To(Equal(entities.EventTypeProfileLiked))
+	g.Expect(events[0].Payload).To(MatchJSON(`{"likedBy":"` + actor.ID.String() + `"}`))
+
+	latest, err = storage.GetLatestEventID(ctx, user.ID)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(latest).To(BeNumerically(">", events[0].ID))
+
+	events, err = storage.GetEventsSince(ctx, user.ID, latest)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(events).To(BeEmpty())
+
+	pruned, err := storage.PruneEvents(ctx, time.Hour)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(pruned).To(BeZero())
+
+	pruned, err = storage.PruneEvents(ctx, -time.Hour)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(pruned).To(Equal(int64(2)))
+}
+
+func testQuestions(g *WithT, storage adapters.Storage) {
+	ctx := context.Background()
+	user := createUser(g, storage, "alice", "female")
+
+	question, err := storage.CreateQuestion(ctx, &entities.Question{
+		Text:    "Cats or dogs?",
+		Answers: []entities.Answer{{Text: "Cats"}, {Text: "Dogs"}},
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(question.Answers).To(HaveLen(2))
+
+	questions, err := storage.GetQuestions(ctx)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(questions).To(HaveLen(1))
+	g.Expect(questions[0].Answers[1].Text).To(Equal("Dogs"))
+
+	_, err = storage.GetQuestion(ctx, uuid.New())
+	g.Expect(err).To(MatchError(entities.ErrQuestionNotFound))
+
+	g.Expect(storage.AnswerQuestion(ctx, &entities.UserAnswer{
+		UserID:     user.ID,
+		QuestionID: question.ID,
+		AnswerID:   uuid.New(),
+		Importance: entities.ImportanceSomewhat,
+	})).To(MatchError(entities.ErrInvalidAnswer))
+
+	g.Expect(storage.AnswerQuestion(ctx, &entities.UserAnswer{
+		UserID:     user.ID,
+		QuestionID: uuid.New(),
+		AnswerID:   question.Answers[0].ID,
+		Importance: entities.ImportanceSomewhat,
+	})).To(MatchError(entities.ErrQuestionNotFound))
+
+	g.Expect(storage.AnswerQuestion(ctx, &entities.UserAnswer{
+		UserID:     user.ID,
+		QuestionID: question.ID,
+		AnswerID:   question.Answers[0].ID,
+		Importance: entities.ImportanceSomewhat,
+	})).To(Succeed())
+
+	answers, err := storage.GetUserAnswers(ctx, user.ID)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(answers).To(Equal([]entities.UserAnswer{{
+		UserID:            user.ID,
+		QuestionID:        question.ID,
+		AnswerID:          question.Answers[0].ID,
+		AcceptedAnswerIDs: []uuid.UUID{},
+		Importance:        entities.ImportanceSomewhat,
+	}}))
+
+	_, err = storage.UpdateQuestion(ctx, &entities.Question{
+		ID:      question.ID,
+		Text:    "Cats, dogs or fish?",
+		Answers: []entities.Answer{{Text: "Cats"}, {Text: "Dogs"}, {Text: "Fish"}},
+	})
+	g.Expect(err).To(MatchError(entities.ErrQuestionAnswered))
+
+	updated, err := storage.UpdateQuestion(ctx, &entities.Question{
+		ID:      question.ID,
+		Text:    "Cat or dog?",
+		Answers: []entities.Answer{{Text: "Cat"}, {Text: "Dog"}},
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(updated.Answers[0]).To(Equal(entities.Answer{ID: question.Answers[0].ID, Text: "Cat"}))
+
+	answersByUser, err := storage.GetAnswersForUsers(ctx, []uuid.UUID{user.ID, uuid.New()})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(answersByUser).To(HaveLen(1))
+	g.Expect(answersByUser[user.ID]).To(HaveLen(1))
+
+	g.Expect(storage.DeleteQuestion(ctx, question.ID)).To(Succeed())
+	g.Expect(storage.DeleteQuestion(ctx, question.ID)).To(MatchError(entities.ErrQuestionNotFound))
+
+	answers, err = storage.GetUserAnswers(ctx, user.ID)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(answers).To(BeEmpty())
+}
+
+func testDailyPicks(g *WithT, storage adapters.Storage) {
+	ctx := context.Background()
+	user := createUser(g, storage, "alice", "female")
+	picked := createUser(g, storage, "bob", "male")
+	swiped := createUser(g, storage, "carl", "male")
+	banned := createUser(g, storage, "dave", "male")
+
+	g.Expect(storage.SaveDiscoveryPreferences(ctx, user.ID, entities.PageInfo{MinAge: 25, MaxAge: 40})).To(Succeed())
+
+	due, err := storage.GetUsersDueDailyPicks(ctx, 10)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	var dueUser *entities.DailyPicksUser
+	for i := range due {
+		if due[i].UserID == user.ID {
+			dueUser = &due[i]
+		}
+	}
+	g.Expect(dueUser).ToNot(BeNil())
+	g.Expect(dueUser.Preferences).To(Equal(entities.PageInfo{MinAge: 25, MaxAge: 40, PreferredGenders: []string{}}))
+	g.Expect(dueUser.ExpiresAt).To(BeTemporally(">", time.Now()))
+
+	swipe(g, storage, user, swiped, entities.SwipeTypePass)
+	g.Expect(storage.SaveDailyPicks(ctx, *dueUser, []entities.DailyPick{
+		{CandidateUserID: swiped.ID, Score: 3, Rank: 1},
+		{CandidateUserID: picked.ID, Score: 2, Rank: 2},
+		{CandidateUserID: banned.ID, Score: 1, Rank: 3},
+	})).To(Succeed())
+	ban(g, storage, banned)
+	g.Expect(storage.SaveDailyPicks(ctx, *dueUser, nil)).To(Succeed())
+
+	picks, err := storage.GetTodaysPicks(ctx, user.ID)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(picks).To(HaveLen(1))
+	g.Expect(picks[0].ID).To(Equal(picked.ID))
+	g.Expect(picks[0].Rank).To(Equal(2))
+	g.Expect(picks[0].Age).To(Equal(picked.GetAge()))
+
+	due, err = storage.GetUsersDueDailyPicks(ctx, 10)
+	g.Expect(err).ToNot(HaveOccurred())
+	for _, dueUser := range due {
+		g.Expect(dueUser.UserID).ToNot(Equal(user.ID))
+	}
+}
+
+func testDesirability(g *WithT, storage adapters.Storage) {
+	ctx := context.Background()
+	alice := createUser(g, storage, "alice", "female")
+	bob := createUser(g, storage, "bob", "male")
+	carl := createUser(g, storage, "carl", "male")
+
+	swipe(g, storage, alice, bob, entities.SwipeTypeLike)
+	swipe(g, storage, carl, bob, entities.SwipeTypeLike)
+	swipe(g, storage, alice, carl, entities.SwipeTypePass)
+
+	applied, err := storage.ApplyDesirabilitySwipes(ctx, 2)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(applied).To(Equal(2))
+
+	applied, err = storage.ApplyDesirabilitySwipes(ctx, 10)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(applied).To(Equal(1))
+
+	score, err := storage.GetDesirability(ctx, bob.ID)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(score).To(BeNumerically(">", entities.DefaultDesirability))
+
+	score, err = storage.GetDesirability(ctx, carl.ID)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(score).To(BeNumerically("<", entities.DefaultDesirability))
+
+	g.Expect(storage.ResetDesirability(ctx)).To(Succeed())
+	score, err = storage.GetDesirability(ctx, bob.ID)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(score).To(Equal(entities.DefaultDesirability))
+
+	applied, err = storage.ApplyDesirabilitySwipes(ctx, 10)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(applied).To(Equal(3))
+
+	history, err := storage.GetSwipeHistory(ctx)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(history).To(HaveLen(3))
+	g.Expect(history[0].OwnerUserID).To(Equal(alice.ID))
+	g.Expect(history[0].SwipedUserID).To(Equal(bob.ID))
+
+	g.Expect(storage.ReplaceRecommendations(ctx, []entities.Recommendation{
+		{UserID: alice.ID, CandidateUserID: carl.ID, Score: 1, Rank: 1},
+	})).To(Succeed())
+	g.Expect(storage.ReplaceRecommendations(ctx, []entities.Recommendation{
+		{UserID: alice.ID, CandidateUserID: uuid.New(), Score: 1, Rank: 1},
+	})).To(HaveOccurred())
+}
+
+func testWithinTx(g *WithT, storage adapters.Storage) {
+	ctx := context.Background()
+	errRollback := errors.New("rollback")
+
+	err := storage.WithinTx(ctx, func(ctx context.Context) error {
+		_, err := storage.CreateUser(ctx, newUser("rolledback", "female"))
+		g.Expect(err).ToNot(HaveOccurred())
+
+		_, err = storage.LoginUser(ctx, "rolledback", "password")
+		g.Expect(err).ToNot(HaveOccurred())
+
+		return errRollback
+	})
+	g.Expect(err).To(MatchError(errRollback))
+
+	_, err = storage.LoginUser(ctx, "rolledback", "password")
+	g.Expect(err).To(MatchError(entities.ErrUserNotFound))
+
+	err = storage.WithinTx(ctx, func(ctx context.Context) error {
+		_, err := storage.CreateUser(ctx, newUser("committed", "male"))
+		return err
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	_, err = storage.LoginUser(ctx, "committed", "password")
+	g.Expect(err).ToNot(HaveOccurred())
+}
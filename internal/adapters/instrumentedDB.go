@@ -0,0 +1,221 @@
+package adapters
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/AlecSmith96/dating-api/internal/usecases"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"runtime"
+	"strings"
+	"time"
+)
+
+var tracer = otel.Tracer("github.com/AlecSmith96/dating-api/internal/adapters")
+
+// QueryObserver records how long each query took
+type QueryObserver interface {
+	ObserveQuery(query string, duration time.Duration, err error)
+}
+
+// instrumentedDB times and traces every query run through it, naming them after the adapter method that ran the query
+// so that the names have a fixed set of values
+type instrumentedDB struct {
+	*sql.DB
+	observer QueryObserver
+}
+
+// instrumentedTx times and traces every query run in the transaction in the same way as instrumentedDB
+type instrumentedTx struct {
+	*sql.Tx
+	observer QueryObserver
+}
+
+func newInstrumentedDB(db *sql.DB, observer QueryObserver) *instrumentedDB {
+	return &instrumentedDB{DB: db, observer: observer}
+}
+
+func (db *instrumentedDB) QueryContext(ctx context.Context, query string, args ...any) (*instrumentedRows, error) {
+	ctx, done := startQuery(ctx, db.observer)
+	rows, err := db.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		done(err)
+		return nil, err
+	}
+
+	return &instrumentedRows{Rows: rows, done: done}, nil
+}
+
+func (db *instrumentedDB) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	ctx, done := startQuery(ctx, db.observer)
+	row := db.DB.QueryRowContext(ctx, query, args...)
+	done(row.Err())
+	return row
+}
+
+func (db *instrumentedDB) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	ctx, done := startQuery(ctx, db.observer)
+	result, err := db.DB.ExecContext(ctx, query, args...)
+	done(err)
+	return result, err
+}
+
+func (db *instrumentedDB) BeginTx(ctx context.Context, opts *sql.TxOptions) (*instrumentedTx, error) {
+	tx, err := db.DB.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &instrumentedTx{Tx: tx, observer: db.observer}, nil
+}
+
+func (tx *instrumentedTx) QueryContext(ctx context.Context, query string, args ...any) (*instrumentedRows, error) {
+	ctx, done := startQuery(ctx, tx.observer)
+	rows, err := tx.Tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		done(err)
+		return nil, err
+	}
+
+	return &instrumentedRows{Rows: rows, done: done}, nil
+}
+
+func (tx *instrumentedTx) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	ctx, done := startQuery(ctx, tx.observer)
+	row := tx.Tx.QueryRowContext(ctx, query, args...)
+	done(row.Err())
+	return row
+}
+
+func (tx *instrumentedTx) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	ctx, done := startQuery(ctx, tx.observer)
+	result, err := tx.Tx.ExecContext(ctx, query, args...)
+	done(err)
+	return result, err
+}
+
+// instrumentedRows are the rows of a query run through instrumentedDB or instrumentedTx. The rows are streamed from the
+// database as they are read, so the query is only finished once they are closed, which is when its duration and any
+// error met while reading them are recorded.
+type instrumentedRows struct {
+	*sql.Rows
+	done func(err error)
+}
+
+func (r *instrumentedRows) Close() error {
+	err := r.Rows.Close()
+	if r.done != nil {
+		queryErr := r.Rows.Err()
+		if queryErr == nil {
+			queryErr = err
+		}
+		r.done(queryErr)
+		r.done = nil
+	}
+
+	return err
+}
+
+// startQuery is a function that starts a span for a query, named after the function that called the instrumented
+// method. The span only records the name of the query, not the statement or its arguments, so that no personal data
+// ends up in the traces. Calling done ends the span and records how long the query took, for queries that return rows
+// it is called once the rows are closed so that errors met while reading them are recorded on the span.
+func startQuery(ctx context.Context, observer QueryObserver) (context.Context, func(err error)) {
+	name := queryName()
+	start := time.Now()
+	ctx, span := tracer.Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(semconv.DBSystemPostgreSQL, attribute.String("db.operation.name", name)),
+	)
+
+	return ctx, func(err error) {
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "query failed")
+		}
+		span.End()
+
+		if observer != nil {
+			observer.ObserveQuery(name, time.Since(start), err)
+		}
+	}
+}
+
+// queryName is a function that returns the name of the adapter function that ran the query, such as GetUser for
+// adapters.(*PostgresAdapter).GetUser or lockModerationCase for adapters.lockModerationCase
+func queryName() string {
+	// skip queryName, startQuery and the instrumented method
+	pc, _, _, ok := runtime.Caller(3)
+	if !ok {
+		return "unknown"
+	}
+
+	name := runtime.FuncForPC(pc).Name()
+	name = name[strings.LastIndex(name, "/")+1:]
+	parts := strings.Split(name, ".")
+	if len(parts) > 2 && strings.HasPrefix(parts[1], "(") {
+		return parts[2]
+	}
+	if len(parts) > 1 {
+		return parts[1]
+	}
+
+	return name
+}
+
+// txKey is the context key of the transaction started by PostgresTxManager
+type txKey struct{}
+
+// rowQuerier is satisfied by both the database and its transactions
+type rowQuerier interface {
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// querier is satisfied by both the database and its transactions
+type querier interface {
+	rowQuerier
+	QueryContext(ctx context.Context, query string, args ...any) (*instrumentedRows, error)
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// conn is a function that returns the transaction the context is running in, or the database when it isn't running in
+// one, so that repository calls made inside PostgresTxManager.WithinTx take part in its transaction
+func (db *instrumentedDB) conn(ctx context.Context) querier {
+	if tx, ok := ctx.Value(txKey{}).(*instrumentedTx); ok {
+		return tx
+	}
+
+	return db
+}
+
+// withinTx is a function that runs fn in the transaction the context is running in. When it isn't running in one, fn
+// is run in a new transaction that is committed if fn succeeds and rolled back otherwise.
+func (db *instrumentedDB) withinTx(ctx context.Context, fn func(ctx context.Context, tx *instrumentedTx) error) error {
+	if tx, ok := ctx.Value(txKey{}).(*instrumentedTx); ok {
+		return fn(ctx, tx)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		usecases.Logger(ctx).DebugContext(ctx, "beginning transaction", "err", err)
+		return err
+	}
+	defer tx.Rollback()
+
+	err = fn(context.WithValue(ctx, txKey{}, tx), tx)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		usecases.Logger(ctx).DebugContext(ctx, "committing transaction", "err", err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,78 @@
+package adapters_test
+
+import (
+	"context"
+	"errors"
+	"github.com/AlecSmith96/dating-api/internal/adapters"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	. "github.com/onsi/gomega"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// spanRecorder is a function that returns the recorder of the spans ended by the tests. The global tracer provider
+// can only be set once for the adapters tracer, so every test shares the one recorder.
+var spanRecorder = sync.OnceValue(func() *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	return recorder
+})
+
+func TestInstrumentedDB_TracesQueries(t *testing.T) {
+	g := NewWithT(t)
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	recorded := len(spanRecorder().Ended())
+
+	adapter := adapters.NewPostgresAdapter(db, 0, "something-secret", nil)
+
+	userID := uuid.New()
+	mock.ExpectQuery(`SELECT role FROM user_role WHERE user_id = \$1;`).WithArgs(userID).
+		WillReturnRows(sqlmock.NewRows([]string{"role"}).AddRow("admin"))
+
+	_, err = adapter.GetUserRole(context.Background(), userID)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	spans := spanRecorder().Ended()[recorded:]
+	g.Expect(spans).To(HaveLen(1))
+	g.Expect(spans[0].Name()).To(Equal("GetUserRole"))
+
+	// the span must not carry the statement or its arguments, as they can contain personal data
+	for _, attribute := range spans[0].Attributes() {
+		g.Expect(string(attribute.Key)).ToNot(Equal("db.statement"))
+		g.Expect(attribute.Value.Emit()).ToNot(ContainSubstring(userID.String()))
+	}
+}
+
+func TestInstrumentedDB_TracesRowsErr(t *testing.T) {
+	g := NewWithT(t)
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	recorded := len(spanRecorder().Ended())
+
+	adapter := adapters.NewPostgresAdapter(db, 0, "something-secret", nil)
+
+	// the error only comes up once the rows are being read, after the query has returned
+	userID := uuid.New()
+	mock.ExpectQuery(`FROM event_log`).WithArgs(userID, int64(0)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "actor_user_id", "event_type", "payload", "created_at"}).
+			AddRow(int64(1), userID, nil, "match", []byte("{}"), time.Now()).
+			RowError(0, errors.New("connection reset")))
+
+	_, err = adapter.GetEventsSince(context.Background(), userID, 0)
+	g.Expect(err).To(HaveOccurred())
+
+	spans := spanRecorder().Ended()[recorded:]
+	g.Expect(spans).To(HaveLen(1))
+	g.Expect(spans[0].Name()).To(Equal("GetEventsSince"))
+	g.Expect(spans[0].Status().Code).To(Equal(codes.Error))
+	g.Expect(spans[0].Events()).To(ContainElement(HaveField("Name", "exception")))
+}
@@ -0,0 +1,118 @@
+package adapters
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/AlecSmith96/dating-api/internal/entities"
+	"github.com/AlecSmith96/dating-api/internal/usecases"
+	"github.com/google/uuid"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	LocalBillingProviderName = "local"
+	// LocalBillingSignatureHeader holds the time the webhook was signed and the signature, as t=<unix time>,v1=<hex>
+	LocalBillingSignatureHeader = "Billing-Signature"
+	// localBillingSignatureTolerance is how old a signature can be before it is rejected, to stop webhooks being replayed
+	localBillingSignatureTolerance = 5 * time.Minute
+)
+
+var _ usecases.BillingProvider = &LocalBillingProvider{}
+
+// LocalBillingProvider is a billing provider for local development and tests, standing in for a real payment provider.
+// Webhooks are JSON encoded localBillingEvents signed with HMAC-SHA256 using a shared secret.
+type LocalBillingProvider struct {
+	secret []byte
+}
+
+// localBillingEvent is the body of a webhook sent by the local billing provider
+type localBillingEvent struct {
+	ID               string    `json:"id"`
+	SubscriptionID   string    `json:"subscriptionId"`
+	UserID           uuid.UUID `json:"userId"`
+	Tier             string    `json:"tier"`
+	Status           string    `json:"status"`
+	CurrentPeriodEnd time.Time `json:"currentPeriodEnd"`
+	OccurredAt       time.Time `json:"occurredAt"`
+}
+
+func NewLocalBillingProvider(secret string) *LocalBillingProvider {
+	return &LocalBillingProvider{secret: []byte(secret)}
+}
+
+func (l *LocalBillingProvider) Name() string {
+	return LocalBillingProviderName
+}
+
+// Sign is a function that returns the signature header value for a webhook body signed at signedAt
+func (l *LocalBillingProvider) Sign(body []byte, signedAt time.Time) string {
+	timestamp := strconv.FormatInt(signedAt.Unix(), 10)
+	return fmt.Sprintf("t=%s,v1=%s", timestamp, l.signature(timestamp, body))
+}
+
+func (l *LocalBillingProvider) signature(timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, l.secret)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// ParseWebhook is a function that verifies the signature of a webhook and reads the billing event from its body
+func (l *LocalBillingProvider) ParseWebhook(header http.Header, body []byte) (*entities.BillingEvent, error) {
+	var timestamp, signature string
+	for _, part := range strings.Split(header.Get(LocalBillingSignatureHeader), ",") {
+		key, value, _ := strings.Cut(part, "=")
+		switch key {
+		case "t":
+			timestamp = value
+		case "v1":
+			signature = value
+		}
+	}
+
+	signedAt, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil || signature == "" {
+		return nil, entities.ErrInvalidSignature
+	}
+
+	if !hmac.Equal([]byte(signature), []byte(l.signature(timestamp, body))) {
+		return nil, entities.ErrInvalidSignature
+	}
+
+	age := time.Since(time.Unix(signedAt, 0))
+	if age > localBillingSignatureTolerance || age < -localBillingSignatureTolerance {
+		return nil, entities.ErrInvalidSignature
+	}
+
+	var localEvent localBillingEvent
+	err = json.Unmarshal(body, &localEvent)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", entities.ErrBadBillingEvent, err)
+	}
+
+	event := &entities.BillingEvent{
+		ID:                     localEvent.ID,
+		Provider:               LocalBillingProviderName,
+		ProviderSubscriptionID: localEvent.SubscriptionID,
+		UserID:                 localEvent.UserID,
+		Tier:                   entities.Tier(localEvent.Tier),
+		Status:                 entities.SubscriptionStatus(localEvent.Status),
+		CurrentPeriodEnd:       localEvent.CurrentPeriodEnd,
+		OccurredAt:             localEvent.OccurredAt,
+		Payload:                body,
+	}
+
+	if event.ID == "" || event.ProviderSubscriptionID == "" || event.UserID == uuid.Nil || event.Tier == "" ||
+		!event.Status.IsValid() || event.CurrentPeriodEnd.IsZero() || event.OccurredAt.IsZero() {
+		return nil, entities.ErrBadBillingEvent
+	}
+
+	return event, nil
+}
@@ -0,0 +1,95 @@
+package adapters_test
+
+import (
+	"fmt"
+	"github.com/AlecSmith96/dating-api/internal/adapters"
+	"github.com/AlecSmith96/dating-api/internal/entities"
+	"github.com/google/uuid"
+	. "github.com/onsi/gomega"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestLocalBillingProvider_ParseWebhook(t *testing.T) {
+	userID := uuid.New()
+	body := []byte(fmt.Sprintf(`{"id":"evt_1","subscriptionId":"sub_1","userId":"%s","tier":"premium","status":"trialing","currentPeriodEnd":"2026-11-19T00:00:00Z","occurredAt":"2026-10-19T00:00:00Z"}`, userID))
+
+	provider := adapters.NewLocalBillingProvider("local-secret")
+
+	tests := []struct {
+		name        string
+		body        []byte
+		signature   string
+		expectedErr error
+	}{
+		{
+			name:      "signed webhook",
+			body:      body,
+			signature: provider.Sign(body, time.Now()),
+		},
+		{
+			name:        "missing signature",
+			body:        body,
+			expectedErr: entities.ErrInvalidSignature,
+		},
+		{
+			name:        "signed with another secret",
+			body:        body,
+			signature:   adapters.NewLocalBillingProvider("another-secret").Sign(body, time.Now()),
+			expectedErr: entities.ErrInvalidSignature,
+		},
+		{
+			name:        "body changed after signing",
+			body:        []byte(`{"id":"evt_2"}`),
+			signature:   provider.Sign(body, time.Now()),
+			expectedErr: entities.ErrInvalidSignature,
+		},
+		{
+			name:        "replayed signature",
+			body:        body,
+			signature:   provider.Sign(body, time.Now().Add(-time.Hour)),
+			expectedErr: entities.ErrInvalidSignature,
+		},
+		{
+			name:        "unknown status",
+			body:        []byte(`{"id":"evt_1","subscriptionId":"sub_1","userId":"` + userID.String() + `","tier":"premium","status":"paused","currentPeriodEnd":"2026-11-19T00:00:00Z","occurredAt":"2026-10-19T00:00:00Z"}`),
+			expectedErr: entities.ErrBadBillingEvent,
+		},
+		{
+			name:        "not json",
+			body:        []byte(`not json`),
+			expectedErr: entities.ErrBadBillingEvent,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			signature := tt.signature
+			if signature == "" && tt.expectedErr != entities.ErrInvalidSignature {
+				signature = provider.Sign(tt.body, time.Now())
+			}
+
+			header := http.Header{}
+			header.Set(adapters.LocalBillingSignatureHeader, signature)
+
+			event, err := provider.ParseWebhook(header, tt.body)
+			if tt.expectedErr != nil {
+				g.Expect(err).To(MatchError(tt.expectedErr))
+				return
+			}
+
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(event.ID).To(Equal("evt_1"))
+			g.Expect(event.Provider).To(Equal("local"))
+			g.Expect(event.ProviderSubscriptionID).To(Equal("sub_1"))
+			g.Expect(event.UserID).To(Equal(userID))
+			g.Expect(event.Tier).To(Equal(entities.TierPremium))
+			g.Expect(event.Status).To(Equal(entities.SubscriptionStatusTrialing))
+			g.Expect(event.CurrentPeriodEnd).To(Equal(time.Date(2026, 11, 19, 0, 0, 0, 0, time.UTC)))
+			g.Expect(string(event.Payload)).To(Equal(string(tt.body)))
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package adapters
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"regexp"
+	"strings"
+)
+
+const (
+	LogFormatJSON = "json"
+	LogFormatText = "text"
+
+	redacted = "[REDACTED]"
+)
+
+// sensitiveKeys are the attributes whose values are always redacted, matched ignoring case
+var sensitiveKeys = map[string]bool{
+	"email":         true,
+	"password":      true,
+	"token":         true,
+	"jwt":           true,
+	"authorization": true,
+	"secret":        true,
+	"latitude":      true,
+	"longitude":     true,
+	"location":      true,
+	"coordinates":   true,
+}
+
+var (
+	emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)
+	jwtPattern   = regexp.MustCompile(`eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+`)
+)
+
+// NewLogHandler creates the handler for the services logs, writing logs at or above the level in the format. Personal
+// data is redacted from the logs, and the trace and span ids are added to the logs of traced requests.
+func NewLogHandler(w io.Writer, level, format string) (slog.Handler, error) {
+	var logLevel slog.Level
+	err := logLevel.UnmarshalText([]byte(level))
+	if err != nil {
+		return nil, fmt.Errorf("parsing log level: %w", err)
+	}
+
+	options := &slog.HandlerOptions{Level: logLevel}
+	var handler slog.Handler
+	switch format {
+	case LogFormatJSON:
+		handler = slog.NewJSONHandler(w, options)
+	case LogFormatText:
+		handler = slog.NewTextHandler(w, options)
+	default:
+		return nil, fmt.Errorf("unknown log format %q", format)
+	}
+
+	return NewTraceLogHandler(NewRedactingHandler(handler)), nil
+}
+
+// RedactingHandler masks personal data in logs. Attributes with a sensitive key, such as email or password, are
+// replaced entirely, and any emails or jwts found in the message, other string attributes or the text that other
+// values print as, such as error messages, are masked.
+type RedactingHandler struct {
+	slog.Handler
+}
+
+func NewRedactingHandler(handler slog.Handler) *RedactingHandler {
+	return &RedactingHandler{Handler: handler}
+}
+
+func (h *RedactingHandler) Handle(ctx context.Context, record slog.Record) error {
+	redactedRecord := slog.NewRecord(record.Time, record.Level, redactString(record.Message), record.PC)
+	record.Attrs(func(attr slog.Attr) bool {
+		redactedRecord.AddAttrs(redactAttr(attr))
+		return true
+	})
+
+	return h.Handler.Handle(ctx, redactedRecord)
+}
+
+func (h *RedactingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	redactedAttrs := make([]slog.Attr, 0, len(attrs))
+	for _, attr := range attrs {
+		redactedAttrs = append(redactedAttrs, redactAttr(attr))
+	}
+
+	return &RedactingHandler{Handler: h.Handler.WithAttrs(redactedAttrs)}
+}
+
+func (h *RedactingHandler) WithGroup(name string) slog.Handler {
+	return &RedactingHandler{Handler: h.Handler.WithGroup(name)}
+}
+
+// redactAttr is a function that returns the attribute with any personal data masked
+func redactAttr(attr slog.Attr) slog.Attr {
+	if sensitiveKeys[strings.ToLower(attr.Key)] {
+		return slog.String(attr.Key, redacted)
+	}
+
+	value := attr.Value.Resolve()
+	switch value.Kind() {
+	case slog.KindGroup:
+		groupAttrs := value.Group()
+		redactedAttrs := make([]any, 0, len(groupAttrs))
+		for _, groupAttr := range groupAttrs {
+			redactedAttrs = append(redactedAttrs, redactAttr(groupAttr))
+		}
+		return slog.Group(attr.Key, redactedAttrs...)
+	case slog.KindString:
+		return slog.String(attr.Key, redactString(value.String()))
+	case slog.KindAny:
+		// errors are logged as text, so they are checked in the same way as strings
+		if err, ok := value.Any().(error); ok {
+			return slog.String(attr.Key, redactString(err.Error()))
+		}
+
+		// other values, such as structs or Stringers, are checked as the text they print as, and only logged as that
+		// text when it had something to mask so that the rest keep their structure
+		text := fmt.Sprint(value.Any())
+		if redactedText := redactString(text); redactedText != text {
+			return slog.String(attr.Key, redactedText)
+		}
+		return attr
+	default:
+		return attr
+	}
+}
+
+func redactString(s string) string {
+	s = emailPattern.ReplaceAllString(s, redacted)
+	return jwtPattern.ReplaceAllString(s, redacted)
+}
@@ -0,0 +1,161 @@
+package memory
+
+import (
+	"context"
+	"github.com/AlecSmith96/dating-api/internal/adapters"
+	"github.com/AlecSmith96/dating-api/internal/entities"
+	"github.com/AlecSmith96/dating-api/internal/usecases"
+	"github.com/google/uuid"
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// tier is a row of the entitlement_tier table
+type tier struct {
+	rank int
+	// dailySwipeLimit is 0 when the tier has unlimited positive swipes
+	dailySwipeLimit      int
+	weeklySuperLikeLimit int
+	canRewind            bool
+	canSeeLikes          bool
+	monthlyBoostLimit    int
+}
+
+// tiers are the tiers seeded by the migrations
+var tiers = map[entities.Tier]tier{
+	entities.TierFree:    {rank: 0, dailySwipeLimit: 100, weeklySuperLikeLimit: 1},
+	entities.TierPlus:    {rank: 1, weeklySuperLikeLimit: 3, canRewind: true, monthlyBoostLimit: 1},
+	entities.TierPremium: {rank: 2, weeklySuperLikeLimit: 5, canRewind: true, canSeeLikes: true, monthlyBoostLimit: 4},
+}
+
+// Adapter stores everything in maps, with the same behaviour as the postgres adapter. It starts out with only the seeded
+// admin user and loses everything when the service stops, so it is only meant for running the service locally and for
+// tests.
+type Adapter struct {
+	mu              sync.Mutex
+	state           *state
+	jwtExpiryMillis int
+	jwtSecretKey    string
+}
+
+var _ adapters.Storage = &Adapter{}
+
+// NewAdapter creates the adapter, seeded with the admin user that the migrations create
+func NewAdapter(jwtExpiryMillis int, jwtSecretKey string) *Adapter {
+	s := newState()
+	admin := userRecord{
+		User: entities.User{
+			ID:          uuid.New(),
+			Email:       "admin",
+			Password:    "admin",
+			Name:        "admin",
+			Gender:      "male",
+			DateOfBirth: time.Date(1990, time.January, 1, 0, 0, 0, 0, time.UTC),
+			Location:    entities.Location{Latitude: 51.4545, Longitude: 2.5879},
+			Timezone:    "UTC",
+			Interests:   []string{},
+		},
+		tier: entities.TierFree,
+		seq:  s.next(),
+	}
+	s.users[admin.ID] = admin
+	s.roles[admin.ID] = entities.RoleAdmin
+
+	return &Adapter{
+		state:           s,
+		jwtExpiryMillis: jwtExpiryMillis,
+		jwtSecretKey:    jwtSecretKey,
+	}
+}
+
+type txKey struct{}
+
+// tx is the transaction a context is running in, the adapter stays locked for it until it is done
+type tx struct {
+	adapter *Adapter
+	done    atomic.Bool
+}
+
+// locked is a function that runs fn with the state locked, or in the transaction the context is running in. Methods
+// check everything that can fail before they change the state, so that a failed call doesn't leave it half changed.
+func (a *Adapter) locked(ctx context.Context, fn func(s *state) error) error {
+	if current, ok := ctx.Value(txKey{}).(*tx); ok && current.adapter == a && !current.done.Load() {
+		return fn(a.state)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return fn(a.state)
+}
+
+// WithinTx is a function that runs fn with the adapter locked, so that nothing else sees the calls it makes until it
+// returns. The state is restored to how it was before fn if it returns an error. A call made within a transaction joins
+// it rather than starting another.
+func (a *Adapter) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if current, ok := ctx.Value(txKey{}).(*tx); ok && current.adapter == a && !current.done.Load() {
+		return fn(ctx)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	current := &tx{adapter: a}
+	defer current.done.Store(true)
+
+	usecases.Logger(ctx).DebugContext(ctx, "beginning transaction")
+	snapshot := a.state.clone()
+	err := fn(context.WithValue(ctx, txKey{}, current))
+	if err != nil {
+		a.state = snapshot
+		return err
+	}
+
+	usecases.Logger(ctx).DebugContext(ctx, "committing transaction")
+	return nil
+}
+
+// PingDatabase is a function that always succeeds, there is no database to reach
+func (a *Adapter) PingDatabase(_ context.Context) error {
+	return nil
+}
+
+// GetMigrationVersion is a function that returns the highest possible version, there is no schema to migrate so the
+// storage is never behind the service
+func (a *Adapter) GetMigrationVersion(_ context.Context) (int64, error) {
+	return math.MaxInt64, nil
+}
+
+// now is a function that returns the current time as postgres stores it in a TIMESTAMP column
+func now() time.Time {
+	return time.Now().UTC().Truncate(time.Microsecond)
+}
+
+// userLocation is a function that returns the users timezone, falling back to UTC like entities.User.AgeAt
+func userLocation(user entities.User) *time.Location {
+	location, err := time.LoadLocation(user.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+
+	return location
+}
+
+// localDate is a function that returns the calendar date in the users timezone at the instant, as postgres returns a
+// DATE
+func localDate(user entities.User, instant time.Time) time.Time {
+	return dateOf(instant.In(userLocation(user)))
+}
+
+// dateOf is a function that returns the calendar date of the time in its own location, as postgres returns a DATE
+func dateOf(instant time.Time) time.Time {
+	year, month, day := instant.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// localMidnight is a function that returns the instant the date starts in the users timezone
+func localMidnight(user entities.User, date time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, userLocation(user))
+}
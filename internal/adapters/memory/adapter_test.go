@@ -0,0 +1,14 @@
+package memory_test
+
+import (
+	"github.com/AlecSmith96/dating-api/internal/adapters"
+	"github.com/AlecSmith96/dating-api/internal/adapters/contract"
+	"github.com/AlecSmith96/dating-api/internal/adapters/memory"
+	"testing"
+)
+
+func TestAdapter_Contract(t *testing.T) {
+	contract.Run(t, func(t *testing.T) adapters.Storage {
+		return memory.NewAdapter(3000000, "something-secret")
+	})
+}
@@ -0,0 +1,137 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"github.com/AlecSmith96/dating-api/internal/entities"
+	"github.com/AlecSmith96/dating-api/internal/usecases"
+	"github.com/google/uuid"
+	"slices"
+	"time"
+)
+
+// boostReportLimit is the number of the users most recent boosts that are reported on
+const boostReportLimit = 50
+
+// StartBoost is a function that starts a boost for the user if their tier has one left in the boost period and they
+// don't already have one running
+func (a *Adapter) StartBoost(ctx context.Context, userID uuid.UUID, duration time.Duration) (*entities.Boost, error) {
+	var boost entities.Boost
+	err := a.locked(ctx, func(s *state) error {
+		user, ok := s.users[userID]
+		if !ok {
+			usecases.Logger(ctx).DebugContext(ctx, "checking boost allowance", "err", sql.ErrNoRows)
+			return sql.ErrNoRows
+		}
+
+		instant := now()
+		for _, existing := range s.boosts {
+			if existing.UserID == userID && existing.IsActive(instant) {
+				return entities.ErrBoostActive
+			}
+		}
+
+		_, entitlement := s.entitlement(user, instant)
+		if s.boostsUsed(userID, instant) >= entitlement.monthlyBoostLimit {
+			return entities.ErrNoBoostsLeft
+		}
+
+		boost = entities.Boost{ID: uuid.New(), UserID: userID, StartsAt: instant, EndsAt: instant.Add(duration).Truncate(time.Microsecond)}
+		s.boosts[boost.ID] = boostRecord{Boost: boost}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &boost, nil
+}
+
+// boostsUsed is a function that counts the boosts the user has started in the current boost period
+func (s *state) boostsUsed(userID uuid.UUID, instant time.Time) int {
+	used := 0
+	for _, boost := range s.boosts {
+		if boost.UserID == userID && boost.StartsAt.After(instant.Add(-entities.BoostPeriod)) {
+			used++
+		}
+	}
+
+	return used
+}
+
+// GetBoostReports is a function that returns the users most recent boosts with the views and likes they produced. Views
+// are counted per day, so the week before a boost is the seven days before the day it started.
+func (a *Adapter) GetBoostReports(ctx context.Context, userID uuid.UUID) ([]entities.BoostReport, error) {
+	reports := []entities.BoostReport{}
+	err := a.locked(ctx, func(s *state) error {
+		var boosts []boostRecord
+		for _, boost := range s.boosts {
+			if boost.UserID == userID {
+				boosts = append(boosts, boost)
+			}
+		}
+		slices.SortFunc(boosts, func(a, b boostRecord) int {
+			return cmp.Compare(b.StartsAt.UnixMicro(), a.StartsAt.UnixMicro())
+		})
+
+		for _, boost := range boosts[:min(boostReportLimit, len(boosts))] {
+			report := entities.BoostReport{Boost: boost.Boost, Views: boost.views}
+			weekStart := boost.StartsAt.Add(-7 * 24 * time.Hour)
+			for _, swipe := range s.swipes {
+				if swipe.SwipedUserID != userID || !swipe.Type.IsPositive() {
+					continue
+				}
+
+				if !swipe.CreatedAt.Before(boost.StartsAt) && swipe.CreatedAt.Before(boost.EndsAt) {
+					report.Likes++
+				}
+				if !swipe.CreatedAt.Before(weekStart) && swipe.CreatedAt.Before(boost.StartsAt) {
+					report.WeekLikes++
+				}
+			}
+
+			startDate := dateOf(boost.StartsAt)
+			for day := startDate.AddDate(0, 0, -7); day.Before(startDate); day = day.AddDate(0, 0, 1) {
+				report.WeekViews += s.profileViews[dateKey{userID: userID, date: day}]
+			}
+
+			reports = append(reports, report)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return reports, nil
+}
+
+// RecordProfileViews is a function that counts the users being shown in discovery, towards their daily views and any
+// active boost
+func (a *Adapter) RecordProfileViews(ctx context.Context, userIDs []uuid.UUID) error {
+	return a.locked(ctx, func(s *state) error {
+		for _, userID := range userIDs {
+			if _, ok := s.users[userID]; !ok {
+				usecases.Logger(ctx).DebugContext(ctx, "recording profile views", "err", entities.ErrTargetUserNotFound)
+				return entities.ErrTargetUserNotFound
+			}
+		}
+
+		instant := now()
+		today := dateOf(instant)
+		for _, userID := range userIDs {
+			s.profileViews[dateKey{userID: userID, date: today}]++
+		}
+
+		for id, boost := range s.boosts {
+			if boost.IsActive(instant) && slices.Contains(userIDs, boost.UserID) {
+				boost.views++
+				s.boosts[id] = boost
+			}
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,152 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"github.com/AlecSmith96/dating-api/internal/entities"
+	"github.com/AlecSmith96/dating-api/internal/usecases"
+	"github.com/google/uuid"
+	"slices"
+)
+
+// SaveDiscoveryPreferences is a function that replaces the users saved discovery preferences
+func (a *Adapter) SaveDiscoveryPreferences(ctx context.Context, userID uuid.UUID, preferences entities.PageInfo) error {
+	return a.locked(ctx, func(s *state) error {
+		if _, ok := s.users[userID]; !ok {
+			usecases.Logger(ctx).DebugContext(ctx, "saving discovery preferences", "err", entities.ErrTargetUserNotFound)
+			return entities.ErrTargetUserNotFound
+		}
+
+		preferences.PreferredGenders = slices.Clone(preferences.PreferredGenders)
+		if preferences.PreferredGenders == nil {
+			preferences.PreferredGenders = []string{}
+		}
+
+		s.preferences[userID] = preferences
+		return nil
+	})
+}
+
+// GetUsersDueDailyPicks is a function that returns up to limit users that haven't had picks generated for their current
+// local day
+func (a *Adapter) GetUsersDueDailyPicks(ctx context.Context, limit int) ([]entities.DailyPicksUser, error) {
+	var users []entities.DailyPicksUser
+	err := a.locked(ctx, func(s *state) error {
+		instant := now()
+		for _, user := range s.users {
+			pickDate := localDate(user.User, instant)
+			if _, ok := s.pickSets[dateKey{userID: user.ID, date: pickDate}]; ok {
+				continue
+			}
+
+			preferences, ok := s.preferences[user.ID]
+			if !ok {
+				preferences = entities.PageInfo{PreferredGenders: []string{}}
+			}
+
+			users = append(users, entities.DailyPicksUser{
+				UserID:      user.ID,
+				Location:    user.Location,
+				Preferences: preferences,
+				PickDate:    pickDate,
+				ExpiresAt:   localMidnight(user.User, pickDate.AddDate(0, 0, 1)),
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(users, func(a, b entities.DailyPicksUser) int {
+		return cmp.Compare(a.UserID.String(), b.UserID.String())
+	})
+
+	return users[:min(limit, len(users))], nil
+}
+
+// SaveDailyPicks is a function that records the users picks for their local day. Picks that have already been saved
+// for the day are kept.
+func (a *Adapter) SaveDailyPicks(ctx context.Context, user entities.DailyPicksUser, picks []entities.DailyPick) error {
+	return a.locked(ctx, func(s *state) error {
+		key := dateKey{userID: user.UserID, date: dateOf(user.PickDate)}
+		if _, ok := s.pickSets[key]; ok {
+			return nil
+		}
+
+		if _, ok := s.users[user.UserID]; !ok {
+			usecases.Logger(ctx).DebugContext(ctx, "inserting daily pick set", "err", entities.ErrTargetUserNotFound)
+			return entities.ErrTargetUserNotFound
+		}
+
+		for _, pick := range picks {
+			if _, ok := s.users[pick.CandidateUserID]; !ok {
+				usecases.Logger(ctx).DebugContext(ctx, "inserting daily picks", "err", entities.ErrTargetUserNotFound)
+				return entities.ErrTargetUserNotFound
+			}
+		}
+
+		s.pickSets[key] = pickSetRecord{expiresAt: user.ExpiresAt, picks: slices.Clone(picks)}
+		return nil
+	})
+}
+
+// GetTodaysPicks is a function that returns the users live picks that they haven't swiped on, best first
+func (a *Adapter) GetTodaysPicks(ctx context.Context, userID uuid.UUID) ([]entities.DailyPickCandidate, error) {
+	picks := []entities.DailyPickCandidate{}
+	err := a.locked(ctx, func(s *state) error {
+		swiped := map[uuid.UUID]bool{}
+		for _, swipe := range s.swipes {
+			if swipe.OwnerUserID == userID {
+				swiped[swipe.SwipedUserID] = true
+			}
+		}
+
+		var keys []dateKey
+		for key := range s.pickSets {
+			if key.userID == userID {
+				keys = append(keys, key)
+			}
+		}
+		slices.SortFunc(keys, func(a, b dateKey) int {
+			return a.date.Compare(b.date)
+		})
+
+		instant := now()
+		for _, key := range keys {
+			pickSet := s.pickSets[key]
+			if !pickSet.expiresAt.After(instant) {
+				continue
+			}
+
+			dayPicks := slices.Clone(pickSet.picks)
+			slices.SortFunc(dayPicks, func(a, b entities.DailyPick) int {
+				return cmp.Compare(a.Rank, b.Rank)
+			})
+
+			for _, pick := range dayPicks {
+				candidate, ok := s.users[pick.CandidateUserID]
+				if !ok || swiped[candidate.ID] || s.isBlocked(userID, candidate.ID) || s.sanctionErr(candidate.ID, instant) != nil {
+					continue
+				}
+
+				picks = append(picks, entities.DailyPickCandidate{
+					ID:        candidate.ID,
+					Name:      candidate.Name,
+					Gender:    candidate.Gender,
+					Age:       candidate.AgeAt(instant),
+					Location:  candidate.Location,
+					Rank:      pick.Rank,
+					ExpiresAt: pickSet.expiresAt,
+				})
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return picks, nil
+}
@@ -0,0 +1,78 @@
+package memory
+
+import (
+	"context"
+	"github.com/AlecSmith96/dating-api/internal/entities"
+	"github.com/google/uuid"
+)
+
+// ApplyDesirabilitySwipes is a function that updates the desirability scores of swiped users from up to limit swipes
+// that haven't been applied yet, oldest first. It returns the number of swipes applied.
+func (a *Adapter) ApplyDesirabilitySwipes(ctx context.Context, limit int) (int, error) {
+	var applied int
+	err := a.locked(ctx, func(s *state) error {
+		desirability := func(userID uuid.UUID) entities.Desirability {
+			if stored, ok := s.desirabilities[userID]; ok {
+				return stored
+			}
+			return *entities.NewDesirability(userID)
+		}
+
+		// swipes are applied in order, so a users score as a rater includes the swipes on them earlier in the batch
+		for _, swipe := range sortedBySeq(s.swipes, swipeSeq) {
+			if applied == limit {
+				break
+			}
+
+			if swipe.desirabilityApplied {
+				continue
+			}
+
+			swipedUser := desirability(swipe.SwipedUserID)
+			swipedUser.ApplySwipe(desirability(swipe.OwnerUserID).Score, swipe.Type.IsPositive())
+			s.desirabilities[swipe.SwipedUserID] = swipedUser
+
+			swipe.desirabilityApplied = true
+			s.swipes[swipe.ID] = swipe
+			applied++
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return applied, nil
+}
+
+// ResetDesirability is a function that deletes every users desirability and marks every swipe as not applied, so
+// the scores can be recomputed from scratch
+func (a *Adapter) ResetDesirability(ctx context.Context) error {
+	return a.locked(ctx, func(s *state) error {
+		clear(s.desirabilities)
+		for id, swipe := range s.swipes {
+			swipe.desirabilityApplied = false
+			s.swipes[id] = swipe
+		}
+
+		return nil
+	})
+}
+
+// GetDesirability is a function that returns the users desirability score, users that haven't been swiped on yet have
+// the default score
+func (a *Adapter) GetDesirability(ctx context.Context, userID uuid.UUID) (float64, error) {
+	score := entities.DefaultDesirability
+	err := a.locked(ctx, func(s *state) error {
+		if stored, ok := s.desirabilities[userID]; ok {
+			score = stored.Score
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return score, nil
+}
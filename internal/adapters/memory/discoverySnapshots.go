@@ -0,0 +1,62 @@
+package memory
+
+import (
+	"context"
+	"github.com/AlecSmith96/dating-api/internal/entities"
+	"github.com/AlecSmith96/dating-api/internal/usecases"
+	"github.com/google/uuid"
+	"slices"
+	"time"
+)
+
+// SaveDiscoverySnapshot is a function that stores the ranked users of the users latest discover request, replacing
+// the snapshot of their previous one
+func (a *Adapter) SaveDiscoverySnapshot(ctx context.Context, userID uuid.UUID, rankedUserIDs []uuid.UUID) (*entities.DiscoverySnapshot, error) {
+	var snapshot entities.DiscoverySnapshot
+	err := a.locked(ctx, func(s *state) error {
+		if _, ok := s.users[userID]; !ok {
+			usecases.Logger(ctx).DebugContext(ctx, "saving discovery snapshot", "err", entities.ErrTargetUserNotFound)
+			return entities.ErrTargetUserNotFound
+		}
+
+		snapshot = entities.DiscoverySnapshot{
+			ID:            uuid.New(),
+			UserID:        userID,
+			RankedUserIDs: slices.Clone(rankedUserIDs),
+			CreatedAt:     now(),
+		}
+		if snapshot.RankedUserIDs == nil {
+			snapshot.RankedUserIDs = []uuid.UUID{}
+		}
+
+		s.snapshots[userID] = snapshot
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot.RankedUserIDs = slices.Clone(snapshot.RankedUserIDs)
+	return &snapshot, nil
+}
+
+// GetDiscoverySnapshot is a function that returns one of the users discovery snapshots, snapshots older than maxAge,
+// that have been replaced or that belong to another user aren't found
+func (a *Adapter) GetDiscoverySnapshot(ctx context.Context, userID, snapshotID uuid.UUID, maxAge time.Duration) (*entities.DiscoverySnapshot, error) {
+	var snapshot entities.DiscoverySnapshot
+	err := a.locked(ctx, func(s *state) error {
+		stored, ok := s.snapshots[userID]
+		if !ok || stored.ID != snapshotID || !stored.CreatedAt.After(now().Add(-maxAge)) {
+			return entities.ErrSnapshotNotFound
+		}
+
+		snapshot = stored
+		snapshot.RankedUserIDs = slices.Clone(stored.RankedUserIDs)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &snapshot, nil
+}
@@ -0,0 +1,100 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"github.com/AlecSmith96/dating-api/internal/entities"
+	"github.com/AlecSmith96/dating-api/internal/usecases"
+	"github.com/google/uuid"
+	"slices"
+	"time"
+)
+
+// eventsPageSize is the most events returned by GetEventsSince
+const eventsPageSize = 100
+
+// RecordEvent is a function that appends an event to the users event log
+func (a *Adapter) RecordEvent(ctx context.Context, event *entities.Event) error {
+	return a.locked(ctx, func(s *state) error {
+		if _, ok := s.users[event.UserID]; !ok {
+			usecases.Logger(ctx).DebugContext(ctx, "inserting event record", "err", entities.ErrTargetUserNotFound)
+			return entities.ErrTargetUserNotFound
+		}
+
+		s.appendEvent(event.UserID, event.ActorUserID, event.Type, event.Payload)
+		return nil
+	})
+}
+
+// appendEvent is a function that adds an event to the users event log with the next event id
+func (s *state) appendEvent(userID, actorUserID uuid.UUID, eventType entities.EventType, payload []byte) {
+	s.lastEventID++
+	s.events[s.lastEventID] = entities.Event{
+		ID:          s.lastEventID,
+		UserID:      userID,
+		ActorUserID: actorUserID,
+		Type:        eventType,
+		Payload:     slices.Clone(payload),
+		CreatedAt:   now(),
+	}
+}
+
+// GetLatestEventID is a function that returns the id of the most recent event for the user, or 0 if there are none
+func (a *Adapter) GetLatestEventID(ctx context.Context, userID uuid.UUID) (int64, error) {
+	var latestEventID int64
+	err := a.locked(ctx, func(s *state) error {
+		for _, event := range s.events {
+			if event.UserID == userID {
+				latestEventID = max(latestEventID, event.ID)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return latestEventID, nil
+}
+
+// GetEventsSince is a function that returns the users events that occurred after lastEventID, oldest first
+func (a *Adapter) GetEventsSince(ctx context.Context, userID uuid.UUID, lastEventID int64) ([]entities.Event, error) {
+	events := []entities.Event{}
+	err := a.locked(ctx, func(s *state) error {
+		for _, event := range s.events {
+			if event.UserID == userID && event.ID > lastEventID && !s.isBlocked(userID, event.ActorUserID) {
+				events = append(events, event)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(events, func(a, b entities.Event) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	return events[:min(eventsPageSize, len(events))], nil
+}
+
+// PruneEvents is a function that deletes all events older than the retention period, returning how many were deleted
+func (a *Adapter) PruneEvents(ctx context.Context, retention time.Duration) (int64, error) {
+	var pruned int64
+	err := a.locked(ctx, func(s *state) error {
+		cutoff := now().Add(-retention)
+		for id, event := range s.events {
+			if event.CreatedAt.Before(cutoff) {
+				delete(s.events, id)
+				pruned++
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return pruned, nil
+}
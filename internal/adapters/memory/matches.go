@@ -0,0 +1,48 @@
+package memory
+
+import (
+	"context"
+	"github.com/AlecSmith96/dating-api/internal/entities"
+	"github.com/AlecSmith96/dating-api/internal/usecases"
+	"github.com/google/uuid"
+)
+
+// IsMatch is a function that creates a match between the users if the swiped user has also liked the owner, returning
+// nil when they haven't
+func (a *Adapter) IsMatch(ctx context.Context, ownerUserID, swipedUserID uuid.UUID) (*entities.Match, error) {
+	var match *entities.Match
+	err := a.locked(ctx, func(s *state) error {
+		likedBack := false
+		for _, swipe := range s.swipes {
+			if swipe.OwnerUserID == swipedUserID && swipe.SwipedUserID == ownerUserID && swipe.Type.IsPositive() {
+				likedBack = true
+				break
+			}
+		}
+
+		if !likedBack || s.isBlocked(ownerUserID, swipedUserID) {
+			usecases.Logger(ctx).DebugContext(ctx, "match does not exist for users", "ownerUserID", ownerUserID, "swipedUserID", swipedUserID)
+			return nil
+		}
+
+		match = &entities.Match{ID: uuid.New(), OwnerUserID: ownerUserID, MatchedUserID: swipedUserID}
+		s.matches[match.ID] = matchRecord{Match: *match, seq: s.next()}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return match, nil
+}
+
+// isMatched is a function that checks if the users have matched
+func (s *state) isMatched(userID, otherUserID uuid.UUID) bool {
+	for _, match := range s.matches {
+		if (match.OwnerUserID == userID && match.MatchedUserID == otherUserID) || (match.OwnerUserID == otherUserID && match.MatchedUserID == userID) {
+			return true
+		}
+	}
+
+	return false
+}
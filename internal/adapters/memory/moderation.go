@@ -0,0 +1,238 @@
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/AlecSmith96/dating-api/internal/entities"
+	"github.com/google/uuid"
+	"time"
+)
+
+// GetModerationCases is a function that returns the cases with the provided status, oldest first
+func (a *Adapter) GetModerationCases(ctx context.Context, status entities.ModerationCaseStatus, limit int) ([]entities.ModerationCase, error) {
+	moderationCases := []entities.ModerationCase{}
+	err := a.locked(ctx, func(s *state) error {
+		for _, moderationCase := range sortedBySeq(s.cases, caseSeq) {
+			if len(moderationCases) == limit {
+				break
+			}
+
+			if moderationCase.Status == status {
+				moderationCases = append(moderationCases, s.moderationCase(moderationCase))
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return moderationCases, nil
+}
+
+// GetModerationCase is a function that returns a single moderation case
+func (a *Adapter) GetModerationCase(ctx context.Context, caseID uuid.UUID) (*entities.ModerationCase, error) {
+	var moderationCase entities.ModerationCase
+	err := a.locked(ctx, func(s *state) error {
+		stored, ok := s.cases[caseID]
+		if !ok {
+			return entities.ErrCaseNotFound
+		}
+
+		moderationCase = s.moderationCase(stored)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &moderationCase, nil
+}
+
+// moderationCase is a function that returns the case with the reason and details of the report that opened it
+func (s *state) moderationCase(stored caseRecord) entities.ModerationCase {
+	moderationCase := stored.ModerationCase
+	if stored.ReportID.Valid {
+		report := s.reports[stored.ReportID.UUID]
+		moderationCase.ReportReason = report.Reason
+		moderationCase.ReportDetails = report.Details
+	}
+
+	return moderationCase
+}
+
+// GetModerationAudit is a function that returns the audit history of a case, oldest first
+func (a *Adapter) GetModerationAudit(ctx context.Context, caseID uuid.UUID) ([]entities.ModerationAuditEntry, error) {
+	auditEntries := []entities.ModerationAuditEntry{}
+	err := a.locked(ctx, func(s *state) error {
+		for _, auditEntry := range s.audit {
+			if auditEntry.CaseID == caseID {
+				auditEntries = append(auditEntries, auditEntry)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return auditEntries, nil
+}
+
+// AssignModerationCase is a function that assigns a case to a moderator. Assigning an open case moves it into review.
+func (a *Adapter) AssignModerationCase(ctx context.Context, caseID, moderatorUserID, actorUserID uuid.UUID) (*entities.ModerationCase, error) {
+	var moderationCase entities.ModerationCase
+	err := a.locked(ctx, func(s *state) error {
+		moderatorRole := s.roles[moderatorUserID]
+		if moderatorRole != entities.RoleModerator && moderatorRole != entities.RoleAdmin {
+			return entities.ErrNotAModerator
+		}
+
+		stored, ok := s.cases[caseID]
+		if !ok {
+			return entities.ErrCaseNotFound
+		}
+
+		if stored.Status.IsClosed() {
+			return entities.ErrInvalidTransition
+		}
+
+		status := stored.Status
+		if status == entities.ModerationCaseStatusOpen {
+			stored.Status = entities.ModerationCaseStatusInReview
+		}
+		stored.AssignedModeratorID = uuid.NullUUID{UUID: moderatorUserID, Valid: true}
+
+		err := s.updateModerationCase(stored, actorUserID, "assigned", map[string]any{
+			"moderatorId": moderatorUserID,
+			"fromStatus":  status,
+			"toStatus":    stored.Status,
+		})
+		if err != nil {
+			return err
+		}
+
+		moderationCase = s.moderationCase(s.cases[stored.ID])
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &moderationCase, nil
+}
+
+// UpdateModerationCaseStatus is a function that moves a case to a new status, if the case allows it
+func (a *Adapter) UpdateModerationCaseStatus(ctx context.Context, caseID uuid.UUID, status entities.ModerationCaseStatus, actorUserID uuid.UUID) (*entities.ModerationCase, error) {
+	var moderationCase entities.ModerationCase
+	err := a.locked(ctx, func(s *state) error {
+		stored, ok := s.cases[caseID]
+		if !ok {
+			return entities.ErrCaseNotFound
+		}
+
+		currentStatus := stored.Status
+		if !currentStatus.CanTransitionTo(status) {
+			return entities.ErrInvalidTransition
+		}
+		stored.Status = status
+
+		err := s.updateModerationCase(stored, actorUserID, "status_changed", map[string]any{
+			"fromStatus": currentStatus,
+			"toStatus":   status,
+		})
+		if err != nil {
+			return err
+		}
+
+		moderationCase = s.moderationCase(s.cases[stored.ID])
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &moderationCase, nil
+}
+
+// ApplyModerationAction is a function that sanctions the subject of a case and marks the case as actioned. Suspending
+// or banning a user revokes all of their tokens, warnings are delivered to the user through their event log.
+func (a *Adapter) ApplyModerationAction(ctx context.Context, action *entities.ModerationAction) (*entities.ModerationCase, error) {
+	var moderationCase entities.ModerationCase
+	err := a.locked(ctx, func(s *state) error {
+		stored, ok := s.cases[action.CaseID]
+		if !ok {
+			return entities.ErrCaseNotFound
+		}
+
+		status := stored.Status
+		if !status.CanTransitionTo(entities.ModerationCaseStatusActioned) {
+			return entities.ErrInvalidTransition
+		}
+		stored.Status = entities.ModerationCaseStatusActioned
+
+		err := s.updateModerationCase(stored, action.ModeratorUserID, "action_taken", map[string]any{
+			"action":          action.Action,
+			"durationSeconds": action.Duration.Seconds(),
+			"note":            action.Note,
+			"fromStatus":      status,
+			"toStatus":        entities.ModerationCaseStatusActioned,
+		})
+		if err != nil {
+			return err
+		}
+
+		instant := now()
+		sanction := sanctionRecord{userID: stored.SubjectUserID, caseID: stored.ID, sanctionType: action.Action, startsAt: instant}
+		if action.Action == entities.ModerationActionSuspend {
+			endsAt := instant.Add(action.Duration).Truncate(time.Microsecond)
+			sanction.endsAt = &endsAt
+		}
+		s.sanctions = append(s.sanctions, sanction)
+
+		if action.Action == entities.ModerationActionWarn {
+			s.appendEvent(stored.SubjectUserID, uuid.Nil, entities.EventTypeModerationWarning, []byte(`{}`))
+		} else {
+			for id, token := range s.tokens {
+				if token.UserID == stored.SubjectUserID && token.revokedAt == nil {
+					token.revokedAt = &instant
+					s.tokens[id] = token
+				}
+			}
+		}
+
+		moderationCase = s.moderationCase(s.cases[stored.ID])
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &moderationCase, nil
+}
+
+// updateModerationCase is a function that saves a change to a case along with an entry in its audit history
+func (s *state) updateModerationCase(moderationCase caseRecord, actorUserID uuid.UUID, action string, details map[string]any) error {
+	detailsJSON, err := json.Marshal(details)
+	if err != nil {
+		return err
+	}
+
+	instant := now()
+	moderationCase.UpdatedAt = instant
+	s.cases[moderationCase.ID] = moderationCase
+	s.audit = append(s.audit, entities.ModerationAuditEntry{
+		ID:          uuid.New(),
+		CaseID:      moderationCase.ID,
+		ActorUserID: actorUserID,
+		Action:      action,
+		Details:     detailsJSON,
+		CreatedAt:   instant,
+	})
+
+	return nil
+}
+
+func caseSeq(moderationCase caseRecord) int64 {
+	return moderationCase.seq
+}
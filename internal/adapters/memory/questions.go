@@ -0,0 +1,265 @@
+package memory
+
+import (
+	"context"
+	"github.com/AlecSmith96/dating-api/internal/entities"
+	"github.com/AlecSmith96/dating-api/internal/usecases"
+	"github.com/google/uuid"
+	"slices"
+)
+
+// CreateQuestion is a function that adds a question and its answers to the question bank
+func (a *Adapter) CreateQuestion(ctx context.Context, question *entities.Question) (*entities.Question, error) {
+	var created entities.Question
+	err := a.locked(ctx, func(s *state) error {
+		instant := now()
+		created = entities.Question{
+			ID:        uuid.New(),
+			Text:      question.Text,
+			Answers:   newAnswers(question.Answers),
+			CreatedAt: instant,
+			UpdatedAt: instant,
+		}
+
+		s.questions[created.ID] = questionRecord{Question: created, seq: s.next()}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return copyQuestion(created), nil
+}
+
+// newAnswers is a function that gives each of the answers a new id
+func newAnswers(answers []entities.Answer) []entities.Answer {
+	created := make([]entities.Answer, 0, len(answers))
+	for _, answer := range answers {
+		created = append(created, entities.Answer{ID: uuid.New(), Text: answer.Text})
+	}
+
+	return created
+}
+
+// copyQuestion is a function that copies a stored question, so callers can't change the stored answers
+func copyQuestion(question entities.Question) *entities.Question {
+	question.Answers = slices.Clone(question.Answers)
+	return &question
+}
+
+// GetQuestions is a function that returns every question in the question bank, oldest first
+func (a *Adapter) GetQuestions(ctx context.Context) ([]entities.Question, error) {
+	questions := []entities.Question{}
+	err := a.locked(ctx, func(s *state) error {
+		for _, question := range sortedBySeq(s.questions, questionSeq) {
+			// postgres joins questions to their answers, so a question without any is left out
+			if len(question.Answers) == 0 {
+				continue
+			}
+			questions = append(questions, *copyQuestion(question.Question))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return questions, nil
+}
+
+// GetQuestion is a function that returns a single question from the question bank
+func (a *Adapter) GetQuestion(ctx context.Context, questionID uuid.UUID) (*entities.Question, error) {
+	var question *entities.Question
+	err := a.locked(ctx, func(s *state) error {
+		stored, ok := s.questions[questionID]
+		if !ok || len(stored.Answers) == 0 {
+			return entities.ErrQuestionNotFound
+		}
+
+		question = copyQuestion(stored.Question)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return question, nil
+}
+
+// UpdateQuestion is a function that replaces the text and answers of a question. Existing answers are reworded in
+// place so users answers to them are kept, answers can only be added or removed while nobody has answered the question.
+func (a *Adapter) UpdateQuestion(ctx context.Context, question *entities.Question) (*entities.Question, error) {
+	var updated entities.Question
+	err := a.locked(ctx, func(s *state) error {
+		stored, ok := s.questions[question.ID]
+		if !ok {
+			return entities.ErrQuestionNotFound
+		}
+
+		if len(stored.Answers) != len(question.Answers) && s.isAnswered(question.ID) {
+			return entities.ErrQuestionAnswered
+		}
+
+		kept := min(len(stored.Answers), len(question.Answers))
+		answers := make([]entities.Answer, 0, len(question.Answers))
+		for i, answer := range question.Answers[:kept] {
+			answers = append(answers, entities.Answer{ID: stored.Answers[i].ID, Text: answer.Text})
+		}
+		answers = append(answers, newAnswers(question.Answers[kept:])...)
+
+		removed := map[uuid.UUID]bool{}
+		for _, answer := range stored.Answers[kept:] {
+			removed[answer.ID] = true
+		}
+
+		// removing answers is only allowed while nobody has answered, but postgres would cascade the delete to any
+		// accepted answers either way
+		for key, userAnswer := range s.userAnswers {
+			if key.second != question.ID {
+				continue
+			}
+			if removed[userAnswer.AnswerID] {
+				delete(s.userAnswers, key)
+			}
+		}
+
+		updated = entities.Question{
+			ID:        stored.ID,
+			Text:      question.Text,
+			Answers:   answers,
+			CreatedAt: stored.CreatedAt,
+			UpdatedAt: now(),
+		}
+		s.questions[updated.ID] = questionRecord{Question: updated, seq: stored.seq}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return copyQuestion(updated), nil
+}
+
+// isAnswered is a function that returns whether any user has answered the question
+func (s *state) isAnswered(questionID uuid.UUID) bool {
+	for key := range s.userAnswers {
+		if key.second == questionID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// DeleteQuestion is a function that deletes a question along with its answers and every users answer to it
+func (a *Adapter) DeleteQuestion(ctx context.Context, questionID uuid.UUID) error {
+	return a.locked(ctx, func(s *state) error {
+		if _, ok := s.questions[questionID]; !ok {
+			return entities.ErrQuestionNotFound
+		}
+
+		delete(s.questions, questionID)
+		for key := range s.userAnswers {
+			if key.second == questionID {
+				delete(s.userAnswers, key)
+			}
+		}
+
+		return nil
+	})
+}
+
+// GetUserAnswers is a function that returns every answer the user has given
+func (a *Adapter) GetUserAnswers(ctx context.Context, userID uuid.UUID) ([]entities.UserAnswer, error) {
+	answers := []entities.UserAnswer{}
+	err := a.locked(ctx, func(s *state) error {
+		for _, answer := range sortedBySeq(s.userAnswers, userAnswerSeq) {
+			if answer.UserID == userID {
+				answers = append(answers, copyUserAnswer(answer.UserAnswer))
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return answers, nil
+}
+
+// GetAnswersForUsers is a function that returns the answers of each of the users, keyed by user id. Users that haven't
+// answered any questions are left out.
+func (a *Adapter) GetAnswersForUsers(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID][]entities.UserAnswer, error) {
+	answersByUser := make(map[uuid.UUID][]entities.UserAnswer)
+	err := a.locked(ctx, func(s *state) error {
+		for _, answer := range sortedBySeq(s.userAnswers, userAnswerSeq) {
+			if slices.Contains(userIDs, answer.UserID) {
+				answersByUser[answer.UserID] = append(answersByUser[answer.UserID], copyUserAnswer(answer.UserAnswer))
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return answersByUser, nil
+}
+
+// copyUserAnswer is a function that copies a stored answer, so callers can't change the stored accepted answers
+func copyUserAnswer(answer entities.UserAnswer) entities.UserAnswer {
+	answer.AcceptedAnswerIDs = slices.Clone(answer.AcceptedAnswerIDs)
+	return answer
+}
+
+// AnswerQuestion is a function that records the users answer to a question, replacing any earlier answer. The users
+// answer and accepted answers must all be answers to the question.
+func (a *Adapter) AnswerQuestion(ctx context.Context, answer *entities.UserAnswer) error {
+	return a.locked(ctx, func(s *state) error {
+		question, ok := s.questions[answer.QuestionID]
+		// every question has answers, so a question without any doesn't exist
+		if !ok || len(question.Answers) == 0 {
+			return entities.ErrQuestionNotFound
+		}
+
+		questionAnswers := map[uuid.UUID]bool{}
+		for _, questionAnswer := range question.Answers {
+			questionAnswers[questionAnswer.ID] = true
+		}
+
+		if !questionAnswers[answer.AnswerID] {
+			return entities.ErrInvalidAnswer
+		}
+		for _, acceptedAnswerID := range answer.AcceptedAnswerIDs {
+			if !questionAnswers[acceptedAnswerID] {
+				return entities.ErrInvalidAnswer
+			}
+		}
+
+		if _, ok := s.users[answer.UserID]; !ok {
+			usecases.Logger(ctx).DebugContext(ctx, "answering question", "err", entities.ErrTargetUserNotFound)
+			return entities.ErrTargetUserNotFound
+		}
+
+		stored := copyUserAnswer(*answer)
+		if stored.AcceptedAnswerIDs == nil {
+			stored.AcceptedAnswerIDs = []uuid.UUID{}
+		}
+
+		key := pair{answer.UserID, answer.QuestionID}
+		existing, ok := s.userAnswers[key]
+		if !ok {
+			existing.seq = s.next()
+		}
+		s.userAnswers[key] = userAnswerRecord{UserAnswer: stored, seq: existing.seq}
+		return nil
+	})
+}
+
+func questionSeq(question questionRecord) int64 {
+	return question.seq
+}
+
+func userAnswerSeq(answer userAnswerRecord) int64 {
+	return answer.seq
+}
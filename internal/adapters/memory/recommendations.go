@@ -0,0 +1,43 @@
+package memory
+
+import (
+	"context"
+	"github.com/AlecSmith96/dating-api/internal/entities"
+	"github.com/AlecSmith96/dating-api/internal/usecases"
+)
+
+// GetSwipeHistory is a function that returns every swipe, oldest first
+func (a *Adapter) GetSwipeHistory(ctx context.Context) ([]entities.Swipe, error) {
+	var swipes []entities.Swipe
+	err := a.locked(ctx, func(s *state) error {
+		for _, swipe := range sortedBySeq(s.swipes, swipeSeq) {
+			swipes = append(swipes, swipe.Swipe)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return swipes, nil
+}
+
+// ReplaceRecommendations is a function that replaces every users recommendations at once, so discovery never sees a
+// partly written set
+func (a *Adapter) ReplaceRecommendations(ctx context.Context, recommendations []entities.Recommendation) error {
+	return a.locked(ctx, func(s *state) error {
+		for _, recommendation := range recommendations {
+			if !s.usersExist(recommendation.UserID, recommendation.CandidateUserID) {
+				usecases.Logger(ctx).DebugContext(ctx, "inserting recommendations", "err", entities.ErrTargetUserNotFound)
+				return entities.ErrTargetUserNotFound
+			}
+		}
+
+		clear(s.recommendations)
+		for _, recommendation := range recommendations {
+			s.recommendations[pair{recommendation.UserID, recommendation.CandidateUserID}] = recommendation
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,98 @@
+package memory
+
+import (
+	"context"
+	"github.com/AlecSmith96/dating-api/internal/entities"
+	"github.com/google/uuid"
+)
+
+// BlockUser is a function that blocks a user and removes any match between the pair. Blocking a user that is already
+// blocked does nothing.
+func (a *Adapter) BlockUser(ctx context.Context, blockerUserID, blockedUserID uuid.UUID) error {
+	return a.locked(ctx, func(s *state) error {
+		if !s.usersExist(blockerUserID, blockedUserID) {
+			return entities.ErrTargetUserNotFound
+		}
+
+		key := pair{blockerUserID, blockedUserID}
+		if _, ok := s.blocks[key]; !ok {
+			s.blocks[key] = entities.Block{ID: uuid.New(), BlockerUserID: blockerUserID, BlockedUserID: blockedUserID, CreatedAt: now()}
+		}
+
+		for id, match := range s.matches {
+			if (match.OwnerUserID == blockerUserID && match.MatchedUserID == blockedUserID) || (match.OwnerUserID == blockedUserID && match.MatchedUserID == blockerUserID) {
+				delete(s.matches, id)
+			}
+		}
+
+		return nil
+	})
+}
+
+// ReportUser is a function that records a report against a user and opens a moderation case for it
+func (a *Adapter) ReportUser(ctx context.Context, report *entities.Report) (*entities.Report, error) {
+	var created entities.Report
+	err := a.locked(ctx, func(s *state) error {
+		if !s.usersExist(report.ReporterUserID, report.ReportedUserID) {
+			return entities.ErrTargetUserNotFound
+		}
+
+		instant := now()
+		created = entities.Report{
+			ID:             uuid.New(),
+			ReporterUserID: report.ReporterUserID,
+			ReportedUserID: report.ReportedUserID,
+			Reason:         report.Reason,
+			Details:        report.Details,
+			CaseID:         uuid.New(),
+			Status:         entities.ModerationCaseStatusOpen,
+			CreatedAt:      instant,
+		}
+		s.reports[created.ID] = created
+		s.cases[created.CaseID] = caseRecord{
+			ModerationCase: entities.ModerationCase{
+				ID:            created.CaseID,
+				SubjectUserID: created.ReportedUserID,
+				ReportID:      uuid.NullUUID{UUID: created.ID, Valid: true},
+				Status:        entities.ModerationCaseStatusOpen,
+				CreatedAt:     instant,
+				UpdatedAt:     instant,
+			},
+			seq: s.next(),
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &created, nil
+}
+
+// GetUserRole is a function that returns the role of the user, users without a role are regular users
+func (a *Adapter) GetUserRole(ctx context.Context, userID uuid.UUID) (entities.Role, error) {
+	role := entities.RoleUser
+	err := a.locked(ctx, func(s *state) error {
+		if stored, ok := s.roles[userID]; ok {
+			role = stored
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return role, nil
+}
+
+// usersExist is a function that checks every one of the users exists
+func (s *state) usersExist(userIDs ...uuid.UUID) bool {
+	for _, userID := range userIDs {
+		if _, ok := s.users[userID]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,222 @@
+package memory
+
+import (
+	"cmp"
+	"encoding/json"
+	"github.com/AlecSmith96/dating-api/internal/entities"
+	"github.com/google/uuid"
+	"maps"
+	"slices"
+	"time"
+)
+
+// pair is the key of a row that belongs to two users, or to a user and something of theirs
+type pair struct {
+	first  uuid.UUID
+	second uuid.UUID
+}
+
+type userRecord struct {
+	entities.User
+	tier entities.Tier
+	seq  int64
+}
+
+type tokenRecord struct {
+	entities.Token
+	revokedAt *time.Time
+	seq       int64
+}
+
+type swipeRecord struct {
+	entities.Swipe
+	desirabilityApplied bool
+	seq                 int64
+}
+
+// quotaKey is the key of the swipes a user has used from a quota, the date is the first local day of the quota period
+type quotaKey struct {
+	userID    uuid.UUID
+	swipeType entities.SwipeType
+	date      time.Time
+}
+
+type matchRecord struct {
+	entities.Match
+	seq int64
+}
+
+type caseRecord struct {
+	entities.ModerationCase
+	seq int64
+}
+
+type sanctionRecord struct {
+	userID       uuid.UUID
+	caseID       uuid.UUID
+	sanctionType entities.ModerationActionType
+	startsAt     time.Time
+	// endsAt is nil for sanctions that don't end
+	endsAt *time.Time
+}
+
+type subscriptionRecord struct {
+	entities.Subscription
+	lastEventAt time.Time
+}
+
+type billingEventKey struct {
+	provider string
+	eventID  string
+}
+
+type billingEventRecord struct {
+	payload        json.RawMessage
+	subscriptionID uuid.UUID
+}
+
+type boostRecord struct {
+	entities.Boost
+	views int
+}
+
+// dateKey is the key of a row that belongs to a user for a calendar date
+type dateKey struct {
+	userID uuid.UUID
+	date   time.Time
+}
+
+type pickSetRecord struct {
+	expiresAt time.Time
+	picks     []entities.DailyPick
+}
+
+type questionRecord struct {
+	entities.Question
+	seq int64
+}
+
+type userAnswerRecord struct {
+	entities.UserAnswer
+	seq int64
+}
+
+// state is everything the adapter stores, with a map for each table. Rows are stored as values and are replaced rather
+// than changed in place, so that a shallow copy of the maps is enough to restore the state after a failed transaction.
+type state struct {
+	users           map[uuid.UUID]userRecord
+	tokens          map[string]tokenRecord
+	swipes          map[uuid.UUID]swipeRecord
+	swipeQuotas     map[quotaKey]int
+	swipeRewinds    []entities.Swipe
+	matches         map[uuid.UUID]matchRecord
+	blocks          map[pair]entities.Block
+	reports         map[uuid.UUID]entities.Report
+	cases           map[uuid.UUID]caseRecord
+	audit           []entities.ModerationAuditEntry
+	sanctions       []sanctionRecord
+	roles           map[uuid.UUID]entities.Role
+	events          map[int64]entities.Event
+	lastEventID     int64
+	subscriptions   map[uuid.UUID]subscriptionRecord
+	billingEvents   map[billingEventKey]billingEventRecord
+	boosts          map[uuid.UUID]boostRecord
+	profileViews    map[dateKey]int
+	desirabilities  map[uuid.UUID]entities.Desirability
+	recommendations map[pair]entities.Recommendation
+	preferences     map[uuid.UUID]entities.PageInfo
+	pickSets        map[dateKey]pickSetRecord
+	questions       map[uuid.UUID]questionRecord
+	userAnswers     map[pair]userAnswerRecord
+	snapshots       map[uuid.UUID]entities.DiscoverySnapshot
+	// seq orders rows by when they were added, where postgres would order them by their created_at
+	seq int64
+}
+
+func newState() *state {
+	return &state{
+		users:           map[uuid.UUID]userRecord{},
+		tokens:          map[string]tokenRecord{},
+		swipes:          map[uuid.UUID]swipeRecord{},
+		swipeQuotas:     map[quotaKey]int{},
+		matches:         map[uuid.UUID]matchRecord{},
+		blocks:          map[pair]entities.Block{},
+		reports:         map[uuid.UUID]entities.Report{},
+		cases:           map[uuid.UUID]caseRecord{},
+		roles:           map[uuid.UUID]entities.Role{},
+		events:          map[int64]entities.Event{},
+		subscriptions:   map[uuid.UUID]subscriptionRecord{},
+		billingEvents:   map[billingEventKey]billingEventRecord{},
+		boosts:          map[uuid.UUID]boostRecord{},
+		profileViews:    map[dateKey]int{},
+		desirabilities:  map[uuid.UUID]entities.Desirability{},
+		recommendations: map[pair]entities.Recommendation{},
+		preferences:     map[uuid.UUID]entities.PageInfo{},
+		pickSets:        map[dateKey]pickSetRecord{},
+		questions:       map[uuid.UUID]questionRecord{},
+		userAnswers:     map[pair]userAnswerRecord{},
+		snapshots:       map[uuid.UUID]entities.DiscoverySnapshot{},
+	}
+}
+
+// clone is a function that copies the state so that it can be restored, every table must be copied here
+func (s *state) clone() *state {
+	return &state{
+		users:           maps.Clone(s.users),
+		tokens:          maps.Clone(s.tokens),
+		swipes:          maps.Clone(s.swipes),
+		swipeQuotas:     maps.Clone(s.swipeQuotas),
+		swipeRewinds:    slices.Clone(s.swipeRewinds),
+		matches:         maps.Clone(s.matches),
+		blocks:          maps.Clone(s.blocks),
+		reports:         maps.Clone(s.reports),
+		cases:           maps.Clone(s.cases),
+		audit:           slices.Clone(s.audit),
+		sanctions:       slices.Clone(s.sanctions),
+		roles:           maps.Clone(s.roles),
+		events:          maps.Clone(s.events),
+		lastEventID:     s.lastEventID,
+		subscriptions:   maps.Clone(s.subscriptions),
+		billingEvents:   maps.Clone(s.billingEvents),
+		boosts:          maps.Clone(s.boosts),
+		profileViews:    maps.Clone(s.profileViews),
+		desirabilities:  maps.Clone(s.desirabilities),
+		recommendations: maps.Clone(s.recommendations),
+		preferences:     maps.Clone(s.preferences),
+		pickSets:        maps.Clone(s.pickSets),
+		questions:       maps.Clone(s.questions),
+		userAnswers:     maps.Clone(s.userAnswers),
+		snapshots:       maps.Clone(s.snapshots),
+		seq:             s.seq,
+	}
+}
+
+// next is a function that returns the sequence number of a new row
+func (s *state) next() int64 {
+	s.seq++
+	return s.seq
+}
+
+// isBlocked is a function that checks if either user has blocked the other
+func (s *state) isBlocked(userID, otherUserID uuid.UUID) bool {
+	_, blocked := s.blocks[pair{userID, otherUserID}]
+	if blocked {
+		return true
+	}
+
+	_, blocked = s.blocks[pair{otherUserID, userID}]
+	return blocked
+}
+
+// sortedBySeq is a function that returns the rows of a table in the order they were added
+func sortedBySeq[K comparable, V any](rows map[K]V, seq func(V) int64) []V {
+	sorted := make([]V, 0, len(rows))
+	for _, row := range rows {
+		sorted = append(sorted, row)
+	}
+	slices.SortFunc(sorted, func(a, b V) int {
+		return cmp.Compare(seq(a), seq(b))
+	})
+
+	return sorted
+}
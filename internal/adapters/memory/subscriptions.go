@@ -0,0 +1,168 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"github.com/AlecSmith96/dating-api/internal/entities"
+	"github.com/AlecSmith96/dating-api/internal/usecases"
+	"github.com/google/uuid"
+	"slices"
+	"time"
+)
+
+// entitlement is a function that returns the highest ranked of the users own tier and the tiers of their subscriptions
+// that haven't expired
+func (s *state) entitlement(user userRecord, instant time.Time) (entities.Tier, tier) {
+	best, bestTier := user.tier, tiers[user.tier]
+	for _, subscription := range s.subscriptions {
+		if subscription.UserID != user.ID || !subscription.isCurrent(instant) {
+			continue
+		}
+
+		if subscriptionTier := tiers[subscription.Tier]; subscriptionTier.rank > bestTier.rank {
+			best, bestTier = subscription.Tier, subscriptionTier
+		}
+	}
+
+	return best, bestTier
+}
+
+// isCurrent is a function that checks whether the subscription gives the user its tier at the instant
+func (r subscriptionRecord) isCurrent(instant time.Time) bool {
+	return r.Status != entities.SubscriptionStatusExpired && r.CurrentPeriodEnd.After(instant)
+}
+
+// GetEntitlements is a function that returns the users tier, the features it includes, their latest subscription,
+// their current swipe quotas and how many boosts they have used
+func (a *Adapter) GetEntitlements(ctx context.Context, userID uuid.UUID) (*entities.Entitlements, error) {
+	var userEntitlements entities.Entitlements
+	err := a.locked(ctx, func(s *state) error {
+		user, ok := s.users[userID]
+		if !ok {
+			usecases.Logger(ctx).DebugContext(ctx, "getting user entitlement", "err", sql.ErrNoRows)
+			return sql.ErrNoRows
+		}
+
+		instant := now()
+		tierName, entitlement := s.entitlement(user, instant)
+		userEntitlements = entities.Entitlements{
+			Tier:        tierName,
+			CanRewind:   entitlement.canRewind,
+			CanSeeLikes: entitlement.canSeeLikes,
+			BoostLimit:  entitlement.monthlyBoostLimit,
+			BoostsUsed:  s.boostsUsed(userID, instant),
+		}
+
+		// the latest subscription is the one giving the user their tier if there is one, otherwise the most recently
+		// changed
+		var subscriptions []subscriptionRecord
+		for _, subscription := range s.subscriptions {
+			if subscription.UserID == userID {
+				subscriptions = append(subscriptions, subscription)
+			}
+		}
+		if len(subscriptions) != 0 {
+			latest := slices.MaxFunc(subscriptions, func(a, b subscriptionRecord) int {
+				if a.isCurrent(instant) != b.isCurrent(instant) {
+					if a.isCurrent(instant) {
+						return 1
+					}
+					return -1
+				}
+				return a.UpdatedAt.Compare(b.UpdatedAt)
+			})
+			userEntitlements.Subscription = &latest.Subscription
+		}
+
+		likeQuota, _, err := s.swipeQuota(userID, entities.SwipeTypeLike, instant)
+		if err != nil {
+			return err
+		}
+		userEntitlements.LikeQuota = *likeQuota
+
+		superLikeQuota, _, err := s.swipeQuota(userID, entities.SwipeTypeSuperLike, instant)
+		if err != nil {
+			return err
+		}
+		userEntitlements.SuperLikeQuota = *superLikeQuota
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &userEntitlements, nil
+}
+
+// ApplyBillingEvent is a function that creates or updates the subscription the event is for. A redelivered event
+// returns entities.ErrDuplicateEvent, and events that happened before the last event applied to the subscription are
+// recorded without changing it.
+func (a *Adapter) ApplyBillingEvent(ctx context.Context, event *entities.BillingEvent) (*entities.Subscription, error) {
+	var applied entities.Subscription
+	err := a.locked(ctx, func(s *state) error {
+		eventKey := billingEventKey{provider: event.Provider, eventID: event.ID}
+		if _, ok := s.billingEvents[eventKey]; ok {
+			return entities.ErrDuplicateEvent
+		}
+
+		var existing *subscriptionRecord
+		for _, subscription := range s.subscriptions {
+			if subscription.Provider == event.Provider && subscription.ProviderSubscriptionID == event.ProviderSubscriptionID {
+				existing = &subscription
+				break
+			}
+		}
+
+		instant := now()
+		switch {
+		case existing == nil:
+			if _, ok := s.users[event.UserID]; !ok {
+				return entities.ErrTargetUserNotFound
+			}
+			if _, ok := tiers[event.Tier]; !ok {
+				return entities.ErrUnknownTier
+			}
+
+			applied = entities.Subscription{
+				ID:                     uuid.New(),
+				UserID:                 event.UserID,
+				Tier:                   event.Tier,
+				Status:                 event.Status,
+				Provider:               event.Provider,
+				ProviderSubscriptionID: event.ProviderSubscriptionID,
+				CurrentPeriodEnd:       event.CurrentPeriodEnd.UTC(),
+				CreatedAt:              instant,
+				UpdatedAt:              instant,
+			}
+			s.subscriptions[applied.ID] = subscriptionRecord{Subscription: applied, lastEventAt: event.OccurredAt.UTC()}
+		case existing.UserID != event.UserID:
+			return entities.ErrBadBillingEvent
+		case event.OccurredAt.After(existing.lastEventAt):
+			if !existing.Status.CanTransitionTo(event.Status) {
+				return entities.ErrStatusNotAllowed
+			}
+			if _, ok := tiers[event.Tier]; !ok {
+				return entities.ErrUnknownTier
+			}
+
+			applied = existing.Subscription
+			applied.Tier = event.Tier
+			applied.Status = event.Status
+			applied.CurrentPeriodEnd = event.CurrentPeriodEnd.UTC()
+			applied.UpdatedAt = instant
+			s.subscriptions[applied.ID] = subscriptionRecord{Subscription: applied, lastEventAt: event.OccurredAt.UTC()}
+		default:
+			usecases.Logger(ctx).DebugContext(ctx, "ignoring stale billing event", "provider", event.Provider, "eventID", event.ID)
+			applied = existing.Subscription
+		}
+
+		s.billingEvents[eventKey] = billingEventRecord{payload: event.Payload, subscriptionID: applied.ID}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &applied, nil
+}
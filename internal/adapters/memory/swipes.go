@@ -0,0 +1,216 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/AlecSmith96/dating-api/internal/entities"
+	"github.com/AlecSmith96/dating-api/internal/usecases"
+	"github.com/google/uuid"
+	"slices"
+	"time"
+)
+
+// RegisterSwipe is a function that records a swipe. Likes use one swipe from the owners daily quota and super likes
+// from their weekly quota, a swipe that fails to register doesn't use up the quota. Passes don't use a quota and return
+// the daily quota. The quota is returned with entities.ErrSwipeQuotaExceeded so the caller can tell the user when it
+// resets.
+func (a *Adapter) RegisterSwipe(ctx context.Context, ownerUserID, swipedUserID uuid.UUID, swipeType entities.SwipeType) (*entities.SwipeQuota, error) {
+	quotaSwipeType := entities.SwipeTypeLike
+	if swipeType == entities.SwipeTypeSuperLike {
+		quotaSwipeType = entities.SwipeTypeSuperLike
+	}
+
+	var quota *entities.SwipeQuota
+	err := a.locked(ctx, func(s *state) error {
+		instant := now()
+		var periodStart time.Time
+		var err error
+		quota, periodStart, err = s.swipeQuota(ownerUserID, quotaSwipeType, instant)
+		if err != nil {
+			usecases.Logger(ctx).DebugContext(ctx, "getting swipe quota", "err", err)
+			return err
+		}
+
+		if swipeType.IsPositive() && !quota.Unlimited && quota.Used >= quota.Limit {
+			return entities.ErrSwipeQuotaExceeded
+		}
+
+		if _, ok := s.users[swipedUserID]; !ok {
+			return entities.ErrTargetUserNotFound
+		}
+
+		if s.isBlocked(ownerUserID, swipedUserID) {
+			return entities.ErrUserBlocked
+		}
+
+		if swipeType.IsPositive() {
+			quota.Used++
+			s.swipeQuotas[quotaKey{userID: ownerUserID, swipeType: quotaSwipeType, date: periodStart}] = quota.Used
+		}
+
+		swipe := entities.Swipe{ID: uuid.New(), OwnerUserID: ownerUserID, SwipedUserID: swipedUserID, Type: swipeType, CreatedAt: instant}
+		s.swipes[swipe.ID] = swipeRecord{Swipe: swipe, seq: s.next()}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, entities.ErrSwipeQuotaExceeded) {
+			return quota, err
+		}
+		return nil, err
+	}
+
+	return quota, nil
+}
+
+// swipeQuota is a function that returns the users quota for likes or super likes, along with the first local day of the
+// current quota period. Likes are counted per local day and super likes per local week, starting on monday.
+func (s *state) swipeQuota(userID uuid.UUID, quotaSwipeType entities.SwipeType, instant time.Time) (*entities.SwipeQuota, time.Time, error) {
+	user, ok := s.users[userID]
+	if !ok {
+		return nil, time.Time{}, sql.ErrNoRows
+	}
+
+	tierName, entitlement := s.entitlement(user, instant)
+	periodStart := localDate(user.User, instant)
+	periodDays := 1
+	limit := entitlement.dailySwipeLimit
+	if quotaSwipeType == entities.SwipeTypeSuperLike {
+		periodStart = periodStart.AddDate(0, 0, -((int(periodStart.Weekday()) + 6) % 7))
+		periodDays = 7
+		limit = entitlement.weeklySuperLikeLimit
+	}
+
+	return &entities.SwipeQuota{
+		Tier:      tierName,
+		SwipeType: quotaSwipeType,
+		Unlimited: limit == 0,
+		Limit:     limit,
+		Used:      s.swipeQuotas[quotaKey{userID: userID, swipeType: quotaSwipeType, date: periodStart}],
+		ResetsAt:  localMidnight(user.User, periodStart.AddDate(0, 0, periodDays)),
+	}, periodStart, nil
+}
+
+// RewindLastSwipe is a function that undoes the users most recent swipe if it was made within the window and hasn't
+// resulted in a match, so the swiped user is shown in discovery again. Rewinds are recorded and don't return the swipe
+// to the users quota.
+func (a *Adapter) RewindLastSwipe(ctx context.Context, userID uuid.UUID, window time.Duration) (*entities.Swipe, error) {
+	var rewound entities.Swipe
+	err := a.locked(ctx, func(s *state) error {
+		cutoff := now().Add(-window)
+		var last *swipeRecord
+		for _, swipe := range s.swipes {
+			if swipe.OwnerUserID != userID || !swipe.CreatedAt.After(cutoff) {
+				continue
+			}
+
+			if last == nil || swipe.CreatedAt.After(last.CreatedAt) || (swipe.CreatedAt.Equal(last.CreatedAt) && swipe.seq > last.seq) {
+				last = &swipe
+			}
+		}
+
+		if last == nil {
+			return entities.ErrNoSwipeToRewind
+		}
+
+		if s.isMatched(userID, last.SwipedUserID) {
+			return entities.ErrSwipeMatched
+		}
+
+		delete(s.swipes, last.ID)
+		s.swipeRewinds = append(s.swipeRewinds, last.Swipe)
+		rewound = last.Swipe
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &rewound, nil
+}
+
+// CountLikesReceived is a function that returns how many users have liked the user that they haven't swiped on yet
+func (a *Adapter) CountLikesReceived(ctx context.Context, userID uuid.UUID) (int, error) {
+	var total int
+	err := a.locked(ctx, func(s *state) error {
+		total = len(s.likesReceived(userID))
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return total, nil
+}
+
+// GetLikesReceived is a function that returns a page of the users that have liked the user that they haven't swiped
+// on yet, super likes first
+func (a *Adapter) GetLikesReceived(ctx context.Context, userID uuid.UUID, limit, offset int) ([]entities.ReceivedLike, error) {
+	likes := []entities.ReceivedLike{}
+	err := a.locked(ctx, func(s *state) error {
+		received := s.likesReceived(userID)
+		slices.SortFunc(received, func(a, b swipeRecord) int {
+			aSuperLike, bSuperLike := a.Type == entities.SwipeTypeSuperLike, b.Type == entities.SwipeTypeSuperLike
+			if aSuperLike != bSuperLike {
+				if aSuperLike {
+					return -1
+				}
+				return 1
+			}
+
+			return cmp.Or(b.CreatedAt.Compare(a.CreatedAt), cmp.Compare(a.ID.String(), b.ID.String()))
+		})
+
+		instant := now()
+		for _, like := range received[min(offset, len(received)):min(offset+limit, len(received))] {
+			owner := s.users[like.OwnerUserID]
+			likes = append(likes, entities.ReceivedLike{
+				UserID:    owner.ID,
+				Name:      owner.Name,
+				Gender:    owner.Gender,
+				Age:       owner.AgeAt(instant),
+				SwipeType: like.Type,
+				LikedAt:   like.CreatedAt,
+			})
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return likes, nil
+}
+
+// likesReceived is a function that returns the likes and super likes on the user from users that they haven't swiped
+// on yet, leaving out users who are suspended or banned
+func (s *state) likesReceived(userID uuid.UUID) []swipeRecord {
+	instant := now()
+	swiped := map[uuid.UUID]bool{}
+	for _, swipe := range s.swipes {
+		if swipe.OwnerUserID == userID {
+			swiped[swipe.SwipedUserID] = true
+		}
+	}
+
+	var received []swipeRecord
+	for _, swipe := range s.swipes {
+		if swipe.SwipedUserID != userID || !swipe.Type.IsPositive() || swiped[swipe.OwnerUserID] || s.isBlocked(userID, swipe.OwnerUserID) || s.sanctionErr(swipe.OwnerUserID, instant) != nil {
+			continue
+		}
+
+		if _, ok := s.users[swipe.OwnerUserID]; !ok {
+			continue
+		}
+
+		received = append(received, swipe)
+	}
+
+	return received
+}
+
+func swipeSeq(swipe swipeRecord) int64 {
+	return swipe.seq
+}
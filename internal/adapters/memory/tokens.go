@@ -0,0 +1,87 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/AlecSmith96/dating-api/internal/adapters"
+	"github.com/AlecSmith96/dating-api/internal/entities"
+	"github.com/AlecSmith96/dating-api/internal/usecases"
+	"github.com/google/uuid"
+)
+
+func (a *Adapter) IssueJWT(ctx context.Context, userID uuid.UUID) (*entities.Token, error) {
+	var token entities.Token
+	err := a.locked(ctx, func(s *state) error {
+		user, ok := s.users[userID]
+		if !ok {
+			usecases.Logger(ctx).DebugContext(ctx, "getting user to issue jwt", "err", sql.ErrNoRows)
+			return sql.ErrNoRows
+		}
+
+		issuedAt := now()
+		tokenString, err := adapters.SignJWT(&user.User, issuedAt, a.jwtSecretKey)
+		if err != nil {
+			usecases.Logger(ctx).DebugContext(ctx, "failed to sign jwt", "err", err)
+			return err
+		}
+
+		token = entities.Token{ID: uuid.NewString(), UserID: userID, Value: tokenString, IssuedAt: issuedAt}
+		s.tokens[token.ID] = tokenRecord{Token: token, seq: s.next()}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &token, nil
+}
+
+// ValidateJwtForUser is a function that checks that the token value parsed is part of a valid token and returns the
+// userID if it is valid.
+func (a *Adapter) ValidateJwtForUser(ctx context.Context, tokenValue string) (uuid.UUID, error) {
+	var token tokenRecord
+	var sanctionErr error
+	err := a.locked(ctx, func(s *state) error {
+		for _, stored := range sortedBySeq(s.tokens, tokenSeq) {
+			if stored.Value == tokenValue {
+				token = stored
+				sanctionErr = s.sanctionErr(stored.UserID, now())
+				return nil
+			}
+		}
+
+		return sql.ErrNoRows
+	})
+	if err != nil {
+		usecases.Logger(ctx).ErrorContext(ctx, "getting token", "err", err)
+		return uuid.UUID{}, err
+	}
+
+	// a sanctioned user is told why they can't continue, even though their tokens will also have been revoked
+	if sanctionErr != nil {
+		usecases.Logger(ctx).DebugContext(ctx, "sanctioned user attempted to use jwt", "userID", token.UserID)
+		return uuid.UUID{}, sanctionErr
+	}
+
+	if token.revokedAt != nil {
+		usecases.Logger(ctx).DebugContext(ctx, "jwt has been revoked", "userID", token.UserID)
+		return uuid.UUID{}, entities.ErrJwtRevoked
+	}
+
+	err = adapters.ParseJWT(tokenValue, a.jwtSecretKey)
+	if err != nil {
+		if errors.Is(err, entities.ErrJwtExpired) {
+			usecases.Logger(ctx).DebugContext(ctx, "jwt is expired", "userID", token.UserID)
+			return uuid.UUID{}, err
+		}
+		usecases.Logger(ctx).DebugContext(ctx, "unable to parse jwt", "err", err)
+		return uuid.UUID{}, err
+	}
+
+	return token.UserID, nil
+}
+
+func tokenSeq(token tokenRecord) int64 {
+	return token.seq
+}
@@ -0,0 +1,293 @@
+package memory
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"database/sql"
+	"github.com/AlecSmith96/dating-api/internal/entities"
+	"github.com/AlecSmith96/dating-api/internal/usecases"
+	"github.com/google/uuid"
+	"github.com/umahmood/haversine"
+	"slices"
+	"time"
+)
+
+func (a *Adapter) CreateUser(ctx context.Context, user *entities.User) (*entities.User, error) {
+	var created entities.User
+	err := a.locked(ctx, func(s *state) error {
+		_, err := time.LoadLocation(user.Timezone)
+		if err != nil {
+			usecases.Logger(ctx).DebugContext(ctx, "creating new user", "err", err)
+			return entities.ErrInvalidTimezone
+		}
+
+		if user.AgeAt(time.Now()) < entities.MinimumAge {
+			usecases.Logger(ctx).DebugContext(ctx, "rejected underage user")
+			return entities.ErrUserUnderage
+		}
+
+		created = *user
+		created.ID = uuid.New()
+		created.Interests = slices.Clone(user.Interests)
+		if created.Interests == nil {
+			created.Interests = []string{}
+		}
+
+		s.users[created.ID] = userRecord{User: created, tier: entities.TierFree, seq: s.next()}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &created, nil
+}
+
+func (a *Adapter) LoginUser(ctx context.Context, email string, password string) (*entities.User, error) {
+	var loggedIn entities.User
+	err := a.locked(ctx, func(s *state) error {
+		for _, user := range sortedBySeq(s.users, userSeq) {
+			if user.Email != email || user.Password != password {
+				continue
+			}
+
+			err := s.sanctionErr(user.ID, now())
+			if err != nil {
+				usecases.Logger(ctx).DebugContext(ctx, "sanctioned user attempted to log in", "userID", user.ID)
+				return err
+			}
+
+			loggedIn = user.User
+			return nil
+		}
+
+		usecases.Logger(ctx).DebugContext(ctx, "incorrect details for user")
+		return entities.ErrUserNotFound
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &loggedIn, nil
+}
+
+// swipeCounts is the number of swipes a user has made and received, as discovery ranks users by them
+type swipeCounts struct {
+	lastActiveAt   *time.Time
+	swipesGiven    int
+	likesGiven     int
+	swipesReceived int
+	likesReceived  int
+}
+
+func (a *Adapter) DiscoverNewUsers(ctx context.Context, ownerUserID uuid.UUID, pageInfo entities.PageInfo) ([]entities.UserDiscovery, error) {
+	var users []entities.UserDiscovery
+	err := a.locked(ctx, func(s *state) error {
+		instant := now()
+		swiped := map[uuid.UUID]bool{}
+		superLikedMe := map[uuid.UUID]bool{}
+		counts := map[uuid.UUID]*swipeCounts{}
+		countsFor := func(userID uuid.UUID) *swipeCounts {
+			if _, ok := counts[userID]; !ok {
+				counts[userID] = &swipeCounts{}
+			}
+			return counts[userID]
+		}
+
+		for _, swipe := range s.swipes {
+			if swipe.OwnerUserID == ownerUserID {
+				swiped[swipe.SwipedUserID] = true
+			}
+			if swipe.SwipedUserID == ownerUserID && swipe.Type == entities.SwipeTypeSuperLike {
+				superLikedMe[swipe.OwnerUserID] = true
+			}
+
+			given := countsFor(swipe.OwnerUserID)
+			if given.lastActiveAt == nil || swipe.CreatedAt.After(*given.lastActiveAt) {
+				createdAt := swipe.CreatedAt
+				given.lastActiveAt = &createdAt
+			}
+			given.swipesGiven++
+
+			received := countsFor(swipe.SwipedUserID)
+			received.swipesReceived++
+
+			if swipe.Type.IsPositive() {
+				given.likesGiven++
+				received.likesReceived++
+			}
+		}
+
+		boosted := map[uuid.UUID]bool{}
+		for _, boost := range s.boosts {
+			if boost.IsActive(instant) {
+				boosted[boost.UserID] = true
+			}
+		}
+
+		owner := s.users[ownerUserID]
+		for _, user := range sortedBySeq(s.users, userSeq) {
+			age := user.AgeAt(instant)
+			if user.ID == ownerUserID || swiped[user.ID] || age < entities.MinimumAge || s.isBlocked(ownerUserID, user.ID) || s.sanctionErr(user.ID, instant) != nil {
+				continue
+			}
+
+			if pageInfo.MinAge != 0 && age < pageInfo.MinAge {
+				continue
+			}
+
+			if pageInfo.MaxAge != 0 && age > pageInfo.MaxAge {
+				continue
+			}
+
+			if len(pageInfo.PreferredGenders) != 0 && !slices.Contains(pageInfo.PreferredGenders, user.Gender) {
+				continue
+			}
+
+			if pageInfo.MaxDistance != 0 && distanceInMiles(pageInfo.Origin, user.Location) > pageInfo.MaxDistance {
+				continue
+			}
+
+			if pageInfo.UserIDs != nil && !slices.Contains(pageInfo.UserIDs, user.ID) {
+				continue
+			}
+
+			sharedInterests := 0
+			for _, interest := range user.Interests {
+				if slices.Contains(owner.Interests, interest) {
+					sharedInterests++
+				}
+			}
+
+			desirability := entities.DefaultDesirability
+			if stored, ok := s.desirabilities[user.ID]; ok {
+				desirability = stored.Score
+			}
+
+			userCounts := countsFor(user.ID)
+			users = append(users, entities.UserDiscovery{
+				ID:                 user.ID,
+				Name:               user.Name,
+				Gender:             user.Gender,
+				DateOfBirth:        user.DateOfBirth,
+				Location:           user.Location,
+				Age:                age,
+				SuperLikedMe:       superLikedMe[user.ID],
+				Boosted:            boosted[user.ID],
+				SharedInterests:    sharedInterests,
+				LastActiveAt:       userCounts.lastActiveAt,
+				SwipesGiven:        userCounts.swipesGiven,
+				LikesGiven:         userCounts.likesGiven,
+				SwipesReceived:     userCounts.swipesReceived,
+				LikesReceived:      userCounts.likesReceived,
+				Desirability:       desirability,
+				RecommendationRank: s.recommendations[pair{ownerUserID, user.ID}].Rank,
+			})
+		}
+
+		if pageInfo.Limit != 0 {
+			slices.SortStableFunc(users, compareDiscoveryOrder)
+			users = users[:min(pageInfo.Limit, len(users))]
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
+func (a *Adapter) GetUsersLocation(ctx context.Context, userID uuid.UUID) (*entities.Location, error) {
+	var location entities.Location
+	err := a.locked(ctx, func(s *state) error {
+		user, ok := s.users[userID]
+		if !ok {
+			usecases.Logger(ctx).DebugContext(ctx, "error getting users location", "err", sql.ErrNoRows)
+			return sql.ErrNoRows
+		}
+
+		location = user.Location
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &location, nil
+}
+
+// sanctionErr is a function that returns the error for a users active suspension or ban, if they have one. Bans take
+// priority.
+func (s *state) sanctionErr(userID uuid.UUID, instant time.Time) error {
+	suspended := false
+	for _, sanction := range s.sanctions {
+		if sanction.userID != userID || sanction.startsAt.After(instant) || (sanction.endsAt != nil && !sanction.endsAt.After(instant)) {
+			continue
+		}
+
+		switch sanction.sanctionType {
+		case entities.ModerationActionBan:
+			return entities.ErrUserBanned
+		case entities.ModerationActionSuspend:
+			suspended = true
+		}
+	}
+
+	if suspended {
+		return entities.ErrUserSuspended
+	}
+
+	return nil
+}
+
+func userSeq(user userRecord) int64 {
+	return user.seq
+}
+
+// compareDiscoveryOrder is a function that orders users in the same way as the postgres adapter does before limiting
+// them, super likes, boosts, recommendations and then the most recently active first
+func compareDiscoveryOrder(a, b entities.UserDiscovery) int {
+	if a.SuperLikedMe != b.SuperLikedMe {
+		if a.SuperLikedMe {
+			return -1
+		}
+		return 1
+	}
+	if a.Boosted != b.Boosted {
+		if a.Boosted {
+			return -1
+		}
+		return 1
+	}
+	if (a.RecommendationRank == 0) != (b.RecommendationRank == 0) {
+		if a.RecommendationRank != 0 {
+			return -1
+		}
+		return 1
+	}
+	if a.RecommendationRank != b.RecommendationRank {
+		return cmp.Compare(a.RecommendationRank, b.RecommendationRank)
+	}
+	if (a.LastActiveAt == nil) != (b.LastActiveAt == nil) {
+		if a.LastActiveAt != nil {
+			return -1
+		}
+		return 1
+	}
+	if a.LastActiveAt != nil && !a.LastActiveAt.Equal(*b.LastActiveAt) {
+		return b.LastActiveAt.Compare(*a.LastActiveAt)
+	}
+	return bytes.Compare(a.ID[:], b.ID[:])
+}
+
+func distanceInMiles(from, to entities.Location) float64 {
+	distance, _ := haversine.Distance(
+		haversine.Coord{Lat: from.Latitude, Lon: from.Longitude},
+		haversine.Coord{Lat: to.Latitude, Lon: to.Longitude},
+	)
+
+	return distance
+}
@@ -1,13 +1,19 @@
 package adapters
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"github.com/AlecSmith96/dating-api/internal/entities"
-	_ "github.com/lib/pq"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
 	. "github.com/onsi/gomega"
 	"github.com/pressly/goose/v3"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 const (
@@ -46,7 +52,7 @@ func setupAdapter(g *WithT, db *sql.DB) (*PostgresAdapter, *sql.DB) {
 	setupDb, err := sql.Open("postgres", databaseConnectionString)
 	g.Expect(err).ToNot(HaveOccurred())
 
-	return NewPostgresAdapter(setupDb, 3000000, "something-secret"), setupDb
+	return NewPostgresAdapter(setupDb, 3000000, "something-secret", nil), setupDb
 }
 
 func TestPostgresAdapter_PerformDataMigration_HappyPath(t *testing.T) {
@@ -152,3 +158,998 @@ func TestAddLocationFields(t *testing.T) {
 		g.Expect(err).ToNot(HaveOccurred())
 	}
 }
+
+func TestAddEventLogTable(t *testing.T) {
+	g := NewGomegaWithT(t)
+	db, err := SetUpMigrationTestDB("add_event_log_table")
+	g.Expect(err).ToNot(HaveOccurred())
+
+	err = goose.UpTo(db, "../../db/goose", 20240617164832) // previous migration
+	g.Expect(err).ToNot(HaveOccurred())
+
+	_, err = db.Exec("SELECT * FROM event_log;")
+	g.Expect(err).To(MatchError("pq: relation \"event_log\" does not exist"))
+
+	err = goose.UpTo(db, "../../db/goose", 20261019100000) // current migration
+	g.Expect(err).ToNot(HaveOccurred())
+
+	var userID string
+	err = db.QueryRow("SELECT id FROM platform_user WHERE email = 'admin';").Scan(&userID)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	var eventID int64
+	err = db.QueryRow("INSERT INTO event_log (user_id, event_type) VALUES ($1, 'new_match') RETURNING id;", userID).Scan(&eventID)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(eventID).To(BeNumerically(">", 0))
+}
+
+func TestAddUserBlockAndReportTables(t *testing.T) {
+	g := NewGomegaWithT(t)
+	db, err := SetUpMigrationTestDB("add_user_block_and_report_tables")
+	g.Expect(err).ToNot(HaveOccurred())
+
+	err = goose.UpTo(db, "../../db/goose", 20261019100000) // previous migration
+	g.Expect(err).ToNot(HaveOccurred())
+
+	_, err = db.Exec("SELECT * FROM user_block;")
+	g.Expect(err).To(MatchError("pq: relation \"user_block\" does not exist"))
+
+	_, err = db.Exec("SELECT * FROM user_report;")
+	g.Expect(err).To(MatchError("pq: relation \"user_report\" does not exist"))
+
+	err = goose.UpTo(db, "../../db/goose", 20261019110000) // current migration
+	g.Expect(err).ToNot(HaveOccurred())
+
+	var userID string
+	err = db.QueryRow("SELECT id FROM platform_user WHERE email = 'admin';").Scan(&userID)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	var status string
+	err = db.QueryRow("INSERT INTO user_report (reporter_user_id, reported_user_id, reason) VALUES ($1, $1, 'spam') RETURNING status;", userID).Scan(&status)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(status).To(Equal("open"))
+
+	_, err = db.Exec("INSERT INTO user_report (reporter_user_id, reported_user_id, reason) VALUES ($1, $1, 'not-a-reason');", userID)
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestAddModerationTables(t *testing.T) {
+	g := NewGomegaWithT(t)
+	db, err := SetUpMigrationTestDB("add_moderation_tables")
+	g.Expect(err).ToNot(HaveOccurred())
+
+	err = goose.UpTo(db, "../../db/goose", 20261019110000) // previous migration
+	g.Expect(err).ToNot(HaveOccurred())
+
+	var userID string
+	err = db.QueryRow("SELECT id FROM platform_user WHERE email = 'admin';").Scan(&userID)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	var reportID string
+	err = db.QueryRow("INSERT INTO user_report (reporter_user_id, reported_user_id, reason) VALUES ($1, $1, 'spam') RETURNING id;", userID).Scan(&reportID)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	err = goose.UpTo(db, "../../db/goose", 20261019120000) // current migration
+	g.Expect(err).ToNot(HaveOccurred())
+
+	// existing reports are moved into the moderation queue
+	var caseID, status string
+	err = db.QueryRow("SELECT id, status FROM moderation_case WHERE report_id = $1;", reportID).Scan(&caseID, &status)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(status).To(Equal("open"))
+
+	var role string
+	err = db.QueryRow("SELECT role FROM user_role WHERE user_id = $1;", userID).Scan(&role)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(role).To(Equal("admin"))
+
+	// the audit table is append only
+	_, err = db.Exec("INSERT INTO moderation_audit (case_id, actor_user_id, action) VALUES ($1, $2, 'assigned');", caseID, userID)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	_, err = db.Exec("UPDATE moderation_audit SET action = 'dismissed';")
+	g.Expect(err).To(MatchError(ContainSubstring("moderation_audit is append only")))
+
+	_, err = db.Exec("DELETE FROM moderation_audit;")
+	g.Expect(err).To(MatchError(ContainSubstring("moderation_audit is append only")))
+}
+
+func TestAddUserTimezone(t *testing.T) {
+	g := NewGomegaWithT(t)
+	db, err := SetUpMigrationTestDB("add_user_timezone")
+	g.Expect(err).ToNot(HaveOccurred())
+
+	err = goose.UpTo(db, "../../db/goose", 20261019120000) // previous migration
+	g.Expect(err).ToNot(HaveOccurred())
+
+	_, err = db.Exec("SELECT timezone FROM platform_user;")
+	g.Expect(err).To(HaveOccurred())
+
+	err = goose.UpTo(db, "../../db/goose", 20261019130000) // current migration
+	g.Expect(err).ToNot(HaveOccurred())
+
+	var timezone string
+	err = db.QueryRow("SELECT timezone FROM platform_user WHERE email = 'admin';").Scan(&timezone)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(timezone).To(Equal("UTC"))
+
+	// postgres and the domain must always agree on a users age, including around leap days
+	ageTestCases := []struct {
+		dateOfBirth string
+		date        string
+	}{
+		{dateOfBirth: "2000-06-15", date: "2018-06-14"},
+		{dateOfBirth: "2000-06-15", date: "2018-06-15"},
+		{dateOfBirth: "2000-12-31", date: "2019-01-01"},
+		{dateOfBirth: "2000-02-29", date: "2018-02-28"},
+		{dateOfBirth: "2000-02-29", date: "2018-03-01"},
+		{dateOfBirth: "2000-02-29", date: "2020-02-28"},
+		{dateOfBirth: "2000-02-29", date: "2020-02-29"},
+		{dateOfBirth: "2001-03-01", date: "2020-02-29"},
+		{dateOfBirth: "2001-12-31", date: "2020-03-01"},
+	}
+	for _, testCase := range ageTestCases {
+		dateOfBirth, err := time.Parse(time.DateOnly, testCase.dateOfBirth)
+		g.Expect(err).ToNot(HaveOccurred())
+		date, err := time.Parse(time.DateOnly, testCase.date)
+		g.Expect(err).ToNot(HaveOccurred())
+
+		var age int
+		err = db.QueryRow("SELECT DATE_PART('year', AGE($1::date, $2::date));", testCase.date, testCase.dateOfBirth).Scan(&age)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(age).To(Equal(entities.AgeOn(dateOfBirth, date)), "born %s on %s", testCase.dateOfBirth, testCase.date)
+	}
+
+	// the age used by the discover endpoint is calculated in the users own timezone
+	now := time.Now().UTC()
+	for _, timezone := range []string{"UTC", "Pacific/Kiritimati", "Pacific/Pago_Pago"} {
+		user := entities.User{DateOfBirth: time.Date(now.Year()-30, now.Month(), now.Day(), 0, 0, 0, 0, time.UTC), Timezone: timezone}
+
+		var age int
+		err = db.QueryRow("SELECT platform_user_age($1, $2);", user.DateOfBirth, user.Timezone).Scan(&age)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(age).To(Equal(user.GetAge()), "timezone %s", timezone)
+	}
+
+	// underage users are rejected on insert and update
+	_, err = db.Exec("INSERT INTO platform_user (email, password, name, gender, date_of_birth) VALUES ('minor', 'minor', 'minor', 'female', NOW() - INTERVAL '17 years');")
+	g.Expect(err).To(MatchError(ContainSubstring("platform_user must be at least 18")))
+
+	_, err = db.Exec("UPDATE platform_user SET date_of_birth = NOW() + INTERVAL '1 day' WHERE email = 'admin';")
+	g.Expect(err).To(MatchError(ContainSubstring("platform_user must be at least 18")))
+}
+
+func TestAddSwipeQuota(t *testing.T) {
+	g := NewGomegaWithT(t)
+	db, err := SetUpMigrationTestDB("add_swipe_quota")
+	g.Expect(err).ToNot(HaveOccurred())
+
+	err = goose.UpTo(db, "../../db/goose", 20261019130000) // previous migration
+	g.Expect(err).ToNot(HaveOccurred())
+
+	_, err = db.Exec("SELECT * FROM swipe_quota;")
+	g.Expect(err).To(HaveOccurred())
+
+	err = goose.UpTo(db, "../../db/goose", 20261019140000) // current migration
+	g.Expect(err).ToNot(HaveOccurred())
+
+	var ownerUserID uuid.UUID
+	var tier string
+	err = db.QueryRow("SELECT id, tier FROM platform_user WHERE email = 'admin';").Scan(&ownerUserID, &tier)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(tier).To(Equal("free"))
+
+	var premiumLimit sql.NullInt64
+	err = db.QueryRow("SELECT daily_swipe_limit FROM entitlement_tier WHERE tier = 'premium';").Scan(&premiumLimit)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(premiumLimit.Valid).To(BeFalse())
+
+	_, err = db.Exec("UPDATE platform_user SET tier = 'gold' WHERE id = $1;", ownerUserID)
+	g.Expect(err).To(HaveOccurred())
+
+	_, err = db.Exec("INSERT INTO swipe_quota (user_id, quota_date, used) VALUES ($1, CURRENT_DATE, 1);", ownerUserID)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	_, err = db.Exec("INSERT INTO swipe_quota (user_id, quota_date, used) VALUES ($1, CURRENT_DATE, 1);", ownerUserID)
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestAddSwipeType(t *testing.T) {
+	g := NewGomegaWithT(t)
+	db, err := SetUpMigrationTestDB("add_swipe_type")
+	g.Expect(err).ToNot(HaveOccurred())
+
+	err = goose.UpTo(db, "../../db/goose", 20261019140000) // previous migration
+	g.Expect(err).ToNot(HaveOccurred())
+
+	var ownerUserID, likedUserID, passedUserID uuid.UUID
+	err = db.QueryRow("SELECT id FROM platform_user WHERE email = 'admin';").Scan(&ownerUserID)
+	g.Expect(err).ToNot(HaveOccurred())
+	err = db.QueryRow("INSERT INTO platform_user (email, password, name, gender, date_of_birth) VALUES ('liked', 'password', 'name', 'female', '1995-01-01') RETURNING id;").Scan(&likedUserID)
+	g.Expect(err).ToNot(HaveOccurred())
+	err = db.QueryRow("INSERT INTO platform_user (email, password, name, gender, date_of_birth) VALUES ('passed', 'password', 'name', 'female', '1995-01-01') RETURNING id;").Scan(&passedUserID)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	_, err = db.Exec("INSERT INTO user_swipe (owner_user_id, swiped_user_id, positive_preference) VALUES ($1, $2, TRUE), ($1, $3, FALSE);", ownerUserID, likedUserID, passedUserID)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	err = goose.UpTo(db, "../../db/goose", 20261019150000) // current migration
+	g.Expect(err).ToNot(HaveOccurred())
+
+	// existing swipes are converted to likes and passes
+	var swipeType string
+	err = db.QueryRow("SELECT swipe_type FROM user_swipe WHERE swiped_user_id = $1;", likedUserID).Scan(&swipeType)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(swipeType).To(Equal("like"))
+
+	err = db.QueryRow("SELECT swipe_type FROM user_swipe WHERE swiped_user_id = $1;", passedUserID).Scan(&swipeType)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(swipeType).To(Equal("pass"))
+
+	_, err = db.Exec("INSERT INTO user_swipe (owner_user_id, swiped_user_id, swipe_type) VALUES ($1, $2, 'love');", ownerUserID, likedUserID)
+	g.Expect(err).To(HaveOccurred())
+
+	var superLikeLimit int
+	err = db.QueryRow("SELECT weekly_superlike_limit FROM entitlement_tier WHERE tier = 'free';").Scan(&superLikeLimit)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(superLikeLimit).To(Equal(1))
+
+	// likes and super likes are counted separately
+	_, err = db.Exec("INSERT INTO swipe_quota (user_id, swipe_type, quota_date, used) VALUES ($1, 'like', CURRENT_DATE, 1), ($1, 'superlike', CURRENT_DATE, 1);", ownerUserID)
+	g.Expect(err).ToNot(HaveOccurred())
+}
+
+// TestSwipeQuotas checks the swipe quotas against a real database, as they rely on postgres to count concurrent swipes
+// correctly
+func TestSwipeQuotas(t *testing.T) {
+	g := NewGomegaWithT(t)
+	db, err := SetUpMigrationTestDB("swipe_quotas")
+	g.Expect(err).ToNot(HaveOccurred())
+
+	err = goose.Up(db, "../../db/goose")
+	g.Expect(err).ToNot(HaveOccurred())
+
+	var ownerUserID uuid.UUID
+	err = db.QueryRow("SELECT id FROM platform_user WHERE email = 'admin';").Scan(&ownerUserID)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	swipedUserIDs := make([]uuid.UUID, 20)
+	for i := range swipedUserIDs {
+		err = db.QueryRow("INSERT INTO platform_user (email, password, name, gender, date_of_birth) VALUES ($1, 'password', 'name', 'female', '1995-01-01') RETURNING id;", fmt.Sprintf("user-%d", i)).
+			Scan(&swipedUserIDs[i])
+		g.Expect(err).ToNot(HaveOccurred())
+	}
+
+	_, err = db.Exec("UPDATE entitlement_tier SET daily_swipe_limit = 5 WHERE tier = 'free';")
+	g.Expect(err).ToNot(HaveOccurred())
+
+	// concurrent likes must never use more than the quota
+	adapter := NewPostgresAdapter(db, 0, "something-secret", nil)
+	var wg sync.WaitGroup
+	var registered, exceeded atomic.Int32
+	for _, swipedUserID := range swipedUserIDs {
+		wg.Add(1)
+		go func(swipedUserID uuid.UUID) {
+			defer wg.Done()
+			_, err := adapter.RegisterSwipe(context.Background(), ownerUserID, swipedUserID, entities.SwipeTypeLike)
+			switch {
+			case err == nil:
+				registered.Add(1)
+			case errors.Is(err, entities.ErrSwipeQuotaExceeded):
+				exceeded.Add(1)
+			}
+		}(swipedUserID)
+	}
+	wg.Wait()
+
+	g.Expect(registered.Load()).To(Equal(int32(5)))
+	g.Expect(exceeded.Load()).To(Equal(int32(15)))
+
+	var swipes int
+	err = db.QueryRow("SELECT COUNT(*) FROM user_swipe WHERE owner_user_id = $1;", ownerUserID).Scan(&swipes)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(swipes).To(Equal(5))
+
+	// passes don't use the quota
+	quota, err := adapter.RegisterSwipe(context.Background(), ownerUserID, swipedUserIDs[0], entities.SwipeTypePass)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(quota.Used).To(Equal(5))
+
+	// super likes have their own weekly quota
+	quota, err = adapter.RegisterSwipe(context.Background(), ownerUserID, swipedUserIDs[1], entities.SwipeTypeSuperLike)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(quota.SwipeType).To(Equal(entities.SwipeTypeSuperLike))
+	g.Expect(quota.Remaining()).To(Equal(0))
+
+	_, err = adapter.RegisterSwipe(context.Background(), ownerUserID, swipedUserIDs[2], entities.SwipeTypeSuperLike)
+	g.Expect(err).To(MatchError(entities.ErrSwipeQuotaExceeded))
+
+	// super likers are shown first in discovery
+	users, err := adapter.DiscoverNewUsers(context.Background(), swipedUserIDs[1], entities.PageInfo{})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(users).To(ContainElement(HaveField("SuperLikedMe", BeTrue())))
+
+	// premium users have no daily limit
+	_, err = db.Exec("UPDATE platform_user SET tier = 'premium' WHERE id = $1;", ownerUserID)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	quota, err = adapter.RegisterSwipe(context.Background(), ownerUserID, swipedUserIDs[3], entities.SwipeTypeLike)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(quota.Unlimited).To(BeTrue())
+
+	// premium users can rewind their last swipe, returning the user to discovery
+	rewoundSwipe, err := adapter.RewindLastSwipe(context.Background(), ownerUserID, 5*time.Minute)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(rewoundSwipe.SwipedUserID).To(Equal(swipedUserIDs[3]))
+
+	users, err = adapter.DiscoverNewUsers(context.Background(), ownerUserID, entities.PageInfo{})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(users).To(ContainElement(HaveField("ID", swipedUserIDs[3])))
+
+	// free users only see how many users have liked them
+	entitlements, err := adapter.GetEntitlements(context.Background(), swipedUserIDs[1])
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(entitlements.CanSeeLikes).To(BeFalse())
+
+	likesReceived, err := adapter.CountLikesReceived(context.Background(), swipedUserIDs[1])
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(likesReceived).To(Equal(1))
+
+	_, err = db.Exec("UPDATE platform_user SET tier = 'premium' WHERE id = $1;", swipedUserIDs[1])
+	g.Expect(err).ToNot(HaveOccurred())
+
+	entitlements, err = adapter.GetEntitlements(context.Background(), swipedUserIDs[1])
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(entitlements.CanSeeLikes).To(BeTrue())
+
+	likes, err := adapter.GetLikesReceived(context.Background(), swipedUserIDs[1], 20, 0)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(likes).To(HaveLen(1))
+	g.Expect(likes[0].UserID).To(Equal(ownerUserID))
+	g.Expect(likes[0].SwipeType).To(Equal(entities.SwipeTypeSuperLike))
+
+	// liking back from the list matches and removes the user from it
+	_, err = adapter.RegisterSwipe(context.Background(), swipedUserIDs[1], ownerUserID, entities.SwipeTypeLike)
+	g.Expect(err).ToNot(HaveOccurred())
+	match, err := adapter.IsMatch(context.Background(), swipedUserIDs[1], ownerUserID)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(match).ToNot(BeNil())
+
+	likesReceived, err = adapter.CountLikesReceived(context.Background(), swipedUserIDs[1])
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(likesReceived).To(Equal(0))
+
+	// subscribing to plus through a billing event lifts the daily limit until the subscription expires
+	subscriberID := swipedUserIDs[4]
+	event := &entities.BillingEvent{
+		ID:                     "evt_1",
+		Provider:               "local",
+		ProviderSubscriptionID: "sub_1",
+		UserID:                 subscriberID,
+		Tier:                   entities.TierPlus,
+		Status:                 entities.SubscriptionStatusActive,
+		CurrentPeriodEnd:       time.Now().Add(30 * 24 * time.Hour).UTC(),
+		OccurredAt:             time.Now().UTC(),
+		Payload:                []byte(`{}`),
+	}
+	_, err = adapter.ApplyBillingEvent(context.Background(), event)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	_, err = adapter.ApplyBillingEvent(context.Background(), event)
+	g.Expect(err).To(MatchError(entities.ErrDuplicateEvent))
+
+	entitlements, err = adapter.GetEntitlements(context.Background(), subscriberID)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(entitlements.Tier).To(Equal(entities.TierPlus))
+	g.Expect(entitlements.CanRewind).To(BeTrue())
+	g.Expect(entitlements.LikeQuota.Unlimited).To(BeTrue())
+	g.Expect(entitlements.Subscription.ProviderSubscriptionID).To(Equal("sub_1"))
+
+	event.ID = "evt_2"
+	event.Status = entities.SubscriptionStatusExpired
+	event.OccurredAt = event.OccurredAt.Add(time.Minute)
+	_, err = adapter.ApplyBillingEvent(context.Background(), event)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	entitlements, err = adapter.GetEntitlements(context.Background(), subscriberID)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(entitlements.Tier).To(Equal(entities.TierFree))
+	g.Expect(entitlements.LikeQuota.Unlimited).To(BeFalse())
+
+	// premium users can boost once at a time, and boosted users are flagged in discovery
+	boost, err := adapter.StartBoost(context.Background(), ownerUserID, 30*time.Minute)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(boost.EndsAt.Sub(boost.StartsAt)).To(Equal(30 * time.Minute))
+
+	_, err = adapter.StartBoost(context.Background(), ownerUserID, 30*time.Minute)
+	g.Expect(err).To(MatchError(entities.ErrBoostActive))
+
+	_, err = adapter.StartBoost(context.Background(), subscriberID, 30*time.Minute)
+	g.Expect(err).To(MatchError(entities.ErrNoBoostsLeft))
+
+	users, err = adapter.DiscoverNewUsers(context.Background(), subscriberID, entities.PageInfo{})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(users).To(ContainElement(And(HaveField("ID", ownerUserID), HaveField("Boosted", BeTrue()))))
+
+	err = adapter.RecordProfileViews(context.Background(), []uuid.UUID{ownerUserID, subscriberID})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	reports, err := adapter.GetBoostReports(context.Background(), ownerUserID)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(reports).To(HaveLen(1))
+	g.Expect(reports[0].Views).To(Equal(1))
+
+	entitlements, err = adapter.GetEntitlements(context.Background(), ownerUserID)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(entitlements.BoostsRemaining()).To(Equal(3))
+}
+
+func TestAddSwipeRewind(t *testing.T) {
+	g := NewGomegaWithT(t)
+	db, err := SetUpMigrationTestDB("add_swipe_rewind")
+	g.Expect(err).ToNot(HaveOccurred())
+
+	err = goose.UpTo(db, "../../db/goose", 20261019150000) // previous migration
+	g.Expect(err).ToNot(HaveOccurred())
+
+	var ownerUserID, oldSwipeUserID, newSwipeUserID uuid.UUID
+	err = db.QueryRow("SELECT id FROM platform_user WHERE email = 'admin';").Scan(&ownerUserID)
+	g.Expect(err).ToNot(HaveOccurred())
+	err = db.QueryRow("INSERT INTO platform_user (email, password, name, gender, date_of_birth) VALUES ('old', 'password', 'name', 'female', '1995-01-01') RETURNING id;").Scan(&oldSwipeUserID)
+	g.Expect(err).ToNot(HaveOccurred())
+	err = db.QueryRow("INSERT INTO platform_user (email, password, name, gender, date_of_birth) VALUES ('new', 'password', 'name', 'female', '1995-01-01') RETURNING id;").Scan(&newSwipeUserID)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	_, err = db.Exec("INSERT INTO user_swipe (owner_user_id, swiped_user_id, swipe_type) VALUES ($1, $2, 'pass');", ownerUserID, oldSwipeUserID)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	err = goose.UpTo(db, "../../db/goose", 20261019160000) // current migration
+	g.Expect(err).ToNot(HaveOccurred())
+
+	// existing swipes have no timestamp, new swipes are timestamped
+	var createdAt sql.NullTime
+	err = db.QueryRow("SELECT created_at FROM user_swipe WHERE swiped_user_id = $1;", oldSwipeUserID).Scan(&createdAt)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(createdAt.Valid).To(BeFalse())
+
+	err = db.QueryRow("INSERT INTO user_swipe (owner_user_id, swiped_user_id, swipe_type) VALUES ($1, $2, 'pass') RETURNING created_at;", ownerUserID, newSwipeUserID).Scan(&createdAt)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(createdAt.Valid).To(BeTrue())
+
+	var canRewind bool
+	err = db.QueryRow("SELECT can_rewind FROM entitlement_tier WHERE tier = 'free';").Scan(&canRewind)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(canRewind).To(BeFalse())
+
+	err = db.QueryRow("SELECT can_rewind FROM entitlement_tier WHERE tier = 'premium';").Scan(&canRewind)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(canRewind).To(BeTrue())
+
+	_, err = db.Exec("INSERT INTO swipe_rewind (user_id, swiped_user_id, swipe_type, swiped_at) VALUES ($1, $2, 'pass', $3);", ownerUserID, newSwipeUserID, createdAt.Time)
+	g.Expect(err).ToNot(HaveOccurred())
+}
+
+func TestAddLikesReceived(t *testing.T) {
+	g := NewGomegaWithT(t)
+	db, err := SetUpMigrationTestDB("add_likes_received")
+	g.Expect(err).ToNot(HaveOccurred())
+
+	err = goose.UpTo(db, "../../db/goose", 20261019160000) // previous migration
+	g.Expect(err).ToNot(HaveOccurred())
+
+	var exists bool
+	err = db.QueryRow("SELECT EXISTS (SELECT 1 FROM information_schema.columns WHERE table_name = 'entitlement_tier' AND column_name = 'can_see_likes');").Scan(&exists)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(exists).To(BeFalse())
+
+	err = goose.UpTo(db, "../../db/goose", 20261019170000) // current migration
+	g.Expect(err).ToNot(HaveOccurred())
+
+	var canSeeLikes bool
+	err = db.QueryRow("SELECT can_see_likes FROM entitlement_tier WHERE tier = 'free';").Scan(&canSeeLikes)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(canSeeLikes).To(BeFalse())
+
+	err = db.QueryRow("SELECT can_see_likes FROM entitlement_tier WHERE tier = 'premium';").Scan(&canSeeLikes)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(canSeeLikes).To(BeTrue())
+
+	err = db.QueryRow("SELECT EXISTS (SELECT 1 FROM pg_indexes WHERE indexname = 'user_swipe_swiped_user_id_idx');").Scan(&exists)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(exists).To(BeTrue())
+}
+
+func TestAddSubscriptions(t *testing.T) {
+	g := NewGomegaWithT(t)
+	db, err := SetUpMigrationTestDB("add_subscriptions")
+	g.Expect(err).ToNot(HaveOccurred())
+
+	err = goose.UpTo(db, "../../db/goose", 20261019170000) // previous migration
+	g.Expect(err).ToNot(HaveOccurred())
+
+	var exists bool
+	err = db.QueryRow("SELECT EXISTS (SELECT 1 FROM entitlement_tier WHERE tier = 'plus');").Scan(&exists)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(exists).To(BeFalse())
+
+	err = goose.UpTo(db, "../../db/goose", 20261019180000) // current migration
+	g.Expect(err).ToNot(HaveOccurred())
+
+	var userID uuid.UUID
+	err = db.QueryRow("SELECT id FROM platform_user WHERE email = 'admin';").Scan(&userID)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	entitledTier := func() string {
+		var tier string
+		err := db.QueryRow("SELECT tier FROM user_entitlement WHERE user_id = $1;", userID).Scan(&tier)
+		g.Expect(err).ToNot(HaveOccurred())
+		return tier
+	}
+
+	g.Expect(entitledTier()).To(Equal("free"))
+
+	var subscriptionID uuid.UUID
+	err = db.QueryRow("INSERT INTO user_subscription (user_id, tier, status, provider, provider_subscription_id, current_period_end, last_event_at) VALUES ($1, 'plus', 'active', 'local', 'sub_1', NOW() + INTERVAL '30 days', NOW()) RETURNING id;", userID).
+		Scan(&subscriptionID)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(entitledTier()).To(Equal("plus"))
+
+	// cancelled subscriptions keep their tier until the end of the period
+	_, err = db.Exec("UPDATE user_subscription SET status = 'cancelled' WHERE id = $1;", subscriptionID)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(entitledTier()).To(Equal("plus"))
+
+	_, err = db.Exec("UPDATE user_subscription SET current_period_end = NOW() - INTERVAL '1 day' WHERE id = $1;", subscriptionID)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(entitledTier()).To(Equal("free"))
+
+	// the highest ranked tier wins
+	_, err = db.Exec("UPDATE user_subscription SET status = 'active', current_period_end = NOW() + INTERVAL '30 days' WHERE id = $1;", subscriptionID)
+	g.Expect(err).ToNot(HaveOccurred())
+	_, err = db.Exec("UPDATE platform_user SET tier = 'premium' WHERE id = $1;", userID)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(entitledTier()).To(Equal("premium"))
+
+	_, err = db.Exec("UPDATE user_subscription SET status = 'paused' WHERE id = $1;", subscriptionID)
+	g.Expect(err).To(HaveOccurred())
+
+	_, err = db.Exec("INSERT INTO user_subscription (user_id, tier, status, provider, provider_subscription_id, current_period_end, last_event_at) VALUES ($1, 'plus', 'active', 'local', 'sub_1', NOW(), NOW());", userID)
+	g.Expect(err).To(HaveOccurred())
+
+	_, err = db.Exec("INSERT INTO billing_event (provider, event_id, subscription_id, payload) VALUES ('local', 'evt_1', $1, '{}');", subscriptionID)
+	g.Expect(err).ToNot(HaveOccurred())
+	_, err = db.Exec("INSERT INTO billing_event (provider, event_id, payload) VALUES ('local', 'evt_1', '{}');")
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestAddBoosts(t *testing.T) {
+	g := NewGomegaWithT(t)
+	db, err := SetUpMigrationTestDB("add_boosts")
+	g.Expect(err).ToNot(HaveOccurred())
+
+	err = goose.UpTo(db, "../../db/goose", 20261019180000) // previous migration
+	g.Expect(err).ToNot(HaveOccurred())
+
+	var exists bool
+	err = db.QueryRow("SELECT EXISTS (SELECT 1 FROM information_schema.tables WHERE table_name = 'user_boost');").Scan(&exists)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(exists).To(BeFalse())
+
+	err = goose.UpTo(db, "../../db/goose", 20261019190000) // current migration
+	g.Expect(err).ToNot(HaveOccurred())
+
+	var userID uuid.UUID
+	err = db.QueryRow("SELECT id FROM platform_user WHERE email = 'admin';").Scan(&userID)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	boostLimits := map[string]int{}
+	rows, err := db.Query("SELECT tier, monthly_boost_limit FROM entitlement_tier;")
+	g.Expect(err).ToNot(HaveOccurred())
+	for rows.Next() {
+		var tier string
+		var limit int
+		g.Expect(rows.Scan(&tier, &limit)).To(Succeed())
+		boostLimits[tier] = limit
+	}
+	g.Expect(rows.Close()).To(Succeed())
+	g.Expect(boostLimits).To(Equal(map[string]int{"free": 0, "plus": 1, "premium": 4}))
+
+	var boostLimit int
+	err = db.QueryRow("SELECT monthly_boost_limit FROM user_entitlement WHERE user_id = $1;", userID).Scan(&boostLimit)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(boostLimit).To(Equal(0))
+
+	_, err = db.Exec("INSERT INTO user_boost (user_id, starts_at, ends_at) VALUES ($1, NOW(), NOW() - INTERVAL '1 minute');", userID)
+	g.Expect(err).To(HaveOccurred())
+
+	_, err = db.Exec("INSERT INTO user_boost (user_id, starts_at, ends_at) VALUES ($1, NOW(), NOW() + INTERVAL '30 minutes');", userID)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	_, err = db.Exec("INSERT INTO profile_view_daily (user_id, view_date, views) VALUES ($1, CURRENT_DATE, 1);", userID)
+	g.Expect(err).ToNot(HaveOccurred())
+	_, err = db.Exec("INSERT INTO profile_view_daily (user_id, view_date, views) VALUES ($1, CURRENT_DATE, 1);", userID)
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestAddUserInterests(t *testing.T) {
+	g := NewGomegaWithT(t)
+	db, err := SetUpMigrationTestDB("add_user_interests")
+	g.Expect(err).ToNot(HaveOccurred())
+
+	err = goose.UpTo(db, "../../db/goose", 20261019190000) // previous migration
+	g.Expect(err).ToNot(HaveOccurred())
+
+	var exists bool
+	err = db.QueryRow("SELECT EXISTS (SELECT 1 FROM information_schema.columns WHERE table_name = 'platform_user' AND column_name = 'interests');").Scan(&exists)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(exists).To(BeFalse())
+
+	err = goose.UpTo(db, "../../db/goose", 20261019200000) // current migration
+	g.Expect(err).ToNot(HaveOccurred())
+
+	// existing users have no interests
+	var interests []string
+	err = db.QueryRow("SELECT interests FROM platform_user WHERE email = 'admin';").Scan(pq.Array(&interests))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(interests).To(BeEmpty())
+
+	adapter := NewPostgresAdapter(db, 0, "something-secret", nil)
+	var adminID uuid.UUID
+	err = db.QueryRow("UPDATE platform_user SET interests = '{Hiking,Chess}' WHERE email = 'admin' RETURNING id;").Scan(&adminID)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	user, err := adapter.CreateUser(context.Background(), &entities.User{
+		Email:       "interests",
+		Password:    "password",
+		Name:        "name",
+		Gender:      "female",
+		DateOfBirth: time.Date(1995, 1, 1, 0, 0, 0, 0, time.UTC),
+		Timezone:    "UTC",
+		Interests:   []string{"Chess", "Hiking", "Cooking"},
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(user.Interests).To(Equal([]string{"Chess", "Hiking", "Cooking"}))
+
+	users, err := adapter.DiscoverNewUsers(context.Background(), adminID, entities.PageInfo{})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(users).To(ContainElement(And(HaveField("ID", user.ID), HaveField("SharedInterests", 2), HaveField("LastActiveAt", BeNil()))))
+
+	_, err = adapter.RegisterSwipe(context.Background(), user.ID, adminID, entities.SwipeTypeLike)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	users, err = adapter.DiscoverNewUsers(context.Background(), adminID, entities.PageInfo{})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(users).To(ContainElement(And(HaveField("ID", user.ID), HaveField("SwipesGiven", 1), HaveField("LikesGiven", 1), HaveField("LastActiveAt", Not(BeNil())))))
+}
+
+func TestAddUserDesirability(t *testing.T) {
+	g := NewGomegaWithT(t)
+	db, err := SetUpMigrationTestDB("add_user_desirability")
+	g.Expect(err).ToNot(HaveOccurred())
+
+	err = goose.UpTo(db, "../../db/goose", 20261019200000) // previous migration
+	g.Expect(err).ToNot(HaveOccurred())
+
+	var exists bool
+	err = db.QueryRow("SELECT EXISTS (SELECT 1 FROM information_schema.tables WHERE table_name = 'user_desirability');").Scan(&exists)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(exists).To(BeFalse())
+
+	var adminID uuid.UUID
+	err = db.QueryRow("SELECT id FROM platform_user WHERE email = 'admin';").Scan(&adminID)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	userIDs := make([]uuid.UUID, 3)
+	for i := range userIDs {
+		err = db.QueryRow("INSERT INTO platform_user (email, password, name, gender, date_of_birth) VALUES ($1, 'password', 'name', 'female', '1995-01-01') RETURNING id;", fmt.Sprintf("user-%d", i)).
+			Scan(&userIDs[i])
+		g.Expect(err).ToNot(HaveOccurred())
+	}
+
+	// swipes made before the migration are applied by the first update
+	_, err = db.Exec("INSERT INTO user_swipe (owner_user_id, swiped_user_id, swipe_type) VALUES ($1, $2, 'like'), ($3, $2, 'like'), ($1, $3, 'pass');", userIDs[0], adminID, userIDs[1])
+	g.Expect(err).ToNot(HaveOccurred())
+
+	err = goose.UpTo(db, "../../db/goose", 20261019210000) // current migration
+	g.Expect(err).ToNot(HaveOccurred())
+
+	adapter := NewPostgresAdapter(db, 0, "something-secret", nil)
+	applied, err := adapter.ApplyDesirabilitySwipes(context.Background(), 2)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(applied).To(Equal(2))
+
+	applied, err = adapter.ApplyDesirabilitySwipes(context.Background(), 2)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(applied).To(Equal(1))
+
+	adminScore, err := adapter.GetDesirability(context.Background(), adminID)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(adminScore).To(BeNumerically(">", entities.DefaultDesirability))
+
+	passedScore, err := adapter.GetDesirability(context.Background(), userIDs[1])
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(passedScore).To(BeNumerically("<", entities.DefaultDesirability))
+
+	raterScore, err := adapter.GetDesirability(context.Background(), userIDs[2])
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(raterScore).To(Equal(entities.DefaultDesirability))
+
+	users, err := adapter.DiscoverNewUsers(context.Background(), userIDs[2], entities.PageInfo{})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(users).To(ContainElement(And(HaveField("ID", adminID), HaveField("Desirability", adminScore))))
+
+	// replaying every swipe from scratch gives the same scores
+	err = adapter.ResetDesirability(context.Background())
+	g.Expect(err).ToNot(HaveOccurred())
+
+	resetScore, err := adapter.GetDesirability(context.Background(), adminID)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(resetScore).To(Equal(entities.DefaultDesirability))
+
+	applied, err = adapter.ApplyDesirabilitySwipes(context.Background(), 100)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(applied).To(Equal(3))
+
+	recomputedScore, err := adapter.GetDesirability(context.Background(), adminID)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(recomputedScore).To(BeNumerically("~", adminScore, 0.0001))
+}
+
+func TestAddRecommendations(t *testing.T) {
+	g := NewGomegaWithT(t)
+	db, err := SetUpMigrationTestDB("add_recommendations")
+	g.Expect(err).ToNot(HaveOccurred())
+
+	err = goose.UpTo(db, "../../db/goose", 20261019210000) // previous migration
+	g.Expect(err).ToNot(HaveOccurred())
+
+	var exists bool
+	err = db.QueryRow("SELECT EXISTS (SELECT 1 FROM information_schema.tables WHERE table_name = 'recommendation');").Scan(&exists)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(exists).To(BeFalse())
+
+	err = goose.UpTo(db, "../../db/goose", 20261019220000) // current migration
+	g.Expect(err).ToNot(HaveOccurred())
+
+	userIDs := make([]uuid.UUID, 3)
+	for i := range userIDs {
+		err = db.QueryRow("INSERT INTO platform_user (email, password, name, gender, date_of_birth) VALUES ($1, 'password', 'name', 'female', '1995-01-01') RETURNING id;", fmt.Sprintf("user-%d", i)).
+			Scan(&userIDs[i])
+		g.Expect(err).ToNot(HaveOccurred())
+	}
+
+	adapter := NewPostgresAdapter(db, 0, "something-secret", nil)
+	err = adapter.ReplaceRecommendations(context.Background(), []entities.Recommendation{
+		{UserID: userIDs[0], CandidateUserID: userIDs[1], Score: 0.7, Rank: 1},
+		{UserID: userIDs[0], CandidateUserID: userIDs[2], Score: 0.5, Rank: 2},
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	// discovery only sees the recommendations made for the user discovering
+	users, err := adapter.DiscoverNewUsers(context.Background(), userIDs[0], entities.PageInfo{})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(users).To(ContainElement(And(HaveField("ID", userIDs[2]), HaveField("RecommendationRank", 2))))
+
+	users, err = adapter.DiscoverNewUsers(context.Background(), userIDs[1], entities.PageInfo{})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(users).To(ContainElement(And(HaveField("ID", userIDs[2]), HaveField("RecommendationRank", 0))))
+
+	// replacing the recommendations removes the old ones
+	err = adapter.ReplaceRecommendations(context.Background(), []entities.Recommendation{
+		{UserID: userIDs[1], CandidateUserID: userIDs[2], Score: 0.9, Rank: 1},
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	var count int
+	err = db.QueryRow("SELECT COUNT(*) FROM recommendation;").Scan(&count)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(count).To(Equal(1))
+
+	_, err = db.Exec("INSERT INTO recommendation (user_id, candidate_user_id, score, rank) VALUES ($1, $2, 0.1, 0);", userIDs[2], userIDs[0])
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestAddDailyPicks(t *testing.T) {
+	g := NewGomegaWithT(t)
+	db, err := SetUpMigrationTestDB("add_daily_picks")
+	g.Expect(err).ToNot(HaveOccurred())
+
+	err = goose.UpTo(db, "../../db/goose", 20261019220000) // previous migration
+	g.Expect(err).ToNot(HaveOccurred())
+
+	var exists bool
+	err = db.QueryRow("SELECT EXISTS (SELECT 1 FROM information_schema.tables WHERE table_name = 'daily_pick');").Scan(&exists)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(exists).To(BeFalse())
+
+	err = goose.UpTo(db, "../../db/goose", 20261019230000) // current migration
+	g.Expect(err).ToNot(HaveOccurred())
+
+	userIDs := make([]uuid.UUID, 3)
+	for i := range userIDs {
+		err = db.QueryRow("INSERT INTO platform_user (email, password, name, gender, date_of_birth, timezone) VALUES ($1, 'password', 'name', 'female', '1995-01-01', 'Pacific/Auckland') RETURNING id;", fmt.Sprintf("user-%d", i)).
+			Scan(&userIDs[i])
+		g.Expect(err).ToNot(HaveOccurred())
+	}
+
+	adapter := NewPostgresAdapter(db, 0, "something-secret", nil)
+	err = adapter.SaveDiscoveryPreferences(context.Background(), userIDs[0], entities.PageInfo{MinAge: 30})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	// saving preferences again replaces them
+	err = adapter.SaveDiscoveryPreferences(context.Background(), userIDs[0], entities.PageInfo{MinAge: 25, MaxAge: 35, PreferredGenders: []string{"female"}})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	_, err = db.Exec("INSERT INTO discovery_preference (user_id, min_age, max_age) VALUES ($1, 40, 30);", userIDs[1])
+	g.Expect(err).To(HaveOccurred())
+
+	due, err := adapter.GetUsersDueDailyPicks(context.Background(), 1000)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	var user entities.DailyPicksUser
+	for _, dueUser := range due {
+		if dueUser.UserID == userIDs[0] {
+			user = dueUser
+		}
+	}
+	g.Expect(user.Preferences).To(Equal(entities.PageInfo{MinAge: 25, MaxAge: 35, PreferredGenders: []string{"female"}}))
+	// picks expire at the users next local midnight
+	g.Expect(user.ExpiresAt).To(BeTemporally(">", time.Now()))
+	g.Expect(user.ExpiresAt).To(BeTemporally("<=", time.Now().Add(24*time.Hour)))
+
+	err = adapter.SaveDailyPicks(context.Background(), user, []entities.DailyPick{
+		{CandidateUserID: userIDs[1], Score: 2.5, Rank: 1},
+		{CandidateUserID: userIDs[2], Score: 2.1, Rank: 2},
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	due, err = adapter.GetUsersDueDailyPicks(context.Background(), 1000)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(due).ToNot(ContainElement(HaveField("UserID", userIDs[0])))
+
+	picks, err := adapter.GetTodaysPicks(context.Background(), userIDs[0])
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(picks).To(HaveLen(2))
+	g.Expect(picks[0].ID).To(Equal(userIDs[1]))
+	g.Expect(picks[0].ExpiresAt).To(BeTemporally("~", user.ExpiresAt, time.Second))
+
+	// swipes on picks are tracked against the pick, and rewinding the swipe clears it
+	_, err = adapter.RegisterSwipe(context.Background(), userIDs[0], userIDs[1], entities.SwipeTypeLike)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	var swipeType sql.NullString
+	err = db.QueryRow("SELECT swipe_type FROM daily_pick WHERE user_id = $1 AND candidate_user_id = $2;", userIDs[0], userIDs[1]).Scan(&swipeType)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(swipeType.String).To(Equal("like"))
+
+	picks, err = adapter.GetTodaysPicks(context.Background(), userIDs[0])
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(picks).To(HaveLen(1))
+
+	_, err = adapter.RewindLastSwipe(context.Background(), userIDs[0], time.Minute)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	err = db.QueryRow("SELECT swipe_type FROM daily_pick WHERE user_id = $1 AND candidate_user_id = $2;", userIDs[0], userIDs[1]).Scan(&swipeType)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(swipeType.Valid).To(BeFalse())
+
+	// blocked picks are hidden
+	err = adapter.BlockUser(context.Background(), userIDs[2], userIDs[0])
+	g.Expect(err).ToNot(HaveOccurred())
+
+	picks, err = adapter.GetTodaysPicks(context.Background(), userIDs[0])
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(picks).To(HaveLen(1))
+	g.Expect(picks[0].ID).To(Equal(userIDs[1]))
+}
+
+func TestAddQuestions(t *testing.T) {
+	g := NewGomegaWithT(t)
+	db, err := SetUpMigrationTestDB("add_questions")
+	g.Expect(err).ToNot(HaveOccurred())
+
+	err = goose.UpTo(db, "../../db/goose", 20261019230000) // previous migration
+	g.Expect(err).ToNot(HaveOccurred())
+
+	var exists bool
+	err = db.QueryRow("SELECT EXISTS (SELECT 1 FROM information_schema.tables WHERE table_name = 'question');").Scan(&exists)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(exists).To(BeFalse())
+
+	err = goose.UpTo(db, "../../db/goose", 20261019233000) // current migration
+	g.Expect(err).ToNot(HaveOccurred())
+
+	var userID uuid.UUID
+	err = db.QueryRow("INSERT INTO platform_user (email, password, name, gender, date_of_birth) VALUES ('questions', 'password', 'name', 'female', '1995-01-01') RETURNING id;").
+		Scan(&userID)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	adapter := NewPostgresAdapter(db, 0, "something-secret", nil)
+	question, err := adapter.CreateQuestion(context.Background(), &entities.Question{
+		Text:    "Do you like dogs?",
+		Answers: []entities.Answer{{Text: "Yes"}, {Text: "No"}},
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(question.Answers).To(HaveLen(2))
+
+	err = adapter.AnswerQuestion(context.Background(), &entities.UserAnswer{
+		UserID:            userID,
+		QuestionID:        question.ID,
+		AnswerID:          question.Answers[0].ID,
+		AcceptedAnswerIDs: []uuid.UUID{question.Answers[0].ID},
+		Importance:        entities.ImportanceVery,
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	_, err = db.Exec("UPDATE user_question_answer SET importance = 'extremely' WHERE user_id = $1;", userID)
+	g.Expect(err).To(HaveOccurred())
+
+	// rewording the answers of an answered question keeps the users answer
+	question, err = adapter.UpdateQuestion(context.Background(), &entities.Question{
+		ID:      question.ID,
+		Text:    "Do you love dogs?",
+		Answers: []entities.Answer{{Text: "Yes, always"}, {Text: "No"}},
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	answers, err := adapter.GetUserAnswers(context.Background(), userID)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(answers).To(HaveLen(1))
+	g.Expect(answers[0].AnswerID).To(Equal(question.Answers[0].ID))
+	g.Expect(answers[0].AcceptedAnswerIDs).To(Equal([]uuid.UUID{question.Answers[0].ID}))
+
+	_, err = adapter.UpdateQuestion(context.Background(), &entities.Question{
+		ID:      question.ID,
+		Text:    "Do you love dogs?",
+		Answers: []entities.Answer{{Text: "Yes, always"}, {Text: "No"}, {Text: "Sometimes"}},
+	})
+	g.Expect(err).To(MatchError(entities.ErrQuestionAnswered))
+
+	// deleting the question deletes the users answers to it
+	err = adapter.DeleteQuestion(context.Background(), question.ID)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	answers, err = adapter.GetUserAnswers(context.Background(), userID)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(answers).To(BeEmpty())
+}
+
+func TestAddDiscoverySnapshots(t *testing.T) {
+	g := NewGomegaWithT(t)
+	db, err := SetUpMigrationTestDB("add_discovery_snapshots")
+	g.Expect(err).ToNot(HaveOccurred())
+
+	err = goose.UpTo(db, "../../db/goose", 20261019233000) // previous migration
+	g.Expect(err).ToNot(HaveOccurred())
+
+	var exists bool
+	err = db.QueryRow("SELECT EXISTS (SELECT 1 FROM information_schema.tables WHERE table_name = 'discovery_snapshot');").Scan(&exists)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(exists).To(BeFalse())
+
+	err = goose.UpTo(db, "../../db/goose", 20261019234000) // current migration
+	g.Expect(err).ToNot(HaveOccurred())
+
+	var userID, otherUserID uuid.UUID
+	err = db.QueryRow("INSERT INTO platform_user (email, password, name, gender, date_of_birth) VALUES ('snapshots', 'password', 'name', 'female', '1995-01-01') RETURNING id;").
+		Scan(&userID)
+	g.Expect(err).ToNot(HaveOccurred())
+	err = db.QueryRow("INSERT INTO platform_user (email, password, name, gender, date_of_birth) VALUES ('snapshots-other', 'password', 'name', 'male', '1995-01-01') RETURNING id;").
+		Scan(&otherUserID)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	adapter := NewPostgresAdapter(db, 0, "something-secret", nil)
+	snapshot, err := adapter.SaveDiscoverySnapshot(context.Background(), userID, []uuid.UUID{otherUserID})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	returnedSnapshot, err := adapter.GetDiscoverySnapshot(context.Background(), userID, snapshot.ID, time.Hour)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(returnedSnapshot.RankedUserIDs).To(Equal([]uuid.UUID{otherUserID}))
+
+	// saving a new first page replaces the users previous snapshot
+	_, err = adapter.SaveDiscoverySnapshot(context.Background(), userID, []uuid.UUID{})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	_, err = adapter.GetDiscoverySnapshot(context.Background(), userID, snapshot.ID, time.Hour)
+	g.Expect(err).To(MatchError(entities.ErrSnapshotNotFound))
+}
@@ -1,36 +1,49 @@
 package adapters
 
 import (
+	"context"
 	"database/sql"
 	"errors"
-	"fmt"
 	"github.com/AlecSmith96/dating-api/internal/entities"
 	"github.com/AlecSmith96/dating-api/internal/usecases"
-	"github.com/golang-jwt/jwt/v4"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"github.com/pressly/goose/v3"
-	"log/slog"
-	"strings"
 	"time"
 )
 
 const (
-	discoverUsersQuery = `SELECT pu.*
-FROM (
-    SELECT pu.*, 
-           DATE_PART('year', AGE(pu.date_of_birth)) AS age
-    FROM platform_user pu
-) pu
-LEFT JOIN user_swipe us
-ON pu.id = us.swiped_user_id AND us.owner_user_id = $1
-WHERE pu.id != $1 AND us.id IS NULL
-`
+	getEventsSinceQuery = `SELECT el.id, el.user_id, el.actor_user_id, el.event_type, el.payload, el.created_at
+FROM event_log el
+WHERE el.user_id = $1 AND el.id > $2
+AND NOT EXISTS (
+    SELECT 1 FROM user_block ub
+    WHERE (ub.blocker_user_id = $1 AND ub.blocked_user_id = el.actor_user_id) OR (ub.blocker_user_id = el.actor_user_id AND ub.blocked_user_id = $1)
+)
+ORDER BY el.id
+LIMIT 100;`
+
+	// activeSanctionQuery selects the type of the users current suspension or ban, bans take priority
+	activeSanctionQuery = `SELECT s.sanction_type
+    FROM user_sanction s
+    WHERE s.user_id = %s AND s.sanction_type IN ('suspend', 'ban') AND s.starts_at <= NOW() AND (s.ends_at IS NULL OR s.ends_at > NOW())
+    ORDER BY s.sanction_type = 'ban' DESC
+    LIMIT 1`
+
+	foreignKeyViolationCode = "23503"
+	checkViolationCode      = "23514"
 )
 
+// PostgresAdapter stores everything in postgres. Users, tokens, swipes and matches are stored by their own
+// repositories, which the adapter embeds along with a transaction manager so that it can be passed for any of the
+// usecase interfaces.
 type PostgresAdapter struct {
-	db              *sql.DB
-	jwtExpiryMillis int
-	jwtSecretKey    string
+	*PostgresUserRepository
+	*PostgresTokenRepository
+	*PostgresSwipeRepository
+	*PostgresMatchRepository
+	*PostgresTxManager
+	db *instrumentedDB
 }
 
 var _ usecases.UserCreator = &PostgresAdapter{}
@@ -38,280 +51,205 @@ var _ usecases.UserAuthenticator = &PostgresAdapter{}
 var _ usecases.JwtProcessor = &PostgresAdapter{}
 var _ usecases.UserDiscoverer = &PostgresAdapter{}
 var _ usecases.SwipeRegister = &PostgresAdapter{}
-
-func NewPostgresAdapter(db *sql.DB, jwtExpiryMillis int, jwtSecretKey string) *PostgresAdapter {
+var _ usecases.SwipeRewinder = &PostgresAdapter{}
+var _ usecases.LikesReceivedLister = &PostgresAdapter{}
+var _ usecases.Entitlements = &PostgresAdapter{}
+var _ usecases.SubscriptionManager = &PostgresAdapter{}
+var _ usecases.BoostManager = &PostgresAdapter{}
+var _ usecases.DesirabilityUpdater = &PostgresAdapter{}
+var _ usecases.RecommendationStore = &PostgresAdapter{}
+var _ usecases.DailyPicksGenerator = &PostgresAdapter{}
+var _ usecases.DailyPicksLister = &PostgresAdapter{}
+var _ usecases.DiscoveryPreferencesSaver = &PostgresAdapter{}
+var _ usecases.QuestionBank = &PostgresAdapter{}
+var _ usecases.QuestionAnswerer = &PostgresAdapter{}
+var _ usecases.EventStreamer = &PostgresAdapter{}
+var _ usecases.EventRecorder = &PostgresAdapter{}
+var _ usecases.EventPruner = &PostgresAdapter{}
+var _ usecases.UserBlocker = &PostgresAdapter{}
+var _ usecases.UserReporter = &PostgresAdapter{}
+var _ usecases.RoleChecker = &PostgresAdapter{}
+var _ usecases.ModerationQueue = &PostgresAdapter{}
+var _ usecases.ReadinessChecker = &PostgresAdapter{}
+var _ usecases.TxManager = &PostgresAdapter{}
+var _ Storage = &PostgresAdapter{}
+
+// NewPostgresAdapter creates the adapter, the queries it runs are timed by the query observer when one is given
+func NewPostgresAdapter(db *sql.DB, jwtExpiryMillis int, jwtSecretKey string, queryObserver QueryObserver) *PostgresAdapter {
 	return &PostgresAdapter{
-		db:              db,
-		jwtExpiryMillis: jwtExpiryMillis,
-		jwtSecretKey:    jwtSecretKey,
+		PostgresUserRepository:  NewPostgresUserRepository(db, queryObserver),
+		PostgresTokenRepository: NewPostgresTokenRepository(db, jwtExpiryMillis, jwtSecretKey, queryObserver),
+		PostgresSwipeRepository: NewPostgresSwipeRepository(db, queryObserver),
+		PostgresMatchRepository: NewPostgresMatchRepository(db, queryObserver),
+		PostgresTxManager:       NewPostgresTxManager(db, queryObserver),
+		db:                      newInstrumentedDB(db, queryObserver),
 	}
 }
 
 // PerformDataMigration is a function that ensure that the database has had all migration ran against it on startup
 func (p *PostgresAdapter) PerformDataMigration(gooseDir string) error {
-	return goose.Up(p.db, gooseDir)
+	return goose.Up(p.db.DB, gooseDir)
 }
 
-func (p *PostgresAdapter) CreateUser(user *entities.User) (*entities.User, error) {
-	var returnedUser entities.User
-	err := p.db.QueryRow("INSERT INTO platform_user(email, password, name, gender, date_of_birth, location_latitude, location_longitude) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING *;",
-		user.Email,
-		user.Password,
-		user.Name,
-		user.Gender,
-		user.DateOfBirth,
-		user.Location.Latitude,
-		user.Location.Longitude,
-	).
-		Scan(
-			&returnedUser.ID,
-			&returnedUser.Email,
-			&returnedUser.Password,
-			&returnedUser.Name,
-			&returnedUser.Gender,
-			&returnedUser.DateOfBirth,
-			&returnedUser.Location.Latitude,
-			&returnedUser.Location.Longitude,
-		)
+// RecordEvent is a function that appends an event to the users event log
+func (p *PostgresAdapter) RecordEvent(ctx context.Context, event *entities.Event) error {
+	_, err := p.db.conn(ctx).ExecContext(ctx, "INSERT INTO event_log (user_id, actor_user_id, event_type, payload) VALUES ($1, $2, $3, $4);", event.UserID, event.ActorUserID, event.Type, []byte(event.Payload))
 	if err != nil {
-		slog.Debug("creating new user", "err", err)
-		return nil, err
+		usecases.Logger(ctx).DebugContext(ctx, "inserting event record", "err", err)
+		return err
 	}
 
-	return &returnedUser, nil
+	return nil
 }
 
-func (p *PostgresAdapter) LoginUser(email string, password string) (*entities.User, error) {
-	var returnedUser entities.User
-	err := p.db.QueryRow("SELECT * FROM platform_user WHERE email = $1 AND password = $2 LIMIT 1;", email, password).
-		Scan(
-			&returnedUser.ID,
-			&returnedUser.Email,
-			&returnedUser.Password,
-			&returnedUser.Name,
-			&returnedUser.Gender,
-			&returnedUser.DateOfBirth,
-			&returnedUser.Location.Latitude,
-			&returnedUser.Location.Longitude,
-		)
+// GetLatestEventID is a function that returns the id of the most recent event for the user, or 0 if there are none
+func (p *PostgresAdapter) GetLatestEventID(ctx context.Context, userID uuid.UUID) (int64, error) {
+	var latestEventID int64
+	err := p.db.conn(ctx).QueryRowContext(ctx, "SELECT COALESCE(MAX(id), 0) FROM event_log WHERE user_id = $1;", userID).
+		Scan(&latestEventID)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			slog.Debug("incorrect details for user", "email", email)
-			return nil, entities.ErrUserNotFound
-		}
-
-		slog.Debug("authenticating user", "err", err)
-		return nil, err
+		usecases.Logger(ctx).DebugContext(ctx, "getting latest event id", "err", err)
+		return 0, err
 	}
 
-	return &returnedUser, nil
+	return latestEventID, nil
 }
 
-type MyCustomClaims struct {
-	UserID string `json:"user_id"`
-	Email  string `json:"email"`
-	Name   string `json:"name"`
-	jwt.RegisteredClaims
-}
-
-func (p *PostgresAdapter) IssueJWT(userID uuid.UUID) (*entities.Token, error) {
-	var returnedUser entities.User
-	err := p.db.QueryRow("SELECT * FROM platform_user WHERE platform_user.id = $1;", userID).
-		Scan(
-			&returnedUser.ID,
-			&returnedUser.Email,
-			&returnedUser.Password,
-			&returnedUser.Name,
-			&returnedUser.Gender,
-			&returnedUser.DateOfBirth,
-			&returnedUser.Location.Latitude,
-			&returnedUser.Location.Longitude,
-		)
+// GetEventsSince is a function that returns the users events that occurred after lastEventID, oldest first
+func (p *PostgresAdapter) GetEventsSince(ctx context.Context, userID uuid.UUID, lastEventID int64) ([]entities.Event, error) {
+	rows, err := p.db.conn(ctx).QueryContext(ctx, getEventsSinceQuery, userID, lastEventID)
 	if err != nil {
-		slog.Debug("getting user to issue jwt", "err", err)
+		usecases.Logger(ctx).DebugContext(ctx, "getting events", "err", err)
 		return nil, err
 	}
+	defer rows.Close()
 
-	issuedAt := time.Now()
-	expirationTime := issuedAt.Add(300000 * time.Millisecond)
-
-	claims := MyCustomClaims{
-		UserID: returnedUser.ID.String(),
-		Email:  returnedUser.Email,
-		Name:   returnedUser.Name,
-		RegisteredClaims: jwt.RegisteredClaims{
-			Issuer:    "dating-api",
-			Subject:   "user authentication",
-			Audience:  jwt.ClaimStrings{"dating-api users"},
-			ExpiresAt: jwt.NewNumericDate(expirationTime),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-			ID:        "unique-id-12345",
-		},
-	}
-
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString([]byte(p.jwtSecretKey))
-	if err != nil {
-		slog.Debug("failed to sign jwt", "err", err)
-		return nil, err
-	}
+	events := []entities.Event{}
+	for rows.Next() {
+		var event entities.Event
+		var actorUserID uuid.NullUUID
+		var payload []byte
+		err = rows.Scan(
+			&event.ID,
+			&event.UserID,
+			&actorUserID,
+			&event.Type,
+			&payload,
+			&event.CreatedAt,
+		)
+		if err != nil {
+			usecases.Logger(ctx).DebugContext(ctx, "unable to read event row", "err", err)
+			return nil, err
+		}
 
-	var returnedToken entities.Token
-	err = p.db.QueryRow("INSERT INTO token (user_id, value, issued_at) VALUES ($1, $2, $3) RETURNING *;", returnedUser.ID, tokenString, issuedAt).
-		Scan(&returnedToken.ID, &returnedToken.UserID, &returnedToken.Value, &returnedToken.IssuedAt)
-	if err != nil {
-		slog.Debug("writing token to storage", "err", err)
-		return nil, err
+		event.ActorUserID = actorUserID.UUID
+		event.Payload = payload
+		events = append(events, event)
 	}
 
-	return &entities.Token{
-		ID:       returnedToken.ID,
-		UserID:   returnedToken.UserID,
-		Value:    returnedToken.Value,
-		IssuedAt: returnedToken.IssuedAt,
-	}, nil
+	return events, rows.Err()
 }
 
-// ValidateJwtForUser is a function that checks that the token value parsed is part of a valid token and returns the
-// userID if it is valid.
-func (p *PostgresAdapter) ValidateJwtForUser(tokenValue string) (uuid.UUID, error) {
-	var returnedToken entities.Token
-	err := p.db.QueryRow("SELECT * FROM token WHERE value = $1;", tokenValue).
-		Scan(&returnedToken.ID, &returnedToken.UserID, &returnedToken.Value, &returnedToken.IssuedAt)
+// PruneEvents is a function that deletes all events older than the retention period, returning how many were deleted
+func (p *PostgresAdapter) PruneEvents(ctx context.Context, retention time.Duration) (int64, error) {
+	result, err := p.db.conn(ctx).ExecContext(ctx, "DELETE FROM event_log WHERE created_at < NOW() - make_interval(secs => $1);", retention.Seconds())
 	if err != nil {
-		slog.Error("getting token", "err", err)
-		return uuid.UUID{}, err
+		usecases.Logger(ctx).DebugContext(ctx, "pruning event log", "err", err)
+		return 0, err
 	}
 
-	_, err = jwt.ParseWithClaims(tokenValue, &MyCustomClaims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return []byte(p.jwtSecretKey), nil
-	})
-	if err != nil {
-		if strings.Contains(err.Error(), "token is expired") {
-			slog.Debug("jwt is expired", "userID", returnedToken.UserID)
-			return uuid.UUID{}, entities.ErrJwtExpired
-		}
-		slog.Debug("unable to parse jwt", "err", err)
-		return uuid.UUID{}, err
-	}
-
-	return returnedToken.UserID, nil
+	return result.RowsAffected()
 }
 
-func (p *PostgresAdapter) DiscoverNewUsers(ownerUserID uuid.UUID, pageInfo entities.PageInfo) ([]entities.UserDiscovery, error) {
-	paramIndex := 2
-	queryString := discoverUsersQuery
-	queryArgs := []any{ownerUserID}
-	if pageInfo.MinAge != 0 {
-		minAgeCheck := fmt.Sprintf(" AND pu.age >= $%d", paramIndex)
-		queryString += minAgeCheck
-		paramIndex++
-		queryArgs = append(queryArgs, pageInfo.MinAge)
-	}
-
-	if pageInfo.MaxAge != 0 {
-		maxAgeCheck := fmt.Sprintf(" AND pu.age <= $%d", paramIndex)
-		queryString += maxAgeCheck
-		paramIndex++
-		queryArgs = append(queryArgs, pageInfo.MaxAge)
-	}
+// isForeignKeyViolation is a function that checks if the error was caused by referencing a row that doesn't exist
+func isForeignKeyViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == foreignKeyViolationCode
+}
 
-	if len(pageInfo.PreferredGenders) != 0 {
-		placeholders := make([]string, len(pageInfo.PreferredGenders))
-		for i := range pageInfo.PreferredGenders {
-			placeholders[i] = fmt.Sprintf("$%d", paramIndex)
-			paramIndex++
-		}
+// isCheckViolation is a function that reports whether err was raised by a check constraint or trigger rejecting a row
+func isCheckViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == checkViolationCode
+}
 
-		genderCheck := fmt.Sprintf(" AND pu.gender IN (%s)", strings.Join(placeholders, ", "))
-		queryString += genderCheck
-		for _, gender := range pageInfo.PreferredGenders {
-			queryArgs = append(queryArgs, gender)
+// BlockUser is a function that blocks a user and removes any match between the pair. Blocking a user that is already
+// blocked does nothing.
+func (p *PostgresAdapter) BlockUser(ctx context.Context, blockerUserID, blockedUserID uuid.UUID) error {
+	return p.db.withinTx(ctx, func(ctx context.Context, tx *instrumentedTx) error {
+		_, err := tx.ExecContext(ctx, "INSERT INTO user_block (blocker_user_id, blocked_user_id) VALUES ($1, $2) ON CONFLICT (blocker_user_id, blocked_user_id) DO NOTHING;", blockerUserID, blockedUserID)
+		if err != nil {
+			if isForeignKeyViolation(err) {
+				return entities.ErrTargetUserNotFound
+			}
+			usecases.Logger(ctx).DebugContext(ctx, "inserting block record", "err", err)
+			return err
 		}
-	}
-	queryString += ";"
 
-	rows, err := p.db.Query(queryString, queryArgs...)
-	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return []entities.UserDiscovery{}, nil
+		_, err = tx.ExecContext(ctx, "DELETE FROM user_match WHERE (owner_user_id = $1 AND matched_user_id = $2) OR (owner_user_id = $2 AND matched_user_id = $1);", blockerUserID, blockedUserID)
+		if err != nil {
+			usecases.Logger(ctx).DebugContext(ctx, "removing matches for blocked user", "err", err)
+			return err
 		}
 
-		slog.Debug("unable to get users", "err", err)
-		return nil, err
-	}
+		return nil
+	})
+}
 
-	var users []entities.UserDiscovery
-	for rows.Next() {
-		var user entities.UserDiscovery
-		err = rows.Scan(
-			&user.ID,
-			&user.Email,
-			&user.Password,
-			&user.Name,
-			&user.Gender,
-			&user.DateOfBirth,
-			&user.Location.Latitude,
-			&user.Location.Longitude,
-			&user.Age,
-		)
+// ReportUser is a function that records a report against a user and opens a moderation case for it
+func (p *PostgresAdapter) ReportUser(ctx context.Context, report *entities.Report) (*entities.Report, error) {
+	var returnedReport entities.Report
+	err := p.db.withinTx(ctx, func(ctx context.Context, tx *instrumentedTx) error {
+		err := tx.QueryRowContext(ctx, "INSERT INTO user_report (reporter_user_id, reported_user_id, reason, details) VALUES ($1, $2, $3, $4) RETURNING id, reporter_user_id, reported_user_id, reason, details, created_at;",
+			report.ReporterUserID,
+			report.ReportedUserID,
+			report.Reason,
+			report.Details,
+		).
+			Scan(
+				&returnedReport.ID,
+				&returnedReport.ReporterUserID,
+				&returnedReport.ReportedUserID,
+				&returnedReport.Reason,
+				&returnedReport.Details,
+				&returnedReport.CreatedAt,
+			)
 		if err != nil {
-			slog.Debug("unable to read user row", "err", err)
-			continue
+			if isForeignKeyViolation(err) {
+				return entities.ErrTargetUserNotFound
+			}
+			usecases.Logger(ctx).DebugContext(ctx, "inserting report record", "err", err)
+			return err
 		}
 
-		users = append(users, user)
-	}
-
-	return users, nil
-}
+		err = tx.QueryRowContext(ctx, "INSERT INTO moderation_case (subject_user_id, report_id) VALUES ($1, $2) RETURNING id, status;", returnedReport.ReportedUserID, returnedReport.ID).
+			Scan(&returnedReport.CaseID, &returnedReport.Status)
+		if err != nil {
+			usecases.Logger(ctx).DebugContext(ctx, "opening moderation case", "err", err)
+			return err
+		}
 
-func (p *PostgresAdapter) GetUsersLocation(userID uuid.UUID) (*entities.Location, error) {
-	var location entities.Location
-	err := p.db.QueryRow("SELECT location_latitude, location_longitude FROM platform_user WHERE id = $1", userID).
-		Scan(&location.Latitude, &location.Longitude)
+		return nil
+	})
 	if err != nil {
-		slog.Debug("error getting users location", "err", err)
 		return nil, err
 	}
 
-	return &location, nil
-}
-
-func (p *PostgresAdapter) RegisterSwipe(ownerUserID, swipedUserID uuid.UUID, isPositivePreference bool) error {
-	_, err := p.db.Exec("INSERT INTO user_swipe (owner_user_id, swiped_user_id, positive_preference) VALUES ($1, $2, $3);", ownerUserID, swipedUserID, isPositivePreference)
-	if err != nil {
-		slog.Debug("error inserting swipe record", "err", err)
-		return err
-	}
-
-	return nil
+	return &returnedReport, nil
 }
 
-func (p *PostgresAdapter) IsMatch(ownerUserID, swipedUserID uuid.UUID) (*entities.Match, error) {
-	var exists bool
-	err := p.db.QueryRow("SELECT EXISTS (SELECT 1 FROM user_swipe WHERE owner_user_id = $1 AND swiped_user_id = $2 AND positive_preference = TRUE);", swipedUserID, ownerUserID).
-		Scan(&exists)
+// GetUserRole is a function that returns the role of the user, users without a role are regular users
+func (p *PostgresAdapter) GetUserRole(ctx context.Context, userID uuid.UUID) (entities.Role, error) {
+	var role entities.Role
+	err := p.db.conn(ctx).QueryRowContext(ctx, "SELECT role FROM user_role WHERE user_id = $1;", userID).
+		Scan(&role)
 	if err != nil {
-		slog.Debug("error checking if swiped user also swiped positively", "err", err)
-		return nil, err
-	}
-
-	var match entities.Match
-	if exists {
-		err = p.db.QueryRow("INSERT INTO user_match (owner_user_id, matched_user_id) VALUES ($1, $2) RETURNING *;", ownerUserID, swipedUserID).
-			Scan(&match.ID, &match.OwnerUserID, &match.MatchedUserID)
-		if err != nil {
-			slog.Debug("creating match record", "err", err)
-			return nil, err
+		if errors.Is(err, sql.ErrNoRows) {
+			return entities.RoleUser, nil
 		}
-
-		return &match, nil
+		usecases.Logger(ctx).DebugContext(ctx, "getting user role", "err", err)
+		return "", err
 	}
 
-	slog.Debug("match does not exist for users", "ownerUserID", ownerUserID, "swipedUserID", swipedUserID)
-	return nil, nil
+	return role, nil
 }
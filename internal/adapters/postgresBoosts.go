@@ -0,0 +1,138 @@
+package adapters
+
+import (
+	"context"
+	"fmt"
+	"github.com/AlecSmith96/dating-api/internal/entities"
+	"github.com/AlecSmith96/dating-api/internal/usecases"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"time"
+)
+
+const (
+	// boostsUsedQuery counts the boosts the user has started in the current boost period
+	boostsUsedQuery = `SELECT COUNT(*) FROM user_boost bo WHERE bo.user_id = %s AND bo.starts_at > NOW() - INTERVAL '30 days'`
+
+	// boostReportsQuery selects the users boosts with the likes during each boost, and the views and likes over the week
+	// before it. Views are counted per day, so the week before is the seven days before the day the boost started.
+	boostReportsQuery = `SELECT bo.id, bo.user_id, bo.starts_at, bo.ends_at, bo.views,
+    (
+        SELECT COUNT(*) FROM user_swipe us
+        WHERE us.swiped_user_id = bo.user_id AND us.swipe_type IN ('like', 'superlike') AND us.created_at >= bo.starts_at AND us.created_at < bo.ends_at
+    ) AS likes,
+    (
+        SELECT COALESCE(SUM(pv.views), 0) FROM profile_view_daily pv
+        WHERE pv.user_id = bo.user_id AND pv.view_date >= bo.starts_at::date - 7 AND pv.view_date < bo.starts_at::date
+    ) AS week_views,
+    (
+        SELECT COUNT(*) FROM user_swipe us
+        WHERE us.swiped_user_id = bo.user_id AND us.swipe_type IN ('like', 'superlike') AND us.created_at >= bo.starts_at - INTERVAL '7 days' AND us.created_at < bo.starts_at
+    ) AS week_likes
+FROM user_boost bo
+WHERE bo.user_id = $1
+ORDER BY bo.starts_at DESC
+LIMIT 50;`
+
+	recordProfileViewsQuery = `INSERT INTO profile_view_daily (user_id, view_date, views)
+SELECT id, CURRENT_DATE, 1 FROM unnest($1::uuid[]) AS id ORDER BY id
+ON CONFLICT (user_id, view_date) DO UPDATE SET views = profile_view_daily.views + 1;`
+
+	recordBoostViewsQuery = `UPDATE user_boost SET views = views + 1
+WHERE user_id = ANY($1::uuid[]) AND starts_at <= NOW() AND ends_at > NOW();`
+)
+
+var startBoostCheckQuery = `SELECT ue.monthly_boost_limit, (` + fmt.Sprintf(boostsUsedQuery, "$1") + `),
+    EXISTS (SELECT 1 FROM user_boost bo WHERE bo.user_id = $1 AND bo.starts_at <= NOW() AND bo.ends_at > NOW())
+FROM user_entitlement ue
+WHERE ue.user_id = $1;`
+
+// StartBoost is a function that starts a boost for the user if their tier has one left in the boost period and they
+// don't already have one running. The user is locked while checking so concurrent requests can't exceed the limit.
+func (p *PostgresAdapter) StartBoost(ctx context.Context, userID uuid.UUID, duration time.Duration) (*entities.Boost, error) {
+	var boost entities.Boost
+	err := p.db.withinTx(ctx, func(ctx context.Context, tx *instrumentedTx) error {
+		_, err := tx.ExecContext(ctx, "SELECT 1 FROM platform_user WHERE id = $1 FOR UPDATE;", userID)
+		if err != nil {
+			usecases.Logger(ctx).DebugContext(ctx, "locking user for boost", "err", err)
+			return err
+		}
+
+		var boostLimit, boostsUsed int
+		var active bool
+		err = tx.QueryRowContext(ctx, startBoostCheckQuery, userID).
+			Scan(&boostLimit, &boostsUsed, &active)
+		if err != nil {
+			usecases.Logger(ctx).DebugContext(ctx, "checking boost allowance", "err", err)
+			return err
+		}
+
+		if active {
+			return entities.ErrBoostActive
+		}
+
+		if boostsUsed >= boostLimit {
+			return entities.ErrNoBoostsLeft
+		}
+
+		err = tx.QueryRowContext(ctx, "INSERT INTO user_boost (user_id, starts_at, ends_at) VALUES ($1, NOW(), NOW() + make_interval(secs => $2)) RETURNING id, user_id, starts_at, ends_at;", userID, duration.Seconds()).
+			Scan(&boost.ID, &boost.UserID, &boost.StartsAt, &boost.EndsAt)
+		if err != nil {
+			usecases.Logger(ctx).DebugContext(ctx, "inserting boost", "err", err)
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &boost, nil
+}
+
+// GetBoostReports is a function that returns the users most recent boosts with the views and likes they produced
+func (p *PostgresAdapter) GetBoostReports(ctx context.Context, userID uuid.UUID) ([]entities.BoostReport, error) {
+	rows, err := p.db.conn(ctx).QueryContext(ctx, boostReportsQuery, userID)
+	if err != nil {
+		usecases.Logger(ctx).DebugContext(ctx, "getting boost reports", "err", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	reports := []entities.BoostReport{}
+	for rows.Next() {
+		var report entities.BoostReport
+		err = rows.Scan(&report.ID, &report.UserID, &report.StartsAt, &report.EndsAt, &report.Views, &report.Likes, &report.WeekViews, &report.WeekLikes)
+		if err != nil {
+			usecases.Logger(ctx).DebugContext(ctx, "unable to read boost report row", "err", err)
+			return nil, err
+		}
+
+		reports = append(reports, report)
+	}
+
+	return reports, rows.Err()
+}
+
+// RecordProfileViews is a function that counts the users being shown in discovery, towards their daily views and any
+// active boost
+func (p *PostgresAdapter) RecordProfileViews(ctx context.Context, userIDs []uuid.UUID) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	_, err := p.db.conn(ctx).ExecContext(ctx, recordProfileViewsQuery, pq.Array(userIDs))
+	if err != nil {
+		usecases.Logger(ctx).DebugContext(ctx, "recording profile views", "err", err)
+		return err
+	}
+
+	_, err = p.db.conn(ctx).ExecContext(ctx, recordBoostViewsQuery, pq.Array(userIDs))
+	if err != nil {
+		usecases.Logger(ctx).DebugContext(ctx, "recording boost views", "err", err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,145 @@
+package adapters_test
+
+import (
+	"context"
+	"errors"
+	"github.com/AlecSmith96/dating-api/internal/adapters"
+	"github.com/AlecSmith96/dating-api/internal/entities"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	. "github.com/onsi/gomega"
+	"testing"
+	"time"
+)
+
+func TestPostgresAdapter_StartBoost(t *testing.T) {
+	g := NewWithT(t)
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	adapter := adapters.NewPostgresAdapter(db, 0, "something-secret", nil)
+
+	userID := uuid.New()
+	boostID := uuid.New()
+	startsAt := time.Now()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`SELECT 1 FROM platform_user WHERE id = \$1 FOR UPDATE;`).WithArgs(userID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(`SELECT ue\.monthly_boost_limit, \(SELECT COUNT\(\*\) FROM user_boost bo WHERE bo\.user_id = \$1 AND bo\.starts_at > NOW\(\) - INTERVAL '30 days'\), EXISTS \(SELECT 1 FROM user_boost bo WHERE bo\.user_id = \$1 AND bo\.starts_at <= NOW\(\) AND bo\.ends_at > NOW\(\)\) FROM user_entitlement ue WHERE ue\.user_id = \$1;`).
+		WithArgs(userID).
+		WillReturnRows(sqlmock.NewRows([]string{"monthly_boost_limit", "boosts_used", "active"}).AddRow(4, 1, false))
+	mock.ExpectQuery(`INSERT INTO user_boost \(user_id, starts_at, ends_at\) VALUES \(\$1, NOW\(\), NOW\(\) \+ make_interval\(secs => \$2\)\) RETURNING id, user_id, starts_at, ends_at;`).
+		WithArgs(userID, float64(1800)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "starts_at", "ends_at"}).
+			AddRow(boostID, userID, startsAt, startsAt.Add(30*time.Minute)))
+	mock.ExpectCommit()
+
+	boost, err := adapter.StartBoost(context.Background(), userID, 30*time.Minute)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(boost.ID).To(Equal(boostID))
+	g.Expect(boost.EndsAt).To(Equal(startsAt.Add(30 * time.Minute)))
+	g.Expect(mock.ExpectationsWereMet()).To(Succeed())
+}
+
+func TestPostgresAdapter_StartBoost_AlreadyActive(t *testing.T) {
+	g := NewWithT(t)
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	adapter := adapters.NewPostgresAdapter(db, 0, "something-secret", nil)
+
+	userID := uuid.New()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`FOR UPDATE`).WithArgs(userID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(`SELECT ue\.monthly_boost_limit`).WithArgs(userID).
+		WillReturnRows(sqlmock.NewRows([]string{"monthly_boost_limit", "boosts_used", "active"}).AddRow(4, 1, true))
+	mock.ExpectRollback()
+
+	boost, err := adapter.StartBoost(context.Background(), userID, 30*time.Minute)
+	g.Expect(err).To(MatchError(entities.ErrBoostActive))
+	g.Expect(boost).To(BeNil())
+	g.Expect(mock.ExpectationsWereMet()).To(Succeed())
+}
+
+func TestPostgresAdapter_StartBoost_NoBoostsLeft(t *testing.T) {
+	g := NewWithT(t)
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	adapter := adapters.NewPostgresAdapter(db, 0, "something-secret", nil)
+
+	userID := uuid.New()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`FOR UPDATE`).WithArgs(userID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(`SELECT ue\.monthly_boost_limit`).WithArgs(userID).
+		WillReturnRows(sqlmock.NewRows([]string{"monthly_boost_limit", "boosts_used", "active"}).AddRow(1, 1, false))
+	mock.ExpectRollback()
+
+	boost, err := adapter.StartBoost(context.Background(), userID, 30*time.Minute)
+	g.Expect(err).To(MatchError(entities.ErrNoBoostsLeft))
+	g.Expect(boost).To(BeNil())
+	g.Expect(mock.ExpectationsWereMet()).To(Succeed())
+}
+
+func TestPostgresAdapter_GetBoostReports(t *testing.T) {
+	g := NewWithT(t)
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	adapter := adapters.NewPostgresAdapter(db, 0, "something-secret", nil)
+
+	userID := uuid.New()
+	boostID := uuid.New()
+	startsAt := time.Now().Add(-time.Hour)
+
+	mock.ExpectQuery(`SELECT bo\.id, bo\.user_id, bo\.starts_at, bo\.ends_at, bo\.views, .* FROM user_boost bo WHERE bo\.user_id = \$1 ORDER BY bo\.starts_at DESC LIMIT 50;`).
+		WithArgs(userID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "starts_at", "ends_at", "views", "likes", "week_views", "week_likes"}).
+			AddRow(boostID, userID, startsAt, startsAt.Add(30*time.Minute), 40, 6, 336, 14))
+
+	reports, err := adapter.GetBoostReports(context.Background(), userID)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(reports).To(HaveLen(1))
+	g.Expect(reports[0].ID).To(Equal(boostID))
+	g.Expect(reports[0].Views).To(Equal(40))
+	g.Expect(reports[0].Likes).To(Equal(6))
+	g.Expect(reports[0].WeekViews).To(Equal(336))
+	g.Expect(reports[0].WeekLikes).To(Equal(14))
+	g.Expect(mock.ExpectationsWereMet()).To(Succeed())
+}
+
+func TestPostgresAdapter_RecordProfileViews(t *testing.T) {
+	g := NewWithT(t)
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	adapter := adapters.NewPostgresAdapter(db, 0, "something-secret", nil)
+
+	mock.ExpectExec(`INSERT INTO profile_view_daily \(user_id, view_date, views\) SELECT id, CURRENT_DATE, 1 FROM unnest\(\$1::uuid\[\]\)`).
+		WithArgs(sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(`UPDATE user_boost SET views = views \+ 1 WHERE user_id = ANY\(\$1::uuid\[\]\)`).
+		WithArgs(sqlmock.AnyArg()).
+		WillReturnError(errors.New("an error occurred"))
+
+	err = adapter.RecordProfileViews(context.Background(), []uuid.UUID{uuid.New(), uuid.New()})
+	g.Expect(err).To(MatchError("an error occurred"))
+	g.Expect(mock.ExpectationsWereMet()).To(Succeed())
+}
+
+func TestPostgresAdapter_RecordProfileViews_NoUsers(t *testing.T) {
+	g := NewWithT(t)
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	adapter := adapters.NewPostgresAdapter(db, 0, "something-secret", nil)
+
+	err = adapter.RecordProfileViews(context.Background(), nil)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(mock.ExpectationsWereMet()).To(Succeed())
+}
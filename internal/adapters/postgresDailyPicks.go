@@ -0,0 +1,177 @@
+package adapters
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/AlecSmith96/dating-api/internal/entities"
+	"github.com/AlecSmith96/dating-api/internal/usecases"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+const (
+	saveDiscoveryPreferencesQuery = `INSERT INTO discovery_preference (user_id, min_age, max_age, preferred_genders, updated_at)
+VALUES ($1, $2, $3, $4, NOW())
+ON CONFLICT (user_id) DO UPDATE SET min_age = EXCLUDED.min_age, max_age = EXCLUDED.max_age,
+    preferred_genders = EXCLUDED.preferred_genders, updated_at = NOW();`
+
+	// usersDueDailyPicksQuery selects the users without a pick set for their current local date, along with their
+	// preferences and their next local midnight
+	usersDueDailyPicksQuery = `SELECT pu.id, pu.location_latitude, pu.location_longitude, dp.min_age, dp.max_age,
+    COALESCE(dp.preferred_genders, '{}'), l.pick_date, (l.pick_date + 1)::timestamp AT TIME ZONE pu.timezone
+FROM platform_user pu
+CROSS JOIN LATERAL (SELECT (NOW() AT TIME ZONE pu.timezone)::date AS pick_date) l
+LEFT JOIN discovery_preference dp ON dp.user_id = pu.id
+WHERE NOT EXISTS (SELECT 1 FROM daily_pick_set ds WHERE ds.user_id = pu.id AND ds.pick_date = l.pick_date)
+ORDER BY pu.id
+LIMIT $1;`
+
+	insertDailyPicksQuery = `INSERT INTO daily_pick (user_id, pick_date, candidate_user_id, score, rank)
+SELECT $1::uuid, $2::date, * FROM unnest($3::uuid[], $4::float8[], $5::int[]);`
+)
+
+// todaysPicksQuery selects the users live picks that they haven't swiped on and that haven't been blocked, suspended or
+// banned since
+var todaysPicksQuery = fmt.Sprintf(`SELECT pu.id, pu.name, pu.gender, platform_user_age(pu.date_of_birth, pu.timezone), pu.location_latitude,
+    pu.location_longitude, dp.rank, ds.expires_at
+FROM daily_pick_set ds
+JOIN daily_pick dp ON dp.user_id = ds.user_id AND dp.pick_date = ds.pick_date
+JOIN platform_user pu ON pu.id = dp.candidate_user_id
+WHERE ds.user_id = $1 AND ds.expires_at > NOW()
+AND NOT EXISTS (SELECT 1 FROM user_swipe us WHERE us.owner_user_id = $1 AND us.swiped_user_id = pu.id)
+AND NOT EXISTS (
+    SELECT 1 FROM user_block ub
+    WHERE (ub.blocker_user_id = $1 AND ub.blocked_user_id = pu.id) OR (ub.blocker_user_id = pu.id AND ub.blocked_user_id = $1)
+)
+AND (%s) IS NULL
+ORDER BY ds.pick_date, dp.rank;`, fmt.Sprintf(activeSanctionQuery, "pu.id"))
+
+// SaveDiscoveryPreferences is a function that replaces the users saved discovery preferences
+func (p *PostgresAdapter) SaveDiscoveryPreferences(ctx context.Context, userID uuid.UUID, preferences entities.PageInfo) error {
+	genders := preferences.PreferredGenders
+	if genders == nil {
+		genders = []string{}
+	}
+
+	_, err := p.db.conn(ctx).ExecContext(ctx, saveDiscoveryPreferencesQuery, userID, nullableAge(preferences.MinAge), nullableAge(preferences.MaxAge), pq.Array(genders))
+	if err != nil {
+		usecases.Logger(ctx).DebugContext(ctx, "saving discovery preferences", "err", err)
+		return err
+	}
+
+	return nil
+}
+
+// nullableAge is a function that stores an age filter of 0, meaning no limit, as NULL
+func nullableAge(age int) sql.NullInt64 {
+	return sql.NullInt64{Int64: int64(age), Valid: age != 0}
+}
+
+// GetUsersDueDailyPicks is a function that returns up to limit users that haven't had picks generated for their current
+// local day
+func (p *PostgresAdapter) GetUsersDueDailyPicks(ctx context.Context, limit int) ([]entities.DailyPicksUser, error) {
+	rows, err := p.db.conn(ctx).QueryContext(ctx, usersDueDailyPicksQuery, limit)
+	if err != nil {
+		usecases.Logger(ctx).DebugContext(ctx, "getting users due daily picks", "err", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users []entities.DailyPicksUser
+	for rows.Next() {
+		var user entities.DailyPicksUser
+		var minAge, maxAge sql.NullInt64
+		err = rows.Scan(
+			&user.UserID,
+			&user.Location.Latitude,
+			&user.Location.Longitude,
+			&minAge,
+			&maxAge,
+			pq.Array(&user.Preferences.PreferredGenders),
+			&user.PickDate,
+			&user.ExpiresAt,
+		)
+		if err != nil {
+			usecases.Logger(ctx).DebugContext(ctx, "unable to read daily picks user row", "err", err)
+			return nil, err
+		}
+
+		user.Preferences.MinAge = int(minAge.Int64)
+		user.Preferences.MaxAge = int(maxAge.Int64)
+		users = append(users, user)
+	}
+
+	return users, rows.Err()
+}
+
+// SaveDailyPicks is a function that records the users picks for their local day. Picks that another instance has
+// already saved for the day are kept.
+func (p *PostgresAdapter) SaveDailyPicks(ctx context.Context, user entities.DailyPicksUser, picks []entities.DailyPick) error {
+	return p.db.withinTx(ctx, func(ctx context.Context, tx *instrumentedTx) error {
+		result, err := tx.ExecContext(ctx, "INSERT INTO daily_pick_set (user_id, pick_date, expires_at) VALUES ($1, $2, $3) ON CONFLICT DO NOTHING;",
+			user.UserID, user.PickDate, user.ExpiresAt)
+		if err != nil {
+			usecases.Logger(ctx).DebugContext(ctx, "inserting daily pick set", "err", err)
+			return err
+		}
+
+		inserted, err := result.RowsAffected()
+		if err != nil {
+			usecases.Logger(ctx).DebugContext(ctx, "error getting inserted daily pick set count", "err", err)
+			return err
+		}
+
+		if inserted != 0 && len(picks) != 0 {
+			candidateUserIDs := make([]uuid.UUID, len(picks))
+			scores := make([]float64, len(picks))
+			ranks := make([]int64, len(picks))
+			for i, pick := range picks {
+				candidateUserIDs[i] = pick.CandidateUserID
+				scores[i] = pick.Score
+				ranks[i] = int64(pick.Rank)
+			}
+
+			_, err = tx.ExecContext(ctx, insertDailyPicksQuery, user.UserID, user.PickDate, pq.Array(candidateUserIDs), pq.Array(scores), pq.Array(ranks))
+			if err != nil {
+				usecases.Logger(ctx).DebugContext(ctx, "inserting daily picks", "err", err)
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// GetTodaysPicks is a function that returns the users live picks that they haven't swiped on, best first
+func (p *PostgresAdapter) GetTodaysPicks(ctx context.Context, userID uuid.UUID) ([]entities.DailyPickCandidate, error) {
+	rows, err := p.db.conn(ctx).QueryContext(ctx, todaysPicksQuery, userID)
+	if err != nil {
+		usecases.Logger(ctx).DebugContext(ctx, "getting todays picks", "err", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	picks := []entities.DailyPickCandidate{}
+	for rows.Next() {
+		var pick entities.DailyPickCandidate
+		err = rows.Scan(
+			&pick.ID,
+			&pick.Name,
+			&pick.Gender,
+			&pick.Age,
+			&pick.Location.Latitude,
+			&pick.Location.Longitude,
+			&pick.Rank,
+			&pick.ExpiresAt,
+		)
+		if err != nil {
+			usecases.Logger(ctx).DebugContext(ctx, "unable to read daily pick row", "err", err)
+			return nil, err
+		}
+
+		picks = append(picks, pick)
+	}
+
+	return picks, rows.Err()
+}
@@ -0,0 +1,147 @@
+package adapters_test
+
+import (
+	"context"
+	"errors"
+	"github.com/AlecSmith96/dating-api/internal/adapters"
+	"github.com/AlecSmith96/dating-api/internal/entities"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	. "github.com/onsi/gomega"
+	"testing"
+	"time"
+)
+
+func TestPostgresAdapter_SaveDiscoveryPreferences(t *testing.T) {
+	g := NewWithT(t)
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	adapter := adapters.NewPostgresAdapter(db, 0, "something-secret", nil)
+
+	userID := uuid.New()
+	mock.ExpectExec(`INSERT INTO discovery_preference \(user_id, min_age, max_age, preferred_genders, updated_at\) VALUES \(\$1, \$2, \$3, \$4, NOW\(\)\) ON CONFLICT \(user_id\) DO UPDATE SET min_age = EXCLUDED\.min_age, max_age = EXCLUDED\.max_age, preferred_genders = EXCLUDED\.preferred_genders, updated_at = NOW\(\);`).
+		WithArgs(userID, 25, nil, "{\"female\"}").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = adapter.SaveDiscoveryPreferences(context.Background(), userID, entities.PageInfo{MinAge: 25, PreferredGenders: []string{"female"}})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(mock.ExpectationsWereMet()).To(Succeed())
+}
+
+func TestPostgresAdapter_GetUsersDueDailyPicks(t *testing.T) {
+	g := NewWithT(t)
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	adapter := adapters.NewPostgresAdapter(db, 0, "something-secret", nil)
+
+	userID := uuid.New()
+	pickDate := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	expiresAt := time.Date(2026, 10, 19, 23, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(`FROM platform_user pu CROSS JOIN LATERAL \(SELECT \(NOW\(\) AT TIME ZONE pu\.timezone\)::date AS pick_date\) l LEFT JOIN discovery_preference dp ON dp\.user_id = pu\.id WHERE NOT EXISTS \(SELECT 1 FROM daily_pick_set ds WHERE ds\.user_id = pu\.id AND ds\.pick_date = l\.pick_date\) ORDER BY pu\.id LIMIT \$1;`).
+		WithArgs(100).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "location_latitude", "location_longitude", "min_age", "max_age", "preferred_genders", "pick_date", "expires_at"}).
+			AddRow(userID, 51.4545, -2.5879, 25, nil, "{female}", pickDate, expiresAt).
+			AddRow(uuid.New(), 51.4545, -2.5879, nil, nil, "{}", pickDate, expiresAt))
+
+	users, err := adapter.GetUsersDueDailyPicks(context.Background(), 100)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(users).To(HaveLen(2))
+	g.Expect(users[0]).To(Equal(entities.DailyPicksUser{
+		UserID:      userID,
+		Location:    entities.Location{Latitude: 51.4545, Longitude: -2.5879},
+		Preferences: entities.PageInfo{MinAge: 25, PreferredGenders: []string{"female"}},
+		PickDate:    pickDate,
+		ExpiresAt:   expiresAt,
+	}))
+	g.Expect(users[1].Preferences).To(Equal(entities.PageInfo{PreferredGenders: []string{}}))
+}
+
+func TestPostgresAdapter_SaveDailyPicks(t *testing.T) {
+	g := NewWithT(t)
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	adapter := adapters.NewPostgresAdapter(db, 0, "something-secret", nil)
+
+	user := entities.DailyPicksUser{UserID: uuid.New(), PickDate: time.Now(), ExpiresAt: time.Now().Add(time.Hour)}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO daily_pick_set \(user_id, pick_date, expires_at\) VALUES \(\$1, \$2, \$3\) ON CONFLICT DO NOTHING;`).
+		WithArgs(user.UserID, user.PickDate, user.ExpiresAt).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO daily_pick \(user_id, pick_date, candidate_user_id, score, rank\) SELECT \$1::uuid, \$2::date, \* FROM unnest\(\$3::uuid\[\], \$4::float8\[\], \$5::int\[\]\);`).
+		WithArgs(user.UserID, user.PickDate, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err = adapter.SaveDailyPicks(context.Background(), user, []entities.DailyPick{{CandidateUserID: uuid.New(), Score: 2.5, Rank: 1}})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(mock.ExpectationsWereMet()).To(Succeed())
+}
+
+func TestPostgresAdapter_SaveDailyPicks_AlreadyPicked(t *testing.T) {
+	g := NewWithT(t)
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	adapter := adapters.NewPostgresAdapter(db, 0, "something-secret", nil)
+
+	user := entities.DailyPicksUser{UserID: uuid.New(), PickDate: time.Now(), ExpiresAt: time.Now().Add(time.Hour)}
+
+	// another instance saved picks for the day first, so theirs are kept
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO daily_pick_set`).
+		WithArgs(user.UserID, user.PickDate, user.ExpiresAt).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	err = adapter.SaveDailyPicks(context.Background(), user, []entities.DailyPick{{CandidateUserID: uuid.New(), Score: 2.5, Rank: 1}})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(mock.ExpectationsWereMet()).To(Succeed())
+}
+
+func TestPostgresAdapter_GetTodaysPicks(t *testing.T) {
+	g := NewWithT(t)
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	adapter := adapters.NewPostgresAdapter(db, 0, "something-secret", nil)
+
+	userID := uuid.New()
+	pick := entities.DailyPickCandidate{
+		ID:        uuid.New(),
+		Name:      "Alex",
+		Gender:    "female",
+		Age:       27,
+		Location:  entities.Location{Latitude: 51.4545, Longitude: -2.5879},
+		Rank:      1,
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+
+	mock.ExpectQuery(`FROM daily_pick_set ds JOIN daily_pick dp ON dp\.user_id = ds\.user_id AND dp\.pick_date = ds\.pick_date JOIN platform_user pu ON pu\.id = dp\.candidate_user_id WHERE ds\.user_id = \$1 AND ds\.expires_at > NOW\(\) AND NOT EXISTS \(SELECT 1 FROM user_swipe us WHERE us\.owner_user_id = \$1 AND us\.swiped_user_id = pu\.id\) AND NOT EXISTS \( SELECT 1 FROM user_block ub`).
+		WithArgs(userID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "gender", "age", "location_latitude", "location_longitude", "rank", "expires_at"}).
+			AddRow(pick.ID, pick.Name, pick.Gender, pick.Age, pick.Location.Latitude, pick.Location.Longitude, pick.Rank, pick.ExpiresAt))
+
+	picks, err := adapter.GetTodaysPicks(context.Background(), userID)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(picks).To(Equal([]entities.DailyPickCandidate{pick}))
+}
+
+func TestPostgresAdapter_GetTodaysPicks_Error(t *testing.T) {
+	g := NewWithT(t)
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	adapter := adapters.NewPostgresAdapter(db, 0, "something-secret", nil)
+
+	mock.ExpectQuery(`FROM daily_pick_set ds`).
+		WillReturnError(errors.New("an error occurred"))
+
+	picks, err := adapter.GetTodaysPicks(context.Background(), uuid.New())
+	g.Expect(err).To(MatchError("an error occurred"))
+	g.Expect(picks).To(BeNil())
+}
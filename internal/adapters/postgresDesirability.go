@@ -0,0 +1,191 @@
+package adapters
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/AlecSmith96/dating-api/internal/entities"
+	"github.com/AlecSmith96/dating-api/internal/usecases"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+const (
+	// lockDesirabilityQuery serialises the writers of desirability scores, so swipes are always applied in order, while
+	// still letting discovery read the scores
+	lockDesirabilityQuery = `LOCK TABLE user_desirability IN SHARE ROW EXCLUSIVE MODE;`
+
+	pendingDesirabilitySwipesQuery = `SELECT us.id, us.owner_user_id, us.swiped_user_id, us.swipe_type
+FROM user_swipe us
+WHERE NOT us.desirability_applied
+ORDER BY us.created_at NULLS FIRST, us.id
+LIMIT $1
+FOR UPDATE;`
+
+	getDesirabilitiesQuery = `SELECT user_id, score, swipes FROM user_desirability WHERE user_id = ANY($1::uuid[]);`
+
+	upsertDesirabilityQuery = `INSERT INTO user_desirability (user_id, score, swipes, updated_at) VALUES ($1, $2, $3, NOW())
+ON CONFLICT (user_id) DO UPDATE SET score = EXCLUDED.score, swipes = EXCLUDED.swipes, updated_at = NOW();`
+
+	markDesirabilityAppliedQuery = `UPDATE user_swipe SET desirability_applied = TRUE WHERE id = ANY($1::uuid[]);`
+)
+
+// ApplyDesirabilitySwipes is a function that updates the desirability scores of swiped users from up to limit swipes
+// that haven't been applied yet, oldest first. It returns the number of swipes applied.
+func (p *PostgresAdapter) ApplyDesirabilitySwipes(ctx context.Context, limit int) (int, error) {
+	var applied int
+	err := p.db.withinTx(ctx, func(ctx context.Context, tx *instrumentedTx) error {
+		_, err := tx.ExecContext(ctx, lockDesirabilityQuery)
+		if err != nil {
+			usecases.Logger(ctx).DebugContext(ctx, "locking desirability", "err", err)
+			return err
+		}
+
+		rows, err := tx.QueryContext(ctx, pendingDesirabilitySwipesQuery, limit)
+		if err != nil {
+			usecases.Logger(ctx).DebugContext(ctx, "getting swipes to apply to desirability", "err", err)
+			return err
+		}
+
+		var swipes []entities.Swipe
+		var userIDs []uuid.UUID
+		for rows.Next() {
+			var swipe entities.Swipe
+			err = rows.Scan(&swipe.ID, &swipe.OwnerUserID, &swipe.SwipedUserID, &swipe.Type)
+			if err != nil {
+				rows.Close()
+				usecases.Logger(ctx).DebugContext(ctx, "unable to read swipe row", "err", err)
+				return err
+			}
+
+			swipes = append(swipes, swipe)
+			userIDs = append(userIDs, swipe.OwnerUserID, swipe.SwipedUserID)
+		}
+		rows.Close()
+		if err = rows.Err(); err != nil {
+			usecases.Logger(ctx).DebugContext(ctx, "reading swipes to apply to desirability", "err", err)
+			return err
+		}
+
+		if len(swipes) == 0 {
+			return nil
+		}
+
+		desirabilities, err := getDesirabilities(ctx, tx, userIDs)
+		if err != nil {
+			return err
+		}
+
+		desirability := func(userID uuid.UUID) *entities.Desirability {
+			if _, ok := desirabilities[userID]; !ok {
+				desirabilities[userID] = entities.NewDesirability(userID)
+			}
+			return desirabilities[userID]
+		}
+
+		// swipes are applied in order, so a users score as a rater includes the swipes on them earlier in the batch
+		var updatedUserIDs []uuid.UUID
+		updated := make(map[uuid.UUID]bool)
+		swipeIDs := make([]uuid.UUID, 0, len(swipes))
+		for _, swipe := range swipes {
+			raterScore := desirability(swipe.OwnerUserID).Score
+			desirability(swipe.SwipedUserID).ApplySwipe(raterScore, swipe.Type.IsPositive())
+
+			if !updated[swipe.SwipedUserID] {
+				updated[swipe.SwipedUserID] = true
+				updatedUserIDs = append(updatedUserIDs, swipe.SwipedUserID)
+			}
+			swipeIDs = append(swipeIDs, swipe.ID)
+		}
+
+		for _, userID := range updatedUserIDs {
+			swipedUser := desirabilities[userID]
+			_, err = tx.ExecContext(ctx, upsertDesirabilityQuery, swipedUser.UserID, swipedUser.Score, swipedUser.Swipes)
+			if err != nil {
+				usecases.Logger(ctx).DebugContext(ctx, "saving desirability", "err", err)
+				return err
+			}
+		}
+
+		_, err = tx.ExecContext(ctx, markDesirabilityAppliedQuery, pq.Array(swipeIDs))
+		if err != nil {
+			usecases.Logger(ctx).DebugContext(ctx, "marking swipes applied to desirability", "err", err)
+			return err
+		}
+
+		applied = len(swipes)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return applied, nil
+}
+
+// getDesirabilities is a function that returns the stored desirability of each of the users that has one
+func getDesirabilities(ctx context.Context, tx *instrumentedTx, userIDs []uuid.UUID) (map[uuid.UUID]*entities.Desirability, error) {
+	rows, err := tx.QueryContext(ctx, getDesirabilitiesQuery, pq.Array(userIDs))
+	if err != nil {
+		usecases.Logger(ctx).DebugContext(ctx, "getting desirabilities", "err", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	desirabilities := make(map[uuid.UUID]*entities.Desirability)
+	for rows.Next() {
+		var desirability entities.Desirability
+		err = rows.Scan(&desirability.UserID, &desirability.Score, &desirability.Swipes)
+		if err != nil {
+			usecases.Logger(ctx).DebugContext(ctx, "unable to read desirability row", "err", err)
+			return nil, err
+		}
+
+		desirabilities[desirability.UserID] = &desirability
+	}
+
+	return desirabilities, rows.Err()
+}
+
+// ResetDesirability is a function that deletes every users desirability and marks every swipe as not applied, so
+// the scores can be recomputed from scratch
+func (p *PostgresAdapter) ResetDesirability(ctx context.Context) error {
+	return p.db.withinTx(ctx, func(ctx context.Context, tx *instrumentedTx) error {
+		_, err := tx.ExecContext(ctx, lockDesirabilityQuery)
+		if err != nil {
+			usecases.Logger(ctx).DebugContext(ctx, "locking desirability", "err", err)
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, "DELETE FROM user_desirability;")
+		if err != nil {
+			usecases.Logger(ctx).DebugContext(ctx, "deleting desirability", "err", err)
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, "UPDATE user_swipe SET desirability_applied = FALSE WHERE desirability_applied;")
+		if err != nil {
+			usecases.Logger(ctx).DebugContext(ctx, "marking swipes not applied to desirability", "err", err)
+			return err
+		}
+
+		return nil
+	})
+}
+
+// GetDesirability is a function that returns the users desirability score, users that haven't been swiped on yet have
+// the default score
+func (p *PostgresAdapter) GetDesirability(ctx context.Context, userID uuid.UUID) (float64, error) {
+	var score float64
+	err := p.db.conn(ctx).QueryRowContext(ctx, "SELECT score FROM user_desirability WHERE user_id = $1;", userID).Scan(&score)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return entities.DefaultDesirability, nil
+		}
+
+		usecases.Logger(ctx).DebugContext(ctx, "getting desirability", "err", err)
+		return 0, err
+	}
+
+	return score, nil
+}
@@ -0,0 +1,144 @@
+package adapters_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"github.com/AlecSmith96/dating-api/internal/adapters"
+	"github.com/AlecSmith96/dating-api/internal/entities"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	. "github.com/onsi/gomega"
+	"testing"
+)
+
+func TestPostgresAdapter_ApplyDesirabilitySwipes(t *testing.T) {
+	g := NewWithT(t)
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	adapter := adapters.NewPostgresAdapter(db, 0, "something-secret", nil)
+
+	raterID := uuid.New()
+	swipedUserID := uuid.New()
+	otherUserID := uuid.New()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`LOCK TABLE user_desirability IN SHARE ROW EXCLUSIVE MODE;`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(`SELECT us\.id, us\.owner_user_id, us\.swiped_user_id, us\.swipe_type FROM user_swipe us WHERE NOT us\.desirability_applied ORDER BY us\.created_at NULLS FIRST, us\.id LIMIT \$1 FOR UPDATE;`).
+		WithArgs(100).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "owner_user_id", "swiped_user_id", "swipe_type"}).
+			AddRow(uuid.New(), raterID, swipedUserID, "like").
+			AddRow(uuid.New(), otherUserID, swipedUserID, "pass"))
+	mock.ExpectQuery(`SELECT user_id, score, swipes FROM user_desirability WHERE user_id = ANY\(\$1::uuid\[\]\);`).
+		WithArgs(sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "score", "swipes"}).
+			AddRow(raterID, 1900.0, 50))
+	// the like from the higher scored rater is worth more than the pass from the new user costs
+	mock.ExpectExec(`INSERT INTO user_desirability \(user_id, score, swipes, updated_at\) VALUES \(\$1, \$2, \$3, NOW\(\)\) ON CONFLICT \(user_id\) DO UPDATE SET score = EXCLUDED\.score, swipes = EXCLUDED\.swipes, updated_at = NOW\(\);`).
+		WithArgs(swipedUserID, floatBetween{1500, 1520}, 2).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE user_swipe SET desirability_applied = TRUE WHERE id = ANY\(\$1::uuid\[\]\);`).
+		WithArgs(sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	applied, err := adapter.ApplyDesirabilitySwipes(context.Background(), 100)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(applied).To(Equal(2))
+	g.Expect(mock.ExpectationsWereMet()).To(Succeed())
+}
+
+func TestPostgresAdapter_ApplyDesirabilitySwipes_NoSwipes(t *testing.T) {
+	g := NewWithT(t)
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	adapter := adapters.NewPostgresAdapter(db, 0, "something-secret", nil)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`LOCK TABLE user_desirability`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(`FROM user_swipe us WHERE NOT us\.desirability_applied`).
+		WithArgs(100).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "owner_user_id", "swiped_user_id", "swipe_type"}))
+	mock.ExpectCommit()
+
+	applied, err := adapter.ApplyDesirabilitySwipes(context.Background(), 100)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(applied).To(Equal(0))
+	g.Expect(mock.ExpectationsWereMet()).To(Succeed())
+}
+
+func TestPostgresAdapter_ResetDesirability(t *testing.T) {
+	g := NewWithT(t)
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	adapter := adapters.NewPostgresAdapter(db, 0, "something-secret", nil)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`LOCK TABLE user_desirability`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`DELETE FROM user_desirability;`).
+		WillReturnResult(sqlmock.NewResult(0, 10))
+	mock.ExpectExec(`UPDATE user_swipe SET desirability_applied = FALSE WHERE desirability_applied;`).
+		WillReturnResult(sqlmock.NewResult(0, 40))
+	mock.ExpectCommit()
+
+	err = adapter.ResetDesirability(context.Background())
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(mock.ExpectationsWereMet()).To(Succeed())
+}
+
+func TestPostgresAdapter_GetDesirability(t *testing.T) {
+	testCases := []struct {
+		name          string
+		rows          *sqlmock.Rows
+		err           error
+		expectedScore float64
+		expectedErr   error
+	}{
+		{name: "stored score", rows: sqlmock.NewRows([]string{"score"}).AddRow(1620.5), expectedScore: 1620.5},
+		{name: "never swiped on", err: sql.ErrNoRows, expectedScore: entities.DefaultDesirability},
+		{name: "error", err: errors.New("an error occurred"), expectedErr: errors.New("an error occurred")},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			g := NewWithT(t)
+			db, mock, err := sqlmock.New()
+			g.Expect(err).ToNot(HaveOccurred())
+
+			adapter := adapters.NewPostgresAdapter(db, 0, "something-secret", nil)
+
+			userID := uuid.New()
+			expectation := mock.ExpectQuery(`SELECT score FROM user_desirability WHERE user_id = \$1;`).WithArgs(userID)
+			if testCase.err != nil {
+				expectation.WillReturnError(testCase.err)
+			} else {
+				expectation.WillReturnRows(testCase.rows)
+			}
+
+			score, err := adapter.GetDesirability(context.Background(), userID)
+			if testCase.expectedErr != nil {
+				g.Expect(err).To(MatchError(testCase.expectedErr.Error()))
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(score).To(Equal(testCase.expectedScore))
+		})
+	}
+}
+
+// floatBetween matches a float argument within a range
+type floatBetween struct {
+	min, max float64
+}
+
+func (f floatBetween) Match(value driver.Value) bool {
+	score, ok := value.(float64)
+	return ok && score > f.min && score < f.max
+}
@@ -0,0 +1,56 @@
+package adapters
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/AlecSmith96/dating-api/internal/entities"
+	"github.com/AlecSmith96/dating-api/internal/usecases"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"time"
+)
+
+var (
+	// saveDiscoverySnapshotQuery replaces the users snapshot with a new one, so the cursors of the old one stop working
+	saveDiscoverySnapshotQuery = fmt.Sprintf(`INSERT INTO discovery_snapshot (user_id, ranked_user_ids)
+VALUES ($1, $2)
+ON CONFLICT (user_id) DO UPDATE SET id = gen_random_uuid(), ranked_user_ids = EXCLUDED.ranked_user_ids, created_at = NOW()
+RETURNING %s;`, columnList("", discoverySnapshotColumns))
+
+	getDiscoverySnapshotQuery = fmt.Sprintf(`SELECT %s FROM discovery_snapshot WHERE id = $1 AND user_id = $2 AND created_at > NOW() - make_interval(secs => $3);`,
+		columnList("", discoverySnapshotColumns))
+)
+
+// SaveDiscoverySnapshot is a function that stores the ranked users of the users latest discover request, replacing
+// the snapshot of their previous one
+func (p *PostgresAdapter) SaveDiscoverySnapshot(ctx context.Context, userID uuid.UUID, rankedUserIDs []uuid.UUID) (*entities.DiscoverySnapshot, error) {
+	var snapshot entities.DiscoverySnapshot
+	err := scanDiscoverySnapshot(p.db.conn(ctx).QueryRowContext(ctx, saveDiscoverySnapshotQuery, userID, pq.Array(rankedUserIDs)), &snapshot)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return nil, entities.ErrTargetUserNotFound
+		}
+		usecases.Logger(ctx).DebugContext(ctx, "saving discovery snapshot", "err", err)
+		return nil, err
+	}
+
+	return &snapshot, nil
+}
+
+// GetDiscoverySnapshot is a function that returns one of the users discovery snapshots, snapshots older than maxAge,
+// that have been replaced or that belong to another user aren't found
+func (p *PostgresAdapter) GetDiscoverySnapshot(ctx context.Context, userID, snapshotID uuid.UUID, maxAge time.Duration) (*entities.DiscoverySnapshot, error) {
+	var snapshot entities.DiscoverySnapshot
+	err := scanDiscoverySnapshot(p.db.conn(ctx).QueryRowContext(ctx, getDiscoverySnapshotQuery, snapshotID, userID, maxAge.Seconds()), &snapshot)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, entities.ErrSnapshotNotFound
+		}
+		usecases.Logger(ctx).DebugContext(ctx, "getting discovery snapshot", "err", err)
+		return nil, err
+	}
+
+	return &snapshot, nil
+}
@@ -0,0 +1,110 @@
+package adapters_test
+
+import (
+	"context"
+	"errors"
+	"github.com/AlecSmith96/dating-api/internal/adapters"
+	"github.com/AlecSmith96/dating-api/internal/entities"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	. "github.com/onsi/gomega"
+	"testing"
+	"time"
+)
+
+func TestPostgresAdapter_SaveDiscoverySnapshot(t *testing.T) {
+	g := NewWithT(t)
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	adapter := adapters.NewPostgresAdapter(db, 0, "something-secret", nil)
+
+	userID := uuid.New()
+	snapshotID := uuid.New()
+	rankedUserIDs := []uuid.UUID{uuid.New(), uuid.New()}
+	rankedUserIDsArray, err := pq.Array(rankedUserIDs).Value()
+	g.Expect(err).ToNot(HaveOccurred())
+	createdAt := time.Now()
+
+	mock.ExpectQuery(`INSERT INTO discovery_snapshot \(user_id, ranked_user_ids\) VALUES \(\$1, \$2\) ON CONFLICT \(user_id\) DO UPDATE SET id = gen_random_uuid\(\), ranked_user_ids = EXCLUDED\.ranked_user_ids, created_at = NOW\(\) RETURNING id, user_id, ranked_user_ids, created_at;`).
+		WithArgs(userID, rankedUserIDsArray).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "ranked_user_ids", "created_at"}).
+			AddRow(snapshotID, userID, rankedUserIDsArray, createdAt))
+
+	snapshot, err := adapter.SaveDiscoverySnapshot(context.Background(), userID, rankedUserIDs)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(snapshot).To(Equal(&entities.DiscoverySnapshot{
+		ID:            snapshotID,
+		UserID:        userID,
+		RankedUserIDs: rankedUserIDs,
+		CreatedAt:     createdAt,
+	}))
+	g.Expect(mock.ExpectationsWereMet()).To(Succeed())
+}
+
+func TestPostgresAdapter_SaveDiscoverySnapshot_UserNotFound(t *testing.T) {
+	g := NewWithT(t)
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	adapter := adapters.NewPostgresAdapter(db, 0, "something-secret", nil)
+
+	mock.ExpectQuery(`INSERT INTO discovery_snapshot`).
+		WillReturnError(&pq.Error{Code: "23503"})
+
+	_, err = adapter.SaveDiscoverySnapshot(context.Background(), uuid.New(), []uuid.UUID{uuid.New()})
+	g.Expect(err).To(MatchError(entities.ErrTargetUserNotFound))
+}
+
+func TestPostgresAdapter_GetDiscoverySnapshot(t *testing.T) {
+	g := NewWithT(t)
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	adapter := adapters.NewPostgresAdapter(db, 0, "something-secret", nil)
+
+	userID := uuid.New()
+	snapshotID := uuid.New()
+	rankedUserID := uuid.New()
+	createdAt := time.Now()
+
+	mock.ExpectQuery(`SELECT id, user_id, ranked_user_ids, created_at FROM discovery_snapshot WHERE id = \$1 AND user_id = \$2 AND created_at > NOW\(\) - make_interval\(secs => \$3\);`).
+		WithArgs(snapshotID, userID, float64(3600)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "ranked_user_ids", "created_at"}).
+			AddRow(snapshotID, userID, "{"+rankedUserID.String()+"}", createdAt))
+
+	snapshot, err := adapter.GetDiscoverySnapshot(context.Background(), userID, snapshotID, time.Hour)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(snapshot.ID).To(Equal(snapshotID))
+	g.Expect(snapshot.RankedUserIDs).To(Equal([]uuid.UUID{rankedUserID}))
+	g.Expect(mock.ExpectationsWereMet()).To(Succeed())
+}
+
+func TestPostgresAdapter_GetDiscoverySnapshot_NotFound(t *testing.T) {
+	g := NewWithT(t)
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	adapter := adapters.NewPostgresAdapter(db, 0, "something-secret", nil)
+
+	mock.ExpectQuery(`FROM discovery_snapshot`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "ranked_user_ids", "created_at"}))
+
+	_, err = adapter.GetDiscoverySnapshot(context.Background(), uuid.New(), uuid.New(), time.Hour)
+	g.Expect(err).To(MatchError(entities.ErrSnapshotNotFound))
+}
+
+func TestPostgresAdapter_GetDiscoverySnapshot_GenericErr(t *testing.T) {
+	g := NewWithT(t)
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	adapter := adapters.NewPostgresAdapter(db, 0, "something-secret", nil)
+
+	mock.ExpectQuery(`FROM discovery_snapshot`).
+		WillReturnError(errors.New("an error occurred"))
+
+	_, err = adapter.GetDiscoverySnapshot(context.Background(), uuid.New(), uuid.New(), time.Hour)
+	g.Expect(err).To(MatchError("an error occurred"))
+}
@@ -0,0 +1,45 @@
+package adapters
+
+import (
+	"context"
+	"github.com/AlecSmith96/dating-api/internal/usecases"
+	"github.com/pressly/goose/v3"
+)
+
+// GetLatestMigrationVersion is a function that returns the version of the newest migration in the goose directory
+func GetLatestMigrationVersion(gooseDir string) (int64, error) {
+	migrations, err := goose.CollectMigrations(gooseDir, 0, goose.MaxVersion)
+	if err != nil {
+		return 0, err
+	}
+
+	latest, err := migrations.Last()
+	if err != nil {
+		return 0, err
+	}
+
+	return latest.Version, nil
+}
+
+func (p *PostgresAdapter) PingDatabase(ctx context.Context) error {
+	err := p.db.PingContext(ctx)
+	if err != nil {
+		usecases.Logger(ctx).DebugContext(ctx, "pinging database", "err", err)
+		return err
+	}
+
+	return nil
+}
+
+// GetMigrationVersion returns the newest migration applied to the database, it reads the goose table directly rather
+// than through goose as that creates the table when it is missing
+func (p *PostgresAdapter) GetMigrationVersion(ctx context.Context) (int64, error) {
+	var version int64
+	err := p.db.conn(ctx).QueryRowContext(ctx, "SELECT COALESCE(MAX(version_id), 0) FROM goose_db_version WHERE is_applied;").Scan(&version)
+	if err != nil {
+		usecases.Logger(ctx).DebugContext(ctx, "getting migration version", "err", err)
+		return 0, err
+	}
+
+	return version, nil
+}
@@ -0,0 +1,33 @@
+package adapters_test
+
+import (
+	"context"
+	"github.com/AlecSmith96/dating-api/internal/adapters"
+	"github.com/DATA-DOG/go-sqlmock"
+	. "github.com/onsi/gomega"
+	"testing"
+)
+
+func TestPostgresAdapter_GetMigrationVersion(t *testing.T) {
+	g := NewWithT(t)
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	adapter := adapters.NewPostgresAdapter(db, 0, "something-secret", nil)
+
+	mock.ExpectQuery(`SELECT COALESCE\(MAX\(version_id\), 0\) FROM goose_db_version WHERE is_applied;`).
+		WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(20261019233000))
+
+	version, err := adapter.GetMigrationVersion(context.Background())
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(version).To(Equal(int64(20261019233000)))
+	g.Expect(mock.ExpectationsWereMet()).To(Succeed())
+}
+
+func TestGetLatestMigrationVersion(t *testing.T) {
+	g := NewWithT(t)
+
+	version, err := adapters.GetLatestMigrationVersion("../../db/goose")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(version).To(BeNumerically(">=", 20261019233000))
+}
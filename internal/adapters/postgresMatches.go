@@ -0,0 +1,58 @@
+package adapters
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/AlecSmith96/dating-api/internal/entities"
+	"github.com/AlecSmith96/dating-api/internal/usecases"
+	"github.com/google/uuid"
+)
+
+const isMatchQuery = `SELECT EXISTS (
+    SELECT 1 FROM user_swipe us
+    WHERE us.owner_user_id = $1 AND us.swiped_user_id = $2 AND us.swipe_type IN ('like', 'superlike')
+    AND NOT EXISTS (
+        SELECT 1 FROM user_block ub
+        WHERE (ub.blocker_user_id = $1 AND ub.blocked_user_id = $2) OR (ub.blocker_user_id = $2 AND ub.blocked_user_id = $1)
+    )
+);`
+
+var createMatchQuery = fmt.Sprintf(`INSERT INTO user_match (owner_user_id, matched_user_id) VALUES ($1, $2) RETURNING %s;`, columnList("", matchColumns))
+
+// PostgresMatchRepository stores the matches between users in user_match
+type PostgresMatchRepository struct {
+	db *instrumentedDB
+}
+
+// NewPostgresMatchRepository creates the repository, the queries it runs are timed by the query observer when one is
+// given
+func NewPostgresMatchRepository(db *sql.DB, queryObserver QueryObserver) *PostgresMatchRepository {
+	return &PostgresMatchRepository{db: newInstrumentedDB(db, queryObserver)}
+}
+
+// IsMatch is a function that creates a match between the users if the swiped user has also liked the owner, returning
+// nil when they haven't
+func (r *PostgresMatchRepository) IsMatch(ctx context.Context, ownerUserID, swipedUserID uuid.UUID) (*entities.Match, error) {
+	var exists bool
+	err := r.db.conn(ctx).QueryRowContext(ctx, isMatchQuery, swipedUserID, ownerUserID).
+		Scan(&exists)
+	if err != nil {
+		usecases.Logger(ctx).DebugContext(ctx, "error checking if swiped user also swiped positively", "err", err)
+		return nil, err
+	}
+
+	if !exists {
+		usecases.Logger(ctx).DebugContext(ctx, "match does not exist for users", "ownerUserID", ownerUserID, "swipedUserID", swipedUserID)
+		return nil, nil
+	}
+
+	var match entities.Match
+	err = scanMatch(r.db.conn(ctx).QueryRowContext(ctx, createMatchQuery, ownerUserID, swipedUserID), &match)
+	if err != nil {
+		usecases.Logger(ctx).DebugContext(ctx, "creating match record", "err", err)
+		return nil, err
+	}
+
+	return &match, nil
+}
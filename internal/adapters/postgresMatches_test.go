@@ -0,0 +1,78 @@
+package adapters_test
+
+import (
+	"context"
+	"errors"
+	"github.com/AlecSmith96/dating-api/internal/adapters"
+	"github.com/AlecSmith96/dating-api/internal/entities"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	. "github.com/onsi/gomega"
+	"testing"
+)
+
+func TestPostgresMatchRepository_IsMatch(t *testing.T) {
+	g := NewWithT(t)
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	repository := adapters.NewPostgresMatchRepository(db, nil)
+
+	match := &entities.Match{
+		ID:            uuid.New(),
+		OwnerUserID:   uuid.New(),
+		MatchedUserID: uuid.New(),
+	}
+
+	mock.ExpectQuery(`SELECT EXISTS \( SELECT 1 FROM user_swipe us WHERE us\.owner_user_id = \$1 AND us\.swiped_user_id = \$2 AND us\.swipe_type IN \('like', 'superlike'\)`).
+		WithArgs(match.MatchedUserID, match.OwnerUserID).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectQuery(`INSERT INTO user_match \(owner_user_id, matched_user_id\) VALUES \(\$1, \$2\) RETURNING id, owner_user_id, matched_user_id;`).
+		WithArgs(match.OwnerUserID, match.MatchedUserID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "owner_user_id", "matched_user_id"}).
+			AddRow(match.ID, match.OwnerUserID, match.MatchedUserID))
+
+	returnedMatch, err := repository.IsMatch(context.Background(), match.OwnerUserID, match.MatchedUserID)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(returnedMatch).To(Equal(match))
+	g.Expect(mock.ExpectationsWereMet()).To(Succeed())
+}
+
+func TestPostgresMatchRepository_IsMatch_NotLikedBack(t *testing.T) {
+	g := NewWithT(t)
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	repository := adapters.NewPostgresMatchRepository(db, nil)
+
+	ownerUserID := uuid.New()
+	swipedUserID := uuid.New()
+
+	mock.ExpectQuery(`SELECT EXISTS \( SELECT 1 FROM user_swipe us`).
+		WithArgs(swipedUserID, ownerUserID).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+
+	returnedMatch, err := repository.IsMatch(context.Background(), ownerUserID, swipedUserID)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(returnedMatch).To(BeNil())
+	g.Expect(mock.ExpectationsWereMet()).To(Succeed())
+}
+
+func TestPostgresMatchRepository_IsMatch_GenericErr(t *testing.T) {
+	g := NewWithT(t)
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	repository := adapters.NewPostgresMatchRepository(db, nil)
+
+	ownerUserID := uuid.New()
+	swipedUserID := uuid.New()
+
+	mock.ExpectQuery(`SELECT EXISTS \( SELECT 1 FROM user_swipe us`).
+		WithArgs(swipedUserID, ownerUserID).
+		WillReturnError(errors.New("an error occurred"))
+
+	returnedMatch, err := repository.IsMatch(context.Background(), ownerUserID, swipedUserID)
+	g.Expect(err).To(MatchError("an error occurred"))
+	g.Expect(returnedMatch).To(BeNil())
+}
@@ -0,0 +1,278 @@
+package adapters
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/AlecSmith96/dating-api/internal/entities"
+	"github.com/AlecSmith96/dating-api/internal/usecases"
+	"github.com/google/uuid"
+)
+
+var (
+	getModerationCaseQuery = fmt.Sprintf(`SELECT %s, COALESCE(ur.reason, ''), COALESCE(ur.details, '')
+FROM moderation_case mc
+LEFT JOIN user_report ur ON ur.id = mc.report_id
+WHERE mc.id = $1;`, columnList("mc", moderationCaseColumns))
+
+	getModerationCasesQuery = fmt.Sprintf(`SELECT %s, COALESCE(ur.reason, ''), COALESCE(ur.details, '')
+FROM moderation_case mc
+LEFT JOIN user_report ur ON ur.id = mc.report_id
+WHERE mc.status = $1
+ORDER BY mc.created_at
+LIMIT $2;`, columnList("mc", moderationCaseColumns))
+
+	getModerationAuditQuery = fmt.Sprintf(`SELECT %s FROM moderation_audit WHERE case_id = $1 ORDER BY created_at;`,
+		columnList("", moderationAuditColumns))
+)
+
+// GetModerationCases is a function that returns the cases with the provided status, oldest first
+func (p *PostgresAdapter) GetModerationCases(ctx context.Context, status entities.ModerationCaseStatus, limit int) ([]entities.ModerationCase, error) {
+	rows, err := p.db.conn(ctx).QueryContext(ctx, getModerationCasesQuery, status, limit)
+	if err != nil {
+		usecases.Logger(ctx).DebugContext(ctx, "getting moderation cases", "err", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	moderationCases := []entities.ModerationCase{}
+	for rows.Next() {
+		var moderationCase entities.ModerationCase
+		err = scanModerationCase(rows, &moderationCase, &moderationCase.ReportReason, &moderationCase.ReportDetails)
+		if err != nil {
+			usecases.Logger(ctx).DebugContext(ctx, "unable to read moderation case row", "err", err)
+			return nil, err
+		}
+
+		moderationCases = append(moderationCases, moderationCase)
+	}
+
+	return moderationCases, rows.Err()
+}
+
+// GetModerationCase is a function that returns a single moderation case
+func (p *PostgresAdapter) GetModerationCase(ctx context.Context, caseID uuid.UUID) (*entities.ModerationCase, error) {
+	var moderationCase entities.ModerationCase
+	err := scanModerationCase(p.db.conn(ctx).QueryRowContext(ctx, getModerationCaseQuery, caseID), &moderationCase, &moderationCase.ReportReason, &moderationCase.ReportDetails)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, entities.ErrCaseNotFound
+		}
+		usecases.Logger(ctx).DebugContext(ctx, "getting moderation case", "err", err)
+		return nil, err
+	}
+
+	return &moderationCase, nil
+}
+
+// GetModerationAudit is a function that returns the audit history of a case, oldest first
+func (p *PostgresAdapter) GetModerationAudit(ctx context.Context, caseID uuid.UUID) ([]entities.ModerationAuditEntry, error) {
+	rows, err := p.db.conn(ctx).QueryContext(ctx, getModerationAuditQuery, caseID)
+	if err != nil {
+		usecases.Logger(ctx).DebugContext(ctx, "getting moderation audit", "err", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	auditEntries := []entities.ModerationAuditEntry{}
+	for rows.Next() {
+		var auditEntry entities.ModerationAuditEntry
+		err = scanModerationAuditEntry(rows, &auditEntry)
+		if err != nil {
+			usecases.Logger(ctx).DebugContext(ctx, "unable to read moderation audit row", "err", err)
+			return nil, err
+		}
+
+		auditEntries = append(auditEntries, auditEntry)
+	}
+
+	return auditEntries, rows.Err()
+}
+
+// AssignModerationCase is a function that assigns a case to a moderator. Assigning an open case moves it into review.
+func (p *PostgresAdapter) AssignModerationCase(ctx context.Context, caseID, moderatorUserID, actorUserID uuid.UUID) (*entities.ModerationCase, error) {
+	err := p.db.withinTx(ctx, func(ctx context.Context, tx *instrumentedTx) error {
+		var moderatorRole entities.Role
+		err := tx.QueryRowContext(ctx, "SELECT role FROM user_role WHERE user_id = $1;", moderatorUserID).
+			Scan(&moderatorRole)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			usecases.Logger(ctx).DebugContext(ctx, "getting moderator role", "err", err)
+			return err
+		}
+
+		if moderatorRole != entities.RoleModerator && moderatorRole != entities.RoleAdmin {
+			return entities.ErrNotAModerator
+		}
+
+		status, _, err := lockModerationCase(ctx, tx, caseID)
+		if err != nil {
+			return err
+		}
+
+		if status.IsClosed() {
+			return entities.ErrInvalidTransition
+		}
+
+		newStatus := status
+		if status == entities.ModerationCaseStatusOpen {
+			newStatus = entities.ModerationCaseStatusInReview
+		}
+
+		_, err = tx.ExecContext(ctx, "UPDATE moderation_case SET assigned_moderator_id = $2, status = $3, updated_at = NOW() WHERE id = $1;", caseID, moderatorUserID, newStatus)
+		if err != nil {
+			usecases.Logger(ctx).DebugContext(ctx, "assigning moderation case", "err", err)
+			return err
+		}
+
+		err = insertModerationAudit(ctx, tx, caseID, actorUserID, "assigned", map[string]any{
+			"moderatorId": moderatorUserID,
+			"fromStatus":  status,
+			"toStatus":    newStatus,
+		})
+		if err != nil {
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return p.GetModerationCase(ctx, caseID)
+}
+
+// UpdateModerationCaseStatus is a function that moves a case to a new status, if the case allows it
+func (p *PostgresAdapter) UpdateModerationCaseStatus(ctx context.Context, caseID uuid.UUID, status entities.ModerationCaseStatus, actorUserID uuid.UUID) (*entities.ModerationCase, error) {
+	err := p.db.withinTx(ctx, func(ctx context.Context, tx *instrumentedTx) error {
+		currentStatus, _, err := lockModerationCase(ctx, tx, caseID)
+		if err != nil {
+			return err
+		}
+
+		if !currentStatus.CanTransitionTo(status) {
+			return entities.ErrInvalidTransition
+		}
+
+		_, err = tx.ExecContext(ctx, "UPDATE moderation_case SET status = $2, updated_at = NOW() WHERE id = $1;", caseID, status)
+		if err != nil {
+			usecases.Logger(ctx).DebugContext(ctx, "updating moderation case status", "err", err)
+			return err
+		}
+
+		err = insertModerationAudit(ctx, tx, caseID, actorUserID, "status_changed", map[string]any{
+			"fromStatus": currentStatus,
+			"toStatus":   status,
+		})
+		if err != nil {
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return p.GetModerationCase(ctx, caseID)
+}
+
+// ApplyModerationAction is a function that sanctions the subject of a case and marks the case as actioned. Suspending
+// or banning a user revokes all of their tokens, warnings are delivered to the user through their event log.
+func (p *PostgresAdapter) ApplyModerationAction(ctx context.Context, action *entities.ModerationAction) (*entities.ModerationCase, error) {
+	err := p.db.withinTx(ctx, func(ctx context.Context, tx *instrumentedTx) error {
+		status, subjectUserID, err := lockModerationCase(ctx, tx, action.CaseID)
+		if err != nil {
+			return err
+		}
+
+		if !status.CanTransitionTo(entities.ModerationCaseStatusActioned) {
+			return entities.ErrInvalidTransition
+		}
+
+		switch action.Action {
+		case entities.ModerationActionSuspend:
+			_, err = tx.ExecContext(ctx, "INSERT INTO user_sanction (user_id, case_id, sanction_type, ends_at) VALUES ($1, $2, $3, NOW() + make_interval(secs => $4));", subjectUserID, action.CaseID, action.Action, action.Duration.Seconds())
+		default:
+			_, err = tx.ExecContext(ctx, "INSERT INTO user_sanction (user_id, case_id, sanction_type) VALUES ($1, $2, $3);", subjectUserID, action.CaseID, action.Action)
+		}
+		if err != nil {
+			usecases.Logger(ctx).DebugContext(ctx, "inserting sanction record", "err", err)
+			return err
+		}
+
+		if action.Action == entities.ModerationActionWarn {
+			_, err = tx.ExecContext(ctx, "INSERT INTO event_log (user_id, event_type, payload) VALUES ($1, $2, $3);", subjectUserID, entities.EventTypeModerationWarning, []byte(`{}`))
+			if err != nil {
+				usecases.Logger(ctx).DebugContext(ctx, "inserting warning event", "err", err)
+				return err
+			}
+		} else {
+			_, err = tx.ExecContext(ctx, "UPDATE token SET revoked_at = NOW() WHERE user_id = $1 AND revoked_at IS NULL;", subjectUserID)
+			if err != nil {
+				usecases.Logger(ctx).DebugContext(ctx, "revoking sanctioned users tokens", "err", err)
+				return err
+			}
+		}
+
+		_, err = tx.ExecContext(ctx, "UPDATE moderation_case SET status = $2, updated_at = NOW() WHERE id = $1;", action.CaseID, entities.ModerationCaseStatusActioned)
+		if err != nil {
+			usecases.Logger(ctx).DebugContext(ctx, "marking moderation case as actioned", "err", err)
+			return err
+		}
+
+		err = insertModerationAudit(ctx, tx, action.CaseID, action.ModeratorUserID, "action_taken", map[string]any{
+			"action":          action.Action,
+			"durationSeconds": action.Duration.Seconds(),
+			"note":            action.Note,
+			"fromStatus":      status,
+			"toStatus":        entities.ModerationCaseStatusActioned,
+		})
+		if err != nil {
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return p.GetModerationCase(ctx, action.CaseID)
+}
+
+// lockModerationCase is a function that locks a case for the rest of the transaction, returning its current status and
+// the user it is about
+func lockModerationCase(ctx context.Context, tx *instrumentedTx, caseID uuid.UUID) (entities.ModerationCaseStatus, uuid.UUID, error) {
+	var status entities.ModerationCaseStatus
+	var subjectUserID uuid.UUID
+	err := tx.QueryRowContext(ctx, "SELECT status, subject_user_id FROM moderation_case WHERE id = $1 FOR UPDATE;", caseID).
+		Scan(&status, &subjectUserID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", uuid.UUID{}, entities.ErrCaseNotFound
+		}
+		usecases.Logger(ctx).DebugContext(ctx, "locking moderation case", "err", err)
+		return "", uuid.UUID{}, err
+	}
+
+	return status, subjectUserID, nil
+}
+
+// insertModerationAudit is a function that appends an entry to the immutable audit history of a case
+func insertModerationAudit(ctx context.Context, tx *instrumentedTx, caseID, actorUserID uuid.UUID, action string, details map[string]any) error {
+	detailsJSON, err := json.Marshal(details)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, "INSERT INTO moderation_audit (case_id, actor_user_id, action, details) VALUES ($1, $2, $3, $4);", caseID, actorUserID, action, detailsJSON)
+	if err != nil {
+		usecases.Logger(ctx).DebugContext(ctx, "inserting moderation audit record", "err", err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,131 @@
+package adapters_test
+
+import (
+	"context"
+	"github.com/AlecSmith96/dating-api/internal/adapters"
+	"github.com/AlecSmith96/dating-api/internal/entities"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	. "github.com/onsi/gomega"
+	"testing"
+	"time"
+)
+
+var moderationCaseColumns = []string{"id", "subject_user_id", "report_id", "status", "assigned_moderator_id", "created_at", "updated_at", "reason", "details"}
+
+func TestPostgresAdapter_GetModerationCases(t *testing.T) {
+	g := NewWithT(t)
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	adapter := adapters.NewPostgresAdapter(db, 0, "something-secret", nil)
+
+	caseID := uuid.New()
+	subjectUserID := uuid.New()
+	reportID := uuid.New()
+
+	mock.ExpectQuery(`SELECT mc\.id, mc\.subject_user_id, mc\.report_id, mc\.status, mc\.assigned_moderator_id, mc\.created_at, mc\.updated_at, COALESCE\(ur\.reason, ''\), COALESCE\(ur\.details, ''\) FROM moderation_case mc LEFT JOIN user_report ur ON ur\.id = mc\.report_id WHERE mc\.status = \$1 ORDER BY mc\.created_at LIMIT \$2;`).
+		WithArgs(entities.ModerationCaseStatusOpen, 50).
+		WillReturnRows(sqlmock.NewRows(moderationCaseColumns).
+			AddRow(caseID, subjectUserID, reportID, "open", nil, time.Now(), time.Now(), "spam", "sent me links"))
+
+	moderationCases, err := adapter.GetModerationCases(context.Background(), entities.ModerationCaseStatusOpen, 50)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(moderationCases).To(HaveLen(1))
+	g.Expect(moderationCases[0].ReportID).To(Equal(uuid.NullUUID{UUID: reportID, Valid: true}))
+	g.Expect(moderationCases[0].AssignedModeratorID.Valid).To(BeFalse())
+	g.Expect(moderationCases[0].ReportReason).To(Equal(entities.ReportReasonSpam))
+}
+
+func TestPostgresAdapter_GetModerationAudit(t *testing.T) {
+	g := NewWithT(t)
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	adapter := adapters.NewPostgresAdapter(db, 0, "something-secret", nil)
+
+	entryID := uuid.New()
+	caseID := uuid.New()
+	actorUserID := uuid.New()
+	createdAt := time.Date(2024, time.June, 15, 9, 30, 0, 0, time.UTC)
+
+	mock.ExpectQuery(`SELECT id, case_id, actor_user_id, action, details, created_at FROM moderation_audit WHERE case_id = \$1 ORDER BY created_at;`).
+		WithArgs(caseID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "case_id", "actor_user_id", "action", "details", "created_at"}).
+			AddRow(entryID, caseID, actorUserID, "assigned", []byte(`{"toStatus":"in_review"}`), createdAt))
+
+	auditEntries, err := adapter.GetModerationAudit(context.Background(), caseID)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(auditEntries).To(HaveLen(1))
+	g.Expect(auditEntries[0].ID).To(Equal(entryID))
+	g.Expect(auditEntries[0].ActorUserID).To(Equal(actorUserID))
+	g.Expect(auditEntries[0].Action).To(Equal("assigned"))
+	g.Expect(auditEntries[0].Details).To(MatchJSON(`{"toStatus":"in_review"}`))
+	g.Expect(auditEntries[0].CreatedAt).To(Equal(createdAt))
+	g.Expect(mock.ExpectationsWereMet()).To(Succeed())
+}
+
+func TestPostgresAdapter_ApplyModerationAction_Suspend(t *testing.T) {
+	g := NewWithT(t)
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	adapter := adapters.NewPostgresAdapter(db, 0, "something-secret", nil)
+
+	action := &entities.ModerationAction{
+		CaseID:          uuid.New(),
+		ModeratorUserID: uuid.New(),
+		Action:          entities.ModerationActionSuspend,
+		Duration:        24 * time.Hour,
+		Note:            "repeated harassment",
+	}
+	subjectUserID := uuid.New()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT status, subject_user_id FROM moderation_case WHERE id = \$1 FOR UPDATE;`).WithArgs(action.CaseID).
+		WillReturnRows(sqlmock.NewRows([]string{"status", "subject_user_id"}).AddRow("in_review", subjectUserID))
+	mock.ExpectExec(`INSERT INTO user_sanction \(user_id, case_id, sanction_type, ends_at\) VALUES \(\$1, \$2, \$3, NOW\(\) \+ make_interval\(secs => \$4\)\);`).
+		WithArgs(subjectUserID, action.CaseID, action.Action, float64(86400)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE token SET revoked_at = NOW\(\) WHERE user_id = \$1 AND revoked_at IS NULL;`).WithArgs(subjectUserID).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(`UPDATE moderation_case SET status = \$2, updated_at = NOW\(\) WHERE id = \$1;`).WithArgs(action.CaseID, entities.ModerationCaseStatusActioned).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO moderation_audit \(case_id, actor_user_id, action, details\) VALUES \(\$1, \$2, \$3, \$4\);`).
+		WithArgs(action.CaseID, action.ModeratorUserID, "action_taken", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectQuery(`SELECT mc\.id, mc\.subject_user_id, mc\.report_id, mc\.status, mc\.assigned_moderator_id, mc\.created_at, mc\.updated_at, COALESCE\(ur\.reason, ''\), COALESCE\(ur\.details, ''\) FROM moderation_case mc LEFT JOIN user_report ur ON ur\.id = mc\.report_id WHERE mc\.id = \$1;`).
+		WithArgs(action.CaseID).
+		WillReturnRows(sqlmock.NewRows(moderationCaseColumns).
+			AddRow(action.CaseID, subjectUserID, nil, "actioned", action.ModeratorUserID, time.Now(), time.Now(), "", ""))
+
+	moderationCase, err := adapter.ApplyModerationAction(context.Background(), action)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(moderationCase.Status).To(Equal(entities.ModerationCaseStatusActioned))
+	g.Expect(mock.ExpectationsWereMet()).To(Succeed())
+}
+
+func TestPostgresAdapter_ApplyModerationAction_ClosedCase(t *testing.T) {
+	g := NewWithT(t)
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	adapter := adapters.NewPostgresAdapter(db, 0, "something-secret", nil)
+
+	action := &entities.ModerationAction{
+		CaseID:          uuid.New(),
+		ModeratorUserID: uuid.New(),
+		Action:          entities.ModerationActionBan,
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT status, subject_user_id FROM moderation_case WHERE id = \$1 FOR UPDATE;`).WithArgs(action.CaseID).
+		WillReturnRows(sqlmock.NewRows([]string{"status", "subject_user_id"}).AddRow("dismissed", uuid.New()))
+	mock.ExpectRollback()
+
+	moderationCase, err := adapter.ApplyModerationAction(context.Background(), action)
+	g.Expect(err).To(MatchError(entities.ErrInvalidTransition))
+	g.Expect(moderationCase).To(BeNil())
+	g.Expect(mock.ExpectationsWereMet()).To(Succeed())
+}
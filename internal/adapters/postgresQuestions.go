@@ -0,0 +1,341 @@
+package adapters
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/AlecSmith96/dating-api/internal/entities"
+	"github.com/AlecSmith96/dating-api/internal/usecases"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+const (
+	getQuestionsQuery = `SELECT q.id, q.text, q.created_at, q.updated_at, qa.id, qa.text
+FROM question q
+JOIN question_answer qa ON qa.question_id = q.id
+ORDER BY q.created_at, q.id, qa.position;`
+
+	getQuestionQuery = `SELECT q.id, q.text, q.created_at, q.updated_at, qa.id, qa.text
+FROM question q
+JOIN question_answer qa ON qa.question_id = q.id
+WHERE q.id = $1
+ORDER BY qa.position;`
+
+	answerQuestionQuery = `INSERT INTO user_question_answer (user_id, question_id, answer_id, accepted_answer_ids, importance, updated_at)
+VALUES ($1, $2, $3, $4, $5, NOW())
+ON CONFLICT (user_id, question_id) DO UPDATE SET answer_id = EXCLUDED.answer_id,
+    accepted_answer_ids = EXCLUDED.accepted_answer_ids, importance = EXCLUDED.importance, updated_at = NOW();`
+)
+
+// CreateQuestion is a function that adds a question and its answers to the question bank
+func (p *PostgresAdapter) CreateQuestion(ctx context.Context, question *entities.Question) (*entities.Question, error) {
+	created := entities.Question{Text: question.Text}
+	err := p.db.withinTx(ctx, func(ctx context.Context, tx *instrumentedTx) error {
+		err := tx.QueryRowContext(ctx, "INSERT INTO question (text) VALUES ($1) RETURNING id, created_at, updated_at;", question.Text).
+			Scan(&created.ID, &created.CreatedAt, &created.UpdatedAt)
+		if err != nil {
+			usecases.Logger(ctx).DebugContext(ctx, "inserting question", "err", err)
+			return err
+		}
+
+		created.Answers, err = insertAnswers(ctx, tx, created.ID, question.Answers, 0)
+		if err != nil {
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &created, nil
+}
+
+// insertAnswers is a function that adds answers to a question, positioned after the first offset answers
+func insertAnswers(ctx context.Context, tx *instrumentedTx, questionID uuid.UUID, answers []entities.Answer, offset int) ([]entities.Answer, error) {
+	inserted := make([]entities.Answer, 0, len(answers))
+	for i, answer := range answers {
+		var answerID uuid.UUID
+		err := tx.QueryRowContext(ctx, "INSERT INTO question_answer (question_id, text, position) VALUES ($1, $2, $3) RETURNING id;",
+			questionID, answer.Text, offset+i).Scan(&answerID)
+		if err != nil {
+			usecases.Logger(ctx).DebugContext(ctx, "inserting question answer", "err", err)
+			return nil, err
+		}
+
+		inserted = append(inserted, entities.Answer{ID: answerID, Text: answer.Text})
+	}
+
+	return inserted, nil
+}
+
+// GetQuestions is a function that returns every question in the question bank, oldest first
+func (p *PostgresAdapter) GetQuestions(ctx context.Context) ([]entities.Question, error) {
+	rows, err := p.db.conn(ctx).QueryContext(ctx, getQuestionsQuery)
+	if err != nil {
+		usecases.Logger(ctx).DebugContext(ctx, "getting questions", "err", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanQuestions(ctx, rows)
+}
+
+// GetQuestion is a function that returns a single question from the question bank
+func (p *PostgresAdapter) GetQuestion(ctx context.Context, questionID uuid.UUID) (*entities.Question, error) {
+	rows, err := p.db.conn(ctx).QueryContext(ctx, getQuestionQuery, questionID)
+	if err != nil {
+		usecases.Logger(ctx).DebugContext(ctx, "getting question", "err", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	questions, err := scanQuestions(ctx, rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(questions) == 0 {
+		return nil, entities.ErrQuestionNotFound
+	}
+
+	return &questions[0], nil
+}
+
+// scanQuestions is a function that reads rows of a question and one of its answers, grouping consecutive rows for the
+// same question
+func scanQuestions(ctx context.Context, rows *instrumentedRows) ([]entities.Question, error) {
+	questions := []entities.Question{}
+	for rows.Next() {
+		var question entities.Question
+		var answer entities.Answer
+		err := rows.Scan(&question.ID, &question.Text, &question.CreatedAt, &question.UpdatedAt, &answer.ID, &answer.Text)
+		if err != nil {
+			usecases.Logger(ctx).DebugContext(ctx, "unable to read question row", "err", err)
+			return nil, err
+		}
+
+		if len(questions) == 0 || questions[len(questions)-1].ID != question.ID {
+			questions = append(questions, question)
+		}
+		last := &questions[len(questions)-1]
+		last.Answers = append(last.Answers, answer)
+	}
+
+	return questions, rows.Err()
+}
+
+// UpdateQuestion is a function that replaces the text and answers of a question. Existing answers are reworded in
+// place so users answers to them are kept, answers can only be added or removed while nobody has answered the question.
+func (p *PostgresAdapter) UpdateQuestion(ctx context.Context, question *entities.Question) (*entities.Question, error) {
+	updated := entities.Question{ID: question.ID, Text: question.Text}
+	err := p.db.withinTx(ctx, func(ctx context.Context, tx *instrumentedTx) error {
+		err := tx.QueryRowContext(ctx, "UPDATE question SET text = $2, updated_at = NOW() WHERE id = $1 RETURNING created_at, updated_at;",
+			question.ID, question.Text).Scan(&updated.CreatedAt, &updated.UpdatedAt)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return entities.ErrQuestionNotFound
+			}
+			usecases.Logger(ctx).DebugContext(ctx, "updating question", "err", err)
+			return err
+		}
+
+		rows, err := tx.QueryContext(ctx, "SELECT id FROM question_answer WHERE question_id = $1 ORDER BY position;", question.ID)
+		if err != nil {
+			usecases.Logger(ctx).DebugContext(ctx, "getting question answers", "err", err)
+			return err
+		}
+
+		var answerIDs []uuid.UUID
+		for rows.Next() {
+			var answerID uuid.UUID
+			err = rows.Scan(&answerID)
+			if err != nil {
+				rows.Close()
+				usecases.Logger(ctx).DebugContext(ctx, "unable to read question answer row", "err", err)
+				return err
+			}
+			answerIDs = append(answerIDs, answerID)
+		}
+		rows.Close()
+		if err = rows.Err(); err != nil {
+			usecases.Logger(ctx).DebugContext(ctx, "reading question answers", "err", err)
+			return err
+		}
+
+		if len(answerIDs) != len(question.Answers) {
+			var answered bool
+			err = tx.QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM user_question_answer WHERE question_id = $1);", question.ID).
+				Scan(&answered)
+			if err != nil {
+				usecases.Logger(ctx).DebugContext(ctx, "checking if question has been answered", "err", err)
+				return err
+			}
+
+			if answered {
+				return entities.ErrQuestionAnswered
+			}
+		}
+
+		kept := min(len(answerIDs), len(question.Answers))
+		for i, answer := range question.Answers[:kept] {
+			_, err = tx.ExecContext(ctx, "UPDATE question_answer SET text = $2 WHERE id = $1;", answerIDs[i], answer.Text)
+			if err != nil {
+				usecases.Logger(ctx).DebugContext(ctx, "updating question answer", "err", err)
+				return err
+			}
+			updated.Answers = append(updated.Answers, entities.Answer{ID: answerIDs[i], Text: answer.Text})
+		}
+
+		_, err = tx.ExecContext(ctx, "DELETE FROM question_answer WHERE question_id = $1 AND position >= $2;", question.ID, kept)
+		if err != nil {
+			usecases.Logger(ctx).DebugContext(ctx, "deleting question answers", "err", err)
+			return err
+		}
+
+		added, err := insertAnswers(ctx, tx, question.ID, question.Answers[kept:], kept)
+		if err != nil {
+			return err
+		}
+		updated.Answers = append(updated.Answers, added...)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
+// DeleteQuestion is a function that deletes a question along with its answers and every users answer to it
+func (p *PostgresAdapter) DeleteQuestion(ctx context.Context, questionID uuid.UUID) error {
+	result, err := p.db.conn(ctx).ExecContext(ctx, "DELETE FROM question WHERE id = $1;", questionID)
+	if err != nil {
+		usecases.Logger(ctx).DebugContext(ctx, "deleting question", "err", err)
+		return err
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		usecases.Logger(ctx).DebugContext(ctx, "error getting deleted question count", "err", err)
+		return err
+	}
+
+	if deleted == 0 {
+		return entities.ErrQuestionNotFound
+	}
+
+	return nil
+}
+
+// GetUserAnswers is a function that returns every answer the user has given
+func (p *PostgresAdapter) GetUserAnswers(ctx context.Context, userID uuid.UUID) ([]entities.UserAnswer, error) {
+	answers, err := p.getUserAnswers(ctx, "SELECT user_id, question_id, answer_id, accepted_answer_ids, importance FROM user_question_answer WHERE user_id = $1;", userID)
+	if err != nil {
+		usecases.Logger(ctx).DebugContext(ctx, "getting user answers", "err", err)
+		return nil, err
+	}
+
+	return answers, nil
+}
+
+// GetAnswersForUsers is a function that returns the answers of each of the users, keyed by user id. Users that haven't
+// answered any questions are left out.
+func (p *PostgresAdapter) GetAnswersForUsers(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID][]entities.UserAnswer, error) {
+	answers, err := p.getUserAnswers(ctx, "SELECT user_id, question_id, answer_id, accepted_answer_ids, importance FROM user_question_answer WHERE user_id = ANY($1);", pq.Array(userIDs))
+	if err != nil {
+		usecases.Logger(ctx).DebugContext(ctx, "getting answers for users", "err", err)
+		return nil, err
+	}
+
+	answersByUser := make(map[uuid.UUID][]entities.UserAnswer)
+	for _, answer := range answers {
+		answersByUser[answer.UserID] = append(answersByUser[answer.UserID], answer)
+	}
+
+	return answersByUser, nil
+}
+
+// getUserAnswers is a function that runs a query selecting user answers and reads the rows
+func (p *PostgresAdapter) getUserAnswers(ctx context.Context, query string, args ...any) ([]entities.UserAnswer, error) {
+	rows, err := p.db.conn(ctx).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	answers := []entities.UserAnswer{}
+	for rows.Next() {
+		var answer entities.UserAnswer
+		err = rows.Scan(
+			&answer.UserID,
+			&answer.QuestionID,
+			&answer.AnswerID,
+			pq.Array(&answer.AcceptedAnswerIDs),
+			&answer.Importance,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		answers = append(answers, answer)
+	}
+
+	return answers, rows.Err()
+}
+
+// AnswerQuestion is a function that records the users answer to a question, replacing any earlier answer. The users
+// answer and accepted answers must all be answers to the question.
+func (p *PostgresAdapter) AnswerQuestion(ctx context.Context, answer *entities.UserAnswer) error {
+	rows, err := p.db.conn(ctx).QueryContext(ctx, "SELECT id FROM question_answer WHERE question_id = $1;", answer.QuestionID)
+	if err != nil {
+		usecases.Logger(ctx).DebugContext(ctx, "getting question answers", "err", err)
+		return err
+	}
+	defer rows.Close()
+
+	questionAnswers := map[uuid.UUID]bool{}
+	for rows.Next() {
+		var answerID uuid.UUID
+		err = rows.Scan(&answerID)
+		if err != nil {
+			usecases.Logger(ctx).DebugContext(ctx, "unable to read question answer row", "err", err)
+			return err
+		}
+		questionAnswers[answerID] = true
+	}
+	if err = rows.Err(); err != nil {
+		usecases.Logger(ctx).DebugContext(ctx, "reading question answers", "err", err)
+		return err
+	}
+
+	// every question has answers, so a question without any doesn't exist
+	if len(questionAnswers) == 0 {
+		return entities.ErrQuestionNotFound
+	}
+
+	if !questionAnswers[answer.AnswerID] {
+		return entities.ErrInvalidAnswer
+	}
+	for _, acceptedAnswerID := range answer.AcceptedAnswerIDs {
+		if !questionAnswers[acceptedAnswerID] {
+			return entities.ErrInvalidAnswer
+		}
+	}
+
+	acceptedAnswerIDs := answer.AcceptedAnswerIDs
+	if acceptedAnswerIDs == nil {
+		acceptedAnswerIDs = []uuid.UUID{}
+	}
+
+	_, err = p.db.conn(ctx).ExecContext(ctx, answerQuestionQuery, answer.UserID, answer.QuestionID, answer.AnswerID, pq.Array(acceptedAnswerIDs), answer.Importance)
+	if err != nil {
+		usecases.Logger(ctx).DebugContext(ctx, "answering question", "err", err)
+		return err
+	}
+
+	return nil
+}
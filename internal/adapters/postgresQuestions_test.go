@@ -0,0 +1,254 @@
+package adapters_test
+
+import (
+	"context"
+	"fmt"
+	"github.com/AlecSmith96/dating-api/internal/adapters"
+	"github.com/AlecSmith96/dating-api/internal/entities"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	. "github.com/onsi/gomega"
+	"testing"
+	"time"
+)
+
+func TestPostgresAdapter_GetQuestions(t *testing.T) {
+	g := NewWithT(t)
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	adapter := adapters.NewPostgresAdapter(db, 0, "something-secret", nil)
+
+	dogsID, catsID := uuid.New(), uuid.New()
+	yesID, noID, catsYesID, catsNoID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	createdAt := time.Now()
+
+	mock.ExpectQuery(`FROM question q JOIN question_answer qa ON qa\.question_id = q\.id ORDER BY q\.created_at, q\.id, qa\.position;`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "text", "created_at", "updated_at", "id", "text"}).
+			AddRow(dogsID, "Do you like dogs?", createdAt, createdAt, yesID, "Yes").
+			AddRow(dogsID, "Do you like dogs?", createdAt, createdAt, noID, "No").
+			AddRow(catsID, "Do you like cats?", createdAt, createdAt, catsYesID, "Yes").
+			AddRow(catsID, "Do you like cats?", createdAt, createdAt, catsNoID, "No"))
+
+	questions, err := adapter.GetQuestions(context.Background())
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(questions).To(Equal([]entities.Question{
+		{
+			ID:        dogsID,
+			Text:      "Do you like dogs?",
+			Answers:   []entities.Answer{{ID: yesID, Text: "Yes"}, {ID: noID, Text: "No"}},
+			CreatedAt: createdAt,
+			UpdatedAt: createdAt,
+		},
+		{
+			ID:        catsID,
+			Text:      "Do you like cats?",
+			Answers:   []entities.Answer{{ID: catsYesID, Text: "Yes"}, {ID: catsNoID, Text: "No"}},
+			CreatedAt: createdAt,
+			UpdatedAt: createdAt,
+		},
+	}))
+}
+
+func TestPostgresAdapter_GetQuestion_NotFound(t *testing.T) {
+	g := NewWithT(t)
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	adapter := adapters.NewPostgresAdapter(db, 0, "something-secret", nil)
+
+	questionID := uuid.New()
+	mock.ExpectQuery(`WHERE q\.id = \$1 ORDER BY qa\.position;`).
+		WithArgs(questionID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "text", "created_at", "updated_at", "id", "text"}))
+
+	_, err = adapter.GetQuestion(context.Background(), questionID)
+	g.Expect(err).To(MatchError(entities.ErrQuestionNotFound))
+}
+
+func TestPostgresAdapter_UpdateQuestion(t *testing.T) {
+	g := NewWithT(t)
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	adapter := adapters.NewPostgresAdapter(db, 0, "something-secret", nil)
+
+	questionID, yesID, noID, sometimesID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	updatedAt := time.Now()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`UPDATE question SET text = \$2, updated_at = NOW\(\) WHERE id = \$1 RETURNING created_at, updated_at;`).
+		WithArgs(questionID, "Do you like dogs?").
+		WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(updatedAt, updatedAt))
+	mock.ExpectQuery(`SELECT id FROM question_answer WHERE question_id = \$1 ORDER BY position;`).
+		WithArgs(questionID).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(yesID).AddRow(noID))
+	mock.ExpectQuery(`SELECT EXISTS \(SELECT 1 FROM user_question_answer WHERE question_id = \$1\);`).
+		WithArgs(questionID).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	mock.ExpectExec(`UPDATE question_answer SET text = \$2 WHERE id = \$1;`).
+		WithArgs(yesID, "Yes").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE question_answer SET text = \$2 WHERE id = \$1;`).
+		WithArgs(noID, "Never").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`DELETE FROM question_answer WHERE question_id = \$1 AND position >= \$2;`).
+		WithArgs(questionID, 2).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(`INSERT INTO question_answer \(question_id, text, position\) VALUES \(\$1, \$2, \$3\) RETURNING id;`).
+		WithArgs(questionID, "Sometimes", 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(sometimesID))
+	mock.ExpectCommit()
+
+	question, err := adapter.UpdateQuestion(context.Background(), &entities.Question{
+		ID:      questionID,
+		Text:    "Do you like dogs?",
+		Answers: []entities.Answer{{Text: "Yes"}, {Text: "Never"}, {Text: "Sometimes"}},
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(question.Answers).To(Equal([]entities.Answer{{ID: yesID, Text: "Yes"}, {ID: noID, Text: "Never"}, {ID: sometimesID, Text: "Sometimes"}}))
+	g.Expect(mock.ExpectationsWereMet()).To(Succeed())
+}
+
+func TestPostgresAdapter_UpdateQuestion_Answered(t *testing.T) {
+	g := NewWithT(t)
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	adapter := adapters.NewPostgresAdapter(db, 0, "something-secret", nil)
+
+	questionID := uuid.New()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`UPDATE question SET text = \$2, updated_at = NOW\(\) WHERE id = \$1 RETURNING created_at, updated_at;`).
+		WithArgs(questionID, "Do you like dogs?").
+		WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(time.Now(), time.Now()))
+	mock.ExpectQuery(`SELECT id FROM question_answer WHERE question_id = \$1 ORDER BY position;`).
+		WithArgs(questionID).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()).AddRow(uuid.New()))
+	mock.ExpectQuery(`SELECT EXISTS \(SELECT 1 FROM user_question_answer WHERE question_id = \$1\);`).
+		WithArgs(questionID).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectRollback()
+
+	_, err = adapter.UpdateQuestion(context.Background(), &entities.Question{
+		ID:      questionID,
+		Text:    "Do you like dogs?",
+		Answers: []entities.Answer{{Text: "Yes"}, {Text: "No"}, {Text: "Sometimes"}},
+	})
+	g.Expect(err).To(MatchError(entities.ErrQuestionAnswered))
+	g.Expect(mock.ExpectationsWereMet()).To(Succeed())
+}
+
+func TestPostgresAdapter_DeleteQuestion_NotFound(t *testing.T) {
+	g := NewWithT(t)
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	adapter := adapters.NewPostgresAdapter(db, 0, "something-secret", nil)
+
+	questionID := uuid.New()
+	mock.ExpectExec(`DELETE FROM question WHERE id = \$1;`).
+		WithArgs(questionID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = adapter.DeleteQuestion(context.Background(), questionID)
+	g.Expect(err).To(MatchError(entities.ErrQuestionNotFound))
+}
+
+func TestPostgresAdapter_GetAnswersForUsers(t *testing.T) {
+	g := NewWithT(t)
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	adapter := adapters.NewPostgresAdapter(db, 0, "something-secret", nil)
+
+	userID, otherUserID := uuid.New(), uuid.New()
+	questionID, yesID, noID := uuid.New(), uuid.New(), uuid.New()
+
+	mock.ExpectQuery(`FROM user_question_answer WHERE user_id = ANY\(\$1\);`).
+		WithArgs(fmt.Sprintf("{\"%s\",\"%s\"}", userID, otherUserID)).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "question_id", "answer_id", "accepted_answer_ids", "importance"}).
+			AddRow(userID, questionID, yesID, fmt.Sprintf("{%s,%s}", yesID, noID), "very").
+			AddRow(otherUserID, questionID, noID, "{}", "irrelevant"))
+
+	answers, err := adapter.GetAnswersForUsers(context.Background(), []uuid.UUID{userID, otherUserID})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(answers).To(HaveLen(2))
+	g.Expect(answers[userID]).To(Equal([]entities.UserAnswer{{
+		UserID:            userID,
+		QuestionID:        questionID,
+		AnswerID:          yesID,
+		AcceptedAnswerIDs: []uuid.UUID{yesID, noID},
+		Importance:        entities.ImportanceVery,
+	}}))
+	g.Expect(answers[otherUserID][0].AcceptedAnswerIDs).To(BeEmpty())
+}
+
+func TestPostgresAdapter_AnswerQuestion(t *testing.T) {
+	g := NewWithT(t)
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	adapter := adapters.NewPostgresAdapter(db, 0, "something-secret", nil)
+
+	userID, questionID, yesID, noID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+
+	mock.ExpectQuery(`SELECT id FROM question_answer WHERE question_id = \$1;`).
+		WithArgs(questionID).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(yesID).AddRow(noID))
+	mock.ExpectExec(`INSERT INTO user_question_answer \(user_id, question_id, answer_id, accepted_answer_ids, importance, updated_at\) VALUES \(\$1, \$2, \$3, \$4, \$5, NOW\(\)\) ON CONFLICT \(user_id, question_id\) DO UPDATE`).
+		WithArgs(userID, questionID, yesID, fmt.Sprintf("{\"%s\"}", yesID), entities.ImportanceSomewhat).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = adapter.AnswerQuestion(context.Background(), &entities.UserAnswer{
+		UserID:            userID,
+		QuestionID:        questionID,
+		AnswerID:          yesID,
+		AcceptedAnswerIDs: []uuid.UUID{yesID},
+		Importance:        entities.ImportanceSomewhat,
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(mock.ExpectationsWereMet()).To(Succeed())
+}
+
+func TestPostgresAdapter_AnswerQuestion_InvalidAnswer(t *testing.T) {
+	g := NewWithT(t)
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	adapter := adapters.NewPostgresAdapter(db, 0, "something-secret", nil)
+
+	questionID, yesID := uuid.New(), uuid.New()
+
+	mock.ExpectQuery(`SELECT id FROM question_answer WHERE question_id = \$1;`).
+		WithArgs(questionID).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(yesID).AddRow(uuid.New()))
+
+	err = adapter.AnswerQuestion(context.Background(), &entities.UserAnswer{
+		UserID:            uuid.New(),
+		QuestionID:        questionID,
+		AnswerID:          yesID,
+		AcceptedAnswerIDs: []uuid.UUID{uuid.New()},
+		Importance:        entities.ImportanceVery,
+	})
+	g.Expect(err).To(MatchError(entities.ErrInvalidAnswer))
+	g.Expect(mock.ExpectationsWereMet()).To(Succeed())
+}
+
+func TestPostgresAdapter_AnswerQuestion_QuestionNotFound(t *testing.T) {
+	g := NewWithT(t)
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	adapter := adapters.NewPostgresAdapter(db, 0, "something-secret", nil)
+
+	questionID := uuid.New()
+
+	mock.ExpectQuery(`SELECT id FROM question_answer WHERE question_id = \$1;`).
+		WithArgs(questionID).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	err = adapter.AnswerQuestion(context.Background(), &entities.UserAnswer{UserID: uuid.New(), QuestionID: questionID, AnswerID: uuid.New(), Importance: entities.ImportanceIrrelevant})
+	g.Expect(err).To(MatchError(entities.ErrQuestionNotFound))
+}
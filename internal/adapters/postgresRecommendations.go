@@ -0,0 +1,77 @@
+package adapters
+
+import (
+	"context"
+	"database/sql"
+	"github.com/AlecSmith96/dating-api/internal/entities"
+	"github.com/AlecSmith96/dating-api/internal/usecases"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+const (
+	swipeHistoryQuery = `SELECT id, owner_user_id, swiped_user_id, swipe_type, created_at FROM user_swipe
+ORDER BY created_at NULLS FIRST, id;`
+
+	insertRecommendationsQuery = `INSERT INTO recommendation (user_id, candidate_user_id, score, rank)
+SELECT * FROM unnest($1::uuid[], $2::uuid[], $3::float8[], $4::int[]);`
+)
+
+// GetSwipeHistory is a function that returns every swipe, oldest first
+func (p *PostgresAdapter) GetSwipeHistory(ctx context.Context) ([]entities.Swipe, error) {
+	rows, err := p.db.conn(ctx).QueryContext(ctx, swipeHistoryQuery)
+	if err != nil {
+		usecases.Logger(ctx).DebugContext(ctx, "getting swipe history", "err", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var swipes []entities.Swipe
+	for rows.Next() {
+		var swipe entities.Swipe
+		var createdAt sql.NullTime
+		err = rows.Scan(&swipe.ID, &swipe.OwnerUserID, &swipe.SwipedUserID, &swipe.Type, &createdAt)
+		if err != nil {
+			usecases.Logger(ctx).DebugContext(ctx, "unable to read swipe row", "err", err)
+			return nil, err
+		}
+
+		swipe.CreatedAt = createdAt.Time
+		swipes = append(swipes, swipe)
+	}
+
+	return swipes, rows.Err()
+}
+
+// ReplaceRecommendations is a function that replaces every users recommendations in a single transaction, so discovery
+// never sees a partly written set
+func (p *PostgresAdapter) ReplaceRecommendations(ctx context.Context, recommendations []entities.Recommendation) error {
+	return p.db.withinTx(ctx, func(ctx context.Context, tx *instrumentedTx) error {
+		_, err := tx.ExecContext(ctx, "DELETE FROM recommendation;")
+		if err != nil {
+			usecases.Logger(ctx).DebugContext(ctx, "deleting recommendations", "err", err)
+			return err
+		}
+
+		if len(recommendations) != 0 {
+			userIDs := make([]uuid.UUID, len(recommendations))
+			candidateUserIDs := make([]uuid.UUID, len(recommendations))
+			scores := make([]float64, len(recommendations))
+			ranks := make([]int64, len(recommendations))
+			for i, recommendation := range recommendations {
+				userIDs[i] = recommendation.UserID
+				candidateUserIDs[i] = recommendation.CandidateUserID
+				scores[i] = recommendation.Score
+				ranks[i] = int64(recommendation.Rank)
+			}
+
+			_, err = tx.ExecContext(ctx, insertRecommendationsQuery, pq.Array(userIDs), pq.Array(candidateUserIDs), pq.Array(scores), pq.Array(ranks))
+			if err != nil {
+				usecases.Logger(ctx).DebugContext(ctx, "inserting recommendations", "err", err)
+				return err
+			}
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,116 @@
+package adapters_test
+
+import (
+	"context"
+	"errors"
+	"github.com/AlecSmith96/dating-api/internal/adapters"
+	"github.com/AlecSmith96/dating-api/internal/entities"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	. "github.com/onsi/gomega"
+	"testing"
+	"time"
+)
+
+func TestPostgresAdapter_GetSwipeHistory(t *testing.T) {
+	g := NewWithT(t)
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	adapter := adapters.NewPostgresAdapter(db, 0, "something-secret", nil)
+
+	swipe := entities.Swipe{
+		ID:           uuid.New(),
+		OwnerUserID:  uuid.New(),
+		SwipedUserID: uuid.New(),
+		Type:         entities.SwipeTypeLike,
+		CreatedAt:    time.Now(),
+	}
+
+	mock.ExpectQuery(`SELECT id, owner_user_id, swiped_user_id, swipe_type, created_at FROM user_swipe ORDER BY created_at NULLS FIRST, id;`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "owner_user_id", "swiped_user_id", "swipe_type", "created_at"}).
+			AddRow(uuid.New(), uuid.New(), uuid.New(), "pass", nil).
+			AddRow(swipe.ID, swipe.OwnerUserID, swipe.SwipedUserID, swipe.Type, swipe.CreatedAt))
+
+	swipes, err := adapter.GetSwipeHistory(context.Background())
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(swipes).To(HaveLen(2))
+	g.Expect(swipes[0].CreatedAt.IsZero()).To(BeTrue())
+	g.Expect(swipes[1]).To(Equal(swipe))
+	g.Expect(mock.ExpectationsWereMet()).To(Succeed())
+}
+
+func TestPostgresAdapter_GetSwipeHistory_Error(t *testing.T) {
+	g := NewWithT(t)
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	adapter := adapters.NewPostgresAdapter(db, 0, "something-secret", nil)
+
+	mock.ExpectQuery(`FROM user_swipe`).
+		WillReturnError(errors.New("an error occurred"))
+
+	swipes, err := adapter.GetSwipeHistory(context.Background())
+	g.Expect(err).To(MatchError("an error occurred"))
+	g.Expect(swipes).To(BeNil())
+}
+
+func TestPostgresAdapter_ReplaceRecommendations(t *testing.T) {
+	g := NewWithT(t)
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	adapter := adapters.NewPostgresAdapter(db, 0, "something-secret", nil)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`DELETE FROM recommendation;`).
+		WillReturnResult(sqlmock.NewResult(0, 10))
+	mock.ExpectExec(`INSERT INTO recommendation \(user_id, candidate_user_id, score, rank\) SELECT \* FROM unnest\(\$1::uuid\[\], \$2::uuid\[\], \$3::float8\[\], \$4::int\[\]\);`).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	userID := uuid.New()
+	err = adapter.ReplaceRecommendations(context.Background(), []entities.Recommendation{
+		{UserID: userID, CandidateUserID: uuid.New(), Score: 0.7, Rank: 1},
+		{UserID: userID, CandidateUserID: uuid.New(), Score: 0.5, Rank: 2},
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(mock.ExpectationsWereMet()).To(Succeed())
+}
+
+func TestPostgresAdapter_ReplaceRecommendations_NoRecommendations(t *testing.T) {
+	g := NewWithT(t)
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	adapter := adapters.NewPostgresAdapter(db, 0, "something-secret", nil)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`DELETE FROM recommendation;`).
+		WillReturnResult(sqlmock.NewResult(0, 10))
+	mock.ExpectCommit()
+
+	err = adapter.ReplaceRecommendations(context.Background(), nil)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(mock.ExpectationsWereMet()).To(Succeed())
+}
+
+func TestPostgresAdapter_ReplaceRecommendations_Error(t *testing.T) {
+	g := NewWithT(t)
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	adapter := adapters.NewPostgresAdapter(db, 0, "something-secret", nil)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`DELETE FROM recommendation;`).
+		WillReturnResult(sqlmock.NewResult(0, 10))
+	mock.ExpectExec(`INSERT INTO recommendation`).
+		WillReturnError(errors.New("an error occurred"))
+	mock.ExpectRollback()
+
+	err = adapter.ReplaceRecommendations(context.Background(), []entities.Recommendation{{UserID: uuid.New(), CandidateUserID: uuid.New(), Score: 0.7, Rank: 1}})
+	g.Expect(err).To(MatchError("an error occurred"))
+	g.Expect(mock.ExpectationsWereMet()).To(Succeed())
+}
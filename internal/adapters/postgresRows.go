@@ -0,0 +1,136 @@
+package adapters
+
+import (
+	"database/sql"
+	"github.com/AlecSmith96/dating-api/internal/entities"
+	"github.com/lib/pq"
+	"strings"
+)
+
+var (
+	// userColumns are the columns of platform_user in the order scanUser reads them
+	userColumns = []string{"id", "email", "password", "name", "gender", "date_of_birth", "location_latitude", "location_longitude", "timezone"}
+	// tokenColumns are the columns of token in the order scanToken reads them
+	tokenColumns = []string{"id", "user_id", "value", "issued_at"}
+	// matchColumns are the columns of user_match in the order scanMatch reads them
+	matchColumns = []string{"id", "owner_user_id", "matched_user_id"}
+	// moderationCaseColumns are the columns of moderation_case in the order scanModerationCase reads them
+	moderationCaseColumns = []string{"id", "subject_user_id", "report_id", "status", "assigned_moderator_id", "created_at", "updated_at"}
+	// moderationAuditColumns are the columns of moderation_audit in the order scanModerationAuditEntry reads them
+	moderationAuditColumns = []string{"id", "case_id", "actor_user_id", "action", "details", "created_at"}
+	// discoverySnapshotColumns are the columns of discovery_snapshot in the order scanDiscoverySnapshot reads them
+	discoverySnapshotColumns = []string{"id", "user_id", "ranked_user_ids", "created_at"}
+)
+
+// rowScanner is satisfied by both sql.Row and sql.Rows, so that a scanner can read a single row or each row of a result
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// columnList is a function that joins columns for a select list, qualifying each of them with the alias of the table
+// when one is given
+func columnList(alias string, columns []string) string {
+	if alias == "" {
+		return strings.Join(columns, ", ")
+	}
+
+	qualified := make([]string, len(columns))
+	for i, column := range columns {
+		qualified[i] = alias + "." + column
+	}
+
+	return strings.Join(qualified, ", ")
+}
+
+// scanUser is a function that reads the userColumns of a row into user, followed by any columns the query selects
+// after them
+func scanUser(row rowScanner, user *entities.User, extra ...any) error {
+	return row.Scan(append([]any{
+		&user.ID,
+		&user.Email,
+		&user.Password,
+		&user.Name,
+		&user.Gender,
+		&user.DateOfBirth,
+		&user.Location.Latitude,
+		&user.Location.Longitude,
+		&user.Timezone,
+	}, extra...)...)
+}
+
+// scanToken is a function that reads the tokenColumns of a row into token, followed by any columns the query selects
+// after them
+func scanToken(row rowScanner, token *entities.Token, extra ...any) error {
+	return row.Scan(append([]any{&token.ID, &token.UserID, &token.Value, &token.IssuedAt}, extra...)...)
+}
+
+// scanMatch is a function that reads the matchColumns of a row into match
+func scanMatch(row rowScanner, match *entities.Match) error {
+	return row.Scan(&match.ID, &match.OwnerUserID, &match.MatchedUserID)
+}
+
+// scanModerationCase is a function that reads the moderationCaseColumns of a row into moderationCase, followed by any
+// columns the query selects after them
+func scanModerationCase(row rowScanner, moderationCase *entities.ModerationCase, extra ...any) error {
+	return row.Scan(append([]any{
+		&moderationCase.ID,
+		&moderationCase.SubjectUserID,
+		&moderationCase.ReportID,
+		&moderationCase.Status,
+		&moderationCase.AssignedModeratorID,
+		&moderationCase.CreatedAt,
+		&moderationCase.UpdatedAt,
+	}, extra...)...)
+}
+
+// scanModerationAuditEntry is a function that reads the moderationAuditColumns of a row into auditEntry
+func scanModerationAuditEntry(row rowScanner, auditEntry *entities.ModerationAuditEntry) error {
+	var details []byte
+	err := row.Scan(&auditEntry.ID, &auditEntry.CaseID, &auditEntry.ActorUserID, &auditEntry.Action, &details, &auditEntry.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	auditEntry.Details = details
+	return nil
+}
+
+// scanUserDiscovery is a function that reads a row of discoverUsersQuery into user, users that haven't been swiped on
+// yet have the default desirability
+func scanUserDiscovery(row rowScanner, user *entities.UserDiscovery) error {
+	var desirability sql.NullFloat64
+	err := row.Scan(
+		&user.ID,
+		&user.Name,
+		&user.Gender,
+		&user.DateOfBirth,
+		&user.Location.Latitude,
+		&user.Location.Longitude,
+		&user.Age,
+		&user.SuperLikedMe,
+		&user.Boosted,
+		&user.SharedInterests,
+		&user.LastActiveAt,
+		&user.SwipesGiven,
+		&user.LikesGiven,
+		&user.SwipesReceived,
+		&user.LikesReceived,
+		&desirability,
+		&user.RecommendationRank,
+	)
+	if err != nil {
+		return err
+	}
+
+	user.Desirability = entities.DefaultDesirability
+	if desirability.Valid {
+		user.Desirability = desirability.Float64
+	}
+
+	return nil
+}
+
+// scanDiscoverySnapshot is a function that reads the discoverySnapshotColumns of a row into snapshot
+func scanDiscoverySnapshot(row rowScanner, snapshot *entities.DiscoverySnapshot) error {
+	return row.Scan(&snapshot.ID, &snapshot.UserID, pq.Array(&snapshot.RankedUserIDs), &snapshot.CreatedAt)
+}
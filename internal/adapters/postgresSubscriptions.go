@@ -0,0 +1,173 @@
+package adapters
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/AlecSmith96/dating-api/internal/entities"
+	"github.com/AlecSmith96/dating-api/internal/usecases"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+const (
+	// subscriptionColumns lists the columns of user_subscription in the order they are scanned into entities.Subscription
+	subscriptionColumns = "id, user_id, tier, status, provider, provider_subscription_id, current_period_end, created_at, updated_at"
+
+	// latest subscription is the one giving the user their tier if there is one, otherwise the most recently changed
+	getLatestSubscriptionQuery = `SELECT ` + subscriptionColumns + `
+FROM user_subscription
+WHERE user_id = $1
+ORDER BY (status != 'expired' AND current_period_end > NOW()) DESC, updated_at DESC
+LIMIT 1;`
+
+	lockSubscriptionQuery = `SELECT ` + subscriptionColumns + `, last_event_at
+FROM user_subscription
+WHERE provider = $1 AND provider_subscription_id = $2
+FOR UPDATE;`
+
+	insertSubscriptionQuery = `INSERT INTO user_subscription (user_id, tier, status, provider, provider_subscription_id, current_period_end, last_event_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING ` + subscriptionColumns + `;`
+
+	updateSubscriptionQuery = `UPDATE user_subscription
+SET tier = $2, status = $3, current_period_end = $4, last_event_at = $5, updated_at = NOW()
+WHERE id = $1
+RETURNING ` + subscriptionColumns + `;`
+
+	subscriptionTierForeignKey = "user_subscription_tier_fkey"
+)
+
+var getUserEntitlementQuery = fmt.Sprintf(`SELECT ue.tier, ue.can_rewind, ue.can_see_likes, ue.monthly_boost_limit, (%s)
+FROM user_entitlement ue
+WHERE ue.user_id = $1;`, fmt.Sprintf(boostsUsedQuery, "ue.user_id"))
+
+func scanSubscription(row rowScanner, extra ...any) (*entities.Subscription, error) {
+	var subscription entities.Subscription
+	err := row.Scan(append([]any{
+		&subscription.ID,
+		&subscription.UserID,
+		&subscription.Tier,
+		&subscription.Status,
+		&subscription.Provider,
+		&subscription.ProviderSubscriptionID,
+		&subscription.CurrentPeriodEnd,
+		&subscription.CreatedAt,
+		&subscription.UpdatedAt,
+	}, extra...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &subscription, nil
+}
+
+// GetEntitlements is a function that returns the users tier, the features it includes, their latest subscription,
+// their current swipe quotas and how many boosts they have used
+func (p *PostgresAdapter) GetEntitlements(ctx context.Context, userID uuid.UUID) (*entities.Entitlements, error) {
+	var userEntitlements entities.Entitlements
+	err := p.db.conn(ctx).QueryRowContext(ctx, getUserEntitlementQuery, userID).
+		Scan(&userEntitlements.Tier, &userEntitlements.CanRewind, &userEntitlements.CanSeeLikes, &userEntitlements.BoostLimit, &userEntitlements.BoostsUsed)
+	if err != nil {
+		usecases.Logger(ctx).DebugContext(ctx, "getting user entitlement", "err", err)
+		return nil, err
+	}
+
+	userEntitlements.Subscription, err = scanSubscription(p.db.conn(ctx).QueryRowContext(ctx, getLatestSubscriptionQuery, userID))
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		usecases.Logger(ctx).DebugContext(ctx, "getting latest subscription", "err", err)
+		return nil, err
+	}
+
+	likeQuota, _, err := getSwipeQuota(ctx, p.db.conn(ctx), userID, entities.SwipeTypeLike)
+	if err != nil {
+		return nil, err
+	}
+	userEntitlements.LikeQuota = *likeQuota
+
+	superLikeQuota, _, err := getSwipeQuota(ctx, p.db.conn(ctx), userID, entities.SwipeTypeSuperLike)
+	if err != nil {
+		return nil, err
+	}
+	userEntitlements.SuperLikeQuota = *superLikeQuota
+
+	return &userEntitlements, nil
+}
+
+// ApplyBillingEvent is a function that creates or updates the subscription the event is for. Each event is recorded in
+// billing_event so a redelivered event returns entities.ErrDuplicateEvent, and events that happened before the last
+// event applied to the subscription are recorded without changing it.
+func (p *PostgresAdapter) ApplyBillingEvent(ctx context.Context, event *entities.BillingEvent) (*entities.Subscription, error) {
+	var subscription *entities.Subscription
+	err := p.db.withinTx(ctx, func(ctx context.Context, tx *instrumentedTx) error {
+		result, err := tx.ExecContext(ctx, "INSERT INTO billing_event (provider, event_id, payload) VALUES ($1, $2, $3) ON CONFLICT DO NOTHING;", event.Provider, event.ID, []byte(event.Payload))
+		if err != nil {
+			usecases.Logger(ctx).DebugContext(ctx, "inserting billing event", "err", err)
+			return err
+		}
+
+		inserted, err := result.RowsAffected()
+		if err != nil {
+			usecases.Logger(ctx).DebugContext(ctx, "error getting inserted billing event count", "err", err)
+			return err
+		}
+
+		if inserted == 0 {
+			return entities.ErrDuplicateEvent
+		}
+
+		var lastEventAt sql.NullTime
+		subscription, err = scanSubscription(tx.QueryRowContext(ctx, lockSubscriptionQuery, event.Provider, event.ProviderSubscriptionID), &lastEventAt)
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			subscription, err = scanSubscription(tx.QueryRowContext(ctx, insertSubscriptionQuery, event.UserID, event.Tier, event.Status, event.Provider, event.ProviderSubscriptionID, event.CurrentPeriodEnd.UTC(), event.OccurredAt.UTC()))
+			if err != nil {
+				return subscriptionWriteError(ctx, err)
+			}
+		case err != nil:
+			usecases.Logger(ctx).DebugContext(ctx, "locking subscription", "err", err)
+			return err
+		case subscription.UserID != event.UserID:
+			return entities.ErrBadBillingEvent
+		case event.OccurredAt.After(lastEventAt.Time):
+			if !subscription.Status.CanTransitionTo(event.Status) {
+				return entities.ErrStatusNotAllowed
+			}
+
+			subscription, err = scanSubscription(tx.QueryRowContext(ctx, updateSubscriptionQuery, subscription.ID, event.Tier, event.Status, event.CurrentPeriodEnd.UTC(), event.OccurredAt.UTC()))
+			if err != nil {
+				return subscriptionWriteError(ctx, err)
+			}
+		default:
+			usecases.Logger(ctx).DebugContext(ctx, "ignoring stale billing event", "provider", event.Provider, "eventID", event.ID)
+		}
+
+		_, err = tx.ExecContext(ctx, "UPDATE billing_event SET subscription_id = $3 WHERE provider = $1 AND event_id = $2;", event.Provider, event.ID, subscription.ID)
+		if err != nil {
+			usecases.Logger(ctx).DebugContext(ctx, "linking billing event to subscription", "err", err)
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return subscription, nil
+}
+
+// subscriptionWriteError is a function that maps the foreign key violations raised when writing a subscription
+func subscriptionWriteError(ctx context.Context, err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == foreignKeyViolationCode {
+		if pqErr.Constraint == subscriptionTierForeignKey {
+			return entities.ErrUnknownTier
+		}
+		return entities.ErrTargetUserNotFound
+	}
+
+	usecases.Logger(ctx).DebugContext(ctx, "writing subscription", "err", err)
+	return err
+}
@@ -0,0 +1,253 @@
+package adapters_test
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/AlecSmith96/dating-api/internal/adapters"
+	"github.com/AlecSmith96/dating-api/internal/entities"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	. "github.com/onsi/gomega"
+	"testing"
+	"time"
+)
+
+var subscriptionColumns = []string{"id", "user_id", "tier", "status", "provider", "provider_subscription_id", "current_period_end", "created_at", "updated_at"}
+
+var userEntitlementColumns = []string{"tier", "can_rewind", "can_see_likes", "monthly_boost_limit", "boosts_used"}
+
+func newBillingEvent() *entities.BillingEvent {
+	return &entities.BillingEvent{
+		ID:                     "evt_1",
+		Provider:               "local",
+		ProviderSubscriptionID: "sub_1",
+		UserID:                 uuid.New(),
+		Tier:                   entities.TierPlus,
+		Status:                 entities.SubscriptionStatusActive,
+		CurrentPeriodEnd:       time.Now().Add(30 * 24 * time.Hour).UTC(),
+		OccurredAt:             time.Now().UTC(),
+		Payload:                json.RawMessage(`{"id":"evt_1"}`),
+	}
+}
+
+func TestPostgresAdapter_GetEntitlements(t *testing.T) {
+	g := NewWithT(t)
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	adapter := adapters.NewPostgresAdapter(db, 0, "something-secret", nil)
+
+	userID := uuid.New()
+	subscriptionID := uuid.New()
+	periodEnd := time.Now().Add(24 * time.Hour)
+	resetsAt := time.Now().Add(time.Hour)
+
+	mock.ExpectQuery(`SELECT ue\.tier, ue\.can_rewind, ue\.can_see_likes, ue\.monthly_boost_limit, \(SELECT COUNT\(\*\) FROM user_boost bo WHERE bo\.user_id = ue\.user_id AND bo\.starts_at > NOW\(\) - INTERVAL '30 days'\) FROM user_entitlement ue WHERE ue\.user_id = \$1;`).WithArgs(userID).
+		WillReturnRows(sqlmock.NewRows(userEntitlementColumns).AddRow("plus", true, false, 1, 1))
+	mock.ExpectQuery(`SELECT id, user_id, tier, status, provider, provider_subscription_id, current_period_end, created_at, updated_at FROM user_subscription WHERE user_id = \$1 ORDER BY \(status != 'expired' AND current_period_end > NOW\(\)\) DESC, updated_at DESC LIMIT 1;`).
+		WithArgs(userID).
+		WillReturnRows(sqlmock.NewRows(subscriptionColumns).
+			AddRow(subscriptionID, userID, "plus", "active", "local", "sub_1", periodEnd, time.Now(), time.Now()))
+	mock.ExpectQuery(`SELECT et\.tier, et\.daily_swipe_limit`).WithArgs(userID, entities.SwipeTypeLike).
+		WillReturnRows(sqlmock.NewRows([]string{"tier", "daily_swipe_limit", "period_start", "resets_at", "used"}).
+			AddRow("plus", nil, time.Now(), resetsAt, 12))
+	mock.ExpectQuery(`SELECT et\.tier, et\.weekly_superlike_limit`).WithArgs(userID, entities.SwipeTypeSuperLike).
+		WillReturnRows(sqlmock.NewRows([]string{"tier", "weekly_superlike_limit", "period_start", "resets_at", "used"}).
+			AddRow("plus", 3, time.Now(), resetsAt, 1))
+
+	entitlements, err := adapter.GetEntitlements(context.Background(), userID)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(entitlements.Tier).To(Equal(entities.TierPlus))
+	g.Expect(entitlements.CanRewind).To(BeTrue())
+	g.Expect(entitlements.CanSeeLikes).To(BeFalse())
+	g.Expect(entitlements.Subscription.ID).To(Equal(subscriptionID))
+	g.Expect(entitlements.Subscription.Status).To(Equal(entities.SubscriptionStatusActive))
+	g.Expect(entitlements.LikeQuota.Unlimited).To(BeTrue())
+	g.Expect(entitlements.SuperLikeQuota.Remaining()).To(Equal(2))
+	g.Expect(entitlements.BoostsRemaining()).To(Equal(0))
+	g.Expect(mock.ExpectationsWereMet()).To(Succeed())
+}
+
+func TestPostgresAdapter_GetEntitlements_NeverSubscribed(t *testing.T) {
+	g := NewWithT(t)
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	adapter := adapters.NewPostgresAdapter(db, 0, "something-secret", nil)
+
+	userID := uuid.New()
+
+	mock.ExpectQuery(`FROM user_entitlement ue`).WithArgs(userID).
+		WillReturnRows(sqlmock.NewRows(userEntitlementColumns).AddRow("free", false, false, 0, 0))
+	mock.ExpectQuery(`FROM user_subscription`).WithArgs(userID).
+		WillReturnRows(sqlmock.NewRows(subscriptionColumns))
+	mock.ExpectQuery(`SELECT et\.tier, et\.daily_swipe_limit`).WithArgs(userID, entities.SwipeTypeLike).
+		WillReturnRows(sqlmock.NewRows([]string{"tier", "daily_swipe_limit", "period_start", "resets_at", "used"}).
+			AddRow("free", 100, time.Now(), time.Now(), 40))
+	mock.ExpectQuery(`SELECT et\.tier, et\.weekly_superlike_limit`).WithArgs(userID, entities.SwipeTypeSuperLike).
+		WillReturnRows(sqlmock.NewRows([]string{"tier", "weekly_superlike_limit", "period_start", "resets_at", "used"}).
+			AddRow("free", 1, time.Now(), time.Now(), 0))
+
+	entitlements, err := adapter.GetEntitlements(context.Background(), userID)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(entitlements.Tier).To(Equal(entities.TierFree))
+	g.Expect(entitlements.Subscription).To(BeNil())
+	g.Expect(entitlements.LikeQuota.Remaining()).To(Equal(60))
+	g.Expect(entitlements.BoostsRemaining()).To(Equal(0))
+	g.Expect(mock.ExpectationsWereMet()).To(Succeed())
+}
+
+func TestPostgresAdapter_ApplyBillingEvent_NewSubscription(t *testing.T) {
+	g := NewWithT(t)
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	adapter := adapters.NewPostgresAdapter(db, 0, "something-secret", nil)
+
+	event := newBillingEvent()
+	subscriptionID := uuid.New()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO billing_event \(provider, event_id, payload\) VALUES \(\$1, \$2, \$3\) ON CONFLICT DO NOTHING;`).
+		WithArgs(event.Provider, event.ID, []byte(event.Payload)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(`SELECT id, user_id, tier, status, provider, provider_subscription_id, current_period_end, created_at, updated_at, last_event_at FROM user_subscription WHERE provider = \$1 AND provider_subscription_id = \$2 FOR UPDATE;`).
+		WithArgs(event.Provider, event.ProviderSubscriptionID).
+		WillReturnRows(sqlmock.NewRows(append(subscriptionColumns, "last_event_at")))
+	mock.ExpectQuery(`INSERT INTO user_subscription \(user_id, tier, status, provider, provider_subscription_id, current_period_end, last_event_at\) VALUES \(\$1, \$2, \$3, \$4, \$5, \$6, \$7\) RETURNING id, user_id, tier, status, provider, provider_subscription_id, current_period_end, created_at, updated_at;`).
+		WithArgs(event.UserID, event.Tier, event.Status, event.Provider, event.ProviderSubscriptionID, event.CurrentPeriodEnd, event.OccurredAt).
+		WillReturnRows(sqlmock.NewRows(subscriptionColumns).
+			AddRow(subscriptionID, event.UserID, "plus", "active", "local", "sub_1", event.CurrentPeriodEnd, time.Now(), time.Now()))
+	mock.ExpectExec(`UPDATE billing_event SET subscription_id = \$3 WHERE provider = \$1 AND event_id = \$2;`).
+		WithArgs(event.Provider, event.ID, subscriptionID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	subscription, err := adapter.ApplyBillingEvent(context.Background(), event)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(subscription.ID).To(Equal(subscriptionID))
+	g.Expect(subscription.Tier).To(Equal(entities.TierPlus))
+	g.Expect(mock.ExpectationsWereMet()).To(Succeed())
+}
+
+func TestPostgresAdapter_ApplyBillingEvent_UpdatesSubscription(t *testing.T) {
+	g := NewWithT(t)
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	adapter := adapters.NewPostgresAdapter(db, 0, "something-secret", nil)
+
+	event := newBillingEvent()
+	event.Status = entities.SubscriptionStatusPastDue
+	subscriptionID := uuid.New()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO billing_event`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(`FROM user_subscription WHERE provider = \$1 AND provider_subscription_id = \$2 FOR UPDATE;`).
+		WithArgs(event.Provider, event.ProviderSubscriptionID).
+		WillReturnRows(sqlmock.NewRows(append(subscriptionColumns, "last_event_at")).
+			AddRow(subscriptionID, event.UserID, "plus", "active", "local", "sub_1", event.CurrentPeriodEnd, time.Now(), time.Now(), event.OccurredAt.Add(-time.Hour)))
+	mock.ExpectQuery(`UPDATE user_subscription SET tier = \$2, status = \$3, current_period_end = \$4, last_event_at = \$5, updated_at = NOW\(\) WHERE id = \$1 RETURNING id, user_id, tier, status, provider, provider_subscription_id, current_period_end, created_at, updated_at;`).
+		WithArgs(subscriptionID, event.Tier, event.Status, event.CurrentPeriodEnd, event.OccurredAt).
+		WillReturnRows(sqlmock.NewRows(subscriptionColumns).
+			AddRow(subscriptionID, event.UserID, "plus", "past_due", "local", "sub_1", event.CurrentPeriodEnd, time.Now(), time.Now()))
+	mock.ExpectExec(`UPDATE billing_event SET subscription_id`).
+		WithArgs(event.Provider, event.ID, subscriptionID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	subscription, err := adapter.ApplyBillingEvent(context.Background(), event)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(subscription.Status).To(Equal(entities.SubscriptionStatusPastDue))
+	g.Expect(mock.ExpectationsWereMet()).To(Succeed())
+}
+
+func TestPostgresAdapter_ApplyBillingEvent_StaleEvent(t *testing.T) {
+	g := NewWithT(t)
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	adapter := adapters.NewPostgresAdapter(db, 0, "something-secret", nil)
+
+	event := newBillingEvent()
+	subscriptionID := uuid.New()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO billing_event`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(`FROM user_subscription WHERE provider = \$1 AND provider_subscription_id = \$2 FOR UPDATE;`).
+		WillReturnRows(sqlmock.NewRows(append(subscriptionColumns, "last_event_at")).
+			AddRow(subscriptionID, event.UserID, "plus", "cancelled", "local", "sub_1", event.CurrentPeriodEnd, time.Now(), time.Now(), event.OccurredAt.Add(time.Hour)))
+	mock.ExpectExec(`UPDATE billing_event SET subscription_id`).
+		WithArgs(event.Provider, event.ID, subscriptionID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	subscription, err := adapter.ApplyBillingEvent(context.Background(), event)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(subscription.Status).To(Equal(entities.SubscriptionStatusCancelled))
+	g.Expect(mock.ExpectationsWereMet()).To(Succeed())
+}
+
+func TestPostgresAdapter_ApplyBillingEvent_Duplicate(t *testing.T) {
+	g := NewWithT(t)
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	adapter := adapters.NewPostgresAdapter(db, 0, "something-secret", nil)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO billing_event`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	subscription, err := adapter.ApplyBillingEvent(context.Background(), newBillingEvent())
+	g.Expect(err).To(MatchError(entities.ErrDuplicateEvent))
+	g.Expect(subscription).To(BeNil())
+	g.Expect(mock.ExpectationsWereMet()).To(Succeed())
+}
+
+func TestPostgresAdapter_ApplyBillingEvent_Expired(t *testing.T) {
+	g := NewWithT(t)
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	adapter := adapters.NewPostgresAdapter(db, 0, "something-secret", nil)
+
+	event := newBillingEvent()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO billing_event`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(`FROM user_subscription WHERE provider = \$1 AND provider_subscription_id = \$2 FOR UPDATE;`).
+		WillReturnRows(sqlmock.NewRows(append(subscriptionColumns, "last_event_at")).
+			AddRow(uuid.New(), event.UserID, "plus", "expired", "local", "sub_1", time.Now(), time.Now(), time.Now(), event.OccurredAt.Add(-time.Hour)))
+	mock.ExpectRollback()
+
+	subscription, err := adapter.ApplyBillingEvent(context.Background(), event)
+	g.Expect(err).To(MatchError(entities.ErrStatusNotAllowed))
+	g.Expect(subscription).To(BeNil())
+	g.Expect(mock.ExpectationsWereMet()).To(Succeed())
+}
+
+func TestPostgresAdapter_ApplyBillingEvent_UnknownTier(t *testing.T) {
+	g := NewWithT(t)
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	adapter := adapters.NewPostgresAdapter(db, 0, "something-secret", nil)
+
+	event := newBillingEvent()
+	event.Tier = "gold"
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO billing_event`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(`FROM user_subscription WHERE provider = \$1 AND provider_subscription_id = \$2 FOR UPDATE;`).
+		WillReturnRows(sqlmock.NewRows(append(subscriptionColumns, "last_event_at")))
+	mock.ExpectQuery(`INSERT INTO user_subscription`).
+		WillReturnError(&pq.Error{Code: "23503", Constraint: "user_subscription_tier_fkey"})
+	mock.ExpectRollback()
+
+	subscription, err := adapter.ApplyBillingEvent(context.Background(), event)
+	g.Expect(err).To(MatchError(entities.ErrUnknownTier))
+	g.Expect(subscription).To(BeNil())
+	g.Expect(mock.ExpectationsWereMet()).To(Succeed())
+}
@@ -0,0 +1,266 @@
+package adapters
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/AlecSmith96/dating-api/internal/entities"
+	"github.com/AlecSmith96/dating-api/internal/usecases"
+	"github.com/google/uuid"
+	"time"
+)
+
+const (
+	registerSwipeQuery = `INSERT INTO user_swipe (owner_user_id, swiped_user_id, swipe_type)
+SELECT $1::uuid, $2::uuid, $3::text
+WHERE NOT EXISTS (
+    SELECT 1 FROM user_block ub
+    WHERE (ub.blocker_user_id = $1 AND ub.blocked_user_id = $2) OR (ub.blocker_user_id = $2 AND ub.blocked_user_id = $1)
+);`
+
+	// swipeQuotaQuery selects the users tier limit for a quota, the first local day of the current quota period, when the
+	// period ends in the users timezone and how much of the quota has been used
+	swipeQuotaQuery = `SELECT et.tier, et.%[1]s, q.period_start, (q.period_start + INTERVAL '%[2]s')::timestamp AT TIME ZONE pu.timezone,
+    COALESCE((SELECT sq.used FROM swipe_quota sq WHERE sq.user_id = pu.id AND sq.swipe_type = $2 AND sq.quota_date = q.period_start), 0)
+FROM platform_user pu
+JOIN user_entitlement et ON et.user_id = pu.id
+CROSS JOIN LATERAL (SELECT %[3]s AS period_start) q
+WHERE pu.id = $1;`
+
+	// consumeSwipeQuotaQuery atomically uses one swipe from the quota, returning no rows when the quota is used up
+	consumeSwipeQuotaQuery = `INSERT INTO swipe_quota (user_id, swipe_type, quota_date, used)
+VALUES ($1, $2, $3, 1)
+ON CONFLICT (user_id, swipe_type, quota_date) DO UPDATE SET used = swipe_quota.used + 1
+WHERE $4::int IS NULL OR swipe_quota.used < $4::int
+RETURNING used;`
+
+	// lastSwipeQuery locks the users most recent swipe if it was made within the rewind window, along with whether the
+	// pair have matched
+	lastSwipeQuery = `SELECT us.id, us.swiped_user_id, us.swipe_type, us.created_at,
+    EXISTS (
+        SELECT 1 FROM user_match um
+        WHERE (um.owner_user_id = us.owner_user_id AND um.matched_user_id = us.swiped_user_id) OR (um.owner_user_id = us.swiped_user_id AND um.matched_user_id = us.owner_user_id)
+    ) AS matched
+FROM user_swipe us
+WHERE us.owner_user_id = $1 AND us.created_at > NOW() - make_interval(secs => $2)
+ORDER BY us.created_at DESC
+LIMIT 1
+FOR UPDATE OF us;`
+)
+
+var (
+	// likes are counted per local day and super likes per local week, starting on monday
+	getDailyLikeQuotaQuery       = fmt.Sprintf(swipeQuotaQuery, "daily_swipe_limit", "1 day", "(NOW() AT TIME ZONE pu.timezone)::date")
+	getWeeklySuperLikeQuotaQuery = fmt.Sprintf(swipeQuotaQuery, "weekly_superlike_limit", "7 days", "date_trunc('week', NOW() AT TIME ZONE pu.timezone)::date")
+
+	// likesReceivedFilter selects the likes and super likes on $1 from users that $1 hasn't swiped on yet, leaving out
+	// users who are suspended or banned
+	likesReceivedFilter = fmt.Sprintf(`FROM user_swipe us
+JOIN platform_user pu ON pu.id = us.owner_user_id
+WHERE us.swiped_user_id = $1 AND us.swipe_type IN ('like', 'superlike')
+AND NOT EXISTS (
+    SELECT 1 FROM user_swipe mine
+    WHERE mine.owner_user_id = $1 AND mine.swiped_user_id = us.owner_user_id
+)
+AND NOT EXISTS (
+    SELECT 1 FROM user_block ub
+    WHERE (ub.blocker_user_id = $1 AND ub.blocked_user_id = us.owner_user_id) OR (ub.blocker_user_id = us.owner_user_id AND ub.blocked_user_id = $1)
+)
+AND (%s) IS NULL`, fmt.Sprintf(activeSanctionQuery, "us.owner_user_id"))
+
+	countLikesReceivedQuery = fmt.Sprintf(`SELECT COUNT(*)
+%s;`, likesReceivedFilter)
+
+	// super likes are listed first, then the most recent likes
+	getLikesReceivedQuery = fmt.Sprintf(`SELECT pu.id, pu.name, pu.gender, platform_user_age(pu.date_of_birth, pu.timezone), us.swipe_type, us.created_at
+%s
+ORDER BY us.swipe_type = 'superlike' DESC, us.created_at DESC NULLS LAST, us.id
+LIMIT $2 OFFSET $3;`, likesReceivedFilter)
+)
+
+// PostgresSwipeRepository stores swipes in user_swipe, along with the quotas they use and the swipes that were rewound
+type PostgresSwipeRepository struct {
+	db *instrumentedDB
+}
+
+var _ usecases.SwipeRewinder = &PostgresSwipeRepository{}
+var _ usecases.LikesReceivedLister = &PostgresSwipeRepository{}
+
+// NewPostgresSwipeRepository creates the repository, the queries it runs are timed by the query observer when one is
+// given
+func NewPostgresSwipeRepository(db *sql.DB, queryObserver QueryObserver) *PostgresSwipeRepository {
+	return &PostgresSwipeRepository{db: newInstrumentedDB(db, queryObserver)}
+}
+
+// RegisterSwipe is a function that records a swipe. Likes use one swipe from the owners daily quota and super likes
+// from their weekly quota, in the same transaction as the swipe so a swipe that fails to register doesn't use up the
+// quota. Passes don't use a quota and return the daily quota. The quota is returned with
+// entities.ErrSwipeQuotaExceeded so the caller can tell the user when it resets.
+func (r *PostgresSwipeRepository) RegisterSwipe(ctx context.Context, ownerUserID, swipedUserID uuid.UUID, swipeType entities.SwipeType) (*entities.SwipeQuota, error) {
+	quotaSwipeType := entities.SwipeTypeLike
+	if swipeType == entities.SwipeTypeSuperLike {
+		quotaSwipeType = entities.SwipeTypeSuperLike
+	}
+
+	var quota *entities.SwipeQuota
+	err := r.db.withinTx(ctx, func(ctx context.Context, tx *instrumentedTx) error {
+		var periodStart time.Time
+		var err error
+		quota, periodStart, err = getSwipeQuota(ctx, tx, ownerUserID, quotaSwipeType)
+		if err != nil {
+			return err
+		}
+
+		var limit sql.NullInt64
+		if !quota.Unlimited {
+			limit = sql.NullInt64{Int64: int64(quota.Limit), Valid: true}
+		}
+
+		if swipeType.IsPositive() {
+			err = tx.QueryRowContext(ctx, consumeSwipeQuotaQuery, ownerUserID, quotaSwipeType, periodStart, limit).
+				Scan(&quota.Used)
+			if err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					return entities.ErrSwipeQuotaExceeded
+				}
+				usecases.Logger(ctx).DebugContext(ctx, "consuming swipe quota", "err", err)
+				return err
+			}
+		}
+
+		result, err := tx.ExecContext(ctx, registerSwipeQuery, ownerUserID, swipedUserID, swipeType)
+		if err != nil {
+			if isForeignKeyViolation(err) {
+				return entities.ErrTargetUserNotFound
+			}
+			usecases.Logger(ctx).DebugContext(ctx, "error inserting swipe record", "err", err)
+			return err
+		}
+
+		inserted, err := result.RowsAffected()
+		if err != nil {
+			usecases.Logger(ctx).DebugContext(ctx, "error getting inserted swipe count", "err", err)
+			return err
+		}
+
+		if inserted == 0 {
+			return entities.ErrUserBlocked
+		}
+
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, entities.ErrSwipeQuotaExceeded) {
+			return quota, err
+		}
+		return nil, err
+	}
+
+	return quota, nil
+}
+
+// getSwipeQuota is a function that returns the users quota for likes or super likes, along with the first local day of
+// the current quota period
+func getSwipeQuota(ctx context.Context, q rowQuerier, userID uuid.UUID, quotaSwipeType entities.SwipeType) (*entities.SwipeQuota, time.Time, error) {
+	quotaQuery := getDailyLikeQuotaQuery
+	if quotaSwipeType == entities.SwipeTypeSuperLike {
+		quotaQuery = getWeeklySuperLikeQuotaQuery
+	}
+
+	quota := entities.SwipeQuota{SwipeType: quotaSwipeType}
+	var limit sql.NullInt64
+	var periodStart time.Time
+	err := q.QueryRowContext(ctx, quotaQuery, userID, quotaSwipeType).
+		Scan(&quota.Tier, &limit, &periodStart, &quota.ResetsAt, &quota.Used)
+	if err != nil {
+		usecases.Logger(ctx).DebugContext(ctx, "getting swipe quota", "err", err)
+		return nil, time.Time{}, err
+	}
+	quota.Unlimited = !limit.Valid
+	quota.Limit = int(limit.Int64)
+
+	return &quota, periodStart, nil
+}
+
+// RewindLastSwipe is a function that undoes the users most recent swipe if it was made within the window and hasn't
+// resulted in a match, so the swiped user is shown in discovery again. Rewinds are recorded in swipe_rewind and don't
+// return the swipe to the users quota.
+func (r *PostgresSwipeRepository) RewindLastSwipe(ctx context.Context, userID uuid.UUID, window time.Duration) (*entities.Swipe, error) {
+	swipe := entities.Swipe{OwnerUserID: userID}
+	err := r.db.withinTx(ctx, func(ctx context.Context, tx *instrumentedTx) error {
+		var matched bool
+		err := tx.QueryRowContext(ctx, lastSwipeQuery, userID, window.Seconds()).
+			Scan(&swipe.ID, &swipe.SwipedUserID, &swipe.Type, &swipe.CreatedAt, &matched)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return entities.ErrNoSwipeToRewind
+			}
+			usecases.Logger(ctx).DebugContext(ctx, "getting last swipe", "err", err)
+			return err
+		}
+
+		if matched {
+			return entities.ErrSwipeMatched
+		}
+
+		_, err = tx.ExecContext(ctx, "DELETE FROM user_swipe WHERE id = $1;", swipe.ID)
+		if err != nil {
+			usecases.Logger(ctx).DebugContext(ctx, "deleting rewound swipe", "err", err)
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, "INSERT INTO swipe_rewind (user_id, swiped_user_id, swipe_type, swiped_at) VALUES ($1, $2, $3, $4);", userID, swipe.SwipedUserID, swipe.Type, swipe.CreatedAt)
+		if err != nil {
+			usecases.Logger(ctx).DebugContext(ctx, "inserting swipe rewind record", "err", err)
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &swipe, nil
+}
+
+// CountLikesReceived is a function that returns how many users have liked the user that they haven't swiped on yet
+func (r *PostgresSwipeRepository) CountLikesReceived(ctx context.Context, userID uuid.UUID) (int, error) {
+	var total int
+	err := r.db.conn(ctx).QueryRowContext(ctx, countLikesReceivedQuery, userID).
+		Scan(&total)
+	if err != nil {
+		usecases.Logger(ctx).DebugContext(ctx, "counting likes received", "err", err)
+		return 0, err
+	}
+
+	return total, nil
+}
+
+// GetLikesReceived is a function that returns a page of the users that have liked the user that they haven't swiped
+// on yet, super likes first
+func (r *PostgresSwipeRepository) GetLikesReceived(ctx context.Context, userID uuid.UUID, limit, offset int) ([]entities.ReceivedLike, error) {
+	rows, err := r.db.conn(ctx).QueryContext(ctx, getLikesReceivedQuery, userID, limit, offset)
+	if err != nil {
+		usecases.Logger(ctx).DebugContext(ctx, "getting likes received", "err", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	likes := []entities.ReceivedLike{}
+	for rows.Next() {
+		var like entities.ReceivedLike
+		var likedAt sql.NullTime
+		err = rows.Scan(&like.UserID, &like.Name, &like.Gender, &like.Age, &like.SwipeType, &likedAt)
+		if err != nil {
+			usecases.Logger(ctx).DebugContext(ctx, "unable to read received like row", "err", err)
+			return nil, err
+		}
+		like.LikedAt = likedAt.Time
+
+		likes = append(likes, like)
+	}
+
+	return likes, rows.Err()
+}
@@ -0,0 +1,283 @@
+package adapters_test
+
+import (
+	"context"
+	"database/sql"
+	"github.com/AlecSmith96/dating-api/internal/adapters"
+	"github.com/AlecSmith96/dating-api/internal/entities"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	. "github.com/onsi/gomega"
+	"testing"
+	"time"
+)
+
+func TestPostgresSwipeRepository_RegisterSwipe(t *testing.T) {
+	g := NewWithT(t)
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	repository := adapters.NewPostgresSwipeRepository(db, nil)
+
+	ownerUserID := uuid.New()
+	swipedUserID := uuid.New()
+	quotaDate := time.Date(2024, time.June, 15, 0, 0, 0, 0, time.UTC)
+	resetsAt := time.Date(2024, time.June, 15, 23, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT et\.tier, et\.daily_swipe_limit, q\.period_start, .* FROM platform_user pu JOIN user_entitlement et ON et\.user_id = pu\.id CROSS JOIN LATERAL \(SELECT \(NOW\(\) AT TIME ZONE pu\.timezone\)::date AS period_start\) q WHERE pu\.id = \$1;`).
+		WithArgs(ownerUserID, entities.SwipeTypeLike).
+		WillReturnRows(sqlmock.NewRows([]string{"tier", "daily_swipe_limit", "date", "resets_at", "used"}).
+			AddRow("free", 100, quotaDate, resetsAt, 41))
+	mock.ExpectQuery(`INSERT INTO swipe_quota \(user_id, swipe_type, quota_date, used\) VALUES \(\$1, \$2, \$3, 1\) ON CONFLICT \(user_id, swipe_type, quota_date\) DO UPDATE SET used = swipe_quota\.used \+ 1 WHERE \$4::int IS NULL OR swipe_quota\.used < \$4::int RETURNING used;`).
+		WithArgs(ownerUserID, entities.SwipeTypeLike, quotaDate, sql.NullInt64{Int64: 100, Valid: true}).
+		WillReturnRows(sqlmock.NewRows([]string{"used"}).AddRow(42))
+	mock.ExpectExec(`INSERT INTO user_swipe \(owner_user_id, swiped_user_id, swipe_type\) SELECT \$1::uuid, \$2::uuid, \$3::text WHERE NOT EXISTS`).
+		WithArgs(ownerUserID, swipedUserID, entities.SwipeTypeLike).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	quota, err := repository.RegisterSwipe(context.Background(), ownerUserID, swipedUserID, entities.SwipeTypeLike)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(quota).To(Equal(&entities.SwipeQuota{
+		Tier:      entities.TierFree,
+		SwipeType: entities.SwipeTypeLike,
+		Limit:     100,
+		Used:      42,
+		ResetsAt:  resetsAt,
+	}))
+	g.Expect(mock.ExpectationsWereMet()).To(Succeed())
+}
+
+func TestPostgresSwipeRepository_RegisterSwipe_Unlimited(t *testing.T) {
+	g := NewWithT(t)
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	repository := adapters.NewPostgresSwipeRepository(db, nil)
+
+	ownerUserID := uuid.New()
+	swipedUserID := uuid.New()
+	quotaDate := time.Date(2024, time.June, 15, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT et\.tier, et\.daily_swipe_limit`).
+		WithArgs(ownerUserID, entities.SwipeTypeLike).
+		WillReturnRows(sqlmock.NewRows([]string{"tier", "daily_swipe_limit", "date", "resets_at", "used"}).
+			AddRow("premium", nil, quotaDate, time.Now(), 0))
+	mock.ExpectQuery(`INSERT INTO swipe_quota`).
+		WithArgs(ownerUserID, entities.SwipeTypeLike, quotaDate, sql.NullInt64{}).
+		WillReturnRows(sqlmock.NewRows([]string{"used"}).AddRow(1000))
+	mock.ExpectExec(`INSERT INTO user_swipe`).
+		WithArgs(ownerUserID, swipedUserID, entities.SwipeTypeLike).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	quota, err := repository.RegisterSwipe(context.Background(), ownerUserID, swipedUserID, entities.SwipeTypeLike)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(quota.Tier).To(Equal(entities.TierPremium))
+	g.Expect(quota.Unlimited).To(BeTrue())
+}
+
+func TestPostgresSwipeRepository_RegisterSwipe_SuperLike(t *testing.T) {
+	g := NewWithT(t)
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	repository := adapters.NewPostgresSwipeRepository(db, nil)
+
+	ownerUserID := uuid.New()
+	swipedUserID := uuid.New()
+	weekStart := time.Date(2024, time.June, 10, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT et\.tier, et\.weekly_superlike_limit, q\.period_start, \(q\.period_start \+ INTERVAL '7 days'\)::timestamp AT TIME ZONE pu\.timezone, .* CROSS JOIN LATERAL \(SELECT date_trunc\('week', NOW\(\) AT TIME ZONE pu\.timezone\)::date AS period_start\) q WHERE pu\.id = \$1;`).
+		WithArgs(ownerUserID, entities.SwipeTypeSuperLike).
+		WillReturnRows(sqlmock.NewRows([]string{"tier", "weekly_superlike_limit", "date", "resets_at", "used"}).
+			AddRow("free", 1, weekStart, weekStart.AddDate(0, 0, 7), 0))
+	mock.ExpectQuery(`INSERT INTO swipe_quota`).
+		WithArgs(ownerUserID, entities.SwipeTypeSuperLike, weekStart, sql.NullInt64{Int64: 1, Valid: true}).
+		WillReturnRows(sqlmock.NewRows([]string{"used"}).AddRow(1))
+	mock.ExpectExec(`INSERT INTO user_swipe`).
+		WithArgs(ownerUserID, swipedUserID, entities.SwipeTypeSuperLike).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	quota, err := repository.RegisterSwipe(context.Background(), ownerUserID, swipedUserID, entities.SwipeTypeSuperLike)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(quota.SwipeType).To(Equal(entities.SwipeTypeSuperLike))
+	g.Expect(quota.Remaining()).To(Equal(0))
+	g.Expect(quota.ResetsAt).To(Equal(weekStart.AddDate(0, 0, 7)))
+	g.Expect(mock.ExpectationsWereMet()).To(Succeed())
+}
+
+func TestPostgresSwipeRepository_RegisterSwipe_QuotaExceeded(t *testing.T) {
+	g := NewWithT(t)
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	repository := adapters.NewPostgresSwipeRepository(db, nil)
+
+	ownerUserID := uuid.New()
+	swipedUserID := uuid.New()
+	quotaDate := time.Date(2024, time.June, 15, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT et\.tier, et\.daily_swipe_limit`).
+		WithArgs(ownerUserID, entities.SwipeTypeLike).
+		WillReturnRows(sqlmock.NewRows([]string{"tier", "daily_swipe_limit", "date", "resets_at", "used"}).
+			AddRow("free", 100, quotaDate, time.Now(), 100))
+	mock.ExpectQuery(`INSERT INTO swipe_quota`).
+		WithArgs(ownerUserID, entities.SwipeTypeLike, quotaDate, sql.NullInt64{Int64: 100, Valid: true}).
+		WillReturnRows(sqlmock.NewRows([]string{"used"}))
+	mock.ExpectRollback()
+
+	quota, err := repository.RegisterSwipe(context.Background(), ownerUserID, swipedUserID, entities.SwipeTypeLike)
+	g.Expect(err).To(MatchError(entities.ErrSwipeQuotaExceeded))
+	g.Expect(quota.Remaining()).To(Equal(0))
+	g.Expect(mock.ExpectationsWereMet()).To(Succeed())
+}
+
+func TestPostgresSwipeRepository_RegisterSwipe_Blocked(t *testing.T) {
+	g := NewWithT(t)
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	repository := adapters.NewPostgresSwipeRepository(db, nil)
+
+	ownerUserID := uuid.New()
+	swipedUserID := uuid.New()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT et\.tier, et\.daily_swipe_limit`).
+		WithArgs(ownerUserID, entities.SwipeTypeLike).
+		WillReturnRows(sqlmock.NewRows([]string{"tier", "daily_swipe_limit", "date", "resets_at", "used"}).
+			AddRow("free", 100, time.Now(), time.Now(), 3))
+	mock.ExpectExec(`INSERT INTO user_swipe \(owner_user_id, swiped_user_id, swipe_type\) SELECT \$1::uuid, \$2::uuid, \$3::text WHERE NOT EXISTS`).
+		WithArgs(ownerUserID, swipedUserID, entities.SwipeTypePass).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	// the quota is only used by positive swipes, and is rolled back if the swipe isn't registered
+	_, err = repository.RegisterSwipe(context.Background(), ownerUserID, swipedUserID, entities.SwipeTypePass)
+	g.Expect(err).To(MatchError(entities.ErrUserBlocked))
+	g.Expect(mock.ExpectationsWereMet()).To(Succeed())
+}
+
+func TestPostgresSwipeRepository_RewindLastSwipe(t *testing.T) {
+	g := NewWithT(t)
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	repository := adapters.NewPostgresSwipeRepository(db, nil)
+
+	swipe := &entities.Swipe{
+		ID:           uuid.New(),
+		OwnerUserID:  uuid.New(),
+		SwipedUserID: uuid.New(),
+		Type:         entities.SwipeTypePass,
+		CreatedAt:    time.Now().Add(-time.Minute),
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT us\.id, us\.swiped_user_id, us\.swipe_type, us\.created_at, EXISTS \( .* \) AS matched FROM user_swipe us WHERE us\.owner_user_id = \$1 AND us\.created_at > NOW\(\) - make_interval\(secs => \$2\) ORDER BY us\.created_at DESC LIMIT 1 FOR UPDATE OF us;`).
+		WithArgs(swipe.OwnerUserID, float64(300)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "swiped_user_id", "swipe_type", "created_at", "matched"}).
+			AddRow(swipe.ID, swipe.SwipedUserID, swipe.Type, swipe.CreatedAt, false))
+	mock.ExpectExec(`DELETE FROM user_swipe WHERE id = \$1;`).WithArgs(swipe.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO swipe_rewind \(user_id, swiped_user_id, swipe_type, swiped_at\) VALUES \(\$1, \$2, \$3, \$4\);`).
+		WithArgs(swipe.OwnerUserID, swipe.SwipedUserID, swipe.Type, swipe.CreatedAt).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	rewoundSwipe, err := repository.RewindLastSwipe(context.Background(), swipe.OwnerUserID, 5*time.Minute)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(rewoundSwipe).To(Equal(swipe))
+	g.Expect(mock.ExpectationsWereMet()).To(Succeed())
+}
+
+func TestPostgresSwipeRepository_RewindLastSwipe_NoSwipe(t *testing.T) {
+	g := NewWithT(t)
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	repository := adapters.NewPostgresSwipeRepository(db, nil)
+
+	userID := uuid.New()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT us\.id, us\.swiped_user_id`).WithArgs(userID, float64(300)).
+		WillReturnError(sql.ErrNoRows)
+	mock.ExpectRollback()
+
+	rewoundSwipe, err := repository.RewindLastSwipe(context.Background(), userID, 5*time.Minute)
+	g.Expect(err).To(MatchError(entities.ErrNoSwipeToRewind))
+	g.Expect(rewoundSwipe).To(BeNil())
+}
+
+func TestPostgresSwipeRepository_RewindLastSwipe_Matched(t *testing.T) {
+	g := NewWithT(t)
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	repository := adapters.NewPostgresSwipeRepository(db, nil)
+
+	userID := uuid.New()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT us\.id, us\.swiped_user_id`).WithArgs(userID, float64(300)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "swiped_user_id", "swipe_type", "created_at", "matched"}).
+			AddRow(uuid.New(), uuid.New(), entities.SwipeTypeLike, time.Now(), true))
+	mock.ExpectRollback()
+
+	rewoundSwipe, err := repository.RewindLastSwipe(context.Background(), userID, 5*time.Minute)
+	g.Expect(err).To(MatchError(entities.ErrSwipeMatched))
+	g.Expect(rewoundSwipe).To(BeNil())
+	g.Expect(mock.ExpectationsWereMet()).To(Succeed())
+}
+
+func TestPostgresSwipeRepository_CountLikesReceived(t *testing.T) {
+	g := NewWithT(t)
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	repository := adapters.NewPostgresSwipeRepository(db, nil)
+
+	userID := uuid.New()
+
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM user_swipe us JOIN platform_user pu ON pu\.id = us\.owner_user_id WHERE us\.swiped_user_id = \$1 AND us\.swipe_type IN \('like', 'superlike'\) AND NOT EXISTS \( SELECT 1 FROM user_swipe mine .* \) AND NOT EXISTS \( SELECT 1 FROM user_block ub .* \) AND \(SELECT s\.sanction_type FROM user_sanction s WHERE s\.user_id = us\.owner_user_id .*\) IS NULL;`).
+		WithArgs(userID).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(4))
+
+	total, err := repository.CountLikesReceived(context.Background(), userID)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(total).To(Equal(4))
+	g.Expect(mock.ExpectationsWereMet()).To(Succeed())
+}
+
+func TestPostgresSwipeRepository_GetLikesReceived(t *testing.T) {
+	g := NewWithT(t)
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	repository := adapters.NewPostgresSwipeRepository(db, nil)
+
+	userID := uuid.New()
+	likedAt := time.Now().Add(-time.Hour)
+	expectedLikes := []entities.ReceivedLike{
+		{UserID: uuid.New(), Name: "Alex", Gender: "female", Age: 27, SwipeType: entities.SwipeTypeSuperLike},
+		{UserID: uuid.New(), Name: "Sam", Gender: "male", Age: 31, SwipeType: entities.SwipeTypeLike, LikedAt: likedAt},
+	}
+
+	mock.ExpectQuery(`SELECT pu\.id, pu\.name, pu\.gender, platform_user_age\(pu\.date_of_birth, pu\.timezone\), us\.swipe_type, us\.created_at FROM user_swipe us .* ORDER BY us\.swipe_type = 'superlike' DESC, us\.created_at DESC NULLS LAST, us\.id LIMIT \$2 OFFSET \$3;`).
+		WithArgs(userID, 20, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "gender", "age", "swipe_type", "created_at"}).
+			AddRow(expectedLikes[0].UserID, "Alex", "female", 27, "superlike", nil).
+			AddRow(expectedLikes[1].UserID, "Sam", "male", 31, "like", likedAt))
+
+	likes, err := repository.GetLikesReceived(context.Background(), userID, 20, 0)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(likes).To(Equal(expectedLikes))
+	g.Expect(mock.ExpectationsWereMet()).To(Succeed())
+}
@@ -0,0 +1,153 @@
+package adapters
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/AlecSmith96/dating-api/internal/entities"
+	"github.com/AlecSmith96/dating-api/internal/usecases"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+	"strings"
+	"time"
+)
+
+var (
+	getTokenUserQuery = fmt.Sprintf(`SELECT %s FROM platform_user WHERE platform_user.id = $1;`, columnList("", userColumns))
+
+	issueTokenQuery = fmt.Sprintf(`INSERT INTO token (user_id, value, issued_at) VALUES ($1, $2, $3) RETURNING %s;`, columnList("", tokenColumns))
+
+	validateTokenQuery = fmt.Sprintf(`SELECT %s, t.revoked_at, (%s) AS active_sanction
+FROM token t
+WHERE t.value = $1;`, columnList("t", tokenColumns), fmt.Sprintf(activeSanctionQuery, "t.user_id"))
+)
+
+// PostgresTokenRepository issues jwts and stores them in token so that they can be revoked
+type PostgresTokenRepository struct {
+	db              *instrumentedDB
+	jwtExpiryMillis int
+	jwtSecretKey    string
+}
+
+var _ usecases.JwtProcessor = &PostgresTokenRepository{}
+
+// NewPostgresTokenRepository creates the repository, the queries it runs are timed by the query observer when one is
+// given
+func NewPostgresTokenRepository(db *sql.DB, jwtExpiryMillis int, jwtSecretKey string, queryObserver QueryObserver) *PostgresTokenRepository {
+	return &PostgresTokenRepository{
+		db:              newInstrumentedDB(db, queryObserver),
+		jwtExpiryMillis: jwtExpiryMillis,
+		jwtSecretKey:    jwtSecretKey,
+	}
+}
+
+type MyCustomClaims struct {
+	UserID string `json:"user_id"`
+	Email  string `json:"email"`
+	Name   string `json:"name"`
+	jwt.RegisteredClaims
+}
+
+func (r *PostgresTokenRepository) IssueJWT(ctx context.Context, userID uuid.UUID) (*entities.Token, error) {
+	var returnedUser entities.User
+	err := scanUser(r.db.conn(ctx).QueryRowContext(ctx, getTokenUserQuery, userID), &returnedUser)
+	if err != nil {
+		usecases.Logger(ctx).DebugContext(ctx, "getting user to issue jwt", "err", err)
+		return nil, err
+	}
+
+	issuedAt := time.Now()
+	tokenString, err := SignJWT(&returnedUser, issuedAt, r.jwtSecretKey)
+	if err != nil {
+		usecases.Logger(ctx).DebugContext(ctx, "failed to sign jwt", "err", err)
+		return nil, err
+	}
+
+	var returnedToken entities.Token
+	err = scanToken(r.db.conn(ctx).QueryRowContext(ctx, issueTokenQuery, returnedUser.ID, tokenString, issuedAt), &returnedToken)
+	if err != nil {
+		usecases.Logger(ctx).DebugContext(ctx, "writing token to storage", "err", err)
+		return nil, err
+	}
+
+	return &returnedToken, nil
+}
+
+// ValidateJwtForUser is a function that checks that the token value parsed is part of a valid token and returns the
+// userID if it is valid.
+func (r *PostgresTokenRepository) ValidateJwtForUser(ctx context.Context, tokenValue string) (uuid.UUID, error) {
+	var returnedToken entities.Token
+	var revokedAt sql.NullTime
+	var activeSanction sql.NullString
+	err := scanToken(r.db.conn(ctx).QueryRowContext(ctx, validateTokenQuery, tokenValue), &returnedToken, &revokedAt, &activeSanction)
+	if err != nil {
+		usecases.Logger(ctx).ErrorContext(ctx, "getting token", "err", err)
+		return uuid.UUID{}, err
+	}
+
+	// a sanctioned user is told why they can't continue, even though their tokens will also have been revoked
+	err = sanctionErr(activeSanction)
+	if err != nil {
+		usecases.Logger(ctx).DebugContext(ctx, "sanctioned user attempted to use jwt", "userID", returnedToken.UserID)
+		return uuid.UUID{}, err
+	}
+
+	if revokedAt.Valid {
+		usecases.Logger(ctx).DebugContext(ctx, "jwt has been revoked", "userID", returnedToken.UserID)
+		return uuid.UUID{}, entities.ErrJwtRevoked
+	}
+
+	err = ParseJWT(tokenValue, r.jwtSecretKey)
+	if err != nil {
+		if errors.Is(err, entities.ErrJwtExpired) {
+			usecases.Logger(ctx).DebugContext(ctx, "jwt is expired", "userID", returnedToken.UserID)
+			return uuid.UUID{}, err
+		}
+		usecases.Logger(ctx).DebugContext(ctx, "unable to parse jwt", "err", err)
+		return uuid.UUID{}, err
+	}
+
+	return returnedToken.UserID, nil
+}
+
+// SignJWT is a function that signs a jwt for the user, the jwt expires five minutes after it is issued
+func SignJWT(user *entities.User, issuedAt time.Time, jwtSecretKey string) (string, error) {
+	expirationTime := issuedAt.Add(300000 * time.Millisecond)
+
+	claims := MyCustomClaims{
+		UserID: user.ID.String(),
+		Email:  user.Email,
+		Name:   user.Name,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    "dating-api",
+			Subject:   "user authentication",
+			Audience:  jwt.ClaimStrings{"dating-api users"},
+			ExpiresAt: jwt.NewNumericDate(expirationTime),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			ID:        "unique-id-12345",
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString([]byte(jwtSecretKey))
+}
+
+// ParseJWT is a function that checks the jwt was signed with the secret key, returning entities.ErrJwtExpired once it
+// has expired
+func ParseJWT(tokenValue, jwtSecretKey string) error {
+	_, err := jwt.ParseWithClaims(tokenValue, &MyCustomClaims{}, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return []byte(jwtSecretKey), nil
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "token is expired") {
+			return entities.ErrJwtExpired
+		}
+		return err
+	}
+
+	return nil
+}
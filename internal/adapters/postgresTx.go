@@ -0,0 +1,28 @@
+package adapters
+
+import (
+	"context"
+	"database/sql"
+	"github.com/AlecSmith96/dating-api/internal/usecases"
+)
+
+// PostgresTxManager runs the calls made to the postgres repositories in a transaction
+type PostgresTxManager struct {
+	db *instrumentedDB
+}
+
+var _ usecases.TxManager = &PostgresTxManager{}
+
+// NewPostgresTxManager creates the transaction manager, it must be given the same database as the repositories
+func NewPostgresTxManager(db *sql.DB, queryObserver QueryObserver) *PostgresTxManager {
+	return &PostgresTxManager{db: newInstrumentedDB(db, queryObserver)}
+}
+
+// WithinTx is a function that runs fn in a transaction, the repositories run their queries in it when they are given
+// the context that fn is called with. A call made within a transaction joins it rather than starting another, and
+// repository methods that need a transaction of their own join it in the same way.
+func (m *PostgresTxManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return m.db.withinTx(ctx, func(ctx context.Context, _ *instrumentedTx) error {
+		return fn(ctx)
+	})
+}
@@ -0,0 +1,244 @@
+package adapters_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/AlecSmith96/dating-api/internal/adapters"
+	"github.com/AlecSmith96/dating-api/internal/entities"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	. "github.com/onsi/gomega"
+	"testing"
+	"time"
+)
+
+func TestPostgresTxManager_WithinTx(t *testing.T) {
+	g := NewWithT(t)
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	txManager := adapters.NewPostgresTxManager(db, nil)
+	swipeRepository := adapters.NewPostgresSwipeRepository(db, nil)
+	matchRepository := adapters.NewPostgresMatchRepository(db, nil)
+
+	ownerUserID := uuid.New()
+	swipedUserID := uuid.New()
+	matchID := uuid.New()
+	quotaDate := time.Date(2024, time.June, 15, 0, 0, 0, 0, time.UTC)
+
+	// the swipe joins the transaction rather than starting its own, so there is a single begin and commit
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT et\.tier, et\.daily_swipe_limit, q\.period_start`).
+		WithArgs(ownerUserID, entities.SwipeTypeLike).
+		WillReturnRows(sqlmock.NewRows([]string{"tier", "daily_swipe_limit", "date", "resets_at", "used"}).
+			AddRow("free", 100, quotaDate, quotaDate.AddDate(0, 0, 1), 0))
+	mock.ExpectQuery(`INSERT INTO swipe_quota`).
+		WithArgs(ownerUserID, entities.SwipeTypeLike, quotaDate, sql.NullInt64{Int64: 100, Valid: true}).
+		WillReturnRows(sqlmock.NewRows([]string{"used"}).AddRow(1))
+	mock.ExpectExec(`INSERT INTO user_swipe`).
+		WithArgs(ownerUserID, swipedUserID, entities.SwipeTypeLike).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(`SELECT EXISTS \( SELECT 1 FROM user_swipe us`).
+		WithArgs(swipedUserID, ownerUserID).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectQuery(`INSERT INTO user_match`).
+		WithArgs(ownerUserID, swipedUserID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "owner_user_id", "matched_user_id"}).AddRow(matchID, ownerUserID, swipedUserID))
+	mock.ExpectCommit()
+
+	var match *entities.Match
+	err = txManager.WithinTx(context.Background(), func(ctx context.Context) error {
+		_, err := swipeRepository.RegisterSwipe(ctx, ownerUserID, swipedUserID, entities.SwipeTypeLike)
+		if err != nil {
+			return err
+		}
+
+		match, err = matchRepository.IsMatch(ctx, ownerUserID, swipedUserID)
+		return err
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(match.ID).To(Equal(matchID))
+	g.Expect(mock.ExpectationsWereMet()).To(Succeed())
+}
+
+func TestPostgresTxManager_WithinTx_RollsBack(t *testing.T) {
+	g := NewWithT(t)
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	txManager := adapters.NewPostgresTxManager(db, nil)
+	swipeRepository := adapters.NewPostgresSwipeRepository(db, nil)
+	matchRepository := adapters.NewPostgresMatchRepository(db, nil)
+
+	ownerUserID := uuid.New()
+	swipedUserID := uuid.New()
+	quotaDate := time.Date(2024, time.June, 15, 0, 0, 0, 0, time.UTC)
+
+	// the swipe isn't kept when creating the match fails
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT et\.tier, et\.daily_swipe_limit, q\.period_start`).
+		WithArgs(ownerUserID, entities.SwipeTypeLike).
+		WillReturnRows(sqlmock.NewRows([]string{"tier", "daily_swipe_limit", "date", "resets_at", "used"}).
+			AddRow("free", 100, quotaDate, quotaDate.AddDate(0, 0, 1), 0))
+	mock.ExpectQuery(`INSERT INTO swipe_quota`).
+		WithArgs(ownerUserID, entities.SwipeTypeLike, quotaDate, sql.NullInt64{Int64: 100, Valid: true}).
+		WillReturnRows(sqlmock.NewRows([]string{"used"}).AddRow(1))
+	mock.ExpectExec(`INSERT INTO user_swipe`).
+		WithArgs(ownerUserID, swipedUserID, entities.SwipeTypeLike).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(`SELECT EXISTS \( SELECT 1 FROM user_swipe us`).
+		WithArgs(swipedUserID, ownerUserID).
+		WillReturnError(errors.New("an error occurred"))
+	mock.ExpectRollback()
+
+	err = txManager.WithinTx(context.Background(), func(ctx context.Context) error {
+		_, err := swipeRepository.RegisterSwipe(ctx, ownerUserID, swipedUserID, entities.SwipeTypeLike)
+		if err != nil {
+			return err
+		}
+
+		_, err = matchRepository.IsMatch(ctx, ownerUserID, swipedUserID)
+		return err
+	})
+	g.Expect(err).To(MatchError("an error occurred"))
+	g.Expect(mock.ExpectationsWereMet()).To(Succeed())
+}
+
+func TestPostgresTxManager_WithinTx_Nested(t *testing.T) {
+	g := NewWithT(t)
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	txManager := adapters.NewPostgresTxManager(db, nil)
+
+	mock.ExpectBegin()
+	mock.ExpectCommit()
+
+	err = txManager.WithinTx(context.Background(), func(ctx context.Context) error {
+		return txManager.WithinTx(ctx, func(ctx context.Context) error {
+			return nil
+		})
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(mock.ExpectationsWereMet()).To(Succeed())
+}
+
+func TestPostgresTxManager_WithinTx_BeginErr(t *testing.T) {
+	g := NewWithT(t)
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	txManager := adapters.NewPostgresTxManager(db, nil)
+
+	mock.ExpectBegin().WillReturnError(errors.New("an error occurred"))
+
+	called := false
+	err = txManager.WithinTx(context.Background(), func(ctx context.Context) error {
+		called = true
+		return nil
+	})
+	g.Expect(err).To(MatchError("an error occurred"))
+	g.Expect(called).To(BeFalse())
+}
+
+func TestPostgresAdapter_WithinTx_JoinsTransaction(t *testing.T) {
+	g := NewWithT(t)
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	adapter := adapters.NewPostgresAdapter(db, 0, "something-secret", nil)
+
+	reporterUserID := uuid.New()
+	reportedUserID := uuid.New()
+	reportID := uuid.New()
+	caseID := uuid.New()
+
+	// blocking and reporting have transactions of their own when called alone, here they join the outer one along
+	// with the event and role queries, so there is a single begin and commit
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT role FROM user_role WHERE user_id = \$1;`).
+		WithArgs(reporterUserID).
+		WillReturnRows(sqlmock.NewRows([]string{"role"}).AddRow(entities.RoleUser))
+	mock.ExpectExec(`INSERT INTO user_block`).
+		WithArgs(reporterUserID, reportedUserID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`DELETE FROM user_match`).
+		WithArgs(reporterUserID, reportedUserID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(`INSERT INTO user_report`).
+		WithArgs(reporterUserID, reportedUserID, entities.ReportReasonSpam, "").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "reporter_user_id", "reported_user_id", "reason", "details", "created_at"}).
+			AddRow(reportID, reporterUserID, reportedUserID, entities.ReportReasonSpam, "", time.Now()))
+	mock.ExpectQuery(`INSERT INTO moderation_case`).
+		WithArgs(reportedUserID, reportID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "status"}).AddRow(caseID, entities.ModerationCaseStatusOpen))
+	mock.ExpectExec(`INSERT INTO event_log`).
+		WithArgs(reportedUserID, reporterUserID, entities.EventTypeProfileLiked, []byte(`{}`)).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectQuery(`SELECT COALESCE\(MAX\(id\), 0\) FROM event_log`).
+		WithArgs(reportedUserID).
+		WillReturnRows(sqlmock.NewRows([]string{"max"}).AddRow(1))
+	mock.ExpectCommit()
+
+	err = adapter.WithinTx(context.Background(), func(ctx context.Context) error {
+		_, err := adapter.GetUserRole(ctx, reporterUserID)
+		if err != nil {
+			return err
+		}
+
+		err = adapter.BlockUser(ctx, reporterUserID, reportedUserID)
+		if err != nil {
+			return err
+		}
+
+		_, err = adapter.ReportUser(ctx, &entities.Report{ReporterUserID: reporterUserID, ReportedUserID: reportedUserID, Reason: entities.ReportReasonSpam})
+		if err != nil {
+			return err
+		}
+
+		err = adapter.RecordEvent(ctx, &entities.Event{UserID: reportedUserID, ActorUserID: reporterUserID, Type: entities.EventTypeProfileLiked, Payload: []byte(`{}`)})
+		if err != nil {
+			return err
+		}
+
+		_, err = adapter.GetLatestEventID(ctx, reportedUserID)
+		return err
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(mock.ExpectationsWereMet()).To(Succeed())
+}
+
+func TestPostgresAdapter_WithinTx_RollsBack(t *testing.T) {
+	g := NewWithT(t)
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	adapter := adapters.NewPostgresAdapter(db, 0, "something-secret", nil)
+
+	blockerUserID := uuid.New()
+	blockedUserID := uuid.New()
+
+	// the block isn't kept when recording the event fails
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO user_block`).
+		WithArgs(blockerUserID, blockedUserID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`DELETE FROM user_match`).
+		WithArgs(blockerUserID, blockedUserID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`INSERT INTO event_log`).
+		WillReturnError(errors.New("an error occurred"))
+	mock.ExpectRollback()
+
+	err = adapter.WithinTx(context.Background(), func(ctx context.Context) error {
+		err := adapter.BlockUser(ctx, blockerUserID, blockedUserID)
+		if err != nil {
+			return err
+		}
+
+		return adapter.RecordEvent(ctx, &entities.Event{UserID: blockedUserID, Type: entities.EventTypeProfileLiked, Payload: []byte(`{}`)})
+	})
+	g.Expect(err).To(MatchError("an error occurred"))
+	g.Expect(mock.ExpectationsWereMet()).To(Succeed())
+}
@@ -0,0 +1,247 @@
+package adapters
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/AlecSmith96/dating-api/internal/entities"
+	"github.com/AlecSmith96/dating-api/internal/usecases"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"strings"
+)
+
+// discoverUsersQuery selects the users that haven't been swiped on or blocked, leaving out those who are suspended or
+// banned
+var discoverUsersQuery = fmt.Sprintf(`SELECT pu.id, pu.name, pu.gender, pu.date_of_birth, pu.location_latitude, pu.location_longitude, pu.age,
+       EXISTS (
+           SELECT 1 FROM user_swipe sl
+           WHERE sl.owner_user_id = pu.id AND sl.swiped_user_id = $1 AND sl.swipe_type = 'superlike'
+       ) AS super_liked_me,
+       EXISTS (
+           SELECT 1 FROM user_boost bo
+           WHERE bo.user_id = pu.id AND bo.starts_at <= NOW() AND bo.ends_at > NOW()
+       ) AS boosted,
+       (
+           SELECT COUNT(*) FROM unnest(pu.interests) AS interest
+           WHERE interest IN (SELECT unnest(me.interests) FROM platform_user me WHERE me.id = $1)
+       ) AS shared_interests,
+       given.last_active_at, given.swipes_given, given.likes_given, received.swipes_received, received.likes_received,
+       (SELECT ud.score FROM user_desirability ud WHERE ud.user_id = pu.id) AS desirability,
+       COALESCE((SELECT rc.rank FROM recommendation rc WHERE rc.user_id = $1 AND rc.candidate_user_id = pu.id), 0) AS recommendation_rank
+FROM (
+    SELECT pu.id, pu.name, pu.gender, pu.date_of_birth, pu.location_latitude, pu.location_longitude, pu.interests,
+           platform_user_age(pu.date_of_birth, pu.timezone) AS age
+    FROM platform_user pu
+) pu
+CROSS JOIN LATERAL (
+    SELECT MAX(sg.created_at) AS last_active_at, COUNT(*) AS swipes_given,
+           COUNT(*) FILTER (WHERE sg.swipe_type IN ('like', 'superlike')) AS likes_given
+    FROM user_swipe sg WHERE sg.owner_user_id = pu.id
+) given
+CROSS JOIN LATERAL (
+    SELECT COUNT(*) AS swipes_received, COUNT(*) FILTER (WHERE sr.swipe_type IN ('like', 'superlike')) AS likes_received
+    FROM user_swipe sr WHERE sr.swiped_user_id = pu.id
+) received
+LEFT JOIN user_swipe us
+ON pu.id = us.swiped_user_id AND us.owner_user_id = $1
+WHERE pu.id != $1 AND us.id IS NULL AND pu.age >= 18
+AND NOT EXISTS (
+    SELECT 1 FROM user_block ub
+    WHERE (ub.blocker_user_id = $1 AND ub.blocked_user_id = pu.id) OR (ub.blocker_user_id = pu.id AND ub.blocked_user_id = $1)
+)
+AND (%s) IS NULL
+`, fmt.Sprintf(activeSanctionQuery, "pu.id"))
+
+// discoverUsersOrder is the order the users are limited in, so the users most likely to be ranked highly are kept
+const discoverUsersOrder = `ORDER BY super_liked_me DESC, boosted DESC,
+         (SELECT rc.rank FROM recommendation rc WHERE rc.user_id = $1 AND rc.candidate_user_id = pu.id) NULLS LAST,
+         last_active_at DESC NULLS LAST, id`
+
+// distanceInMilesQuery is the haversine distance from the latitude and longitude parameters to the user, measured the
+// same way as the rankers so that users on the edge of maxDistance aren't shown at a distance past it
+const distanceInMilesQuery = `2 * 3958 * asin(LEAST(1, sqrt(power(sin(radians(pu.location_latitude - $%[1]d) / 2), 2) + cos(radians($%[1]d)) * cos(radians(pu.location_latitude)) * power(sin(radians(pu.location_longitude - $%[2]d) / 2), 2))))`
+
+var (
+	createUserQuery = fmt.Sprintf(`INSERT INTO platform_user(email, password, name, gender, date_of_birth, location_latitude, location_longitude, timezone, interests) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING %s, interests;`,
+		columnList("", userColumns))
+
+	loginUserQuery = fmt.Sprintf(`SELECT %s, (%s) AS active_sanction
+FROM platform_user pu
+WHERE pu.email = $1 AND pu.password = $2
+LIMIT 1;`, columnList("pu", userColumns), fmt.Sprintf(activeSanctionQuery, "pu.id"))
+)
+
+// PostgresUserRepository stores users in platform_user
+type PostgresUserRepository struct {
+	db *instrumentedDB
+}
+
+var _ usecases.UserCreator = &PostgresUserRepository{}
+
+// NewPostgresUserRepository creates the repository, the queries it runs are timed by the query observer when one is
+// given
+func NewPostgresUserRepository(db *sql.DB, queryObserver QueryObserver) *PostgresUserRepository {
+	return &PostgresUserRepository{db: newInstrumentedDB(db, queryObserver)}
+}
+
+func (r *PostgresUserRepository) CreateUser(ctx context.Context, user *entities.User) (*entities.User, error) {
+	var returnedUser entities.User
+	row := r.db.conn(ctx).QueryRowContext(ctx, createUserQuery,
+		user.Email,
+		user.Password,
+		user.Name,
+		user.Gender,
+		user.DateOfBirth,
+		user.Location.Latitude,
+		user.Location.Longitude,
+		user.Timezone,
+		pq.Array(user.Interests),
+	)
+	err := scanUser(row, &returnedUser, pq.Array(&returnedUser.Interests))
+	if err != nil {
+		if isCheckViolation(err) {
+			usecases.Logger(ctx).DebugContext(ctx, "rejected underage user", "err", err)
+			return nil, entities.ErrUserUnderage
+		}
+		usecases.Logger(ctx).DebugContext(ctx, "creating new user", "err", err)
+		return nil, err
+	}
+
+	return &returnedUser, nil
+}
+
+func (r *PostgresUserRepository) LoginUser(ctx context.Context, email string, password string) (*entities.User, error) {
+	var returnedUser entities.User
+	var activeSanction sql.NullString
+	err := scanUser(r.db.conn(ctx).QueryRowContext(ctx, loginUserQuery, email, password), &returnedUser, &activeSanction)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			usecases.Logger(ctx).DebugContext(ctx, "incorrect details for user")
+			return nil, entities.ErrUserNotFound
+		}
+
+		usecases.Logger(ctx).DebugContext(ctx, "authenticating user", "err", err)
+		return nil, err
+	}
+
+	err = sanctionErr(activeSanction)
+	if err != nil {
+		usecases.Logger(ctx).DebugContext(ctx, "sanctioned user attempted to log in", "userID", returnedUser.ID)
+		return nil, err
+	}
+
+	return &returnedUser, nil
+}
+
+func (r *PostgresUserRepository) DiscoverNewUsers(ctx context.Context, ownerUserID uuid.UUID, pageInfo entities.PageInfo) ([]entities.UserDiscovery, error) {
+	paramIndex := 2
+	queryString := discoverUsersQuery
+	queryArgs := []any{ownerUserID}
+	if pageInfo.MinAge != 0 {
+		minAgeCheck := fmt.Sprintf(" AND pu.age >= $%d", paramIndex)
+		queryString += minAgeCheck
+		paramIndex++
+		queryArgs = append(queryArgs, pageInfo.MinAge)
+	}
+
+	if pageInfo.MaxAge != 0 {
+		maxAgeCheck := fmt.Sprintf(" AND pu.age <= $%d", paramIndex)
+		queryString += maxAgeCheck
+		paramIndex++
+		queryArgs = append(queryArgs, pageInfo.MaxAge)
+	}
+
+	if len(pageInfo.PreferredGenders) != 0 {
+		placeholders := make([]string, len(pageInfo.PreferredGenders))
+		for i := range pageInfo.PreferredGenders {
+			placeholders[i] = fmt.Sprintf("$%d", paramIndex)
+			paramIndex++
+		}
+
+		genderCheck := fmt.Sprintf(" AND pu.gender IN (%s)", strings.Join(placeholders, ", "))
+		queryString += genderCheck
+		for _, gender := range pageInfo.PreferredGenders {
+			queryArgs = append(queryArgs, gender)
+		}
+	}
+
+	if pageInfo.MaxDistance != 0 {
+		distanceCheck := fmt.Sprintf(" AND %s <= $%d", fmt.Sprintf(distanceInMilesQuery, paramIndex, paramIndex+1), paramIndex+2)
+		queryString += distanceCheck
+		paramIndex += 3
+		queryArgs = append(queryArgs, pageInfo.Origin.Latitude, pageInfo.Origin.Longitude, pageInfo.MaxDistance)
+	}
+
+	if pageInfo.UserIDs != nil {
+		userIDCheck := fmt.Sprintf(" AND pu.id = ANY($%d)", paramIndex)
+		queryString += userIDCheck
+		paramIndex++
+		queryArgs = append(queryArgs, pq.Array(pageInfo.UserIDs))
+	}
+
+	if pageInfo.Limit != 0 {
+		queryString += fmt.Sprintf("\n%s LIMIT $%d", discoverUsersOrder, paramIndex)
+		paramIndex++
+		queryArgs = append(queryArgs, pageInfo.Limit)
+	}
+	queryString += ";"
+
+	rows, err := r.db.conn(ctx).QueryContext(ctx, queryString, queryArgs...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return []entities.UserDiscovery{}, nil
+		}
+
+		usecases.Logger(ctx).DebugContext(ctx, "unable to get users", "err", err)
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var users []entities.UserDiscovery
+	for rows.Next() {
+		var user entities.UserDiscovery
+		err = scanUserDiscovery(rows, &user)
+		if err != nil {
+			usecases.Logger(ctx).DebugContext(ctx, "unable to read user row", "err", err)
+			return nil, err
+		}
+
+		users = append(users, user)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		usecases.Logger(ctx).DebugContext(ctx, "reading user rows", "err", err)
+		return nil, err
+	}
+
+	return users, nil
+}
+
+func (r *PostgresUserRepository) GetUsersLocation(ctx context.Context, userID uuid.UUID) (*entities.Location, error) {
+	var location entities.Location
+	err := r.db.conn(ctx).QueryRowContext(ctx, "SELECT location_latitude, location_longitude FROM platform_user WHERE id = $1", userID).
+		Scan(&location.Latitude, &location.Longitude)
+	if err != nil {
+		usecases.Logger(ctx).DebugContext(ctx, "error getting users location", "err", err)
+		return nil, err
+	}
+
+	return &location, nil
+}
+
+// sanctionErr is a function that returns the error for a users active suspension or ban, if they have one
+func sanctionErr(activeSanction sql.NullString) error {
+	if !activeSanction.Valid {
+		return nil
+	}
+
+	if entities.ModerationActionType(activeSanction.String) == entities.ModerationActionBan {
+		return entities.ErrUserBanned
+	}
+
+	return entities.ErrUserSuspended
+}
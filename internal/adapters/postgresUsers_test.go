@@ -0,0 +1,405 @@
+package adapters_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/AlecSmith96/dating-api/internal/adapters"
+	"github.com/AlecSmith96/dating-api/internal/entities"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	. "github.com/onsi/gomega"
+	"testing"
+	"time"
+)
+
+func TestPostgresUserRepository_LoginUser(t *testing.T) {
+	g := NewWithT(t)
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	repository := adapters.NewPostgresUserRepository(db, nil)
+
+	user := &entities.User{
+		ID:          uuid.New(),
+		Name:        gofakeit.Name(),
+		Gender:      gofakeit.Gender(),
+		DateOfBirth: gofakeit.Date(),
+		Location: entities.Location{
+			Latitude:  gofakeit.Address().Latitude,
+			Longitude: gofakeit.Address().Longitude,
+		},
+	}
+
+	mock.ExpectQuery(`SELECT pu\.id, pu\.email, pu\.password, pu\.name, pu\.gender, pu\.date_of_birth, pu\.location_latitude, pu\.location_longitude, pu\.timezone, \(SELECT s\.sanction_type FROM user_sanction s .*\) AS active_sanction FROM platform_user pu WHERE pu\.email = \$1 AND pu\.password = \$2 LIMIT 1;`).WithArgs(user.Email, user.Password).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "email", "password", "name", "gender", "date_of_birth", "location_latitude", "location_longitude", "timezone", "active_sanction"}).
+			AddRow(user.ID, user.Email, user.Password, user.Name, user.Gender, user.DateOfBirth, user.Location.Latitude, user.Location.Longitude, "UTC", nil))
+
+	_, err = repository.LoginUser(context.Background(), user.Email, user.Password)
+	g.Expect(err).ToNot(HaveOccurred())
+}
+
+func TestPostgresUserRepository_LoginUser_ErrNoRows(t *testing.T) {
+	g := NewWithT(t)
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	repository := adapters.NewPostgresUserRepository(db, nil)
+
+	user := &entities.User{
+		ID:          uuid.New(),
+		Name:        gofakeit.Name(),
+		Gender:      gofakeit.Gender(),
+		DateOfBirth: gofakeit.Date(),
+		Location: entities.Location{
+			Latitude:  gofakeit.Address().Latitude,
+			Longitude: gofakeit.Address().Longitude,
+		},
+	}
+
+	mock.ExpectQuery(`SELECT pu\.id, pu\.email, pu\.password, pu\.name, pu\.gender, pu\.date_of_birth, pu\.location_latitude, pu\.location_longitude, pu\.timezone, \(SELECT s\.sanction_type FROM user_sanction s .*\) AS active_sanction FROM platform_user pu WHERE pu\.email = \$1 AND pu\.password = \$2 LIMIT 1;`).WithArgs(user.Email, user.Password).
+		WillReturnError(sql.ErrNoRows)
+
+	_, err = repository.LoginUser(context.Background(), user.Email, user.Password)
+	g.Expect(err).To(MatchError(entities.ErrUserNotFound))
+}
+
+func TestPostgresUserRepository_LoginUser_GenericErr(t *testing.T) {
+	g := NewWithT(t)
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	repository := adapters.NewPostgresUserRepository(db, nil)
+
+	user := &entities.User{
+		ID:          uuid.New(),
+		Name:        gofakeit.Name(),
+		Gender:      gofakeit.Gender(),
+		DateOfBirth: gofakeit.Date(),
+		Location: entities.Location{
+			Latitude:  gofakeit.Address().Latitude,
+			Longitude: gofakeit.Address().Longitude,
+		},
+	}
+
+	mock.ExpectQuery(`SELECT pu\.id, pu\.email, pu\.password, pu\.name, pu\.gender, pu\.date_of_birth, pu\.location_latitude, pu\.location_longitude, pu\.timezone, \(SELECT s\.sanction_type FROM user_sanction s .*\) AS active_sanction FROM platform_user pu WHERE pu\.email = \$1 AND pu\.password = \$2 LIMIT 1;`).WithArgs(user.Email, user.Password).
+		WillReturnError(errors.New("an error occurred"))
+
+	_, err = repository.LoginUser(context.Background(), user.Email, user.Password)
+	g.Expect(err).To(MatchError("an error occurred"))
+}
+
+func TestPostgresUserRepository_CreateUser(t *testing.T) {
+	g := NewWithT(t)
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	repository := adapters.NewPostgresUserRepository(db, nil)
+
+	user := &entities.User{
+		ID:          uuid.New(),
+		Name:        gofakeit.Name(),
+		Gender:      gofakeit.Gender(),
+		DateOfBirth: gofakeit.Date(),
+		Location: entities.Location{
+			Latitude:  gofakeit.Address().Latitude,
+			Longitude: gofakeit.Address().Longitude,
+		},
+		Interests: []string{"Hiking", "Chess"},
+	}
+
+	mock.ExpectQuery(`INSERT INTO platform_user\(email, password, name, gender, date_of_birth, location_latitude, location_longitude, timezone, interests\) VALUES \(\$1, \$2, \$3, \$4, \$5, \$6, \$7, \$8, \$9\) RETURNING id, email, password, name, gender, date_of_birth, location_latitude, location_longitude, timezone, interests;`).
+		WithArgs(user.Email, user.Password, user.Name, user.Gender, user.DateOfBirth, user.Location.Latitude, user.Location.Longitude, user.Timezone, sqlmock.AnyArg()).WillReturnRows(sqlmock.NewRows([]string{"id", "email", "password", "name", "gender", "date_of_birth", "location_latitude", "location_longitude", "timezone", "interests"}).
+		AddRow(user.ID, user.Email, user.Password, user.Name, user.Gender, user.DateOfBirth, user.Location.Latitude, user.Location.Longitude, user.Timezone, "{Hiking,Chess}"))
+
+	userResp, err := repository.CreateUser(context.Background(), user)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(userResp).To(Equal(user))
+}
+
+func TestPostgresUserRepository_CreateUser_ReturnsErr(t *testing.T) {
+	g := NewWithT(t)
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	repository := adapters.NewPostgresUserRepository(db, nil)
+
+	user := &entities.User{
+		ID:          uuid.New(),
+		Name:        gofakeit.Name(),
+		Gender:      gofakeit.Gender(),
+		DateOfBirth: gofakeit.Date(),
+		Location: entities.Location{
+			Latitude:  gofakeit.Address().Latitude,
+			Longitude: gofakeit.Address().Longitude,
+		},
+	}
+
+	mock.ExpectQuery(`INSERT INTO platform_user\(email, password, name, gender, date_of_birth, location_latitude, location_longitude, timezone, interests\) VALUES \(\$1, \$2, \$3, \$4, \$5, \$6, \$7, \$8, \$9\) RETURNING id, email, password, name, gender, date_of_birth, location_latitude, location_longitude, timezone, interests;`).
+		WithArgs(user.Email, user.Password, user.Name, user.Gender, user.DateOfBirth, user.Location.Latitude, user.Location.Longitude, user.Timezone, sqlmock.AnyArg()).WillReturnError(errors.New("an error occurred"))
+
+	userResp, err := repository.CreateUser(context.Background(), user)
+	g.Expect(err).To(MatchError("an error occurred"))
+	g.Expect(userResp).To(BeNil())
+}
+
+func TestPostgresUserRepository_CreateUser_Underage(t *testing.T) {
+	g := NewWithT(t)
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	repository := adapters.NewPostgresUserRepository(db, nil)
+
+	user := &entities.User{
+		Name:        gofakeit.Name(),
+		Gender:      gofakeit.Gender(),
+		DateOfBirth: time.Now().AddDate(-17, 0, 0),
+		Timezone:    "Europe/London",
+	}
+
+	mock.ExpectQuery(`INSERT INTO platform_user\(email, password, name, gender, date_of_birth, location_latitude, location_longitude, timezone, interests\) VALUES \(\$1, \$2, \$3, \$4, \$5, \$6, \$7, \$8, \$9\) RETURNING id, email, password, name, gender, date_of_birth, location_latitude, location_longitude, timezone, interests;`).
+		WithArgs(user.Email, user.Password, user.Name, user.Gender, user.DateOfBirth, user.Location.Latitude, user.Location.Longitude, user.Timezone, sqlmock.AnyArg()).WillReturnError(&pq.Error{Code: "23514"})
+
+	userResp, err := repository.CreateUser(context.Background(), user)
+	g.Expect(err).To(MatchError(entities.ErrUserUnderage))
+	g.Expect(userResp).To(BeNil())
+}
+
+func TestPostgresUserRepository_DiscoverNewUsers(t *testing.T) {
+	g := NewWithT(t)
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	repository := adapters.NewPostgresUserRepository(db, nil)
+
+	ownerUserID := uuid.New()
+	pageInfo := entities.PageInfo{
+		MinAge:           18,
+		MaxAge:           80,
+		PreferredGenders: []string{"female"},
+	}
+
+	users := []entities.UserDiscovery{
+		{
+			ID:          uuid.New(),
+			Name:        gofakeit.Name(),
+			Gender:      gofakeit.Gender(),
+			DateOfBirth: gofakeit.Date(),
+			Age:         23,
+			Location: entities.Location{
+				Latitude:  gofakeit.Address().Latitude,
+				Longitude: gofakeit.Address().Longitude,
+			},
+		},
+		{
+			ID:          uuid.New(),
+			Name:        gofakeit.Name(),
+			Gender:      gofakeit.Gender(),
+			DateOfBirth: gofakeit.Date(),
+			Age:         23,
+			Location: entities.Location{
+				Latitude:  gofakeit.Address().Latitude,
+				Longitude: gofakeit.Address().Longitude,
+			},
+		},
+	}
+
+	mock.ExpectQuery("SELECT pu\\.id, pu\\.name, pu\\.gender, pu\\.date_of_birth, pu\\.location_latitude, pu\\.location_longitude, pu\\.age, EXISTS \\( SELECT 1 FROM user_swipe sl WHERE sl\\.owner_user_id = pu\\.id AND sl\\.swiped_user_id = \\$1 AND sl\\.swipe_type = 'superlike' \\) AS super_liked_me, EXISTS \\( SELECT 1 FROM user_boost bo WHERE bo\\.user_id = pu\\.id AND bo\\.starts_at <= NOW\\(\\) AND bo\\.ends_at > NOW\\(\\) \\) AS boosted, \\( SELECT COUNT\\(\\*\\) FROM unnest\\(pu\\.interests\\) AS interest WHERE interest IN \\(SELECT unnest\\(me\\.interests\\) FROM platform_user me WHERE me\\.id = \\$1\\) \\) AS shared_interests, given\\.last_active_at, given\\.swipes_given, given\\.likes_given, received\\.swipes_received, received\\.likes_received, \\(SELECT ud\\.score FROM user_desirability ud WHERE ud\\.user_id = pu\\.id\\) AS desirability, COALESCE\\(\\(SELECT rc\\.rank FROM recommendation rc WHERE rc\\.user_id = \\$1 AND rc\\.candidate_user_id = pu\\.id\\), 0\\) AS recommendation_rank FROM \\( SELECT pu\\.id, pu\\.name, pu\\.gender, pu\\.date_of_birth, pu\\.location_latitude, pu\\.location_longitude, pu\\.interests, platform_user_age\\(pu\\.date_of_birth, pu\\.timezone\\) AS age FROM platform_user pu \\) pu CROSS JOIN LATERAL \\( SELECT MAX\\(sg\\.created_at\\) AS last_active_at, COUNT\\(\\*\\) AS swipes_given, COUNT\\(\\*\\) FILTER \\(WHERE sg\\.swipe_type IN \\('like', 'superlike'\\)\\) AS likes_given FROM user_swipe sg WHERE sg\\.owner_user_id = pu\\.id \\) given CROSS JOIN LATERAL \\( SELECT COUNT\\(\\*\\) AS swipes_received, COUNT\\(\\*\\) FILTER \\(WHERE sr\\.swipe_type IN \\('like', 'superlike'\\)\\) AS likes_received FROM user_swipe sr WHERE sr\\.swiped_user_id = pu\\.id \\) received LEFT JOIN user_swipe us ON pu\\.id = us\\.swiped_user_id AND us\\.owner_user_id = \\$1 WHERE pu\\.id != \\$1 AND us\\.id IS NULL AND pu\\.age >= 18 AND NOT EXISTS \\( SELECT 1 FROM user_block ub WHERE \\(ub\\.blocker_user_id = \\$1 AND ub\\.blocked_user_id = pu\\.id\\) OR \\(ub\\.blocker_user_id = pu\\.id AND ub\\.blocked_user_id = \\$1\\) \\) AND \\(SELECT s\\.sanction_type FROM user_sanction s WHERE s\\.user_id = pu\\.id .*\\) IS NULL AND pu\\.age >= \\$2 AND pu\\.age <= \\$3 AND pu\\.gender IN \\(\\$4\\);").
+		WithArgs(ownerUserID, pageInfo.MinAge, pageInfo.MaxAge, pageInfo.PreferredGenders[0]).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "gender", "date_of_birth", "location_latitude", "location_longitude", "age", "super_liked_me", "boosted", "shared_interests", "last_active_at", "swipes_given", "likes_given", "swipes_received", "likes_received", "desirability", "recommendation_rank"}).
+			AddRow(users[0].ID, users[0].Name, users[0].Gender, users[0].DateOfBirth, users[0].Location.Latitude, users[0].Location.Longitude, users[0].Age, users[0].SuperLikedMe, users[0].Boosted, 2, time.Now(), 10, 4, 20, 5, 1620.5, 3).
+			AddRow(users[1].ID, users[1].Name, users[1].Gender, users[1].DateOfBirth, users[1].Location.Latitude, users[1].Location.Longitude, users[0].Age, users[1].SuperLikedMe, users[1].Boosted, 0, nil, 0, 0, 0, 0, nil, 0)).
+		RowsWillBeClosed()
+
+	returnedUsers, err := repository.DiscoverNewUsers(context.Background(), ownerUserID, pageInfo)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(returnedUsers).To(HaveLen(2))
+	g.Expect(returnedUsers[0].SharedInterests).To(Equal(2))
+	g.Expect(returnedUsers[0].LastActiveAt).ToNot(BeNil())
+	g.Expect(returnedUsers[0].LikesReceived).To(Equal(5))
+	g.Expect(returnedUsers[0].Desirability).To(Equal(1620.5))
+	g.Expect(returnedUsers[0].RecommendationRank).To(Equal(3))
+	g.Expect(returnedUsers[1].LastActiveAt).To(BeNil())
+	g.Expect(returnedUsers[1].Desirability).To(Equal(entities.DefaultDesirability))
+	g.Expect(returnedUsers[1].RecommendationRank).To(Equal(0))
+	g.Expect(mock.ExpectationsWereMet()).To(Succeed())
+}
+
+func TestPostgresUserRepository_DiscoverNewUsers_ErrNoRows(t *testing.T) {
+	g := NewWithT(t)
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	repository := adapters.NewPostgresUserRepository(db, nil)
+
+	ownerUserID := uuid.New()
+	pageInfo := entities.PageInfo{
+		MinAge:           18,
+		MaxAge:           80,
+		PreferredGenders: []string{"female"},
+	}
+
+	mock.ExpectQuery("SELECT pu\\.id, pu\\.name, pu\\.gender, pu\\.date_of_birth, pu\\.location_latitude, pu\\.location_longitude, pu\\.age, EXISTS \\( SELECT 1 FROM user_swipe sl WHERE sl\\.owner_user_id = pu\\.id AND sl\\.swiped_user_id = \\$1 AND sl\\.swipe_type = 'superlike' \\) AS super_liked_me, EXISTS \\( SELECT 1 FROM user_boost bo WHERE bo\\.user_id = pu\\.id AND bo\\.starts_at <= NOW\\(\\) AND bo\\.ends_at > NOW\\(\\) \\) AS boosted, \\( SELECT COUNT\\(\\*\\) FROM unnest\\(pu\\.interests\\) AS interest WHERE interest IN \\(SELECT unnest\\(me\\.interests\\) FROM platform_user me WHERE me\\.id = \\$1\\) \\) AS shared_interests, given\\.last_active_at, given\\.swipes_given, given\\.likes_given, received\\.swipes_received, received\\.likes_received, \\(SELECT ud\\.score FROM user_desirability ud WHERE ud\\.user_id = pu\\.id\\) AS desirability, COALESCE\\(\\(SELECT rc\\.rank FROM recommendation rc WHERE rc\\.user_id = \\$1 AND rc\\.candidate_user_id = pu\\.id\\), 0\\) AS recommendation_rank FROM \\( SELECT pu\\.id, pu\\.name, pu\\.gender, pu\\.date_of_birth, pu\\.location_latitude, pu\\.location_longitude, pu\\.interests, platform_user_age\\(pu\\.date_of_birth, pu\\.timezone\\) AS age FROM platform_user pu \\) pu CROSS JOIN LATERAL \\( SELECT MAX\\(sg\\.created_at\\) AS last_active_at, COUNT\\(\\*\\) AS swipes_given, COUNT\\(\\*\\) FILTER \\(WHERE sg\\.swipe_type IN \\('like', 'superlike'\\)\\) AS likes_given FROM user_swipe sg WHERE sg\\.owner_user_id = pu\\.id \\) given CROSS JOIN LATERAL \\( SELECT COUNT\\(\\*\\) AS swipes_received, COUNT\\(\\*\\) FILTER \\(WHERE sr\\.swipe_type IN \\('like', 'superlike'\\)\\) AS likes_received FROM user_swipe sr WHERE sr\\.swiped_user_id = pu\\.id \\) received LEFT JOIN user_swipe us ON pu\\.id = us\\.swiped_user_id AND us\\.owner_user_id = \\$1 WHERE pu\\.id != \\$1 AND us\\.id IS NULL AND pu\\.age >= 18 AND NOT EXISTS \\( SELECT 1 FROM user_block ub WHERE \\(ub\\.blocker_user_id = \\$1 AND ub\\.blocked_user_id = pu\\.id\\) OR \\(ub\\.blocker_user_id = pu\\.id AND ub\\.blocked_user_id = \\$1\\) \\) AND \\(SELECT s\\.sanction_type FROM user_sanction s WHERE s\\.user_id = pu\\.id .*\\) IS NULL AND pu\\.age >= \\$2 AND pu\\.age <= \\$3 AND pu\\.gender IN \\(\\$4\\);").
+		WithArgs(ownerUserID, pageInfo.MinAge, pageInfo.MaxAge, pageInfo.PreferredGenders[0]).
+		WillReturnError(sql.ErrNoRows)
+
+	returnedUsers, err := repository.DiscoverNewUsers(context.Background(), ownerUserID, pageInfo)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(returnedUsers).To(HaveLen(0))
+}
+
+func TestPostgresUserRepository_DiscoverNewUsers_GenericErr(t *testing.T) {
+	g := NewWithT(t)
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	repository := adapters.NewPostgresUserRepository(db, nil)
+
+	ownerUserID := uuid.New()
+	pageInfo := entities.PageInfo{
+		MinAge:           18,
+		MaxAge:           80,
+		PreferredGenders: []string{"female"},
+	}
+
+	mock.ExpectQuery("SELECT pu\\.id, pu\\.name, pu\\.gender, pu\\.date_of_birth, pu\\.location_latitude, pu\\.location_longitude, pu\\.age, EXISTS \\( SELECT 1 FROM user_swipe sl WHERE sl\\.owner_user_id = pu\\.id AND sl\\.swiped_user_id = \\$1 AND sl\\.swipe_type = 'superlike' \\) AS super_liked_me, EXISTS \\( SELECT 1 FROM user_boost bo WHERE bo\\.user_id = pu\\.id AND bo\\.starts_at <= NOW\\(\\) AND bo\\.ends_at > NOW\\(\\) \\) AS boosted, \\( SELECT COUNT\\(\\*\\) FROM unnest\\(pu\\.interests\\) AS interest WHERE interest IN \\(SELECT unnest\\(me\\.interests\\) FROM platform_user me WHERE me\\.id = \\$1\\) \\) AS shared_interests, given\\.last_active_at, given\\.swipes_given, given\\.likes_given, received\\.swipes_received, received\\.likes_received, \\(SELECT ud\\.score FROM user_desirability ud WHERE ud\\.user_id = pu\\.id\\) AS desirability, COALESCE\\(\\(SELECT rc\\.rank FROM recommendation rc WHERE rc\\.user_id = \\$1 AND rc\\.candidate_user_id = pu\\.id\\), 0\\) AS recommendation_rank FROM \\( SELECT pu\\.id, pu\\.name, pu\\.gender, pu\\.date_of_birth, pu\\.location_latitude, pu\\.location_longitude, pu\\.interests, platform_user_age\\(pu\\.date_of_birth, pu\\.timezone\\) AS age FROM platform_user pu \\) pu CROSS JOIN LATERAL \\( SELECT MAX\\(sg\\.created_at\\) AS last_active_at, COUNT\\(\\*\\) AS swipes_given, COUNT\\(\\*\\) FILTER \\(WHERE sg\\.swipe_type IN \\('like', 'superlike'\\)\\) AS likes_given FROM user_swipe sg WHERE sg\\.owner_user_id = pu\\.id \\) given CROSS JOIN LATERAL \\( SELECT COUNT\\(\\*\\) AS swipes_received, COUNT\\(\\*\\) FILTER \\(WHERE sr\\.swipe_type IN \\('like', 'superlike'\\)\\) AS likes_received FROM user_swipe sr WHERE sr\\.swiped_user_id = pu\\.id \\) received LEFT JOIN user_swipe us ON pu\\.id = us\\.swiped_user_id AND us\\.owner_user_id = \\$1 WHERE pu\\.id != \\$1 AND us\\.id IS NULL AND pu\\.age >= 18 AND NOT EXISTS \\( SELECT 1 FROM user_block ub WHERE \\(ub\\.blocker_user_id = \\$1 AND ub\\.blocked_user_id = pu\\.id\\) OR \\(ub\\.blocker_user_id = pu\\.id AND ub\\.blocked_user_id = \\$1\\) \\) AND \\(SELECT s\\.sanction_type FROM user_sanction s WHERE s\\.user_id = pu\\.id .*\\) IS NULL AND pu\\.age >= \\$2 AND pu\\.age <= \\$3 AND pu\\.gender IN \\(\\$4\\);").
+		WithArgs(ownerUserID, pageInfo.MinAge, pageInfo.MaxAge, pageInfo.PreferredGenders[0]).
+		WillReturnError(errors.New("an error occurred"))
+
+	returnedUsers, err := repository.DiscoverNewUsers(context.Background(), ownerUserID, pageInfo)
+	g.Expect(err).To(MatchError("an error occurred"))
+	g.Expect(returnedUsers).To(BeNil())
+}
+
+func TestPostgresUserRepository_DiscoverNewUsers_UserIDs(t *testing.T) {
+	g := NewWithT(t)
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	repository := adapters.NewPostgresUserRepository(db, nil)
+
+	ownerUserID := uuid.New()
+	userIDs := []uuid.UUID{uuid.New(), uuid.New()}
+	userIDsArray, err := pq.Array(userIDs).Value()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	mock.ExpectQuery("AND pu\\.age >= \\$2 AND pu\\.id = ANY\\(\\$3\\);").
+		WithArgs(ownerUserID, 25, userIDsArray).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "gender", "date_of_birth", "location_latitude", "location_longitude", "age", "super_liked_me", "boosted", "shared_interests", "last_active_at", "swipes_given", "likes_given", "swipes_received", "likes_received", "desirability", "recommendation_rank"}).
+			AddRow(userIDs[1], "Jane", "female", time.Now(), 51.5, -0.1, 30, false, false, 0, nil, 0, 0, 0, 0, nil, 0))
+
+	returnedUsers, err := repository.DiscoverNewUsers(context.Background(), ownerUserID, entities.PageInfo{MinAge: 25, UserIDs: userIDs})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(returnedUsers).To(HaveLen(1))
+	g.Expect(returnedUsers[0].ID).To(Equal(userIDs[1]))
+	g.Expect(mock.ExpectationsWereMet()).To(Succeed())
+}
+
+func TestPostgresUserRepository_DiscoverNewUsers_MaxDistance(t *testing.T) {
+	g := NewWithT(t)
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	repository := adapters.NewPostgresUserRepository(db, nil)
+
+	ownerUserID := uuid.New()
+	origin := entities.Location{Latitude: 51.5072, Longitude: -0.1276}
+
+	mock.ExpectQuery("AND 2 \\* 3958 \\* asin\\(.*radians\\(pu\\.location_latitude - \\$2\\).*radians\\(pu\\.location_longitude - \\$3\\).*\\) <= \\$4;").
+		WithArgs(ownerUserID, origin.Latitude, origin.Longitude, 50.0).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "gender", "date_of_birth", "location_latitude", "location_longitude", "age", "super_liked_me", "boosted", "shared_interests", "last_active_at", "swipes_given", "likes_given", "swipes_received", "likes_received", "desirability", "recommendation_rank"}))
+
+	returnedUsers, err := repository.DiscoverNewUsers(context.Background(), ownerUserID, entities.PageInfo{MaxDistance: 50, Origin: origin})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(returnedUsers).To(BeEmpty())
+	g.Expect(mock.ExpectationsWereMet()).To(Succeed())
+}
+
+func TestPostgresUserRepository_DiscoverNewUsers_Limit(t *testing.T) {
+	g := NewWithT(t)
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	repository := adapters.NewPostgresUserRepository(db, nil)
+
+	ownerUserID := uuid.New()
+
+	mock.ExpectQuery("AND pu\\.gender IN \\(\\$2\\)\\s+ORDER BY super_liked_me DESC, boosted DESC,.*last_active_at DESC NULLS LAST, id LIMIT \\$3;").
+		WithArgs(ownerUserID, "female", 200).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "gender", "date_of_birth", "location_latitude", "location_longitude", "age", "super_liked_me", "boosted", "shared_interests", "last_active_at", "swipes_given", "likes_given", "swipes_received", "likes_received", "desirability", "recommendation_rank"}))
+
+	returnedUsers, err := repository.DiscoverNewUsers(context.Background(), ownerUserID, entities.PageInfo{PreferredGenders: []string{"female"}, Limit: 200})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(returnedUsers).To(BeEmpty())
+	g.Expect(mock.ExpectationsWereMet()).To(Succeed())
+}
+
+func TestPostgresUserRepository_DiscoverNewUsers_ScanErr(t *testing.T) {
+	g := NewWithT(t)
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	repository := adapters.NewPostgresUserRepository(db, nil)
+
+	ownerUserID := uuid.New()
+	mock.ExpectQuery("SELECT pu\\.id, pu\\.name").
+		WithArgs(ownerUserID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "gender", "date_of_birth", "location_latitude", "location_longitude", "age", "super_liked_me", "boosted", "shared_interests", "last_active_at", "swipes_given", "likes_given", "swipes_received", "likes_received", "desirability", "recommendation_rank"}).
+			AddRow("not-a-uuid", "Jane", "female", time.Now(), 51.5, -0.1, 30, false, false, 0, nil, 0, 0, 0, 0, nil, 0)).
+		RowsWillBeClosed()
+
+	returnedUsers, err := repository.DiscoverNewUsers(context.Background(), ownerUserID, entities.PageInfo{})
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(returnedUsers).To(BeNil())
+	g.Expect(mock.ExpectationsWereMet()).To(Succeed())
+}
+
+func TestPostgresUserRepository_DiscoverNewUsers_RowErr(t *testing.T) {
+	g := NewWithT(t)
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	repository := adapters.NewPostgresUserRepository(db, nil)
+
+	ownerUserID := uuid.New()
+	mock.ExpectQuery("SELECT pu\\.id, pu\\.name").
+		WithArgs(ownerUserID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "gender", "date_of_birth", "location_latitude", "location_longitude", "age", "super_liked_me", "boosted", "shared_interests", "last_active_at", "swipes_given", "likes_given", "swipes_received", "likes_received", "desirability", "recommendation_rank"}).
+			AddRow(uuid.New(), "Jane", "female", time.Now(), 51.5, -0.1, 30, false, false, 0, nil, 0, 0, 0, 0, nil, 0).
+			AddRow(uuid.New(), "Joan", "female", time.Now(), 51.5, -0.1, 30, false, false, 0, nil, 0, 0, 0, 0, nil, 0).
+			RowError(1, errors.New("connection reset"))).
+		RowsWillBeClosed()
+
+	returnedUsers, err := repository.DiscoverNewUsers(context.Background(), ownerUserID, entities.PageInfo{})
+	g.Expect(err).To(MatchError("connection reset"))
+	g.Expect(returnedUsers).To(BeNil())
+	g.Expect(mock.ExpectationsWereMet()).To(Succeed())
+}
+
+func TestPostgresUserRepository_LoginUser_Banned(t *testing.T) {
+	g := NewWithT(t)
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	repository := adapters.NewPostgresUserRepository(db, nil)
+
+	user := &entities.User{
+		ID:          uuid.New(),
+		Name:        gofakeit.Name(),
+		Gender:      gofakeit.Gender(),
+		DateOfBirth: gofakeit.Date(),
+		Location: entities.Location{
+			Latitude:  gofakeit.Address().Latitude,
+			Longitude: gofakeit.Address().Longitude,
+		},
+	}
+
+	mock.ExpectQuery(`SELECT pu\.id, pu\.email, pu\.password, pu\.name, pu\.gender, pu\.date_of_birth, pu\.location_latitude, pu\.location_longitude, pu\.timezone, \(SELECT s\.sanction_type FROM user_sanction s .*\) AS active_sanction FROM platform_user pu WHERE pu\.email = \$1 AND pu\.password = \$2 LIMIT 1;`).WithArgs(user.Email, user.Password).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "email", "password", "name", "gender", "date_of_birth", "location_latitude", "location_longitude", "timezone", "active_sanction"}).
+			AddRow(user.ID, user.Email, user.Password, user.Name, user.Gender, user.DateOfBirth, user.Location.Latitude, user.Location.Longitude, "UTC", "ban"))
+
+	_, err = repository.LoginUser(context.Background(), user.Email, user.Password)
+	g.Expect(err).To(MatchError(entities.ErrUserBanned))
+}
@@ -0,0 +1,143 @@
+package adapters
+
+import (
+	"database/sql"
+	"github.com/AlecSmith96/dating-api/internal/entities"
+	"github.com/AlecSmith96/dating-api/internal/usecases"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const metricsNamespace = "dating_api"
+
+// unmatchedRoute is the route label for requests that don't match a route, so that unknown paths don't each get a series
+const unmatchedRoute = "unmatched"
+
+// PrometheusMetrics records the services metrics in its own registry, which is served by Handler
+type PrometheusMetrics struct {
+	registry              *prometheus.Registry
+	requestDuration       *prometheus.HistogramVec
+	queryDuration         *prometheus.HistogramVec
+	logins                *prometheus.CounterVec
+	swipes                *prometheus.CounterVec
+	matches               prometheus.Counter
+	jwtValidationFailures *prometheus.CounterVec
+}
+
+var _ usecases.MetricsRecorder = &PrometheusMetrics{}
+
+func NewPrometheusMetrics() *PrometheusMetrics {
+	m := &PrometheusMetrics{
+		registry: prometheus.NewRegistry(),
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "How long requests took, by route template, method and status.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"route", "method", "status"}),
+		queryDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "db_query_duration_seconds",
+			Help:      "How long database queries took, by the adapter method that ran them.",
+			Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5},
+		}, []string{"query", "outcome"}),
+		logins: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "logins_total",
+			Help:      "Login attempts, by whether they succeeded.",
+		}, []string{"result"}),
+		swipes: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "swipes_total",
+			Help:      "Swipes registered, by swipe type.",
+		}, []string{"type"}),
+		matches: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "matches_total",
+			Help:      "Matches created.",
+		}),
+		jwtValidationFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "jwt_validation_failures_total",
+			Help:      "Requests rejected by jwt validation, by reason.",
+		}, []string{"reason"}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.requestDuration,
+		m.queryDuration,
+		m.logins,
+		m.swipes,
+		m.matches,
+		m.jwtValidationFailures,
+	)
+
+	return m
+}
+
+// RegisterDB adds the connection pool stats of the database to the metrics
+func (m *PrometheusMetrics) RegisterDB(db *sql.DB, name string) error {
+	return m.registry.Register(collectors.NewDBStatsCollector(db, name))
+}
+
+// Handler serves the metrics in the Prometheus exposition format
+func (m *PrometheusMetrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+// Gatherer returns the registry the metrics are recorded in
+func (m *PrometheusMetrics) Gatherer() prometheus.Gatherer {
+	return m.registry
+}
+
+func (m *PrometheusMetrics) ObserveRequest(route, method string, status int, duration time.Duration) {
+	if route == "" {
+		route = unmatchedRoute
+	}
+
+	// the method of an unmatched request is whatever the client sent
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete, http.MethodOptions:
+	default:
+		method = "other"
+	}
+
+	m.requestDuration.WithLabelValues(route, method, strconv.Itoa(status)).Observe(duration.Seconds())
+}
+
+// ObserveQuery records how long a query took, the query label is the adapter method so it has a fixed set of values
+func (m *PrometheusMetrics) ObserveQuery(query string, duration time.Duration, err error) {
+	outcome := "success"
+	if err != nil {
+		outcome = "error"
+	}
+
+	m.queryDuration.WithLabelValues(query, outcome).Observe(duration.Seconds())
+}
+
+func (m *PrometheusMetrics) RecordLogin(success bool) {
+	result := "success"
+	if !success {
+		result = "failure"
+	}
+
+	m.logins.WithLabelValues(result).Inc()
+}
+
+func (m *PrometheusMetrics) RecordSwipe(swipeType entities.SwipeType) {
+	m.swipes.WithLabelValues(string(swipeType)).Inc()
+}
+
+func (m *PrometheusMetrics) RecordMatch() {
+	m.matches.Inc()
+}
+
+func (m *PrometheusMetrics) RecordJwtValidationFailure(reason string) {
+	m.jwtValidationFailures.WithLabelValues(reason).Inc()
+}
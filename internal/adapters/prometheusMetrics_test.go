@@ -0,0 +1,110 @@
+package adapters_test
+
+import (
+	"context"
+	"errors"
+	"github.com/AlecSmith96/dating-api/internal/adapters"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPrometheusMetrics_ObserveRequest(t *testing.T) {
+	g := NewWithT(t)
+	metrics := adapters.NewPrometheusMetrics()
+
+	metrics.ObserveRequest("/dating-api/v1/admin/questions/:id", http.MethodGet, http.StatusOK, 10*time.Millisecond)
+	metrics.ObserveRequest("", "BREW", http.StatusNotFound, time.Millisecond)
+
+	g.Expect(gatheredLabels(g, metrics, "dating_api_http_request_duration_seconds")).To(ConsistOf(
+		map[string]string{"route": "/dating-api/v1/admin/questions/:id", "method": "GET", "status": "200"},
+		map[string]string{"route": "unmatched", "method": "other", "status": "404"},
+	))
+}
+
+func TestPrometheusMetrics_ObserveQuery(t *testing.T) {
+	g := NewWithT(t)
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	metrics := adapters.NewPrometheusMetrics()
+	adapter := adapters.NewPostgresAdapter(db, 0, "something-secret", metrics)
+
+	mock.ExpectQuery(`FROM goose_db_version`).
+		WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(20261019233000))
+
+	_, err = adapter.GetMigrationVersion(context.Background())
+	g.Expect(err).ToNot(HaveOccurred())
+
+	g.Expect(gatheredLabels(g, metrics, "dating_api_db_query_duration_seconds")).To(ConsistOf(
+		map[string]string{"query": "GetMigrationVersion", "outcome": "success"},
+	))
+}
+
+func TestPrometheusMetrics_ObserveQuery_RowsErr(t *testing.T) {
+	g := NewWithT(t)
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	metrics := adapters.NewPrometheusMetrics()
+	adapter := adapters.NewPostgresAdapter(db, 0, "something-secret", metrics)
+
+	// the error only comes up once the rows are being read, after the query has returned
+	userID := uuid.New()
+	mock.ExpectQuery(`FROM event_log`).WithArgs(userID, int64(0)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "actor_user_id", "event_type", "payload", "created_at"}).
+			AddRow(int64(1), userID, nil, "match", []byte("{}"), time.Now()).
+			RowError(0, errors.New("connection reset")))
+
+	_, err = adapter.GetEventsSince(context.Background(), userID, 0)
+	g.Expect(err).To(HaveOccurred())
+
+	g.Expect(gatheredLabels(g, metrics, "dating_api_db_query_duration_seconds")).To(ConsistOf(
+		map[string]string{"query": "GetEventsSince", "outcome": "error"},
+	))
+}
+
+func TestPrometheusMetrics_RecordLogin(t *testing.T) {
+	g := NewWithT(t)
+	metrics := adapters.NewPrometheusMetrics()
+
+	metrics.RecordLogin(true)
+	metrics.RecordLogin(false)
+	metrics.RecordLogin(false)
+
+	err := testutil.GatherAndCompare(metrics.Gatherer(), strings.NewReader(`
+# HELP dating_api_logins_total Login attempts, by whether they succeeded.
+# TYPE dating_api_logins_total counter
+dating_api_logins_total{result="failure"} 2
+dating_api_logins_total{result="success"} 1
+`), "dating_api_logins_total")
+	g.Expect(err).ToNot(HaveOccurred())
+}
+
+// gatheredLabels is a function that returns the labels of each series of the metric
+func gatheredLabels(g *WithT, metrics *adapters.PrometheusMetrics, name string) []map[string]string {
+	families, err := metrics.Gatherer().Gather()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	var series []map[string]string
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+
+		for _, metric := range family.GetMetric() {
+			labels := map[string]string{}
+			for _, label := range metric.GetLabel() {
+				labels[label.GetName()] = label.GetValue()
+			}
+			series = append(series, labels)
+		}
+	}
+
+	return series
+}
@@ -0,0 +1,39 @@
+package adapters
+
+import "github.com/AlecSmith96/dating-api/internal/usecases"
+
+const (
+	StoragePostgres = "postgres"
+	StorageMemory   = "memory"
+)
+
+// Storage is every usecase interface that is backed by storage, it is implemented by the postgres adapter and by the
+// in-memory adapter so that either can be passed for all of them
+type Storage interface {
+	usecases.UserCreator
+	usecases.UserAuthenticator
+	usecases.JwtProcessor
+	usecases.UserDiscoverer
+	usecases.SwipeRegister
+	usecases.SwipeRewinder
+	usecases.LikesReceivedLister
+	usecases.Entitlements
+	usecases.SubscriptionManager
+	usecases.BoostManager
+	usecases.DesirabilityUpdater
+	usecases.RecommendationStore
+	usecases.DailyPicksGenerator
+	usecases.DailyPicksLister
+	usecases.DiscoveryPreferencesSaver
+	usecases.QuestionBank
+	usecases.QuestionAnswerer
+	usecases.EventStreamer
+	usecases.EventRecorder
+	usecases.EventPruner
+	usecases.UserBlocker
+	usecases.UserReporter
+	usecases.RoleChecker
+	usecases.ModerationQueue
+	usecases.ReadinessChecker
+	usecases.TxManager
+}
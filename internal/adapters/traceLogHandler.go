@@ -0,0 +1,36 @@
+package adapters
+
+import (
+	"context"
+	"go.opentelemetry.io/otel/trace"
+	"log/slog"
+)
+
+// TraceLogHandler adds the trace and span ids of the context to each log, so that logs can be found from a trace
+type TraceLogHandler struct {
+	slog.Handler
+}
+
+func NewTraceLogHandler(handler slog.Handler) *TraceLogHandler {
+	return &TraceLogHandler{Handler: handler}
+}
+
+func (h *TraceLogHandler) Handle(ctx context.Context, record slog.Record) error {
+	spanContext := trace.SpanContextFromContext(ctx)
+	if spanContext.IsValid() {
+		record.AddAttrs(
+			slog.String("trace_id", spanContext.TraceID().String()),
+			slog.String("span_id", spanContext.SpanID().String()),
+		)
+	}
+
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h *TraceLogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &TraceLogHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+func (h *TraceLogHandler) WithGroup(name string) slog.Handler {
+	return &TraceLogHandler{Handler: h.Handler.WithGroup(name)}
+}
@@ -0,0 +1,34 @@
+package adapters_test
+
+import (
+	"bytes"
+	"context"
+	"github.com/AlecSmith96/dating-api/internal/adapters"
+	. "github.com/onsi/gomega"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"log/slog"
+	"testing"
+)
+
+func TestTraceLogHandler(t *testing.T) {
+	g := NewWithT(t)
+	var buf bytes.Buffer
+	logger := slog.New(adapters.NewTraceLogHandler(slog.NewTextHandler(&buf, nil)))
+
+	ctx, span := sdktrace.NewTracerProvider().Tracer("test").Start(context.Background(), "request")
+	logger.InfoContext(ctx, "handling request")
+	span.End()
+
+	g.Expect(buf.String()).To(ContainSubstring("trace_id=" + span.SpanContext().TraceID().String()))
+	g.Expect(buf.String()).To(ContainSubstring("span_id=" + span.SpanContext().SpanID().String()))
+}
+
+func TestTraceLogHandler_WithoutSpan(t *testing.T) {
+	g := NewWithT(t)
+	var buf bytes.Buffer
+	logger := slog.New(adapters.NewTraceLogHandler(slog.NewTextHandler(&buf, nil)))
+
+	logger.InfoContext(context.Background(), "starting up")
+
+	g.Expect(buf.String()).ToNot(ContainSubstring("trace_id"))
+}
@@ -0,0 +1,59 @@
+package adapters
+
+import (
+	"context"
+	"fmt"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"io"
+	"os"
+)
+
+const serviceName = "dating-api"
+
+// NewTracerProvider sets up tracing for the service, making the provider and the W3C trace context propagator global so
+// that the instrumentation picks them up. Spans are exported over OTLP to the endpoint when one is given, otherwise
+// they are written to the trace file, or stdout when there isn't one. Shutting the provider down flushes any spans
+// that haven't been exported yet.
+func NewTracerProvider(ctx context.Context, otlpEndpoint, traceFile string) (*sdktrace.TracerProvider, error) {
+	exporter, err := newSpanExporter(ctx, otlpEndpoint, traceFile)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(serviceName)))
+	if err != nil {
+		return nil, err
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	return provider, nil
+}
+
+func newSpanExporter(ctx context.Context, otlpEndpoint, traceFile string) (sdktrace.SpanExporter, error) {
+	if otlpEndpoint != "" {
+		return otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(otlpEndpoint))
+	}
+
+	var w io.Writer = os.Stdout
+	if traceFile != "" {
+		f, err := os.OpenFile(traceFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return nil, fmt.Errorf("opening trace file: %w", err)
+		}
+		w = f
+	}
+
+	return stdouttrace.New(stdouttrace.WithWriter(w))
+}
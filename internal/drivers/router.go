@@ -1,69 +1,363 @@
 package drivers
 
 import (
+	"context"
 	"errors"
 	"github.com/AlecSmith96/dating-api/docs"
 	"github.com/AlecSmith96/dating-api/internal/entities"
 	"github.com/AlecSmith96/dating-api/internal/usecases"
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
 	"github.com/swaggo/files"
 	"github.com/swaggo/gin-swagger"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"log/slog"
 	"net/http"
+	"reflect"
+	"regexp"
+	"runtime"
+	"slices"
 	"strings"
+	"time"
 )
 
+const (
+	serviceName     = "dating-api"
+	requestIDHeader = "X-Request-ID"
+)
+
+// requestIDPattern is the request ids accepted from clients, anything else is replaced so that clients can't write
+// arbitrary text into the logs
+var requestIDPattern = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+var tracer = otel.Tracer("github.com/AlecSmith96/dating-api/internal/drivers")
+
 // TokenAuthMiddleware is a custom middleware function that processes the provided JWT in the Authorization header of
 // the request. If the JWT is valid, it sets the userID value in the requests context and parses it to the usecase.
-func TokenAuthMiddleware(jwtProcessor usecases.JwtProcessor) gin.HandlerFunc {
+func TokenAuthMiddleware(jwtProcessor usecases.JwtProcessor, metricsRecorder usecases.MetricsRecorder) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		ctx := c.Request.Context()
 		authHeaderValue := c.GetHeader("Authorization")
 		jwt := strings.Split(authHeaderValue, " ")
 
 		if len(jwt) != 2 || (jwt[0] != "Bearer" && jwt[0] != "bearer") {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, entities.ErrorMessage{Message: "invalid jwt"})
+			metricsRecorder.RecordJwtValidationFailure(usecases.JwtFailureMalformed)
+			abortWithError(c, entities.NewError(http.StatusUnauthorized, entities.CodeJwtInvalid, "invalid jwt"))
 			return
 		}
 
 		if jwt[1] == "" {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, entities.ErrorMessage{Message: "jwt is missing"})
+			metricsRecorder.RecordJwtValidationFailure(usecases.JwtFailureMissing)
+			abortWithError(c, entities.NewError(http.StatusUnauthorized, entities.CodeJwtMissing, "jwt is missing"))
 			return
 		}
 
-		userID, err := jwtProcessor.ValidateJwtForUser(jwt[1])
+		userID, err := jwtProcessor.ValidateJwtForUser(ctx, jwt[1])
 		if err != nil {
+			if _, ok := usecases.CancellationStatus(ctx, err); ok {
+				abortWithError(c, err)
+				return
+			}
+			if errors.Is(err, entities.ErrUserBanned) {
+				metricsRecorder.RecordJwtValidationFailure(usecases.JwtFailureBanned)
+				abortWithError(c, entities.NewError(http.StatusForbidden, entities.CodeAccountBanned, "account is banned"))
+				return
+			}
+			if errors.Is(err, entities.ErrUserSuspended) {
+				metricsRecorder.RecordJwtValidationFailure(usecases.JwtFailureSuspended)
+				abortWithError(c, entities.NewError(http.StatusForbidden, entities.CodeAccountSuspended, "account is suspended"))
+				return
+			}
 			if errors.Is(err, entities.ErrJwtExpired) {
-				c.AbortWithStatusJSON(http.StatusUnauthorized, entities.ErrorMessage{Message: "jwt is expired"})
+				metricsRecorder.RecordJwtValidationFailure(usecases.JwtFailureExpired)
+				abortWithError(c, entities.NewError(http.StatusUnauthorized, entities.CodeJwtExpired, "jwt is expired"))
+				return
+			}
+			if errors.Is(err, entities.ErrJwtRevoked) {
+				metricsRecorder.RecordJwtValidationFailure(usecases.JwtFailureRevoked)
+				abortWithError(c, entities.NewError(http.StatusUnauthorized, entities.CodeJwtRevoked, "jwt has been revoked"))
 				return
 			}
-			c.AbortWithStatusJSON(http.StatusUnauthorized, entities.ErrorMessage{Message: "invalid jwt"})
+			metricsRecorder.RecordJwtValidationFailure(usecases.JwtFailureInvalid)
+			abortWithError(c, entities.NewError(http.StatusUnauthorized, entities.CodeJwtInvalid, "invalid jwt"))
 			return
 		}
 
+		// the rest of the requests logs are for this user
+		ctx = usecases.WithLogger(ctx, usecases.Logger(ctx).With("user_id", userID))
+		c.Request = c.Request.WithContext(ctx)
+
 		c.Set("userID", userID)
 		c.Next()
 	}
 }
 
-func NewRouter(
-	userCreator usecases.UserCreator,
-	userAuthenticator usecases.UserAuthenticator,
-	jwtProcessor usecases.JwtProcessor,
-	userDiscoverer usecases.UserDiscoverer,
-	swipeRegister usecases.SwipeRegister,
-) *gin.Engine {
-	r := gin.Default()
+// RequireRole is a custom middleware function that only allows users with one of the provided roles through. It must
+// run after TokenAuthMiddleware, and sets the userRole value in the requests context.
+func RequireRole(roleChecker usecases.RoleChecker, roles ...entities.Role) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		userID, ok := c.Get("userID")
+		if !ok {
+			abortWithError(c, entities.NewError(http.StatusUnauthorized, entities.CodeJwtInvalid, "invalid jwt"))
+			return
+		}
+
+		role, err := roleChecker.GetUserRole(ctx, userID.(uuid.UUID))
+		if err != nil {
+			usecases.Logger(ctx).ErrorContext(ctx, "getting user role", "err", err)
+			abortWithError(c, err)
+			return
+		}
+
+		if !slices.Contains(roles, role) {
+			abortWithError(c, entities.NewError(http.StatusForbidden, entities.CodeInsufficientPermissions, "insufficient permissions"))
+			return
+		}
+
+		c.Set("userRole", role)
+		c.Next()
+	}
+}
+
+// abortWithError is a function that stops the request, leaving err for ErrorMiddleware to respond with
+func abortWithError(c *gin.Context, err error) {
+	c.Abort()
+	c.Error(err)
+}
+
+// ErrorMiddleware is a custom middleware function that writes the response for requests that failed. Usecases and
+// middleware add their error to the context rather than writing it themselves, so that every error is returned as
+// application/problem+json and nothing about unexpected errors reaches the client.
+func ErrorMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+
+		usecases.WriteProblem(c, c.Errors.Last().Err)
+	}
+}
+
+// registerValidations is a function that adds the validation rules the requests use to the validator, and has it name
+// fields as clients send them so that validation errors refer to pageInfo.minAge rather than PageInfo.MinAge
+func registerValidations() {
+	if validate, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		validate.RegisterTagNameFunc(usecases.FieldName)
+		_ = validate.RegisterValidation("gender", usecases.ValidateGender)
+	}
+}
+
+// traced is a function that wraps a usecase so that each call to it gets its own span, named after the usecase
+func traced(usecase gin.HandlerFunc) gin.HandlerFunc {
+	name := usecaseName(usecase)
+	return func(c *gin.Context) {
+		ctx, span := tracer.Start(c.Request.Context(), name)
+		defer span.End()
+
+		c.Request = c.Request.WithContext(ctx)
+		usecase(c)
+
+		// failed requests are written by ErrorMiddleware once the span has ended, so their status comes from the error
+		status := c.Writer.Status()
+		if err := c.Errors.Last(); err != nil && !c.Writer.Written() {
+			status = usecases.ToError(ctx, err.Err).Status
+		}
+
+		span.SetAttributes(semconv.HTTPResponseStatusCode(status))
+		if status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(status))
+		}
+	}
+}
+
+// usecaseName is a function that returns the name of the usecase that created the handler, such as SwipeUser for the
+// handler returned by usecases.NewSwipeUser
+func usecaseName(usecase gin.HandlerFunc) string {
+	name := runtime.FuncForPC(reflect.ValueOf(usecase).Pointer()).Name()
+	name = name[strings.LastIndex(name, "/")+1:]
+	parts := strings.Split(name, ".")
+	if len(parts) < 2 {
+		return name
+	}
+
+	return strings.TrimPrefix(parts[1], "New")
+}
+
+// isTracedRequest is a function that filters the probes and metrics scrapes out of the traces, as they are frequent
+// and uninteresting
+func isTracedRequest(r *http.Request) bool {
+	switch r.URL.Path {
+	case "/healthz", "/readyz", "/metrics":
+		return false
+	default:
+		return true
+	}
+}
+
+// RequestLoggingMiddleware is a custom middleware function that gives each request a logger carrying its request id
+// and route, then logs the outcome of the request. The request id is taken from the X-Request-ID header so that it
+// can be followed across services, or generated when the header is missing or malformed, and is returned in the
+// response.
+func RequestLoggingMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		requestID := c.GetHeader(requestIDHeader)
+		if !requestIDPattern.MatchString(requestID) {
+			requestID = uuid.NewString()
+		}
+		c.Header(requestIDHeader, requestID)
+
+		logger := slog.Default().With("request_id", requestID, "method", c.Request.Method, "route", c.FullPath())
+		c.Request = c.Request.WithContext(usecases.WithLogger(c.Request.Context(), logger))
+		c.Next()
+
+		// later middleware add to the logger, such as the user id once the request is authenticated
+		ctx := c.Request.Context()
+		usecases.Logger(ctx).InfoContext(ctx, "handled request", "status", c.Writer.Status(), "duration", time.Since(start))
+	}
+}
+
+// MetricsMiddleware is a custom middleware function that records how long each request took. Requests are labelled with
+// the route template rather than the path, so that path parameters don't each get their own series.
+func MetricsMiddleware(metricsRecorder usecases.MetricsRecorder) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		metricsRecorder.ObserveRequest(c.FullPath(), c.Request.Method, c.Writer.Status(), time.Since(start))
+	}
+}
+
+// QueryTimeoutMiddleware is a custom middleware function that gives the request a deadline, so the queries it runs are
+// cancelled once the timeout has passed
+func QueryTimeoutMiddleware(timeout time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+		defer cancel()
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
+
+// RouterDeps are what the handlers of the router are built from. In production most of them are the one storage
+// adapter, but each is its own field so that tests can swap out the parts they exercise.
+type RouterDeps struct {
+	UserCreator       usecases.UserCreator
+	UserAuthenticator usecases.UserAuthenticator
+	JwtProcessor      usecases.JwtProcessor
+	UserDiscoverer    usecases.UserDiscoverer
+	SwipeRegister     usecases.SwipeRegister
+	TxManager         usecases.TxManager
+	EventStreamer     usecases.EventStreamer
+	EventRecorder     usecases.EventRecorder
+	UserBlocker       usecases.UserBlocker
+	UserReporter      usecases.UserReporter
+	RoleChecker       usecases.RoleChecker
+	ModerationQueue   usecases.ModerationQueue
+	SwipeRewinder     usecases.SwipeRewinder
+	// SwipeRewindWindow is how long after a swipe it can still be rewound
+	SwipeRewindWindow   time.Duration
+	LikesReceivedLister usecases.LikesReceivedLister
+	Entitlements        usecases.Entitlements
+	SubscriptionManager usecases.SubscriptionManager
+	BillingProviders    []usecases.BillingProvider
+	BoostManager        usecases.BoostManager
+	// BoostDuration is how long a boost lasts for
+	BoostDuration             time.Duration
+	Ranker                    usecases.Ranker
+	DailyPicksLister          usecases.DailyPicksLister
+	DiscoveryPreferencesSaver usecases.DiscoveryPreferencesSaver
+	QuestionBank              usecases.QuestionBank
+	QuestionAnswerer          usecases.QuestionAnswerer
+	// QueryTimeout is the deadline given to each request, and to each query of the event stream
+	QueryTimeout time.Duration
+	// Shutdown is closed to end the open event streams
+	Shutdown         <-chan struct{}
+	ReadinessChecker usecases.ReadinessChecker
+	// MigrationVersion is the migration the database must be at for the service to be ready
+	MigrationVersion int64
+	// Draining is closed once the server starts shutting down, so that it reports it is no longer ready
+	Draining        <-chan struct{}
+	MetricsRecorder usecases.MetricsRecorder
+	MetricsHandler  http.Handler
+}
+
+// NewRouter builds the routes of the api from its dependencies
+func NewRouter(deps RouterDeps) *gin.Engine {
+	r := gin.New()
+	r.Use(
+		gin.Recovery(),
+		otelgin.Middleware(serviceName, otelgin.WithFilter(isTracedRequest)),
+		RequestLoggingMiddleware(),
+		MetricsMiddleware(deps.MetricsRecorder),
+		ErrorMiddleware(),
+	)
+	r.NoRoute(func(c *gin.Context) {
+		c.Error(entities.NewError(http.StatusNotFound, entities.CodeNotFound, "route not found"))
+	})
+	registerValidations()
+
+	// the probes sit outside the api so that they are at the paths orchestrators expect
+	r.GET("/healthz", usecases.NewHealthz())
+	r.GET("/readyz", usecases.NewReadyz(deps.ReadinessChecker, deps.MigrationVersion, deps.Draining))
+	r.GET("/metrics", gin.WrapH(deps.MetricsHandler))
 
 	docs.SwaggerInfo.BasePath = "/dating-api/v1"
 	v1 := r.Group("/dating-api/v1")
 	{
 		v1.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
-		v1.POST("/login", usecases.NewLoginUser(userAuthenticator))
+		// the event stream stays open, so it sets a deadline on each of its queries instead of the whole request
+		v1.GET("/user/events", TokenAuthMiddleware(deps.JwtProcessor, deps.MetricsRecorder), traced(usecases.NewStreamEvents(deps.EventStreamer, deps.QueryTimeout, deps.Shutdown)))
+
+		// errors are also written inside the timeout, as once it is cancelled every failure would look like the client
+		// cancelling the request
+		timed := v1.Group("", QueryTimeoutMiddleware(deps.QueryTimeout), ErrorMiddleware())
+		timed.POST("/login", traced(usecases.NewLoginUser(deps.UserAuthenticator, deps.MetricsRecorder)))
+		timed.POST("/billing/webhooks/:provider", traced(usecases.NewBillingWebhook(deps.SubscriptionManager, deps.BillingProviders...)))
+
+		protected := timed.Group("/user", TokenAuthMiddleware(deps.JwtProcessor, deps.MetricsRecorder))
+		{
+			protected.POST("/create", traced(usecases.NewCreateUser(deps.UserCreator)))
+			protected.GET("/discover", traced(usecases.NewDiscoverPotentialMatches(deps.UserDiscoverer, deps.Ranker, deps.RoleChecker)))
+			protected.PUT("/preferences", traced(usecases.NewSaveDiscoveryPreferences(deps.DiscoveryPreferencesSaver)))
+			protected.GET("/picks/today", traced(usecases.NewGetTodaysPicks(deps.DailyPicksLister)))
+			protected.GET("/questions", traced(usecases.NewGetUserQuestions(deps.QuestionAnswerer)))
+			protected.PUT("/questions/:id/answer", traced(usecases.NewAnswerQuestion(deps.QuestionAnswerer)))
+			protected.POST("/swipe", traced(usecases.NewSwipeUser(deps.SwipeRegister, deps.TxManager, deps.EventRecorder, deps.MetricsRecorder)))
+			protected.POST("/swipe/rewind", traced(usecases.NewRewindSwipe(deps.Entitlements, deps.SwipeRewinder, deps.SwipeRewindWindow)))
+			protected.GET("/likes/received", traced(usecases.NewGetLikesReceived(deps.Entitlements, deps.LikesReceivedLister)))
+			protected.GET("/entitlements", traced(usecases.NewGetEntitlements(deps.Entitlements)))
+			protected.POST("/boost", traced(usecases.NewBoostProfile(deps.Entitlements, deps.BoostManager, deps.BoostDuration)))
+			protected.GET("/boosts", traced(usecases.NewGetBoostReports(deps.BoostManager)))
+			protected.POST("/block/:id", traced(usecases.NewBlockUser(deps.UserBlocker)))
+			protected.POST("/report/:id", traced(usecases.NewReportUser(deps.UserReporter)))
+		}
+
+		admin := timed.Group("/admin", TokenAuthMiddleware(deps.JwtProcessor, deps.MetricsRecorder), RequireRole(deps.RoleChecker, entities.RoleModerator, entities.RoleAdmin))
+		{
+			admin.GET("/moderation/cases", traced(usecases.NewGetModerationCases(deps.ModerationQueue)))
+			admin.GET("/moderation/cases/:id", traced(usecases.NewGetModerationCase(deps.ModerationQueue)))
+			admin.POST("/moderation/cases/:id/assign", traced(usecases.NewAssignModerationCase(deps.ModerationQueue)))
+			admin.POST("/moderation/cases/:id/status", traced(usecases.NewUpdateModerationCaseStatus(deps.ModerationQueue)))
+			admin.POST("/moderation/cases/:id/actions", traced(usecases.NewApplyModerationAction(deps.ModerationQueue)))
+		}
 
-		protected := v1.Group("/user", TokenAuthMiddleware(jwtProcessor))
+		questions := timed.Group("/admin/questions", TokenAuthMiddleware(deps.JwtProcessor, deps.MetricsRecorder), RequireRole(deps.RoleChecker, entities.RoleAdmin))
 		{
-			protected.POST("/create", usecases.NewCreateUser(userCreator))
-			protected.GET("/discover", usecases.NewDiscoverPotentialMatches(userDiscoverer))
-			protected.POST("/swipe", usecases.NewSwipeUser(swipeRegister))
+			questions.POST("", traced(usecases.NewCreateQuestion(deps.QuestionBank)))
+			questions.GET("", traced(usecases.NewGetQuestions(deps.QuestionBank)))
+			questions.GET("/:id", traced(usecases.NewGetQuestion(deps.QuestionBank)))
+			questions.PUT("/:id", traced(usecases.NewUpdateQuestion(deps.QuestionBank)))
+			questions.DELETE("/:id", traced(usecases.NewDeleteQuestion(deps.QuestionBank)))
 		}
 	}
 
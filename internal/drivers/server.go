@@ -0,0 +1,72 @@
+package drivers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+)
+
+// ServerTimeouts are the limits on how long the server spends reading and writing each request
+type ServerTimeouts struct {
+	ReadHeader time.Duration
+	Read       time.Duration
+	Write      time.Duration
+	Idle       time.Duration
+}
+
+// Server serves the router over http, or https when it has a certificate and key
+type Server struct {
+	server   *http.Server
+	certFile string
+	keyFile  string
+}
+
+// NewServer creates a server for the router. Shutting the server down calls stopStreams so that long lived requests,
+// such as the event stream, finish rather than holding up the shutdown.
+func NewServer(address string, router http.Handler, timeouts ServerTimeouts, certFile, keyFile string, stopStreams func()) *Server {
+	server := &http.Server{
+		Addr:              address,
+		Handler:           router,
+		ReadHeaderTimeout: timeouts.ReadHeader,
+		ReadTimeout:       timeouts.Read,
+		WriteTimeout:      timeouts.Write,
+		IdleTimeout:       timeouts.Idle,
+	}
+	server.RegisterOnShutdown(stopStreams)
+
+	return &Server{
+		server:   server,
+		certFile: certFile,
+		keyFile:  keyFile,
+	}
+}
+
+// ListenAndServe serves requests until the server is shut down, it only returns an error if the server fails
+func (s *Server) ListenAndServe() error {
+	var err error
+	if s.certFile != "" && s.keyFile != "" {
+		err = s.server.ListenAndServeTLS(s.certFile, s.keyFile)
+	} else {
+		err = s.server.ListenAndServe()
+	}
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+
+	return err
+}
+
+// Shutdown stops the server accepting new connections and waits up to drain for in-flight requests to finish, any
+// requests still running after that have their connections closed
+func (s *Server) Shutdown(drain time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), drain)
+	defer cancel()
+
+	err := s.server.Shutdown(ctx)
+	if errors.Is(err, context.DeadlineExceeded) {
+		return errors.Join(err, s.server.Close())
+	}
+
+	return err
+}
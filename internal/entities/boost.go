@@ -0,0 +1,54 @@
+package entities
+
+import (
+	"github.com/google/uuid"
+	"math"
+	"time"
+)
+
+// BoostPeriod is the rolling period a tiers boost limit applies to
+const BoostPeriod = 30 * 24 * time.Hour
+
+// boostBaselinePeriod is how far before a boost its baseline views and likes are measured over
+const boostBaselinePeriod = 7 * 24 * time.Hour
+
+// Boost is a struct representing a period where a user is ranked higher in other users discovery
+type Boost struct {
+	ID       uuid.UUID
+	UserID   uuid.UUID
+	StartsAt time.Time
+	EndsAt   time.Time
+}
+
+// IsActive is a function that checks whether the boost is running at the instant
+func (b *Boost) IsActive(instant time.Time) bool {
+	return !instant.Before(b.StartsAt) && instant.Before(b.EndsAt)
+}
+
+// BoostReport is a struct representing how a boost performed
+type BoostReport struct {
+	Boost
+	// Views and Likes are the number of times the profile was shown in discovery and liked while the boost was active
+	Views int
+	Likes int
+	// WeekViews and WeekLikes are the views and likes the profile got in the week before the boost, for the baseline
+	WeekViews int
+	WeekLikes int
+}
+
+// baseline is a function that scales a count over the week before the boost to the length of the boost
+func (r *BoostReport) baseline(weekCount int) float64 {
+	return float64(weekCount) * float64(r.EndsAt.Sub(r.StartsAt)) / float64(boostBaselinePeriod)
+}
+
+// ExtraViews is a function that returns how many more views the profile got than it would be expected to get without
+// the boost
+func (r *BoostReport) ExtraViews() int {
+	return max(0, r.Views-int(math.Round(r.baseline(r.WeekViews))))
+}
+
+// ExtraLikes is a function that returns how many more likes the profile got than it would be expected to get without
+// the boost
+func (r *BoostReport) ExtraLikes() int {
+	return max(0, r.Likes-int(math.Round(r.baseline(r.WeekLikes))))
+}
@@ -0,0 +1,49 @@
+package entities_test
+
+import (
+	"github.com/AlecSmith96/dating-api/internal/entities"
+	. "github.com/onsi/gomega"
+	"testing"
+	"time"
+)
+
+func TestBoostReport_Extra(t *testing.T) {
+	startsAt := time.Date(2026, 10, 19, 18, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name               string
+		duration           time.Duration
+		views, likes       int
+		weekViews          int
+		weekLikes          int
+		expectedExtraViews int
+		expectedExtraLikes int
+	}{
+		// 3360 views and 336 likes a week is 10 views and 1 like every 30 minutes
+		{name: "above the usual rate", duration: 30 * time.Minute, views: 40, likes: 6, weekViews: 3360, weekLikes: 336, expectedExtraViews: 30, expectedExtraLikes: 5},
+		{name: "below the usual rate", duration: 30 * time.Minute, views: 5, likes: 0, weekViews: 3360, weekLikes: 336, expectedExtraViews: 0, expectedExtraLikes: 0},
+		{name: "no activity the week before", duration: 30 * time.Minute, views: 12, likes: 2, weekViews: 0, weekLikes: 0, expectedExtraViews: 12, expectedExtraLikes: 2},
+		{name: "longer boost", duration: time.Hour, views: 40, likes: 6, weekViews: 3360, weekLikes: 336, expectedExtraViews: 20, expectedExtraLikes: 4},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			g := NewWithT(t)
+			report := entities.BoostReport{
+				Boost:     entities.Boost{StartsAt: startsAt, EndsAt: startsAt.Add(testCase.duration)},
+				Views:     testCase.views,
+				Likes:     testCase.likes,
+				WeekViews: testCase.weekViews,
+				WeekLikes: testCase.weekLikes,
+			}
+			g.Expect(report.ExtraViews()).To(Equal(testCase.expectedExtraViews))
+			g.Expect(report.ExtraLikes()).To(Equal(testCase.expectedExtraLikes))
+		})
+	}
+}
+
+func TestEntitlements_BoostsRemaining(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect((&entities.Entitlements{BoostLimit: 4, BoostsUsed: 1}).BoostsRemaining()).To(Equal(3))
+	g.Expect((&entities.Entitlements{BoostLimit: 1, BoostsUsed: 2}).BoostsRemaining()).To(Equal(0))
+}
@@ -0,0 +1,37 @@
+package entities
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// DailyPicksUser is a struct representing a user that is due their daily picks for their current local day
+type DailyPicksUser struct {
+	UserID   uuid.UUID
+	Location Location
+	// Preferences are the users saved discovery filters, they are empty if the user hasn't saved any
+	Preferences PageInfo
+	// PickDate is the users current local date
+	PickDate time.Time
+	// ExpiresAt is the users next local midnight
+	ExpiresAt time.Time
+}
+
+// DailyPick is a struct representing a candidate picked for a user for one local day
+type DailyPick struct {
+	CandidateUserID uuid.UUID
+	Score           float64
+	// Rank is the candidates position in the users picks, starting at 1
+	Rank int
+}
+
+// DailyPickCandidate is a struct representing a live daily pick as it is shown to the user it was picked for
+type DailyPickCandidate struct {
+	ID        uuid.UUID
+	Name      string
+	Gender    string
+	Age       int
+	Location  Location
+	Rank      int
+	ExpiresAt time.Time
+}
@@ -0,0 +1,50 @@
+package entities
+
+import (
+	"github.com/google/uuid"
+	"math"
+)
+
+// DefaultDesirability is the desirability score users start with
+const DefaultDesirability = 1500.0
+
+const (
+	// desirabilityScale is the difference in scores at which a like from the higher scored user is ten times less
+	// expected than a like from the lower scored user
+	desirabilityScale = 400.0
+	// desirabilityMaxK and desirabilityMinK bound how far a single swipe can move a score
+	desirabilityMaxK = 40.0
+	desirabilityMinK = 10.0
+	// desirabilityKDecay is the number of swipes after which a swipe moves a score half as far as the first swipe
+	desirabilityKDecay = 30.0
+)
+
+// Desirability is a struct representing a users hidden desirability score. It is only used to rank discovery and must
+// never be returned by the API.
+type Desirability struct {
+	UserID uuid.UUID
+	Score  float64
+	// Swipes is the number of swipes on the user the score has been updated from
+	Swipes int
+}
+
+// NewDesirability is a function that returns the starting desirability for a user
+func NewDesirability(userID uuid.UUID) *Desirability {
+	return &Desirability{UserID: userID, Score: DefaultDesirability}
+}
+
+// ApplySwipe is a function that updates the score from a swipe on the user by a user with the rater score, like an Elo
+// rating. A like counts as a win against the rater and a pass as a loss, so a like from a higher scored user raises the
+// score more than a like from a lower scored one. Scores move quickly for new users and settle as swipes are applied.
+func (d *Desirability) ApplySwipe(raterScore float64, liked bool) {
+	expected := 1 / (1 + math.Pow(10, (raterScore-d.Score)/desirabilityScale))
+
+	outcome := 0.0
+	if liked {
+		outcome = 1
+	}
+
+	k := max(desirabilityMinK, desirabilityMaxK/(1+float64(d.Swipes)/desirabilityKDecay))
+	d.Score += k * (outcome - expected)
+	d.Swipes++
+}
@@ -0,0 +1,43 @@
+package entities_test
+
+import (
+	"github.com/AlecSmith96/dating-api/internal/entities"
+	"github.com/google/uuid"
+	. "github.com/onsi/gomega"
+	"testing"
+)
+
+func TestDesirability_ApplySwipe(t *testing.T) {
+	testCases := []struct {
+		name          string
+		score         float64
+		swipes        int
+		raterScore    float64
+		liked         bool
+		expectedScore float64
+	}{
+		{name: "like from an equal user", score: 1500, raterScore: 1500, liked: true, expectedScore: 1520},
+		{name: "pass from an equal user", score: 1500, raterScore: 1500, liked: false, expectedScore: 1480},
+		{name: "like from a higher scored user", score: 1500, raterScore: 1900, liked: true, expectedScore: 1536.3636},
+		{name: "like from a lower scored user", score: 1500, raterScore: 1100, liked: true, expectedScore: 1503.6364},
+		{name: "pass from a lower scored user", score: 1500, raterScore: 1100, liked: false, expectedScore: 1463.6364},
+		{name: "like once the score has settled", score: 1500, swipes: 30, raterScore: 1500, liked: true, expectedScore: 1510},
+		{name: "like after many swipes", score: 1500, swipes: 1000, raterScore: 1500, liked: true, expectedScore: 1505},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			g := NewWithT(t)
+			desirability := &entities.Desirability{UserID: uuid.New(), Score: testCase.score, Swipes: testCase.swipes}
+			desirability.ApplySwipe(testCase.raterScore, testCase.liked)
+			g.Expect(desirability.Score).To(BeNumerically("~", testCase.expectedScore, 0.001))
+			g.Expect(desirability.Swipes).To(Equal(testCase.swipes + 1))
+		})
+	}
+}
+
+func TestNewDesirability(t *testing.T) {
+	g := NewWithT(t)
+	userID := uuid.New()
+	g.Expect(entities.NewDesirability(userID)).To(Equal(&entities.Desirability{UserID: userID, Score: entities.DefaultDesirability}))
+}
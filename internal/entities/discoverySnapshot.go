@@ -0,0 +1,16 @@
+package entities
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// DiscoverySnapshot is a struct representing the ranked list of users that the pages of a discover request are taken
+// from, so that swiping between pages doesn't move the users that haven't been shown yet
+type DiscoverySnapshot struct {
+	ID     uuid.UUID
+	UserID uuid.UUID
+	// RankedUserIDs are the users that were discovered, in the order they were ranked
+	RankedUserIDs []uuid.UUID
+	CreatedAt     time.Time
+}
@@ -0,0 +1,57 @@
+package entities
+
+import (
+	"time"
+)
+
+// Tier is the plan a user is on, which decides the features and limits they have
+type Tier string
+
+const (
+	TierFree    Tier = "free"
+	TierPlus    Tier = "plus"
+	TierPremium Tier = "premium"
+)
+
+// Entitlements is a struct representing what a user can do on their current tier, taking their subscriptions into
+// account
+type Entitlements struct {
+	Tier Tier
+	// Subscription is the users latest subscription, it is nil if they have never subscribed
+	Subscription *Subscription
+	CanRewind    bool
+	CanSeeLikes  bool
+	// LikeQuota and SuperLikeQuota are the users current swipe quotas
+	LikeQuota      SwipeQuota
+	SuperLikeQuota SwipeQuota
+	// BoostLimit is the number of boosts the user can start in any BoostPeriod, BoostsUsed is how many they have
+	BoostLimit int
+	BoostsUsed int
+}
+
+// BoostsRemaining is a function that returns the number of boosts the user can start now
+func (e *Entitlements) BoostsRemaining() int {
+	return max(0, e.BoostLimit-e.BoostsUsed)
+}
+
+// SwipeQuota is a users allowance of a type of swipe for the current period, likes are counted per local day and
+// super likes per local week
+type SwipeQuota struct {
+	Tier      Tier
+	SwipeType SwipeType
+	// Unlimited is true when the users tier has no daily limit, Limit is ignored when it is set
+	Unlimited bool
+	Limit     int
+	Used      int
+	// ResetsAt is the users next local midnight, when their quota resets
+	ResetsAt time.Time
+}
+
+// Remaining is a function that returns the number of positive swipes left in the quota
+func (q *SwipeQuota) Remaining() int {
+	if q.Used >= q.Limit {
+		return 0
+	}
+
+	return q.Limit - q.Used
+}
@@ -1,12 +1,107 @@
 package entities
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 var (
-	ErrUserNotFound = errors.New("user not found for parsed details")
-	ErrJwtExpired   = errors.New("jwt is expired")
+	ErrUserNotFound       = errors.New("user not found for parsed details")
+	ErrJwtExpired         = errors.New("jwt is expired")
+	ErrTargetUserNotFound = errors.New("target user not found")
+	ErrUserBlocked        = errors.New("user is blocked")
+	ErrJwtRevoked         = errors.New("jwt has been revoked")
+	ErrUserSuspended      = errors.New("user is suspended")
+	ErrUserBanned         = errors.New("user is banned")
+	ErrCaseNotFound       = errors.New("moderation case not found")
+	ErrInvalidTransition  = errors.New("moderation case can not be moved to that status")
+	ErrNotAModerator      = errors.New("user is not a moderator")
+	ErrUserUnderage       = errors.New("user is under the minimum age")
+	ErrInvalidTimezone    = errors.New("invalid timezone")
+	ErrSwipeQuotaExceeded = errors.New("daily swipe quota exceeded")
+	ErrNoSwipeToRewind    = errors.New("no swipe to rewind")
+	ErrSwipeMatched       = errors.New("swipe has resulted in a match")
+	ErrInvalidSignature   = errors.New("webhook signature is invalid")
+	ErrBadBillingEvent    = errors.New("billing event is invalid")
+	ErrDuplicateEvent     = errors.New("billing event has already been applied")
+	ErrUnknownTier        = errors.New("unknown tier")
+	ErrStatusNotAllowed   = errors.New("subscription can not be moved to that status")
+	ErrNoBoostsLeft       = errors.New("no boosts left in the users tier")
+	ErrBoostActive        = errors.New("user already has an active boost")
+	ErrUnknownRanker      = errors.New("unknown discovery ranker")
+	ErrQuestionNotFound   = errors.New("question not found")
+	ErrQuestionAnswered   = errors.New("question has already been answered")
+	ErrInvalidAnswer      = errors.New("answer is not one of the questions answers")
+	ErrSnapshotNotFound   = errors.New("discovery snapshot not found")
+)
+
+// Codes are the stable identifiers of the errors returned to clients. Unlike the messages, which may be reworded,
+// clients can rely on them.
+const (
+	CodeInvalidRequest          = "invalid_request"
+	CodeNotFound                = "not_found"
+	CodeInternalError           = "internal_error"
+	CodeRequestCancelled        = "request_cancelled"
+	CodeRequestTimedOut         = "request_timed_out"
+	CodeJwtMissing              = "jwt_missing"
+	CodeJwtInvalid              = "jwt_invalid"
+	CodeJwtExpired              = "jwt_expired"
+	CodeJwtRevoked              = "jwt_revoked"
+	CodeAccountBanned           = "account_banned"
+	CodeAccountSuspended        = "account_suspended"
+	CodeInsufficientPermissions = "insufficient_permissions"
+	CodeIncorrectCredentials    = "incorrect_credentials"
+	CodeUserNotFound            = "user_not_found"
+	CodeUserUnderage            = "user_underage"
+	CodeInvalidTimezone         = "invalid_timezone"
+	CodeSelfAction              = "self_action"
+	CodeSwipeQuotaExceeded      = "swipe_quota_exceeded"
+	CodeNoSwipeToRewind         = "no_swipe_to_rewind"
+	CodeSwipeMatched            = "swipe_matched"
+	CodeNotInPlan               = "not_in_plan"
+	CodeNoBoostsLeft            = "no_boosts_left"
+	CodeBoostActive             = "boost_active"
+	CodeUnknownBillingProvider  = "unknown_billing_provider"
+	CodeInvalidSignature        = "invalid_signature"
+	CodeInvalidBillingEvent     = "invalid_billing_event"
+	CodeUnknownTier             = "unknown_tier"
+	CodeStatusNotAllowed        = "status_not_allowed"
+	CodeCaseNotFound            = "case_not_found"
+	CodeInvalidTransition       = "invalid_transition"
+	CodeNotAModerator           = "not_a_moderator"
+	CodeQuestionNotFound        = "question_not_found"
+	CodeQuestionAnswered        = "question_answered"
+	CodeInvalidAnswer           = "invalid_answer"
 )
 
-type ErrorMessage struct {
-	Message string `json:"message"`
+// Error is an error that is safe to return to clients. It has a stable code for clients to check, the http status to
+// respond with and a message that doesn't reveal anything about how the service works.
+type Error struct {
+	Code    string
+	Status  int
+	Message string
+	// Fields are the reasons each of the invalid fields of the request is invalid
+	Fields []FieldError
+}
+
+// FieldError is the reason a field of the request is invalid
+type FieldError struct {
+	// Field is the name of the field as the client sent it, such as pageInfo.minAge
+	Field string
+	// Code is the rule the field broke, such as required or max
+	Code    string
+	Message string
+}
+
+// NewError is a function that creates an error to return to clients
+func NewError(status int, code, message string) *Error {
+	return &Error{
+		Code:    code,
+		Status:  status,
+		Message: message,
+	}
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s: %s", e.Code, e.Message)
 }
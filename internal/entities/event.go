@@ -0,0 +1,28 @@
+package entities
+
+import (
+	"encoding/json"
+	"github.com/google/uuid"
+	"time"
+)
+
+type EventType string
+
+const (
+	EventTypeNewMatch     EventType = "new_match"
+	EventTypeProfileLiked EventType = "profile_liked"
+	// EventTypeSuperLiked is sent to a user instead of profile_liked when someone super likes them
+	EventTypeSuperLiked EventType = "super_liked"
+	// EventTypeModerationWarning is sent to a user when a moderator warns them about their behaviour
+	EventTypeModerationWarning EventType = "moderation_warning"
+)
+
+// Event is a struct representing a domain event delivered to a user through the event stream
+type Event struct {
+	ID          int64
+	UserID      uuid.UUID
+	ActorUserID uuid.UUID
+	Type        EventType
+	Payload     json.RawMessage
+	CreatedAt   time.Time
+}
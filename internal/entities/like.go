@@ -0,0 +1,17 @@
+package entities
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// ReceivedLike is a like or super like on the user from someone they haven't swiped on yet
+type ReceivedLike struct {
+	UserID    uuid.UUID
+	Name      string
+	Gender    string
+	Age       int
+	SwipeType SwipeType
+	// LikedAt is the zero time for likes made before swipes were timestamped
+	LikedAt time.Time
+}
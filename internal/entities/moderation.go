@@ -0,0 +1,76 @@
+package entities
+
+import (
+	"encoding/json"
+	"github.com/google/uuid"
+	"time"
+)
+
+type Role string
+
+const (
+	RoleUser      Role = "user"
+	RoleModerator Role = "moderator"
+	RoleAdmin     Role = "admin"
+)
+
+type ModerationCaseStatus string
+
+const (
+	ModerationCaseStatusOpen      ModerationCaseStatus = "open"
+	ModerationCaseStatusInReview  ModerationCaseStatus = "in_review"
+	ModerationCaseStatusActioned  ModerationCaseStatus = "actioned"
+	ModerationCaseStatusDismissed ModerationCaseStatus = "dismissed"
+)
+
+// IsClosed is a function that checks whether the case has been resolved. Closed cases can't be changed.
+func (s ModerationCaseStatus) IsClosed() bool {
+	return s == ModerationCaseStatusActioned || s == ModerationCaseStatusDismissed
+}
+
+// CanTransitionTo is a function that checks whether a case in this status can be moved to the next status
+func (s ModerationCaseStatus) CanTransitionTo(next ModerationCaseStatus) bool {
+	return !s.IsClosed() && s != next
+}
+
+type ModerationActionType string
+
+const (
+	ModerationActionWarn    ModerationActionType = "warn"
+	ModerationActionSuspend ModerationActionType = "suspend"
+	ModerationActionBan     ModerationActionType = "ban"
+)
+
+// ModerationCase is a struct representing an item in the moderation queue
+type ModerationCase struct {
+	ID                  uuid.UUID
+	SubjectUserID       uuid.UUID
+	ReportID            uuid.NullUUID
+	Status              ModerationCaseStatus
+	AssignedModeratorID uuid.NullUUID
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+	// ReportReason and ReportDetails are copied from the report that opened the case, if there is one
+	ReportReason  ReportReason
+	ReportDetails string
+}
+
+// ModerationAction is a struct representing an action taken by a moderator against the subject of a case
+type ModerationAction struct {
+	CaseID          uuid.UUID
+	ModeratorUserID uuid.UUID
+	Action          ModerationActionType
+	// Duration is how long a suspension lasts, it is ignored for other actions
+	Duration time.Duration
+	Note     string
+}
+
+// ModerationAuditEntry is a struct representing an immutable record of a change made to a moderation case
+type ModerationAuditEntry struct {
+	ID          uuid.UUID
+	CaseID      uuid.UUID
+	ActorUserID uuid.UUID
+	Action      string
+	Details     json.RawMessage
+	CreatedAt   time.Time
+}
@@ -1,7 +1,18 @@
 package entities
 
+import "github.com/google/uuid"
+
 type PageInfo struct {
 	MinAge           int      `json:"minAge"`
 	MaxAge           int      `json:"maxAge"`
 	PreferredGenders []string `json:"preferredGenders"`
+	// MaxDistance is the furthest away in miles from Origin the users can be when it is set
+	MaxDistance float64 `json:"-"`
+	// Origin is where the distance to the users is measured from
+	Origin Location `json:"-"`
+	// UserIDs limits the users to those in the list when it is set
+	UserIDs []uuid.UUID `json:"-"`
+	// Limit is the most users returned when it is set, those that super liked the requesting user, are boosted, are
+	// recommended or were active most recently are returned first
+	Limit int `json:"-"`
 }
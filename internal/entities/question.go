@@ -0,0 +1,127 @@
+package entities
+
+import (
+	"github.com/google/uuid"
+	"math"
+	"time"
+)
+
+// Importance is how much a user cares about the answer someone else gives to a question
+type Importance string
+
+const (
+	ImportanceIrrelevant Importance = "irrelevant"
+	ImportanceALittle    Importance = "a_little"
+	ImportanceSomewhat   Importance = "somewhat"
+	ImportanceVery       Importance = "very"
+	ImportanceMandatory  Importance = "mandatory"
+)
+
+// Weight is a function that returns how much a question with this importance counts towards compatibility
+func (i Importance) Weight() float64 {
+	switch i {
+	case ImportanceALittle:
+		return 1
+	case ImportanceSomewhat:
+		return 10
+	case ImportanceVery:
+		return 50
+	case ImportanceMandatory:
+		return 250
+	default:
+		return 0
+	}
+}
+
+// Question is a struct representing a question in the question bank
+type Question struct {
+	ID   uuid.UUID
+	Text string
+	// Answers are the possible answers to the question, in the order they are shown
+	Answers   []Answer
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Answer is a struct representing one of the possible answers to a question
+type Answer struct {
+	ID   uuid.UUID
+	Text string
+}
+
+// UserAnswer is a struct representing how a user answered a question
+type UserAnswer struct {
+	UserID     uuid.UUID
+	QuestionID uuid.UUID
+	// AnswerID is the users own answer
+	AnswerID uuid.UUID
+	// AcceptedAnswerIDs are the answers the user would accept from someone else
+	AcceptedAnswerIDs []uuid.UUID
+	Importance        Importance
+}
+
+// accepts is a function that returns whether the user would accept the answer from someone else, every answer is
+// accepted when the user hasn't picked any
+func (a UserAnswer) accepts(answerID uuid.UUID) bool {
+	if len(a.AcceptedAnswerIDs) == 0 {
+		return true
+	}
+
+	for _, acceptedAnswerID := range a.AcceptedAnswerIDs {
+		if acceptedAnswerID == answerID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Compatibility is a function that returns the mutual match percentage of two users from the questions they have both
+// answered. Each user is satisfied by the share of the importance they put on those questions that the other user
+// answered acceptably, and the match is the geometric mean of the two, less a margin of error of 1 / the number of
+// questions in common so a few questions can't give a high match. It returns false if they have no questions in
+// common.
+func Compatibility(mine, theirs []UserAnswer) (int, bool) {
+	theirAnswers := make(map[uuid.UUID]UserAnswer, len(theirs))
+	for _, answer := range theirs {
+		theirAnswers[answer.QuestionID] = answer
+	}
+
+	var myEarned, myPossible, theirEarned, theirPossible float64
+	common := 0
+	for _, myAnswer := range mine {
+		theirAnswer, ok := theirAnswers[myAnswer.QuestionID]
+		if !ok {
+			continue
+		}
+		common++
+
+		myPossible += myAnswer.Importance.Weight()
+		if myAnswer.accepts(theirAnswer.AnswerID) {
+			myEarned += myAnswer.Importance.Weight()
+		}
+
+		theirPossible += theirAnswer.Importance.Weight()
+		if theirAnswer.accepts(myAnswer.AnswerID) {
+			theirEarned += theirAnswer.Importance.Weight()
+		}
+	}
+
+	if common == 0 {
+		return 0, false
+	}
+
+	match := math.Sqrt(satisfaction(myEarned, myPossible)*satisfaction(theirEarned, theirPossible)) - 1/float64(common)
+
+	return int(math.Round(100 * math.Max(0, match))), true
+}
+
+// satisfaction is a function that returns the share of the importance a user put on questions that was earned, users
+// that don't care about any of the questions are fully satisfied
+func satisfaction(earned, possible float64) float64 {
+	if possible == 0 {
+		return 1
+	}
+
+	return earned / possible
+}
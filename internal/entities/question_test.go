@@ -0,0 +1,121 @@
+package entities_test
+
+import (
+	"github.com/AlecSmith96/dating-api/internal/entities"
+	"github.com/google/uuid"
+	. "github.com/onsi/gomega"
+	"testing"
+)
+
+func TestCompatibility(t *testing.T) {
+	// questionCase is how each user answered a question they both answered, iAccept is whether my accepted answers
+	// include theirs and theyAccept whether theirs include mine
+	type questionCase struct {
+		myImportance    entities.Importance
+		theirImportance entities.Importance
+		iAccept         bool
+		theyAccept      bool
+	}
+
+	allAccepted := func(n int, importance entities.Importance) []questionCase {
+		questions := make([]questionCase, n)
+		for i := range questions {
+			questions[i] = questionCase{myImportance: importance, theirImportance: importance, iAccept: true, theyAccept: true}
+		}
+		return questions
+	}
+
+	testCases := []struct {
+		name                  string
+		questions             []questionCase
+		expectedCompatibility int
+		expectedOK            bool
+	}{
+		{name: "no questions in common", questions: nil, expectedOK: false},
+		{name: "one question in common is within the margin of error", questions: allAccepted(1, entities.ImportanceVery), expectedCompatibility: 0, expectedOK: true},
+		{name: "two acceptable answers", questions: allAccepted(2, entities.ImportanceVery), expectedCompatibility: 50, expectedOK: true},
+		{name: "ten acceptable answers", questions: allAccepted(10, entities.ImportanceSomewhat), expectedCompatibility: 90, expectedOK: true},
+		{name: "irrelevant questions satisfy both users", questions: allAccepted(4, entities.ImportanceIrrelevant), expectedCompatibility: 75, expectedOK: true},
+		{
+			name: "a failed mandatory question outweighs the others",
+			questions: []questionCase{
+				{myImportance: entities.ImportanceMandatory, theirImportance: entities.ImportanceSomewhat, iAccept: false, theyAccept: true},
+				{myImportance: entities.ImportanceVery, theirImportance: entities.ImportanceSomewhat, iAccept: true, theyAccept: true},
+				{myImportance: entities.ImportanceVery, theirImportance: entities.ImportanceSomewhat, iAccept: true, theyAccept: true},
+				{myImportance: entities.ImportanceVery, theirImportance: entities.ImportanceSomewhat, iAccept: true, theyAccept: true},
+			},
+			expectedCompatibility: 36,
+			expectedOK:            true,
+		},
+		{
+			name: "a failed question that only matters a little",
+			questions: []questionCase{
+				{myImportance: entities.ImportanceALittle, theirImportance: entities.ImportanceVery, iAccept: false, theyAccept: true},
+				{myImportance: entities.ImportanceVery, theirImportance: entities.ImportanceVery, iAccept: true, theyAccept: true},
+				{myImportance: entities.ImportanceVery, theirImportance: entities.ImportanceVery, iAccept: true, theyAccept: true},
+				{myImportance: entities.ImportanceVery, theirImportance: entities.ImportanceVery, iAccept: true, theyAccept: true},
+			},
+			expectedCompatibility: 75,
+			expectedOK:            true,
+		},
+		{
+			name: "neither user accepts the other",
+			questions: []questionCase{
+				{myImportance: entities.ImportanceVery, theirImportance: entities.ImportanceVery, iAccept: false, theyAccept: false},
+				{myImportance: entities.ImportanceVery, theirImportance: entities.ImportanceVery, iAccept: false, theyAccept: false},
+			},
+			expectedCompatibility: 0,
+			expectedOK:            true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			var mine, theirs []entities.UserAnswer
+			for _, question := range testCase.questions {
+				questionID, myAnswerID, theirAnswerID, otherAnswerID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+
+				myAnswer := entities.UserAnswer{QuestionID: questionID, AnswerID: myAnswerID, Importance: question.myImportance, AcceptedAnswerIDs: []uuid.UUID{otherAnswerID}}
+				if question.iAccept {
+					myAnswer.AcceptedAnswerIDs = append(myAnswer.AcceptedAnswerIDs, theirAnswerID)
+				}
+
+				theirAnswer := entities.UserAnswer{QuestionID: questionID, AnswerID: theirAnswerID, Importance: question.theirImportance, AcceptedAnswerIDs: []uuid.UUID{otherAnswerID}}
+				if question.theyAccept {
+					theirAnswer.AcceptedAnswerIDs = append(theirAnswer.AcceptedAnswerIDs, myAnswerID)
+				}
+
+				mine = append(mine, myAnswer)
+				theirs = append(theirs, theirAnswer)
+			}
+			// questions only one of the users answered don't count
+			mine = append(mine, entities.UserAnswer{QuestionID: uuid.New(), AnswerID: uuid.New(), Importance: entities.ImportanceMandatory})
+
+			compatibility, ok := entities.Compatibility(mine, theirs)
+			g.Expect(ok).To(Equal(testCase.expectedOK))
+			g.Expect(compatibility).To(Equal(testCase.expectedCompatibility))
+
+			// compatibility is mutual
+			reversed, _ := entities.Compatibility(theirs, mine)
+			g.Expect(reversed).To(Equal(compatibility))
+		})
+	}
+}
+
+func TestUserAnswer_NoAcceptedAnswers(t *testing.T) {
+	g := NewWithT(t)
+	questionIDs := []uuid.UUID{uuid.New(), uuid.New()}
+
+	// a user that hasn't picked any accepted answers accepts every answer
+	var mine, theirs []entities.UserAnswer
+	for _, questionID := range questionIDs {
+		mine = append(mine, entities.UserAnswer{QuestionID: questionID, AnswerID: uuid.New(), Importance: entities.ImportanceVery})
+		theirs = append(theirs, entities.UserAnswer{QuestionID: questionID, AnswerID: uuid.New(), Importance: entities.ImportanceVery})
+	}
+
+	compatibility, ok := entities.Compatibility(mine, theirs)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(compatibility).To(Equal(50))
+}
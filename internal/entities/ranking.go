@@ -0,0 +1,49 @@
+package entities
+
+import "time"
+
+// RankingContext is a struct representing the user discovering new users, that candidates are ranked for
+type RankingContext struct {
+	Location Location
+	PageInfo PageInfo
+	// Desirability is the users hidden desirability score, candidates with similar scores are ranked higher
+	Desirability float64
+	Now          time.Time
+}
+
+// RankingSignals is a struct representing how well a candidate scores on each of the discovery ranking signals, each
+// signal is between 0 and 1
+type RankingSignals struct {
+	Distance        float64
+	AgeFit          float64
+	SharedInterests float64
+	RecentActivity  float64
+	Boost           float64
+	Popularity      float64
+	Reciprocal      float64
+	DesirabilityFit float64
+}
+
+// RankingWeights is a struct representing how much each signal counts towards a candidates score
+type RankingWeights RankingSignals
+
+// Score is a function that returns the sum of the signals multiplied by their weights
+func (s RankingSignals) Score(weights RankingWeights) float64 {
+	return s.Distance*weights.Distance +
+		s.AgeFit*weights.AgeFit +
+		s.SharedInterests*weights.SharedInterests +
+		s.RecentActivity*weights.RecentActivity +
+		s.Boost*weights.Boost +
+		s.Popularity*weights.Popularity +
+		s.Reciprocal*weights.Reciprocal +
+		s.DesirabilityFit*weights.DesirabilityFit
+}
+
+// RankedCandidate is a struct representing a user in discovery along with how they were ranked
+type RankedCandidate struct {
+	User UserDiscovery
+	// DistanceFromMe is the distance between the candidate and the user discovering them in miles
+	DistanceFromMe float64
+	Signals        RankingSignals
+	Score          float64
+}
@@ -0,0 +1,12 @@
+package entities
+
+import "github.com/google/uuid"
+
+// Recommendation is a struct representing a candidate the recommender suggests showing to a user in discovery
+type Recommendation struct {
+	UserID          uuid.UUID
+	CandidateUserID uuid.UUID
+	Score           float64
+	// Rank is the candidates position in the users recommendations, starting at 1
+	Rank int
+}
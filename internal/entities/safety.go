@@ -0,0 +1,38 @@
+package entities
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type ReportReason string
+
+const (
+	ReportReasonSpam                 ReportReason = "spam"
+	ReportReasonHarassment           ReportReason = "harassment"
+	ReportReasonInappropriateContent ReportReason = "inappropriate_content"
+	ReportReasonFakeProfile          ReportReason = "fake_profile"
+	ReportReasonScam                 ReportReason = "scam"
+	ReportReasonUnderage             ReportReason = "underage"
+	ReportReasonOther                ReportReason = "other"
+)
+
+// Block is a struct representing one user blocking another, which hides the pair from each other in both directions
+type Block struct {
+	ID            uuid.UUID
+	BlockerUserID uuid.UUID
+	BlockedUserID uuid.UUID
+	CreatedAt     time.Time
+}
+
+// Report is a struct representing a report made against a user, to be reviewed by a moderator
+type Report struct {
+	ID             uuid.UUID
+	ReporterUserID uuid.UUID
+	ReportedUserID uuid.UUID
+	Reason         ReportReason
+	Details        string
+	CaseID         uuid.UUID
+	Status         ModerationCaseStatus
+	CreatedAt      time.Time
+}
@@ -0,0 +1,66 @@
+package entities
+
+import (
+	"encoding/json"
+	"github.com/google/uuid"
+	"time"
+)
+
+type SubscriptionStatus string
+
+const (
+	SubscriptionStatusTrialing  SubscriptionStatus = "trialing"
+	SubscriptionStatusActive    SubscriptionStatus = "active"
+	SubscriptionStatusPastDue   SubscriptionStatus = "past_due"
+	SubscriptionStatusCancelled SubscriptionStatus = "cancelled"
+	SubscriptionStatusExpired   SubscriptionStatus = "expired"
+)
+
+// IsValid is a function that checks whether the status is one of the known subscription statuses
+func (s SubscriptionStatus) IsValid() bool {
+	switch s {
+	case SubscriptionStatusTrialing, SubscriptionStatusActive, SubscriptionStatusPastDue, SubscriptionStatusCancelled, SubscriptionStatusExpired:
+		return true
+	}
+
+	return false
+}
+
+// CanTransitionTo is a function that checks whether a subscription in this status can be moved to the next status.
+// Expired subscriptions are finished, resubscribing creates a new subscription.
+func (s SubscriptionStatus) CanTransitionTo(next SubscriptionStatus) bool {
+	if s == SubscriptionStatusExpired {
+		return next == SubscriptionStatusExpired
+	}
+
+	return next != SubscriptionStatusTrialing || s == SubscriptionStatusTrialing
+}
+
+// Subscription is a struct representing a users subscription to a paid tier with a billing provider
+type Subscription struct {
+	ID                     uuid.UUID
+	UserID                 uuid.UUID
+	Tier                   Tier
+	Status                 SubscriptionStatus
+	Provider               string
+	ProviderSubscriptionID string
+	CurrentPeriodEnd       time.Time
+	CreatedAt              time.Time
+	UpdatedAt              time.Time
+}
+
+// BillingEvent is a struct representing a change to a subscription sent by a billing provider
+type BillingEvent struct {
+	// ID is the providers id for the event, events are only applied once
+	ID                     string
+	Provider               string
+	ProviderSubscriptionID string
+	UserID                 uuid.UUID
+	Tier                   Tier
+	Status                 SubscriptionStatus
+	CurrentPeriodEnd       time.Time
+	// OccurredAt is when the change happened at the provider, events older than the last one applied are ignored
+	OccurredAt time.Time
+	// Payload is the body of the webhook the event was sent in
+	Payload json.RawMessage
+}
@@ -0,0 +1,34 @@
+package entities_test
+
+import (
+	"github.com/AlecSmith96/dating-api/internal/entities"
+	. "github.com/onsi/gomega"
+	"testing"
+)
+
+func TestSubscriptionStatus_CanTransitionTo(t *testing.T) {
+	testCases := []struct {
+		from     entities.SubscriptionStatus
+		to       entities.SubscriptionStatus
+		expected bool
+	}{
+		{from: entities.SubscriptionStatusTrialing, to: entities.SubscriptionStatusTrialing, expected: true},
+		{from: entities.SubscriptionStatusTrialing, to: entities.SubscriptionStatusActive, expected: true},
+		{from: entities.SubscriptionStatusTrialing, to: entities.SubscriptionStatusExpired, expected: true},
+		{from: entities.SubscriptionStatusActive, to: entities.SubscriptionStatusActive, expected: true},
+		{from: entities.SubscriptionStatusActive, to: entities.SubscriptionStatusPastDue, expected: true},
+		{from: entities.SubscriptionStatusActive, to: entities.SubscriptionStatusTrialing, expected: false},
+		{from: entities.SubscriptionStatusPastDue, to: entities.SubscriptionStatusActive, expected: true},
+		{from: entities.SubscriptionStatusCancelled, to: entities.SubscriptionStatusActive, expected: true},
+		{from: entities.SubscriptionStatusCancelled, to: entities.SubscriptionStatusExpired, expected: true},
+		{from: entities.SubscriptionStatusExpired, to: entities.SubscriptionStatusActive, expected: false},
+		{from: entities.SubscriptionStatusExpired, to: entities.SubscriptionStatusExpired, expected: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(string(testCase.from)+" to "+string(testCase.to), func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(testCase.from.CanTransitionTo(testCase.to)).To(Equal(testCase.expected))
+		})
+	}
+}
@@ -1,10 +1,28 @@
 package entities
 
-import "github.com/google/uuid"
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// SwipeType is the kind of swipe a user made on another user
+type SwipeType string
+
+const (
+	SwipeTypeLike      SwipeType = "like"
+	SwipeTypePass      SwipeType = "pass"
+	SwipeTypeSuperLike SwipeType = "superlike"
+)
+
+// IsPositive is a function that returns whether the swipe shows interest in the swiped user
+func (t SwipeType) IsPositive() bool {
+	return t == SwipeTypeLike || t == SwipeTypeSuperLike
+}
 
 type Swipe struct {
-	ID                 uuid.UUID `json:"id"`
-	OwnerUserID        uuid.UUID `json:"ownerUserID"`
-	SwipedUserID       uuid.UUID `json:"swipedUserID"`
-	PositivePreference bool      `json:"positivePreference"`
+	ID           uuid.UUID `json:"id"`
+	OwnerUserID  uuid.UUID `json:"ownerUserID"`
+	SwipedUserID uuid.UUID `json:"swipedUserID"`
+	Type         SwipeType `json:"type"`
+	CreatedAt    time.Time `json:"createdAt"`
 }
@@ -5,6 +5,12 @@ import (
 	"time"
 )
 
+// MinimumAge is the age a user must be to hold an account
+const MinimumAge = 18
+
+// Genders are the genders users can have, discovery can only be filtered by these
+var Genders = []string{"male", "female"}
+
 type User struct {
 	ID          uuid.UUID
 	Email       string
@@ -13,6 +19,10 @@ type User struct {
 	Gender      string
 	DateOfBirth time.Time
 	Location    Location
+	// Timezone is the IANA name of the users timezone, their birthday starts at midnight in this timezone
+	Timezone string
+	// Interests are the users hobbies, shared interests rank users higher in each others discovery
+	Interests []string
 }
 
 type Location struct {
@@ -20,11 +30,42 @@ type Location struct {
 	Longitude float64
 }
 
+// GetAge is a function that returns the users age right now in their own timezone
 func (u *User) GetAge() int {
-	now := time.Now()
-	age := now.Year() - u.DateOfBirth.Year()
+	return u.AgeAt(time.Now())
+}
+
+// AgeAt is a function that returns the users age at the provided instant in their own timezone
+func (u *User) AgeAt(instant time.Time) int {
+	location, err := time.LoadLocation(u.Timezone)
+	if err != nil {
+		location = time.UTC
+	}
+
+	return AgeOn(u.DateOfBirth, instant.In(location))
+}
+
+// Validate is a function that checks the user is allowed to hold an account
+func (u *User) Validate() error {
+	_, err := time.LoadLocation(u.Timezone)
+	if err != nil {
+		return ErrInvalidTimezone
+	}
+
+	if u.GetAge() < MinimumAge {
+		return ErrUserUnderage
+	}
+
+	return nil
+}
+
+// AgeOn is a function that returns the number of whole years between the date of birth and the calendar date of the
+// provided time, ignoring the time of day. It matches DATE_PART('year', AGE(date, date_of_birth)) in postgres, so a
+// leap day birthday is only reached on the 1st of March in non leap years.
+func AgeOn(dateOfBirth, date time.Time) int {
+	age := date.Year() - dateOfBirth.Year()
 
-	if now.YearDay() < u.DateOfBirth.YearDay() {
+	if date.Month() < dateOfBirth.Month() || (date.Month() == dateOfBirth.Month() && date.Day() < dateOfBirth.Day()) {
 		age--
 	}
 
@@ -8,11 +8,28 @@ import (
 // UserDiscovery is a struct representing a users as it appears in the discover endpoint
 type UserDiscovery struct {
 	ID          uuid.UUID
-	Email       string
-	Password    string
 	Name        string
 	Gender      string
 	DateOfBirth time.Time
 	Location    Location
 	Age         int
+	// SuperLikedMe is true when the user has super liked the user discovering them
+	SuperLikedMe bool
+	// Boosted is true when the user has an active boost
+	Boosted bool
+	// SharedInterests is the number of interests the user has in common with the user discovering them
+	SharedInterests int
+	// LastActiveAt is when the user last swiped, it is nil if they never have
+	LastActiveAt *time.Time
+	// SwipesGiven is the number of swipes the user has made and LikesGiven how many of them were likes or super likes
+	SwipesGiven int
+	LikesGiven  int
+	// SwipesReceived is the number of swipes on the user and LikesReceived how many of them were likes or super likes
+	SwipesReceived int
+	LikesReceived  int
+	// Desirability is the users hidden desirability score, it must never be returned by the API
+	Desirability float64
+	// RecommendationRank is the users position in the recommendations for the user discovering them, it is 0 if they
+	// weren't recommended
+	RecommendationRank int
 }
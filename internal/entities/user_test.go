@@ -0,0 +1,102 @@
+package entities_test
+
+import (
+	"github.com/AlecSmith96/dating-api/internal/entities"
+	. "github.com/onsi/gomega"
+	"testing"
+	"time"
+)
+
+var ageTestCases = []struct {
+	name        string
+	dateOfBirth string
+	date        string
+	expectedAge int
+}{
+	{name: "day before birthday", dateOfBirth: "2000-06-15", date: "2018-06-14", expectedAge: 17},
+	{name: "on birthday", dateOfBirth: "2000-06-15", date: "2018-06-15", expectedAge: 18},
+	{name: "day after birthday", dateOfBirth: "2000-06-15", date: "2018-06-16", expectedAge: 18},
+	{name: "earlier month", dateOfBirth: "2000-06-15", date: "2018-05-30", expectedAge: 17},
+	{name: "later month", dateOfBirth: "2000-06-15", date: "2018-07-01", expectedAge: 18},
+	{name: "new years eve birthday", dateOfBirth: "2000-12-31", date: "2018-12-31", expectedAge: 18},
+	{name: "new years day before new years eve birthday", dateOfBirth: "2000-12-31", date: "2019-01-01", expectedAge: 18},
+	{name: "leap day birthday on the 28th in a non leap year", dateOfBirth: "2000-02-29", date: "2018-02-28", expectedAge: 17},
+	{name: "leap day birthday on the 1st of march in a non leap year", dateOfBirth: "2000-02-29", date: "2018-03-01", expectedAge: 18},
+	{name: "leap day birthday on the 28th in a leap year", dateOfBirth: "2000-02-29", date: "2020-02-28", expectedAge: 19},
+	{name: "leap day birthday in a leap year", dateOfBirth: "2000-02-29", date: "2020-02-29", expectedAge: 20},
+	{name: "1st of march birthday after a leap day", dateOfBirth: "2001-03-01", date: "2020-02-29", expectedAge: 18},
+	{name: "1st of march birthday in a leap year", dateOfBirth: "2002-03-01", date: "2020-03-01", expectedAge: 18},
+	{name: "day after leap day in a leap year, born in a non leap year", dateOfBirth: "2001-12-31", date: "2020-03-01", expectedAge: 18},
+	{name: "same day of the year in a leap year, before the birthday", dateOfBirth: "2001-12-31", date: "2019-12-31", expectedAge: 18},
+	{name: "born today", dateOfBirth: "2018-06-15", date: "2018-06-15", expectedAge: 0},
+}
+
+func TestAgeOn(t *testing.T) {
+	for _, testCase := range ageTestCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			g := NewWithT(t)
+			dateOfBirth, err := time.Parse(time.DateOnly, testCase.dateOfBirth)
+			g.Expect(err).ToNot(HaveOccurred())
+			date, err := time.Parse(time.DateOnly, testCase.date)
+			g.Expect(err).ToNot(HaveOccurred())
+
+			g.Expect(entities.AgeOn(dateOfBirth, date)).To(Equal(testCase.expectedAge))
+		})
+	}
+}
+
+func TestUser_AgeAt(t *testing.T) {
+	dateOfBirth := time.Date(2000, time.June, 15, 0, 0, 0, 0, time.UTC)
+	// 11pm on the 14th in London is already the 15th in Tokyo and still the 14th in New York
+	instant := time.Date(2018, time.June, 14, 22, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		timezone    string
+		expectedAge int
+	}{
+		{timezone: "Europe/London", expectedAge: 17},
+		{timezone: "Asia/Tokyo", expectedAge: 18},
+		{timezone: "America/New_York", expectedAge: 17},
+		{timezone: "", expectedAge: 17},
+		{timezone: "Not/A_Timezone", expectedAge: 17},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.timezone, func(t *testing.T) {
+			g := NewWithT(t)
+			user := entities.User{DateOfBirth: dateOfBirth, Timezone: testCase.timezone}
+
+			g.Expect(user.AgeAt(instant)).To(Equal(testCase.expectedAge))
+		})
+	}
+}
+
+func TestUser_Validate(t *testing.T) {
+	now := time.Now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name        string
+		user        entities.User
+		expectedErr error
+	}{
+		{name: "adult", user: entities.User{DateOfBirth: today.AddDate(-30, 0, 0), Timezone: "Europe/London"}, expectedErr: nil},
+		{name: "eighteenth birthday", user: entities.User{DateOfBirth: today.AddDate(-18, 0, -1), Timezone: "UTC"}, expectedErr: nil},
+		{name: "minor", user: entities.User{DateOfBirth: today.AddDate(-18, 0, 1), Timezone: "UTC"}, expectedErr: entities.ErrUserUnderage},
+		{name: "born in the future", user: entities.User{DateOfBirth: today.AddDate(1, 0, 0), Timezone: "UTC"}, expectedErr: entities.ErrUserUnderage},
+		{name: "invalid timezone", user: entities.User{DateOfBirth: today.AddDate(-30, 0, 0), Timezone: "Not/A_Timezone"}, expectedErr: entities.ErrInvalidTimezone},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			g := NewWithT(t)
+			err := testCase.user.Validate()
+			if testCase.expectedErr == nil {
+				g.Expect(err).ToNot(HaveOccurred())
+				return
+			}
+
+			g.Expect(err).To(MatchError(testCase.expectedErr))
+		})
+	}
+}
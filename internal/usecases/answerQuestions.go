@@ -0,0 +1,196 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"github.com/AlecSmith96/dating-api/internal/entities"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+)
+
+//go:generate mockgen --build_flags=--mod=mod -destination=../../mocks/questionAnswerer.go  . "QuestionAnswerer"
+type QuestionAnswerer interface {
+	GetQuestions(ctx context.Context) ([]entities.Question, error)
+	GetUserAnswers(ctx context.Context, userID uuid.UUID) ([]entities.UserAnswer, error)
+	AnswerQuestion(ctx context.Context, answer *entities.UserAnswer) error
+}
+
+// AnswerQuestionRequestBody represents how the requesting user answered a question
+// @Description the users own answer, the answers they would accept from someone else and how much they care
+type AnswerQuestionRequestBody struct {
+	// AnswerID is the id of the users own answer
+	AnswerID string `json:"answerId" binding:"required,uuid"`
+	// AcceptedAnswerIDs are the ids of the answers the user would accept, required unless the question is irrelevant
+	AcceptedAnswerIDs []string `json:"acceptedAnswerIds" binding:"required_unless=Importance irrelevant,dive,uuid"`
+	// Importance is how much the user cares about the answer someone else gives
+	Importance string `json:"importance" binding:"required,oneof=irrelevant a_little somewhat very mandatory" enums:"irrelevant,a_little,somewhat,very,mandatory"`
+}
+
+// UserQuestionsResponseBody represents the question bank as seen by the requesting user
+// @Description every question in the question bank with the requesting users answer
+type UserQuestionsResponseBody struct {
+	// Questions are the questions in the question bank, oldest first
+	Questions []UserQuestionResponseBody `json:"questions"`
+}
+
+// UserQuestionResponseBody represents a question and the requesting users answer to it
+// @Description a question, its possible answers and the requesting users answer
+type UserQuestionResponseBody struct {
+	// ID is the id of the question
+	ID string `json:"id"`
+	// Text is the question
+	Text string `json:"text"`
+	// Answers are the possible answers to the question, in the order they are shown
+	Answers []AnswerResponseBody `json:"answers"`
+	// MyAnswer is how the requesting user answered, it is omitted if they haven't
+	MyAnswer *UserAnswerResponseBody `json:"myAnswer,omitempty"`
+}
+
+// UserAnswerResponseBody represents how the requesting user answered a question
+// @Description the users own answer, the answers they would accept and how much they care
+type UserAnswerResponseBody struct {
+	// AnswerID is the id of the users own answer
+	AnswerID string `json:"answerId"`
+	// AcceptedAnswerIDs are the ids of the answers the user would accept
+	AcceptedAnswerIDs []string `json:"acceptedAnswerIds"`
+	// Importance is how much the user cares about the answer someone else gives
+	Importance string `json:"importance"`
+}
+
+// NewGetUserQuestions lists the question bank with the requesting users answers
+// @Summary Get questions
+// @Description Lists every question in the question bank along with how the requesting user answered it
+// @Security BearerAuth
+// @Tags questions
+// @Produce json
+// @Success 200 {object} UserQuestionsResponseBody
+// @Failure 500 {object} ProblemResponseBody
+// @Router /user/questions [get]
+func NewGetUserQuestions(questionAnswerer QuestionAnswerer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		userID, ok := c.Get("userID")
+		if !ok {
+			Logger(ctx).ErrorContext(ctx, "unable to get userID from context")
+			c.Error(errUserIDMissing)
+			return
+		}
+		requestingUserID := userID.(uuid.UUID)
+
+		questions, err := questionAnswerer.GetQuestions(ctx)
+		if err != nil {
+			Logger(ctx).ErrorContext(ctx, "getting questions", "err", err)
+			c.Error(err)
+			return
+		}
+
+		answers, err := questionAnswerer.GetUserAnswers(ctx, requestingUserID)
+		if err != nil {
+			Logger(ctx).ErrorContext(ctx, "getting user answers", "err", err)
+			c.Error(err)
+			return
+		}
+
+		answersByQuestion := make(map[uuid.UUID]entities.UserAnswer, len(answers))
+		for _, answer := range answers {
+			answersByQuestion[answer.QuestionID] = answer
+		}
+
+		response := UserQuestionsResponseBody{Questions: make([]UserQuestionResponseBody, 0, len(questions))}
+		for _, question := range questions {
+			questionResponse := toQuestionResponseBody(&question)
+			userQuestion := UserQuestionResponseBody{
+				ID:      questionResponse.ID,
+				Text:    questionResponse.Text,
+				Answers: questionResponse.Answers,
+			}
+
+			if answer, ok := answersByQuestion[question.ID]; ok {
+				userQuestion.MyAnswer = &UserAnswerResponseBody{
+					AnswerID:          answer.AnswerID.String(),
+					AcceptedAnswerIDs: make([]string, 0, len(answer.AcceptedAnswerIDs)),
+					Importance:        string(answer.Importance),
+				}
+				for _, acceptedAnswerID := range answer.AcceptedAnswerIDs {
+					userQuestion.MyAnswer.AcceptedAnswerIDs = append(userQuestion.MyAnswer.AcceptedAnswerIDs, acceptedAnswerID.String())
+				}
+			}
+
+			response.Questions = append(response.Questions, userQuestion)
+		}
+
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+// NewAnswerQuestion records the requesting users answer to a question
+// @Summary Answer a question
+// @Description Records the requesting users own answer to a question, the answers they would accept from someone else
+// @Description and how much they care, replacing any earlier answer. Answers are used to work out the compatibility
+// @Description of users in discovery.
+// @Security BearerAuth
+// @Tags questions
+// @Accept json
+// @Param id path string true "The id of the question"
+// @Param answer body AnswerQuestionRequestBody true "Answer Question Request Body"
+// @Success 204
+// @Failure 400 {object} ProblemResponseBody
+// @Failure 404 {object} ProblemResponseBody
+// @Failure 500 {object} ProblemResponseBody
+// @Router /user/questions/{id}/answer [put]
+func NewAnswerQuestion(questionAnswerer QuestionAnswerer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		userID, ok := c.Get("userID")
+		if !ok {
+			Logger(ctx).ErrorContext(ctx, "unable to get userID from context")
+			c.Error(errUserIDMissing)
+			return
+		}
+		requestingUserID := userID.(uuid.UUID)
+
+		questionID, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			Logger(ctx).ErrorContext(ctx, "parsing question id", "err", err)
+			c.Error(invalidField("id", "uuid", "must be a uuid"))
+			return
+		}
+
+		var request AnswerQuestionRequestBody
+		err = c.ShouldBindJSON(&request)
+		if err != nil {
+			Logger(ctx).ErrorContext(ctx, "validating request body", "err", err)
+			c.Error(bindingError(err))
+			return
+		}
+
+		// the ids have already been validated
+		answer := &entities.UserAnswer{
+			UserID:            requestingUserID,
+			QuestionID:        questionID,
+			AnswerID:          uuid.MustParse(request.AnswerID),
+			AcceptedAnswerIDs: make([]uuid.UUID, 0, len(request.AcceptedAnswerIDs)),
+			Importance:        entities.Importance(request.Importance),
+		}
+		for _, acceptedAnswerID := range request.AcceptedAnswerIDs {
+			answer.AcceptedAnswerIDs = append(answer.AcceptedAnswerIDs, uuid.MustParse(acceptedAnswerID))
+		}
+
+		err = questionAnswerer.AnswerQuestion(ctx, answer)
+		if err != nil {
+			switch {
+			case errors.Is(err, entities.ErrQuestionNotFound):
+				c.Error(entities.NewError(http.StatusNotFound, entities.CodeQuestionNotFound, "question not found"))
+			case errors.Is(err, entities.ErrInvalidAnswer):
+				c.Error(entities.NewError(http.StatusBadRequest, entities.CodeInvalidAnswer, "answers must be answers to the question"))
+			default:
+				Logger(ctx).ErrorContext(ctx, "answering question", "err", err)
+				c.Error(err)
+			}
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,210 @@
+package usecases_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/AlecSmith96/dating-api/internal/entities"
+	"github.com/AlecSmith96/dating-api/internal/usecases"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+)
+
+var _ = Describe("getting the questions to answer", func() {
+	var w *httptest.ResponseRecorder
+
+	var validateJwtForUserUUID uuid.UUID
+
+	var getQuestionsResponse []entities.Question
+	var getQuestionsErr error
+
+	var getUserAnswersResponse []entities.UserAnswer
+	var getUserAnswersErr error
+	var getUserAnswersCallCount int
+
+	BeforeEach(func() {
+		validateJwtForUserUUID = uuid.New()
+
+		getQuestionsResponse = []entities.Question{
+			{ID: uuid.New(), Text: "Do you like dogs?", Answers: []entities.Answer{{ID: uuid.New(), Text: "Yes"}, {ID: uuid.New(), Text: "No"}}},
+			{ID: uuid.New(), Text: "Do you like cats?", Answers: []entities.Answer{{ID: uuid.New(), Text: "Yes"}, {ID: uuid.New(), Text: "No"}}},
+		}
+		getQuestionsErr = nil
+
+		getUserAnswersResponse = []entities.UserAnswer{
+			{
+				UserID:            validateJwtForUserUUID,
+				QuestionID:        getQuestionsResponse[1].ID,
+				AnswerID:          getQuestionsResponse[1].Answers[0].ID,
+				AcceptedAnswerIDs: []uuid.UUID{getQuestionsResponse[1].Answers[0].ID},
+				Importance:        entities.ImportanceSomewhat,
+			},
+		}
+		getUserAnswersErr = nil
+		getUserAnswersCallCount = 1
+	})
+
+	JustBeforeEach(func() {
+		w = httptest.NewRecorder()
+
+		jwtProcessor.EXPECT().ValidateJwtForUser(gomock.Any(), mockJWT).Return(validateJwtForUserUUID, nil).Times(1)
+		questionAnswerer.EXPECT().GetQuestions(gomock.Any()).Return(getQuestionsResponse, getQuestionsErr).Times(1)
+		questionAnswerer.EXPECT().GetUserAnswers(gomock.Any(), validateJwtForUserUUID).Return(getUserAnswersResponse, getUserAnswersErr).Times(getUserAnswersCallCount)
+
+		req, err := http.NewRequest("GET", "http://localhost:8080/dating-api/v1/user/questions", nil)
+		req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", mockJWT))
+		Expect(err).ToNot(HaveOccurred())
+		r.ServeHTTP(w, req)
+	})
+
+	It("should return every question with the users answers", func() {
+		Expect(w.Code).To(Equal(http.StatusOK))
+		var resp usecases.UserQuestionsResponseBody
+		err := json.NewDecoder(w.Body).Decode(&resp)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(resp.Questions).To(HaveLen(2))
+		Expect(resp.Questions[0].MyAnswer).To(BeNil())
+		Expect(resp.Questions[1].MyAnswer).ToNot(BeNil())
+		Expect(resp.Questions[1].MyAnswer.AnswerID).To(Equal(getQuestionsResponse[1].Answers[0].ID.String()))
+		Expect(resp.Questions[1].MyAnswer.Importance).To(Equal("somewhat"))
+	})
+
+	When("getting the users answers returns an error", func() {
+		BeforeEach(func() {
+			getUserAnswersResponse = nil
+			getUserAnswersErr = errors.New("an error occurred")
+		})
+
+		It("should return a 500 Internal Server Error", func() {
+			Expect(w.Code).To(Equal(http.StatusInternalServerError))
+		})
+	})
+
+	When("getting the questions returns an error", func() {
+		BeforeEach(func() {
+			getQuestionsResponse = nil
+			getQuestionsErr = errors.New("an error occurred")
+			getUserAnswersCallCount = 0
+		})
+
+		It("should return a 500 Internal Server Error", func() {
+			Expect(w.Code).To(Equal(http.StatusInternalServerError))
+		})
+	})
+})
+
+var _ = Describe("answering a question", func() {
+	var w *httptest.ResponseRecorder
+	var requestBodyJSON []byte
+
+	var validateJwtForUserUUID uuid.UUID
+	var questionID, answerID, acceptedAnswerID uuid.UUID
+
+	var expectedAnswer *entities.UserAnswer
+	var answerQuestionErr error
+	var answerQuestionCallCount int
+
+	BeforeEach(func() {
+		validateJwtForUserUUID = uuid.New()
+		questionID = uuid.New()
+		answerID = uuid.New()
+		acceptedAnswerID = uuid.New()
+
+		requestBodyJSON = []byte(fmt.Sprintf(`{"answerId": "%s", "acceptedAnswerIds": ["%s", "%s"], "importance": "very"}`, answerID, answerID, acceptedAnswerID))
+
+		expectedAnswer = &entities.UserAnswer{
+			UserID:            validateJwtForUserUUID,
+			QuestionID:        questionID,
+			AnswerID:          answerID,
+			AcceptedAnswerIDs: []uuid.UUID{answerID, acceptedAnswerID},
+			Importance:        entities.ImportanceVery,
+		}
+		answerQuestionErr = nil
+		answerQuestionCallCount = 1
+	})
+
+	JustBeforeEach(func() {
+		w = httptest.NewRecorder()
+
+		jwtProcessor.EXPECT().ValidateJwtForUser(gomock.Any(), mockJWT).Return(validateJwtForUserUUID, nil).Times(1)
+		questionAnswerer.EXPECT().AnswerQuestion(gomock.Any(), expectedAnswer).Return(answerQuestionErr).Times(answerQuestionCallCount)
+
+		req, err := http.NewRequest("PUT", fmt.Sprintf("http://localhost:8080/dating-api/v1/user/questions/%s/answer", questionID), bytes.NewReader(requestBodyJSON))
+		req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", mockJWT))
+		Expect(err).ToNot(HaveOccurred())
+		r.ServeHTTP(w, req)
+	})
+
+	It("should record the answer", func() {
+		Expect(w.Code).To(Equal(http.StatusNoContent))
+	})
+
+	When("the question is irrelevant to the user", func() {
+		BeforeEach(func() {
+			requestBodyJSON = []byte(fmt.Sprintf(`{"answerId": "%s", "importance": "irrelevant"}`, answerID))
+			expectedAnswer.AcceptedAnswerIDs = []uuid.UUID{}
+			expectedAnswer.Importance = entities.ImportanceIrrelevant
+		})
+
+		It("should record the answer without accepted answers", func() {
+			Expect(w.Code).To(Equal(http.StatusNoContent))
+		})
+	})
+
+	When("no accepted answers are given for a relevant question", func() {
+		BeforeEach(func() {
+			requestBodyJSON = []byte(fmt.Sprintf(`{"answerId": "%s", "importance": "very"}`, answerID))
+			answerQuestionCallCount = 0
+		})
+
+		It("should return a 400 Bad Request", func() {
+			Expect(w.Code).To(Equal(http.StatusBadRequest))
+		})
+	})
+
+	When("the importance is unknown", func() {
+		BeforeEach(func() {
+			requestBodyJSON = []byte(fmt.Sprintf(`{"answerId": "%s", "acceptedAnswerIds": ["%s"], "importance": "extremely"}`, answerID, answerID))
+			answerQuestionCallCount = 0
+		})
+
+		It("should return a 400 Bad Request", func() {
+			Expect(w.Code).To(Equal(http.StatusBadRequest))
+		})
+	})
+
+	When("an answer isn't one of the questions answers", func() {
+		BeforeEach(func() {
+			answerQuestionErr = entities.ErrInvalidAnswer
+		})
+
+		It("should return a 400 Bad Request", func() {
+			Expect(w.Code).To(Equal(http.StatusBadRequest))
+		})
+	})
+
+	When("the question doesn't exist", func() {
+		BeforeEach(func() {
+			answerQuestionErr = entities.ErrQuestionNotFound
+		})
+
+		It("should return a 404 Not Found", func() {
+			Expect(w.Code).To(Equal(http.StatusNotFound))
+		})
+	})
+
+	When("the adapter returns an error", func() {
+		BeforeEach(func() {
+			answerQuestionErr = errors.New("an error occurred")
+		})
+
+		It("should return a 500 Internal Server Error", func() {
+			Expect(w.Code).To(Equal(http.StatusInternalServerError))
+		})
+	})
+})
@@ -0,0 +1,98 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"github.com/AlecSmith96/dating-api/internal/entities"
+	"github.com/gin-gonic/gin"
+	"io"
+	"net/http"
+)
+
+const maxBillingWebhookBytes = 1 << 20
+
+// BillingProvider is a payment provider that sends subscription changes through signed webhooks
+//
+//go:generate mockgen --build_flags=--mod=mod -destination=../../mocks/billingProvider.go  . "BillingProvider"
+type BillingProvider interface {
+	// Name is the name of the provider in the webhook url
+	Name() string
+	// ParseWebhook verifies the signature of the webhook and returns the billing event it contains, it returns
+	// entities.ErrInvalidSignature if the signature doesn't match and entities.ErrBadBillingEvent if the event can't
+	// be read
+	ParseWebhook(header http.Header, body []byte) (*entities.BillingEvent, error)
+}
+
+//go:generate mockgen --build_flags=--mod=mod -destination=../../mocks/subscriptionManager.go  . "SubscriptionManager"
+type SubscriptionManager interface {
+	ApplyBillingEvent(ctx context.Context, event *entities.BillingEvent) (*entities.Subscription, error)
+}
+
+// NewBillingWebhook receives subscription changes from billing providers
+// @Summary Receive a billing webhook
+// @Description Applies a subscription change sent by a billing provider. The request must be signed by the provider,
+// @Description redelivered events are acknowledged without being applied again and events older than the last one
+// @Description applied to the subscription are ignored.
+// @Tags billing
+// @Accept json
+// @Param provider path string true "The name of the billing provider"
+// @Success 204
+// @Failure 400 {object} ProblemResponseBody
+// @Failure 401 {object} ProblemResponseBody
+// @Failure 404 {object} ProblemResponseBody
+// @Failure 409 {object} ProblemResponseBody
+// @Failure 500 {object} ProblemResponseBody
+// @Router /billing/webhooks/{provider} [post]
+func NewBillingWebhook(subscriptionManager SubscriptionManager, billingProviders ...BillingProvider) gin.HandlerFunc {
+	providers := make(map[string]BillingProvider, len(billingProviders))
+	for _, provider := range billingProviders {
+		providers[provider.Name()] = provider
+	}
+
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		provider, ok := providers[c.Param("provider")]
+		if !ok {
+			c.Error(entities.NewError(http.StatusNotFound, entities.CodeUnknownBillingProvider, "unknown billing provider"))
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxBillingWebhookBytes))
+		if err != nil {
+			Logger(ctx).ErrorContext(ctx, "reading billing webhook", "err", err)
+			c.Error(bindingError(err))
+			return
+		}
+
+		event, err := provider.ParseWebhook(c.Request.Header, body)
+		if err != nil {
+			if errors.Is(err, entities.ErrInvalidSignature) {
+				c.Error(entities.NewError(http.StatusUnauthorized, entities.CodeInvalidSignature, "invalid signature"))
+				return
+			}
+			Logger(ctx).ErrorContext(ctx, "parsing billing webhook", "provider", provider.Name(), "err", err)
+			c.Error(entities.NewError(http.StatusBadRequest, entities.CodeInvalidBillingEvent, "invalid billing event"))
+			return
+		}
+
+		_, err = subscriptionManager.ApplyBillingEvent(ctx, event)
+		if err != nil {
+			switch {
+			case errors.Is(err, entities.ErrDuplicateEvent):
+				c.Status(http.StatusNoContent)
+			case errors.Is(err, entities.ErrTargetUserNotFound):
+				c.Error(entities.NewError(http.StatusNotFound, entities.CodeUserNotFound, "user not found"))
+			case errors.Is(err, entities.ErrUnknownTier):
+				c.Error(entities.NewError(http.StatusBadRequest, entities.CodeUnknownTier, "unknown tier"))
+			case errors.Is(err, entities.ErrStatusNotAllowed):
+				c.Error(entities.NewError(http.StatusConflict, entities.CodeStatusNotAllowed, "subscription can not be moved to that status"))
+			default:
+				Logger(ctx).ErrorContext(ctx, "applying billing event", "provider", provider.Name(), "eventID", event.ID, "err", err)
+				c.Error(err)
+			}
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,152 @@
+package usecases_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/AlecSmith96/dating-api/internal/adapters"
+	"github.com/AlecSmith96/dating-api/internal/entities"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"time"
+)
+
+var _ = Describe("receiving a billing webhook", func() {
+	var w *httptest.ResponseRecorder
+
+	var provider string
+	var userID uuid.UUID
+	var body []byte
+	var signature string
+
+	var applyBillingEventErr error
+	var applyBillingEventCallCount int
+	var appliedEvent *entities.BillingEvent
+
+	BeforeEach(func() {
+		provider = "local"
+		userID = uuid.New()
+		body = []byte(fmt.Sprintf(`{"id":"evt_1","subscriptionId":"sub_1","userId":"%s","tier":"plus","status":"active","currentPeriodEnd":"2026-11-19T00:00:00Z","occurredAt":"2026-10-19T00:00:00Z"}`, userID))
+		signature = billingProvider.Sign(body, time.Now())
+
+		applyBillingEventErr = nil
+		applyBillingEventCallCount = 1
+		appliedEvent = nil
+	})
+
+	JustBeforeEach(func() {
+		w = httptest.NewRecorder()
+
+		subscriptionManager.EXPECT().ApplyBillingEvent(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, event *entities.BillingEvent) (*entities.Subscription, error) {
+				appliedEvent = event
+				if applyBillingEventErr != nil {
+					return nil, applyBillingEventErr
+				}
+				return &entities.Subscription{ID: uuid.New(), UserID: event.UserID, Tier: event.Tier, Status: event.Status}, nil
+			}).Times(applyBillingEventCallCount)
+
+		req, err := http.NewRequest("POST", "http://localhost:8080/dating-api/v1/billing/webhooks/"+provider, bytes.NewReader(body))
+		Expect(err).ToNot(HaveOccurred())
+		req.Header.Set(adapters.LocalBillingSignatureHeader, signature)
+		r.ServeHTTP(w, req)
+	})
+
+	It("should apply the billing event", func() {
+		Expect(w.Code).To(Equal(http.StatusNoContent))
+		Expect(appliedEvent.ID).To(Equal("evt_1"))
+		Expect(appliedEvent.Provider).To(Equal("local"))
+		Expect(appliedEvent.UserID).To(Equal(userID))
+		Expect(appliedEvent.Tier).To(Equal(entities.TierPlus))
+		Expect(appliedEvent.Status).To(Equal(entities.SubscriptionStatusActive))
+	})
+
+	When("the provider is unknown", func() {
+		BeforeEach(func() {
+			provider = "unknown"
+			applyBillingEventCallCount = 0
+		})
+
+		It("should return a 404 Not Found", func() {
+			Expect(w.Code).To(Equal(http.StatusNotFound))
+		})
+	})
+
+	When("the signature doesn't match", func() {
+		BeforeEach(func() {
+			signature = adapters.NewLocalBillingProvider("another-secret").Sign(body, time.Now())
+			applyBillingEventCallCount = 0
+		})
+
+		It("should return a 401 Unauthorized", func() {
+			Expect(w.Code).To(Equal(http.StatusUnauthorized))
+		})
+	})
+
+	When("the event is invalid", func() {
+		BeforeEach(func() {
+			body = []byte(`{"id":"evt_1"}`)
+			signature = billingProvider.Sign(body, time.Now())
+			applyBillingEventCallCount = 0
+		})
+
+		It("should return a 400 Bad Request", func() {
+			Expect(w.Code).To(Equal(http.StatusBadRequest))
+		})
+	})
+
+	When("the event has already been applied", func() {
+		BeforeEach(func() {
+			applyBillingEventErr = entities.ErrDuplicateEvent
+		})
+
+		It("should acknowledge the event", func() {
+			Expect(w.Code).To(Equal(http.StatusNoContent))
+		})
+	})
+
+	When("the subscription can't move to the status", func() {
+		BeforeEach(func() {
+			applyBillingEventErr = entities.ErrStatusNotAllowed
+		})
+
+		It("should return a 409 Conflict", func() {
+			Expect(w.Code).To(Equal(http.StatusConflict))
+		})
+	})
+
+	When("the tier is unknown", func() {
+		BeforeEach(func() {
+			applyBillingEventErr = entities.ErrUnknownTier
+		})
+
+		It("should return a 400 Bad Request", func() {
+			Expect(w.Code).To(Equal(http.StatusBadRequest))
+		})
+	})
+
+	When("the user doesn't exist", func() {
+		BeforeEach(func() {
+			applyBillingEventErr = entities.ErrTargetUserNotFound
+		})
+
+		It("should return a 404 Not Found", func() {
+			Expect(w.Code).To(Equal(http.StatusNotFound))
+		})
+	})
+
+	When("the adapter returns an error", func() {
+		BeforeEach(func() {
+			applyBillingEventErr = errors.New("an error occurred")
+		})
+
+		It("should return a 500 Internal Server Error", func() {
+			Expect(w.Code).To(Equal(http.StatusInternalServerError))
+		})
+	})
+})
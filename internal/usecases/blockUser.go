@@ -0,0 +1,64 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"github.com/AlecSmith96/dating-api/internal/entities"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+)
+
+//go:generate mockgen --build_flags=--mod=mod -destination=../../mocks/userBlocker.go  . "UserBlocker"
+type UserBlocker interface {
+	BlockUser(ctx context.Context, blockerUserID, blockedUserID uuid.UUID) error
+}
+
+// NewBlockUser blocks a user
+// @Summary Block a user
+// @Description Blocks a user, removing the pair from each others discovery, matches and events in both directions
+// @Security BearerAuth
+// @Tags safety
+// @Param id path string true "The id of the user to block"
+// @Success 204
+// @Failure 400 {object} ProblemResponseBody
+// @Failure 404 {object} ProblemResponseBody
+// @Failure 500 {object} ProblemResponseBody
+// @Router /user/block/{id} [post]
+func NewBlockUser(userBlocker UserBlocker) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		userID, ok := c.Get("userID")
+		if !ok {
+			Logger(ctx).ErrorContext(ctx, "unable to get userID from context")
+			c.Error(errUserIDMissing)
+			return
+		}
+		requestingUserID := userID.(uuid.UUID)
+
+		blockedUserID, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			Logger(ctx).ErrorContext(ctx, "parsing blocked user id", "err", err)
+			c.Error(invalidField("id", "uuid", "must be a uuid"))
+			return
+		}
+
+		if blockedUserID == requestingUserID {
+			c.Error(entities.NewError(http.StatusBadRequest, entities.CodeSelfAction, "unable to block yourself"))
+			return
+		}
+
+		err = userBlocker.BlockUser(ctx, requestingUserID, blockedUserID)
+		if err != nil {
+			if errors.Is(err, entities.ErrTargetUserNotFound) {
+				c.Error(entities.NewError(http.StatusNotFound, entities.CodeUserNotFound, "user not found"))
+				return
+			}
+			Logger(ctx).ErrorContext(ctx, "blocking user", "err", err)
+			c.Error(err)
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
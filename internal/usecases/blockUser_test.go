@@ -0,0 +1,95 @@
+package usecases_test
+
+import (
+	"errors"
+	"fmt"
+	"github.com/AlecSmith96/dating-api/internal/entities"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+)
+
+var _ = Describe("blocking a user", func() {
+	var w *httptest.ResponseRecorder
+	var blockedUserID string
+
+	var validateJwtForUserUUID uuid.UUID
+	var validateJwtForUserErr error
+	var validateJwtForUserCallCount int
+
+	var blockUserErr error
+	var blockUserCallCount int
+
+	BeforeEach(func() {
+		blockedUserID = uuid.New().String()
+
+		validateJwtForUserUUID = uuid.New()
+		validateJwtForUserErr = nil
+		validateJwtForUserCallCount = 1
+
+		blockUserErr = nil
+		blockUserCallCount = 1
+	})
+
+	JustBeforeEach(func() {
+		w = httptest.NewRecorder()
+
+		jwtProcessor.EXPECT().ValidateJwtForUser(gomock.Any(), mockJWT).Return(validateJwtForUserUUID, validateJwtForUserErr).Times(validateJwtForUserCallCount)
+		parsedBlockedUserID, _ := uuid.Parse(blockedUserID)
+		userBlocker.EXPECT().BlockUser(gomock.Any(), validateJwtForUserUUID, parsedBlockedUserID).Return(blockUserErr).Times(blockUserCallCount)
+
+		req, err := http.NewRequest("POST", fmt.Sprintf("http://localhost:8080/dating-api/v1/user/block/%s", blockedUserID), nil)
+		req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", mockJWT))
+		Expect(err).ToNot(HaveOccurred())
+		r.ServeHTTP(w, req)
+	})
+
+	It("should block the user", func() {
+		Expect(w.Code).To(Equal(http.StatusNoContent))
+	})
+
+	When("the user id is invalid", func() {
+		BeforeEach(func() {
+			blockedUserID = "not-a-uuid"
+			blockUserCallCount = 0
+		})
+
+		It("should return a 400 Bad Request", func() {
+			Expect(w.Code).To(Equal(http.StatusBadRequest))
+		})
+	})
+
+	When("the user tries to block themselves", func() {
+		BeforeEach(func() {
+			blockedUserID = validateJwtForUserUUID.String()
+			blockUserCallCount = 0
+		})
+
+		It("should return a 400 Bad Request", func() {
+			Expect(w.Code).To(Equal(http.StatusBadRequest))
+		})
+	})
+
+	When("the user to block does not exist", func() {
+		BeforeEach(func() {
+			blockUserErr = entities.ErrTargetUserNotFound
+		})
+
+		It("should return a 404 Not Found", func() {
+			Expect(w.Code).To(Equal(http.StatusNotFound))
+		})
+	})
+
+	When("the adapter returns an error", func() {
+		BeforeEach(func() {
+			blockUserErr = errors.New("an error occurred")
+		})
+
+		It("should return a 500 Internal Server Error", func() {
+			Expect(w.Code).To(Equal(http.StatusInternalServerError))
+		})
+	})
+})
@@ -0,0 +1,159 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"github.com/AlecSmith96/dating-api/internal/entities"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"time"
+)
+
+//go:generate mockgen --build_flags=--mod=mod -destination=../../mocks/boostManager.go  . "BoostManager"
+type BoostManager interface {
+	StartBoost(ctx context.Context, userID uuid.UUID, duration time.Duration) (*entities.Boost, error)
+	GetBoostReports(ctx context.Context, userID uuid.UUID) ([]entities.BoostReport, error)
+}
+
+// BoostResponseBody represents a boost
+// @Description a period where the users profile is ranked higher in discovery
+type BoostResponseBody struct {
+	// ID is the id of the boost
+	ID string `json:"id"`
+	// StartsAt is when the boost started
+	StartsAt time.Time `json:"startsAt"`
+	// EndsAt is when the boost ends
+	EndsAt time.Time `json:"endsAt"`
+	// BoostsRemaining is the number of boosts the user has left, it is only returned when starting a boost
+	BoostsRemaining *int `json:"boostsRemaining,omitempty"`
+}
+
+// BoostReportsResponseBody represents the users boosts
+// @Description the users boosts and how they performed, most recent first
+type BoostReportsResponseBody struct {
+	// Boosts is the list of the users boosts
+	Boosts []BoostReportResponseBody `json:"boosts"`
+}
+
+// BoostReportResponseBody represents how a boost performed
+// @Description how a boost performed, compared to the week before it
+type BoostReportResponseBody struct {
+	BoostResponseBody
+	// Active is true while the boost is running
+	Active bool `json:"active"`
+	// Views is the number of times the profile was shown in discovery during the boost
+	Views int `json:"views"`
+	// Likes is the number of likes the profile got during the boost
+	Likes int `json:"likes"`
+	// ExtraViews is the number of views above the profiles usual rate over the week before the boost
+	ExtraViews int `json:"extraViews"`
+	// ExtraLikes is the number of likes above the profiles usual rate over the week before the boost
+	ExtraLikes int `json:"extraLikes"`
+}
+
+// NewBoostProfile starts a boost
+// @Summary Boost the requesting users profile
+// @Description Ranks the requesting users profile higher in other users discovery for the length of the boost. Each
+// @Description plan includes a number of boosts in any 30 days, and only one boost can be active at a time.
+// @Security BearerAuth
+// @Tags users
+// @Produce json
+// @Success 201 {object} BoostResponseBody
+// @Failure 403 {object} ProblemResponseBody
+// @Failure 409 {object} ProblemResponseBody
+// @Failure 500 {object} ProblemResponseBody
+// @Router /user/boost [post]
+func NewBoostProfile(entitlements Entitlements, boostManager BoostManager, boostDuration time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		userID, ok := c.Get("userID")
+		if !ok {
+			Logger(ctx).ErrorContext(ctx, "unable to get userID from context")
+			c.Error(errUserIDMissing)
+			return
+		}
+		requestingUserID := userID.(uuid.UUID)
+
+		userEntitlements, ok := getEntitlements(c, entitlements, requestingUserID)
+		if !ok {
+			return
+		}
+
+		if userEntitlements.BoostsRemaining() == 0 {
+			c.Error(entities.NewError(http.StatusForbidden, entities.CodeNoBoostsLeft, "no boosts left in your plan"))
+			return
+		}
+
+		boost, err := boostManager.StartBoost(ctx, requestingUserID, boostDuration)
+		if err != nil {
+			switch {
+			case errors.Is(err, entities.ErrNoBoostsLeft):
+				c.Error(entities.NewError(http.StatusForbidden, entities.CodeNoBoostsLeft, "no boosts left in your plan"))
+			case errors.Is(err, entities.ErrBoostActive):
+				c.Error(entities.NewError(http.StatusConflict, entities.CodeBoostActive, "a boost is already active"))
+			default:
+				Logger(ctx).ErrorContext(ctx, "starting boost", "err", err)
+				c.Error(err)
+			}
+			return
+		}
+
+		boostsRemaining := userEntitlements.BoostsRemaining() - 1
+		c.JSON(http.StatusCreated, BoostResponseBody{
+			ID:              boost.ID.String(),
+			StartsAt:        boost.StartsAt,
+			EndsAt:          boost.EndsAt,
+			BoostsRemaining: &boostsRemaining,
+		})
+	}
+}
+
+// NewGetBoostReports gets how the users boosts performed
+// @Summary Get boost reports
+// @Description Gets the requesting users boosts, most recent first, with the views and likes each produced. Extra views
+// @Description and likes are measured against the profiles rate over the week before the boost.
+// @Security BearerAuth
+// @Tags users
+// @Produce json
+// @Success 200 {object} BoostReportsResponseBody
+// @Failure 500 {object} ProblemResponseBody
+// @Router /user/boosts [get]
+func NewGetBoostReports(boostManager BoostManager) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		userID, ok := c.Get("userID")
+		if !ok {
+			Logger(ctx).ErrorContext(ctx, "unable to get userID from context")
+			c.Error(errUserIDMissing)
+			return
+		}
+		requestingUserID := userID.(uuid.UUID)
+
+		reports, err := boostManager.GetBoostReports(ctx, requestingUserID)
+		if err != nil {
+			Logger(ctx).ErrorContext(ctx, "getting boost reports", "err", err)
+			c.Error(err)
+			return
+		}
+
+		now := time.Now()
+		response := BoostReportsResponseBody{Boosts: []BoostReportResponseBody{}}
+		for _, report := range reports {
+			response.Boosts = append(response.Boosts, BoostReportResponseBody{
+				BoostResponseBody: BoostResponseBody{
+					ID:       report.ID.String(),
+					StartsAt: report.StartsAt,
+					EndsAt:   report.EndsAt,
+				},
+				Active:     report.IsActive(now),
+				Views:      report.Views,
+				Likes:      report.Likes,
+				ExtraViews: report.ExtraViews(),
+				ExtraLikes: report.ExtraLikes(),
+			})
+		}
+
+		c.JSON(http.StatusOK, response)
+	}
+}
@@ -0,0 +1,208 @@
+package usecases_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/AlecSmith96/dating-api/internal/entities"
+	"github.com/AlecSmith96/dating-api/internal/usecases"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"time"
+)
+
+var _ = Describe("boosting a profile", func() {
+	var w *httptest.ResponseRecorder
+
+	var validateJwtForUserUUID uuid.UUID
+
+	var getEntitlementsResponse *entities.Entitlements
+	var getEntitlementsErr error
+
+	var startBoostResponse *entities.Boost
+	var startBoostErr error
+	var startBoostCallCount int
+
+	BeforeEach(func() {
+		validateJwtForUserUUID = uuid.New()
+
+		getEntitlementsResponse = &entities.Entitlements{
+			Tier:       entities.TierPremium,
+			BoostLimit: 4,
+			BoostsUsed: 1,
+		}
+		getEntitlementsErr = nil
+
+		startsAt := time.Now().UTC().Truncate(time.Second)
+		startBoostResponse = &entities.Boost{
+			ID:       uuid.New(),
+			UserID:   validateJwtForUserUUID,
+			StartsAt: startsAt,
+			EndsAt:   startsAt.Add(boostDuration),
+		}
+		startBoostErr = nil
+		startBoostCallCount = 1
+	})
+
+	JustBeforeEach(func() {
+		w = httptest.NewRecorder()
+
+		jwtProcessor.EXPECT().ValidateJwtForUser(gomock.Any(), mockJWT).Return(validateJwtForUserUUID, nil).Times(1)
+		entitlements.EXPECT().GetEntitlements(gomock.Any(), validateJwtForUserUUID).Return(getEntitlementsResponse, getEntitlementsErr).Times(1)
+		boostManager.EXPECT().StartBoost(gomock.Any(), validateJwtForUserUUID, boostDuration).Return(startBoostResponse, startBoostErr).Times(startBoostCallCount)
+
+		req, err := http.NewRequest("POST", "http://localhost:8080/dating-api/v1/user/boost", nil)
+		req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", mockJWT))
+		Expect(err).ToNot(HaveOccurred())
+		r.ServeHTTP(w, req)
+	})
+
+	It("should return a 201 Created with the boost", func() {
+		Expect(w.Code).To(Equal(http.StatusCreated))
+		var resp usecases.BoostResponseBody
+		err := json.NewDecoder(w.Body).Decode(&resp)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(resp.ID).To(Equal(startBoostResponse.ID.String()))
+		Expect(resp.EndsAt).To(BeTemporally("==", startBoostResponse.EndsAt))
+		Expect(*resp.BoostsRemaining).To(Equal(2))
+	})
+
+	When("the users plan has no boosts left", func() {
+		BeforeEach(func() {
+			getEntitlementsResponse.BoostsUsed = 4
+			startBoostCallCount = 0
+		})
+
+		It("should return a 403 Forbidden", func() {
+			Expect(w.Code).To(Equal(http.StatusForbidden))
+		})
+	})
+
+	When("the last boost is used by a concurrent request", func() {
+		BeforeEach(func() {
+			startBoostResponse = nil
+			startBoostErr = entities.ErrNoBoostsLeft
+		})
+
+		It("should return a 403 Forbidden", func() {
+			Expect(w.Code).To(Equal(http.StatusForbidden))
+		})
+	})
+
+	When("the user already has an active boost", func() {
+		BeforeEach(func() {
+			startBoostResponse = nil
+			startBoostErr = entities.ErrBoostActive
+		})
+
+		It("should return a 409 Conflict", func() {
+			Expect(w.Code).To(Equal(http.StatusConflict))
+		})
+	})
+
+	When("getting the entitlements returns an error", func() {
+		BeforeEach(func() {
+			getEntitlementsResponse = nil
+			getEntitlementsErr = errors.New("an error occurred")
+			startBoostCallCount = 0
+		})
+
+		It("should return a 500 Internal Server Error", func() {
+			Expect(w.Code).To(Equal(http.StatusInternalServerError))
+		})
+	})
+
+	When("starting the boost returns an error", func() {
+		BeforeEach(func() {
+			startBoostResponse = nil
+			startBoostErr = errors.New("an error occurred")
+		})
+
+		It("should return a 500 Internal Server Error", func() {
+			Expect(w.Code).To(Equal(http.StatusInternalServerError))
+		})
+	})
+})
+
+var _ = Describe("getting boost reports", func() {
+	var w *httptest.ResponseRecorder
+
+	var validateJwtForUserUUID uuid.UUID
+
+	var getBoostReportsResponse []entities.BoostReport
+	var getBoostReportsErr error
+
+	BeforeEach(func() {
+		validateJwtForUserUUID = uuid.New()
+
+		startsAt := time.Now().Add(-time.Hour).UTC().Truncate(time.Second)
+		getBoostReportsResponse = []entities.BoostReport{
+			{
+				Boost: entities.Boost{
+					ID:       uuid.New(),
+					UserID:   validateJwtForUserUUID,
+					StartsAt: startsAt,
+					EndsAt:   startsAt.Add(boostDuration),
+				},
+				Views:     40,
+				Likes:     6,
+				WeekViews: 3360,
+				WeekLikes: 336,
+			},
+		}
+		getBoostReportsErr = nil
+	})
+
+	JustBeforeEach(func() {
+		w = httptest.NewRecorder()
+
+		jwtProcessor.EXPECT().ValidateJwtForUser(gomock.Any(), mockJWT).Return(validateJwtForUserUUID, nil).Times(1)
+		boostManager.EXPECT().GetBoostReports(gomock.Any(), validateJwtForUserUUID).Return(getBoostReportsResponse, getBoostReportsErr).Times(1)
+
+		req, err := http.NewRequest("GET", "http://localhost:8080/dating-api/v1/user/boosts", nil)
+		req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", mockJWT))
+		Expect(err).ToNot(HaveOccurred())
+		r.ServeHTTP(w, req)
+	})
+
+	It("should return the boosts with the views and likes above the usual rate", func() {
+		Expect(w.Code).To(Equal(http.StatusOK))
+		var resp usecases.BoostReportsResponseBody
+		err := json.NewDecoder(w.Body).Decode(&resp)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(resp.Boosts).To(HaveLen(1))
+		Expect(resp.Boosts[0].ID).To(Equal(getBoostReportsResponse[0].ID.String()))
+		Expect(resp.Boosts[0].Active).To(BeFalse())
+		Expect(resp.Boosts[0].BoostsRemaining).To(BeNil())
+		Expect(resp.Boosts[0].Views).To(Equal(40))
+		// 3360 views and 336 likes a week is 10 views and 1 like every 30 minutes
+		Expect(resp.Boosts[0].ExtraViews).To(Equal(30))
+		Expect(resp.Boosts[0].ExtraLikes).To(Equal(5))
+	})
+
+	When("the user has never boosted", func() {
+		BeforeEach(func() {
+			getBoostReportsResponse = []entities.BoostReport{}
+		})
+
+		It("should return an empty list", func() {
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(w.Body.String()).To(MatchJSON(`{"boosts":[]}`))
+		})
+	})
+
+	When("the adapter returns an error", func() {
+		BeforeEach(func() {
+			getBoostReportsResponse = nil
+			getBoostReportsErr = errors.New("an error occurred")
+		})
+
+		It("should return a 500 Internal Server Error", func() {
+			Expect(w.Code).To(Equal(http.StatusInternalServerError))
+		})
+	})
+})
@@ -0,0 +1,24 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+// StatusClientClosedRequest is the status for requests the client disconnected from before the response was written.
+// It isn't a standard status, but is widely used so that cancelled requests aren't reported as server errors.
+const StatusClientClosedRequest = 499
+
+// CancellationStatus is a function that returns the status for an error caused by the request being cancelled by the
+// client or running out of time, it returns false for any other error
+func CancellationStatus(ctx context.Context, err error) (int, bool) {
+	switch {
+	case errors.Is(err, context.Canceled), errors.Is(ctx.Err(), context.Canceled):
+		return StatusClientClosedRequest, true
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(ctx.Err(), context.DeadlineExceeded):
+		return http.StatusGatewayTimeout, true
+	default:
+		return 0, false
+	}
+}
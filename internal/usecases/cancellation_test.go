@@ -0,0 +1,85 @@
+package usecases_test
+
+import (
+	"context"
+	"fmt"
+	"github.com/AlecSmith96/dating-api/internal/entities"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"time"
+)
+
+var _ = Describe("cancelling a request", func() {
+	var w *httptest.ResponseRecorder
+
+	var validateJwtForUserUUID uuid.UUID
+	var validateJwtForUserErr error
+
+	var getEntitlementsErr error
+	var getEntitlementsCallCount int
+	var getEntitlementsDeadline time.Time
+
+	BeforeEach(func() {
+		validateJwtForUserUUID = uuid.New()
+		validateJwtForUserErr = nil
+
+		getEntitlementsErr = nil
+		getEntitlementsCallCount = 1
+		getEntitlementsDeadline = time.Time{}
+	})
+
+	JustBeforeEach(func() {
+		w = httptest.NewRecorder()
+
+		jwtProcessor.EXPECT().ValidateJwtForUser(gomock.Any(), mockJWT).Return(validateJwtForUserUUID, validateJwtForUserErr).Times(1)
+		entitlements.EXPECT().GetEntitlements(gomock.Any(), validateJwtForUserUUID).DoAndReturn(func(ctx context.Context, _ uuid.UUID) (*entities.Entitlements, error) {
+			getEntitlementsDeadline, _ = ctx.Deadline()
+			return &entities.Entitlements{Tier: entities.TierFree}, getEntitlementsErr
+		}).Times(getEntitlementsCallCount)
+
+		req, err := http.NewRequest("GET", "http://localhost:8080/dating-api/v1/user/entitlements", nil)
+		req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", mockJWT))
+		Expect(err).ToNot(HaveOccurred())
+		r.ServeHTTP(w, req)
+	})
+
+	It("should give the queries a deadline of the query timeout", func() {
+		Expect(w.Code).To(Equal(http.StatusOK))
+		Expect(getEntitlementsDeadline).To(BeTemporally("~", time.Now().Add(queryTimeout), time.Second))
+	})
+
+	When("a query runs out of time", func() {
+		BeforeEach(func() {
+			getEntitlementsErr = context.DeadlineExceeded
+		})
+
+		It("should return a 504 Gateway Timeout", func() {
+			Expect(w.Code).To(Equal(http.StatusGatewayTimeout))
+		})
+	})
+
+	When("the client cancels the request", func() {
+		BeforeEach(func() {
+			getEntitlementsErr = fmt.Errorf("getting entitlements: %w", context.Canceled)
+		})
+
+		It("should return a 499 Client Closed Request", func() {
+			Expect(w.Code).To(Equal(499))
+		})
+	})
+
+	When("validating the token runs out of time", func() {
+		BeforeEach(func() {
+			validateJwtForUserErr = context.DeadlineExceeded
+			getEntitlementsCallCount = 0
+		})
+
+		It("should return a 504 Gateway Timeout", func() {
+			Expect(w.Code).To(Equal(http.StatusGatewayTimeout))
+		})
+	})
+})
@@ -1,16 +1,19 @@
 package usecases
 
 import (
+	"context"
+	"errors"
 	"github.com/AlecSmith96/dating-api/internal/entities"
 	"github.com/brianvoe/gofakeit/v7"
 	"github.com/gin-gonic/gin"
-	"log/slog"
 	"net/http"
+	"slices"
+	"time"
 )
 
 //go:generate mockgen --build_flags=--mod=mod -destination=../../mocks/userCreator.go  . "UserCreator"
 type UserCreator interface {
-	CreateUser(user *entities.User) (*entities.User, error)
+	CreateUser(ctx context.Context, user *entities.User) (*entities.User, error)
 }
 
 // CreateUserResponseBody represents the newly generated user object
@@ -29,8 +32,12 @@ type CreateUserResponseBody struct {
 	Gender string `json:"gender"`
 	// Age the generated age for the user
 	Age int `json:"age"`
+	// Timezone the generated timezone for the user
+	Timezone string `json:"timezone"`
 	// Location the generated location for the user
 	Location Location `json:"location"`
+	// Interests the generated interests for the user
+	Interests []string `json:"interests"`
 }
 
 type Location struct {
@@ -44,32 +51,42 @@ type Location struct {
 
 // NewCreateUser generates a new user record
 // @Summary Create a new user
-// @Description Generates a new user record based on fake data
+// @Description Generates a new user record based on fake data. Generated users are always at least 18 years old.
 // @Security BearerAuth
 // @Tags users
 // @Produce json
 // @Success 200 {object} CreateUserResponseBody
-// @Failure 400
-// @Failure 500
+// @Failure 400 {object} ProblemResponseBody
+// @Failure 500 {object} ProblemResponseBody
 // @Router /user/create [post]
 func NewCreateUser(userCreator UserCreator) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		ctx := c.Request.Context()
 		newUser := &entities.User{
 			Email:       gofakeit.Email(),
 			Password:    gofakeit.Password(true, true, true, true, true, 15),
 			Name:        gofakeit.Name(),
 			Gender:      gofakeit.Gender(),
-			DateOfBirth: gofakeit.Date(),
+			DateOfBirth: fakeDateOfBirth(),
 			Location: entities.Location{
 				Latitude:  gofakeit.Address().Latitude,
 				Longitude: gofakeit.Address().Longitude,
 			},
+			Timezone:  gofakeit.TimeZoneRegion(),
+			Interests: fakeInterests(),
 		}
 
-		user, err := userCreator.CreateUser(newUser)
+		err := newUser.Validate()
 		if err != nil {
-			slog.Error("creating new user", "err", err)
-			c.JSON(http.StatusInternalServerError, entities.ErrorMessage{Message: err.Error()})
+			Logger(ctx).ErrorContext(ctx, "validating new user", "err", err)
+			c.Error(newUserError(err))
+			return
+		}
+
+		user, err := userCreator.CreateUser(ctx, newUser)
+		if err != nil {
+			Logger(ctx).ErrorContext(ctx, "creating new user", "err", err)
+			c.Error(newUserError(err))
 			return
 		}
 
@@ -80,10 +97,47 @@ func NewCreateUser(userCreator UserCreator) gin.HandlerFunc {
 			Name:     user.Name,
 			Gender:   user.Gender,
 			Age:      user.GetAge(),
+			Timezone: user.Timezone,
 			Location: Location{
 				Latitude:  user.Location.Latitude,
 				Longitude: user.Location.Longitude,
 			},
+			Interests: user.Interests,
 		})
 	}
 }
+
+// newUserError is a function that returns the error for a new user that is invalid, any other error is returned as is
+func newUserError(err error) error {
+	switch {
+	case errors.Is(err, entities.ErrUserUnderage):
+		return entities.NewError(http.StatusBadRequest, entities.CodeUserUnderage, "user is under the minimum age")
+	case errors.Is(err, entities.ErrInvalidTimezone):
+		return entities.NewError(http.StatusBadRequest, entities.CodeInvalidTimezone, "invalid timezone")
+	default:
+		return err
+	}
+}
+
+// fakeDateOfBirth is a function that generates a date of birth for a user aged between 18 and 80. The latest date is
+// two days before the users 18th birthday so the user is an adult in every timezone.
+func fakeDateOfBirth() time.Time {
+	now := time.Now().UTC()
+	dateOfBirth := gofakeit.DateRange(now.AddDate(-80, 0, 0), now.AddDate(-entities.MinimumAge, 0, -2))
+
+	return time.Date(dateOfBirth.Year(), dateOfBirth.Month(), dateOfBirth.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// fakeInterests is a function that generates between three and five different interests for a user
+func fakeInterests() []string {
+	count := gofakeit.IntRange(3, 5)
+	interests := make([]string, 0, count)
+	for len(interests) < count {
+		interest := gofakeit.Hobby()
+		if !slices.Contains(interests, interest) {
+			interests = append(interests, interest)
+		}
+	}
+
+	return interests
+}
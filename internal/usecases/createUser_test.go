@@ -1,6 +1,7 @@
 package usecases_test
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -13,6 +14,8 @@ import (
 	"go.uber.org/mock/gomock"
 	"net/http"
 	"net/http/httptest"
+	"slices"
+	"time"
 )
 
 const (
@@ -29,8 +32,10 @@ var _ = Describe("creating a user", func() {
 	var createUserResponse *entities.User
 	var createUserErr error
 	var createUserCallCount int
+	var generatedUser *entities.User
 
 	BeforeEach(func() {
+		generatedUser = nil
 		validateJwtForUserUUID = uuid.New()
 		validateJwtForUserErr = nil
 		validateJwtForUserCallCount = 1
@@ -40,11 +45,13 @@ var _ = Describe("creating a user", func() {
 			Password:    gofakeit.Password(true, true, true, true, true, 15),
 			Name:        gofakeit.Name(),
 			Gender:      gofakeit.Gender(),
-			DateOfBirth: gofakeit.Date(),
+			DateOfBirth: gofakeit.DateRange(time.Now().AddDate(-80, 0, 0), time.Now().AddDate(-18, 0, -2)),
 			Location: entities.Location{
 				Latitude:  gofakeit.Address().Latitude,
 				Longitude: gofakeit.Address().Longitude,
 			},
+			Timezone:  gofakeit.TimeZoneRegion(),
+			Interests: []string{"Hiking", "Chess", "Cooking"},
 		}
 		createUserErr = nil
 		createUserCallCount = 1
@@ -53,8 +60,11 @@ var _ = Describe("creating a user", func() {
 	JustBeforeEach(func() {
 		w = httptest.NewRecorder()
 
-		jwtProcessor.EXPECT().ValidateJwtForUser(mockJWT).Return(validateJwtForUserUUID, validateJwtForUserErr).Times(validateJwtForUserCallCount)
-		userCreator.EXPECT().CreateUser(gomock.AssignableToTypeOf(&entities.User{})).Return(createUserResponse, createUserErr).Times(createUserCallCount)
+		jwtProcessor.EXPECT().ValidateJwtForUser(gomock.Any(), mockJWT).Return(validateJwtForUserUUID, validateJwtForUserErr).Times(validateJwtForUserCallCount)
+		userCreator.EXPECT().CreateUser(gomock.Any(), gomock.AssignableToTypeOf(&entities.User{})).DoAndReturn(func(_ context.Context, user *entities.User) (*entities.User, error) {
+			generatedUser = user
+			return createUserResponse, createUserErr
+		}).Times(createUserCallCount)
 
 		req, err := http.NewRequest("POST", "http://localhost:8080/dating-api/v1/user/create", nil)
 		req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", mockJWT))
@@ -74,8 +84,26 @@ var _ = Describe("creating a user", func() {
 		Expect(user.Name).To(Equal(createUserResponse.Name))
 		Expect(user.Gender).To(Equal(createUserResponse.Gender))
 		Expect(user.Age).To(Equal(createUserResponse.GetAge()))
+		Expect(user.Timezone).To(Equal(createUserResponse.Timezone))
 		Expect(user.Location.Latitude).To(Equal(createUserResponse.Location.Latitude))
 		Expect(user.Location.Longitude).To(Equal(createUserResponse.Location.Longitude))
+		Expect(user.Interests).To(Equal(createUserResponse.Interests))
+	})
+
+	It("should only generate adult users", func() {
+		Expect(generatedUser).ToNot(BeNil())
+		Expect(generatedUser.GetAge()).To(BeNumerically(">=", entities.MinimumAge))
+		Expect(generatedUser.GetAge()).To(BeNumerically("<=", 80))
+		Expect(generatedUser.Validate()).To(Succeed())
+	})
+
+	It("should generate between three and five different interests", func() {
+		Expect(generatedUser).ToNot(BeNil())
+		Expect(len(generatedUser.Interests)).To(BeNumerically(">=", 3))
+		Expect(len(generatedUser.Interests)).To(BeNumerically("<=", 5))
+		interests := slices.Clone(generatedUser.Interests)
+		slices.Sort(interests)
+		Expect(slices.Compact(interests)).To(HaveLen(len(generatedUser.Interests)))
 	})
 
 	When("the jwt cannot be validated", func() {
@@ -100,4 +128,15 @@ var _ = Describe("creating a user", func() {
 			Expect(w.Code).To(Equal(http.StatusInternalServerError))
 		})
 	})
+
+	When("the adapter rejects the user as underage", func() {
+		BeforeEach(func() {
+			createUserResponse = nil
+			createUserErr = entities.ErrUserUnderage
+		})
+
+		It("should return a 400 Bad Request", func() {
+			Expect(w.Code).To(Equal(http.StatusBadRequest))
+		})
+	})
 })
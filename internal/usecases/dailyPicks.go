@@ -0,0 +1,176 @@
+package usecases
+
+import (
+	"context"
+	"github.com/AlecSmith96/dating-api/internal/entities"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"time"
+)
+
+//go:generate mockgen --build_flags=--mod=mod -destination=../../mocks/dailyPicksGenerator.go  . "DailyPicksGenerator"
+type DailyPicksGenerator interface {
+	// GetUsersDueDailyPicks returns up to limit users that haven't had picks generated for their current local day
+	GetUsersDueDailyPicks(ctx context.Context, limit int) ([]entities.DailyPicksUser, error)
+	// SaveDailyPicks records the users picks for the day, an empty list still marks them as picked for
+	SaveDailyPicks(ctx context.Context, user entities.DailyPicksUser, picks []entities.DailyPick) error
+}
+
+//go:generate mockgen --build_flags=--mod=mod -destination=../../mocks/dailyPicksLister.go  . "DailyPicksLister"
+type DailyPicksLister interface {
+	GetTodaysPicks(ctx context.Context, userID uuid.UUID) ([]entities.DailyPickCandidate, error)
+	GetUsersLocation(ctx context.Context, userID uuid.UUID) (*entities.Location, error)
+}
+
+// TodaysPicksResponseBody represents the requesting users daily picks
+// @Description the candidates picked for the requesting user today that they haven't swiped on yet
+type TodaysPicksResponseBody struct {
+	// Picks are the picked users, best first
+	Picks []DailyPickResponseBody `json:"picks"`
+	// ExpiresAt is when the picks expire, at the requesting users next local midnight. It is omitted when there are no
+	// picks.
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+}
+
+// DailyPickResponseBody represents a user picked for the requesting user
+// @Description a user picked for the requesting user, swipe on it through /user/swipe
+type DailyPickResponseBody struct {
+	// ID is the id of the user
+	ID string `json:"id"`
+	// Name is the name of the user
+	Name string `json:"name"`
+	// Gender is the gender of the user
+	Gender string `json:"gender"`
+	// Age is the age of the user
+	Age int `json:"age"`
+	// DistanceFromMe is the distance between the users measured in miles
+	DistanceFromMe float64 `json:"distanceFromMe"`
+}
+
+// GenerateDailyPicks is a function that picks up to perUser candidates for every user due their daily picks, in
+// batches of batchSize. Candidates are found the same way as discovery, using the users saved preferences, and ranked
+// by the picks ranker. It returns the number of users picked for.
+func GenerateDailyPicks(ctx context.Context, dailyPicksGenerator DailyPicksGenerator, userDiscoverer UserDiscoverer, picksRanker Ranker, perUser, batchSize int) (int, error) {
+	total := 0
+	for {
+		users, err := dailyPicksGenerator.GetUsersDueDailyPicks(ctx, batchSize)
+		if err != nil {
+			return total, err
+		}
+
+		for _, user := range users {
+			candidates, err := userDiscoverer.DiscoverNewUsers(ctx, user.UserID, user.Preferences)
+			if err != nil {
+				return total, err
+			}
+
+			desirability, err := userDiscoverer.GetDesirability(ctx, user.UserID)
+			if err != nil {
+				return total, err
+			}
+
+			ranked := picksRanker.Rank(entities.RankingContext{
+				Location:     user.Location,
+				PageInfo:     user.Preferences,
+				Desirability: desirability,
+				Now:          time.Now(),
+			}, candidates)
+
+			picks := make([]entities.DailyPick, 0, min(perUser, len(ranked)))
+			for i, candidate := range ranked[:min(perUser, len(ranked))] {
+				picks = append(picks, entities.DailyPick{
+					CandidateUserID: candidate.User.ID,
+					Score:           candidate.Score,
+					Rank:            i + 1,
+				})
+			}
+
+			err = dailyPicksGenerator.SaveDailyPicks(ctx, user, picks)
+			if err != nil {
+				return total, err
+			}
+			total++
+		}
+
+		if len(users) < batchSize {
+			return total, nil
+		}
+	}
+}
+
+// RunDailyPicksGenerator is a background job that generates the daily picks of users whose local day has started every
+// interval, until the context is cancelled
+func RunDailyPicksGenerator(ctx context.Context, dailyPicksGenerator DailyPicksGenerator, userDiscoverer UserDiscoverer, picksRanker Ranker, perUser, batchSize int, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		picked, err := GenerateDailyPicks(ctx, dailyPicksGenerator, userDiscoverer, picksRanker, perUser, batchSize)
+		if err != nil {
+			Logger(ctx).ErrorContext(ctx, "generating daily picks", "err", err)
+		} else {
+			Logger(ctx).DebugContext(ctx, "generated daily picks", "users", picked)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// NewGetTodaysPicks lists the requesting users daily picks
+// @Summary Get today's picks
+// @Description Lists the small set of candidates picked for the requesting user today by compatibility, from their
+// @Description saved preferences. Picks are generated once a day and expire at the users local midnight, picks the
+// @Description user has swiped on or that have been blocked since are left out.
+// @Security BearerAuth
+// @Tags users
+// @Produce json
+// @Success 200 {object} TodaysPicksResponseBody
+// @Failure 500 {object} ProblemResponseBody
+// @Router /user/picks/today [get]
+func NewGetTodaysPicks(dailyPicksLister DailyPicksLister) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		userID, ok := c.Get("userID")
+		if !ok {
+			Logger(ctx).ErrorContext(ctx, "unable to get userID from context")
+			c.Error(errUserIDMissing)
+			return
+		}
+		requestingUserID := userID.(uuid.UUID)
+
+		location, err := dailyPicksLister.GetUsersLocation(ctx, requestingUserID)
+		if err != nil {
+			Logger(ctx).ErrorContext(ctx, "getting users location", "err", err)
+			c.Error(err)
+			return
+		}
+
+		picks, err := dailyPicksLister.GetTodaysPicks(ctx, requestingUserID)
+		if err != nil {
+			Logger(ctx).ErrorContext(ctx, "getting todays picks", "err", err)
+			c.Error(err)
+			return
+		}
+
+		response := TodaysPicksResponseBody{Picks: make([]DailyPickResponseBody, 0, len(picks))}
+		for _, pick := range picks {
+			response.Picks = append(response.Picks, DailyPickResponseBody{
+				ID:             pick.ID.String(),
+				Name:           pick.Name,
+				Gender:         pick.Gender,
+				Age:            pick.Age,
+				DistanceFromMe: distanceInMiles(*location, pick.Location),
+			})
+
+			expiresAt := pick.ExpiresAt
+			response.ExpiresAt = &expiresAt
+		}
+
+		c.JSON(http.StatusOK, response)
+	}
+}
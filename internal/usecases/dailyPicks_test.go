@@ -0,0 +1,188 @@
+package usecases_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/AlecSmith96/dating-api/internal/entities"
+	"github.com/AlecSmith96/dating-api/internal/usecases"
+	mock_usecases "github.com/AlecSmith96/dating-api/mocks"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"time"
+)
+
+var _ = Describe("getting todays picks", func() {
+	var w *httptest.ResponseRecorder
+
+	var validateJwtForUserUUID uuid.UUID
+
+	var getUsersLocationResponse *entities.Location
+	var getUsersLocationErr error
+
+	var getTodaysPicksResponse []entities.DailyPickCandidate
+	var getTodaysPicksErr error
+	var getTodaysPicksCallCount int
+
+	BeforeEach(func() {
+		validateJwtForUserUUID = uuid.New()
+
+		getUsersLocationResponse = &entities.Location{Latitude: 51.4545, Longitude: -2.5879}
+		getUsersLocationErr = nil
+
+		expiresAt := time.Now().Add(5 * time.Hour).UTC().Truncate(time.Second)
+		getTodaysPicksResponse = []entities.DailyPickCandidate{
+			{ID: uuid.New(), Name: "Alex", Gender: "female", Age: 27, Location: *getUsersLocationResponse, Rank: 1, ExpiresAt: expiresAt},
+			{ID: uuid.New(), Name: "Sam", Gender: "male", Age: 31, Location: entities.Location{Latitude: 51.5072, Longitude: -0.1276}, Rank: 3, ExpiresAt: expiresAt},
+		}
+		getTodaysPicksErr = nil
+		getTodaysPicksCallCount = 1
+	})
+
+	JustBeforeEach(func() {
+		w = httptest.NewRecorder()
+
+		jwtProcessor.EXPECT().ValidateJwtForUser(gomock.Any(), mockJWT).Return(validateJwtForUserUUID, nil).Times(1)
+		dailyPicksLister.EXPECT().GetUsersLocation(gomock.Any(), validateJwtForUserUUID).Return(getUsersLocationResponse, getUsersLocationErr).Times(1)
+		dailyPicksLister.EXPECT().GetTodaysPicks(gomock.Any(), validateJwtForUserUUID).Return(getTodaysPicksResponse, getTodaysPicksErr).Times(getTodaysPicksCallCount)
+
+		req, err := http.NewRequest("GET", "http://localhost:8080/dating-api/v1/user/picks/today", nil)
+		req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", mockJWT))
+		Expect(err).ToNot(HaveOccurred())
+		r.ServeHTTP(w, req)
+	})
+
+	It("should return the picks in order with when they expire", func() {
+		Expect(w.Code).To(Equal(http.StatusOK))
+		var resp usecases.TodaysPicksResponseBody
+		err := json.NewDecoder(w.Body).Decode(&resp)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(resp.Picks).To(HaveLen(2))
+		Expect(resp.Picks[0].ID).To(Equal(getTodaysPicksResponse[0].ID.String()))
+		Expect(resp.Picks[0].DistanceFromMe).To(Equal(0.0))
+		Expect(resp.Picks[1].Name).To(Equal("Sam"))
+		Expect(resp.Picks[1].DistanceFromMe).To(BeNumerically("~", 106, 2))
+		Expect(resp.ExpiresAt).ToNot(BeNil())
+		Expect(resp.ExpiresAt.Equal(getTodaysPicksResponse[0].ExpiresAt)).To(BeTrue())
+	})
+
+	When("the user has no picks", func() {
+		BeforeEach(func() {
+			getTodaysPicksResponse = []entities.DailyPickCandidate{}
+		})
+
+		It("should return an empty list without an expiry", func() {
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(w.Body.String()).To(MatchJSON(`{"picks": []}`))
+		})
+	})
+
+	When("the users location can't be found", func() {
+		BeforeEach(func() {
+			getUsersLocationResponse = nil
+			getUsersLocationErr = errors.New("an error occurred")
+			getTodaysPicksCallCount = 0
+		})
+
+		It("should return a 500 Internal Server Error", func() {
+			Expect(w.Code).To(Equal(http.StatusInternalServerError))
+		})
+	})
+
+	When("the adapter returns an error", func() {
+		BeforeEach(func() {
+			getTodaysPicksResponse = nil
+			getTodaysPicksErr = errors.New("an error occurred")
+		})
+
+		It("should return a 500 Internal Server Error", func() {
+			Expect(w.Code).To(Equal(http.StatusInternalServerError))
+		})
+	})
+})
+
+var _ = Describe("generating daily picks", func() {
+	var dailyPicksGenerator *mock_usecases.MockDailyPicksGenerator
+	var discoverer *mock_usecases.MockUserDiscoverer
+	var picksRanker *usecases.DistanceRanker
+	var user entities.DailyPicksUser
+	var candidates []entities.UserDiscovery
+
+	BeforeEach(func() {
+		ctrl := gomock.NewController(GinkgoT())
+		dailyPicksGenerator = mock_usecases.NewMockDailyPicksGenerator(ctrl)
+		discoverer = mock_usecases.NewMockUserDiscoverer(ctrl)
+		picksRanker = usecases.NewDistanceRanker()
+
+		user = entities.DailyPicksUser{
+			UserID:      uuid.New(),
+			Location:    entities.Location{Latitude: 51.4545, Longitude: -2.5879},
+			Preferences: entities.PageInfo{MinAge: 25, MaxAge: 35, PreferredGenders: []string{"female"}},
+		}
+
+		candidates = []entities.UserDiscovery{
+			{ID: uuid.New(), Location: entities.Location{Latitude: 55.9533, Longitude: -3.1883}},
+			{ID: uuid.New(), Location: entities.Location{Latitude: 51.4545, Longitude: -2.5879}},
+			{ID: uuid.New(), Location: entities.Location{Latitude: 51.5072, Longitude: -0.1276}},
+		}
+	})
+
+	It("should save the best candidates from the users preferences for each user due picks", func() {
+		gomock.InOrder(
+			dailyPicksGenerator.EXPECT().GetUsersDueDailyPicks(gomock.Any(), 10).Return([]entities.DailyPicksUser{user}, nil),
+			discoverer.EXPECT().DiscoverNewUsers(gomock.Any(), user.UserID, user.Preferences).Return(candidates, nil),
+			discoverer.EXPECT().GetDesirability(gomock.Any(), user.UserID).Return(entities.DefaultDesirability, nil),
+			dailyPicksGenerator.EXPECT().SaveDailyPicks(gomock.Any(), user, []entities.DailyPick{
+				{CandidateUserID: candidates[1].ID, Rank: 1},
+				{CandidateUserID: candidates[2].ID, Rank: 2},
+			}).Return(nil),
+		)
+
+		picked, err := usecases.GenerateDailyPicks(context.Background(), dailyPicksGenerator, discoverer, picksRanker, 2, 10)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(picked).To(Equal(1))
+	})
+
+	It("should still save an empty set for users without candidates", func() {
+		gomock.InOrder(
+			dailyPicksGenerator.EXPECT().GetUsersDueDailyPicks(gomock.Any(), 10).Return([]entities.DailyPicksUser{user}, nil),
+			discoverer.EXPECT().DiscoverNewUsers(gomock.Any(), user.UserID, user.Preferences).Return(nil, nil),
+			discoverer.EXPECT().GetDesirability(gomock.Any(), user.UserID).Return(entities.DefaultDesirability, nil),
+			dailyPicksGenerator.EXPECT().SaveDailyPicks(gomock.Any(), user, []entities.DailyPick{}).Return(nil),
+		)
+
+		picked, err := usecases.GenerateDailyPicks(context.Background(), dailyPicksGenerator, discoverer, picksRanker, 2, 10)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(picked).To(Equal(1))
+	})
+
+	It("should keep fetching users until a batch isn't full", func() {
+		gomock.InOrder(
+			dailyPicksGenerator.EXPECT().GetUsersDueDailyPicks(gomock.Any(), 1).Return([]entities.DailyPicksUser{user}, nil),
+			discoverer.EXPECT().DiscoverNewUsers(gomock.Any(), user.UserID, user.Preferences).Return(nil, nil),
+			discoverer.EXPECT().GetDesirability(gomock.Any(), user.UserID).Return(entities.DefaultDesirability, nil),
+			dailyPicksGenerator.EXPECT().SaveDailyPicks(gomock.Any(), user, gomock.Any()).Return(nil),
+			dailyPicksGenerator.EXPECT().GetUsersDueDailyPicks(gomock.Any(), 1).Return(nil, nil),
+		)
+
+		picked, err := usecases.GenerateDailyPicks(context.Background(), dailyPicksGenerator, discoverer, picksRanker, 2, 1)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(picked).To(Equal(1))
+	})
+
+	It("should stop without saving picks if the candidates can't be found", func() {
+		gomock.InOrder(
+			dailyPicksGenerator.EXPECT().GetUsersDueDailyPicks(gomock.Any(), 10).Return([]entities.DailyPicksUser{user}, nil),
+			discoverer.EXPECT().DiscoverNewUsers(gomock.Any(), user.UserID, user.Preferences).Return(nil, errors.New("an error occurred")),
+		)
+
+		picked, err := usecases.GenerateDailyPicks(context.Background(), dailyPicksGenerator, discoverer, picksRanker, 2, 10)
+		Expect(err).To(MatchError("an error occurred"))
+		Expect(picked).To(Equal(0))
+	})
+})
@@ -0,0 +1,62 @@
+package usecases
+
+import (
+	"context"
+	"time"
+)
+
+//go:generate mockgen --build_flags=--mod=mod -destination=../../mocks/desirabilityUpdater.go  . "DesirabilityUpdater"
+type DesirabilityUpdater interface {
+	ApplyDesirabilitySwipes(ctx context.Context, limit int) (int, error)
+	ResetDesirability(ctx context.Context) error
+}
+
+// UpdateDesirability is a function that applies every swipe that hasn't been applied yet to the swiped users
+// desirability scores, in batches of batchSize. It returns the number of swipes applied.
+func UpdateDesirability(ctx context.Context, desirabilityUpdater DesirabilityUpdater, batchSize int) (int, error) {
+	total := 0
+	for {
+		applied, err := desirabilityUpdater.ApplyDesirabilitySwipes(ctx, batchSize)
+		total += applied
+		if err != nil {
+			return total, err
+		}
+
+		if applied < batchSize {
+			return total, nil
+		}
+	}
+}
+
+// RecomputeDesirability is a function that resets every users desirability score and replays all swipes from scratch,
+// in the order they were made. It returns the number of swipes replayed.
+func RecomputeDesirability(ctx context.Context, desirabilityUpdater DesirabilityUpdater, batchSize int) (int, error) {
+	err := desirabilityUpdater.ResetDesirability(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	return UpdateDesirability(ctx, desirabilityUpdater, batchSize)
+}
+
+// RunDesirabilityUpdater is a background job that applies new swipes to the swiped users desirability scores every
+// interval, until the context is cancelled.
+func RunDesirabilityUpdater(ctx context.Context, desirabilityUpdater DesirabilityUpdater, batchSize int, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		applied, err := UpdateDesirability(ctx, desirabilityUpdater, batchSize)
+		if err != nil {
+			Logger(ctx).ErrorContext(ctx, "updating desirability", "err", err)
+		} else {
+			Logger(ctx).DebugContext(ctx, "updated desirability", "applied", applied)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
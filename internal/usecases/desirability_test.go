@@ -0,0 +1,64 @@
+package usecases_test
+
+import (
+	"context"
+	"errors"
+	"github.com/AlecSmith96/dating-api/internal/usecases"
+	mock_usecases "github.com/AlecSmith96/dating-api/mocks"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+)
+
+var _ = Describe("updating desirability", func() {
+	var desirabilityUpdater *mock_usecases.MockDesirabilityUpdater
+
+	BeforeEach(func() {
+		desirabilityUpdater = mock_usecases.NewMockDesirabilityUpdater(gomock.NewController(GinkgoT()))
+	})
+
+	It("should apply swipes in batches until a batch isn't full", func() {
+		gomock.InOrder(
+			desirabilityUpdater.EXPECT().ApplyDesirabilitySwipes(gomock.Any(), 10).Return(10, nil),
+			desirabilityUpdater.EXPECT().ApplyDesirabilitySwipes(gomock.Any(), 10).Return(10, nil),
+			desirabilityUpdater.EXPECT().ApplyDesirabilitySwipes(gomock.Any(), 10).Return(3, nil),
+		)
+
+		applied, err := usecases.UpdateDesirability(context.Background(), desirabilityUpdater, 10)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(applied).To(Equal(23))
+	})
+
+	It("should return the swipes applied before an error", func() {
+		gomock.InOrder(
+			desirabilityUpdater.EXPECT().ApplyDesirabilitySwipes(gomock.Any(), 10).Return(10, nil),
+			desirabilityUpdater.EXPECT().ApplyDesirabilitySwipes(gomock.Any(), 10).Return(0, errors.New("an error occurred")),
+		)
+
+		applied, err := usecases.UpdateDesirability(context.Background(), desirabilityUpdater, 10)
+		Expect(err).To(MatchError("an error occurred"))
+		Expect(applied).To(Equal(10))
+	})
+
+	Describe("recomputing desirability", func() {
+		It("should reset every score before replaying all swipes", func() {
+			gomock.InOrder(
+				desirabilityUpdater.EXPECT().ResetDesirability(gomock.Any()).Return(nil),
+				desirabilityUpdater.EXPECT().ApplyDesirabilitySwipes(gomock.Any(), 10).Return(10, nil),
+				desirabilityUpdater.EXPECT().ApplyDesirabilitySwipes(gomock.Any(), 10).Return(0, nil),
+			)
+
+			replayed, err := usecases.RecomputeDesirability(context.Background(), desirabilityUpdater, 10)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(replayed).To(Equal(10))
+		})
+
+		It("should not replay any swipes if the reset fails", func() {
+			desirabilityUpdater.EXPECT().ResetDesirability(gomock.Any()).Return(errors.New("an error occurred"))
+
+			replayed, err := usecases.RecomputeDesirability(context.Background(), desirabilityUpdater, 10)
+			Expect(err).To(MatchError("an error occurred"))
+			Expect(replayed).To(Equal(0))
+		})
+	})
+})
@@ -1,24 +1,61 @@
 package usecases
 
 import (
-	"cmp"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"github.com/AlecSmith96/dating-api/internal/entities"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
-	"github.com/umahmood/haversine"
-	"log/slog"
+	"maps"
 	"net/http"
-	"slices"
+	"time"
+)
+
+const (
+	defaultDiscoverLimit = 20
+	maxDiscoverLimit     = 100
+	// discoverySnapshotPages is how many pages of users the first page ranks and keeps for the cursors after it, once
+	// they have all been returned the first page is requested again for more
+	discoverySnapshotPages = 10
+	// discoverySnapshotMaxAge is how long the cursors of a discover request can be used for, after which the first page
+	// has to be requested again
+	discoverySnapshotMaxAge = time.Hour
 )
 
 //go:generate mockgen --build_flags=--mod=mod -destination=../../mocks/userDiscoverer.go  . "UserDiscoverer"
 type UserDiscoverer interface {
-	DiscoverNewUsers(ownerUserID uuid.UUID, pageInfo entities.PageInfo) ([]entities.UserDiscovery, error)
-	GetUsersLocation(userID uuid.UUID) (*entities.Location, error)
+	DiscoverNewUsers(ctx context.Context, ownerUserID uuid.UUID, pageInfo entities.PageInfo) ([]entities.UserDiscovery, error)
+	GetUsersLocation(ctx context.Context, userID uuid.UUID) (*entities.Location, error)
+	RecordProfileViews(ctx context.Context, userIDs []uuid.UUID) error
+	GetDesirability(ctx context.Context, userID uuid.UUID) (float64, error)
+	GetAnswersForUsers(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID][]entities.UserAnswer, error)
+	SaveDiscoverySnapshot(ctx context.Context, userID uuid.UUID, rankedUserIDs []uuid.UUID) (*entities.DiscoverySnapshot, error)
+	GetDiscoverySnapshot(ctx context.Context, userID, snapshotID uuid.UUID, maxAge time.Duration) (*entities.DiscoverySnapshot, error)
+}
+
+// DiscoverPotentialMatchesRequestQuery represents the filters and the page of the returned list of users
+type DiscoverPotentialMatchesRequestQuery struct {
+	// MinAge is the minimum age of any users returned in the list
+	MinAge int `form:"minAge" binding:"omitempty,min=18"`
+	// MaxAge is the maximum age of any users returned in the list
+	MaxAge int `form:"maxAge" binding:"omitempty,min=18,gtefield=MinAge"`
+	// Genders are the genders to include in the list, the parameter is repeated for each gender
+	Genders []string `form:"gender" binding:"omitempty,dive,gender"`
+	// MinCompatibility is the minimum compatibility percentage of any users returned in the list
+	MinCompatibility int `form:"minCompatibility" binding:"omitempty,min=0,max=100"`
+	// MaxDistance is the furthest away in miles any users returned in the list can be
+	MaxDistance float64 `form:"maxDistance" binding:"omitempty,gt=0"`
+	// Limit is the maximum number of users to return
+	Limit int `form:"limit" binding:"omitempty,min=1"`
+	// Cursor is the nextCursor of the previous page, it is left out for the first page
+	Cursor string `form:"cursor"`
 }
 
 // DiscoverPotentialMatchesRequestBody represents the filters for the returned list of users
-// @Description the request body for the discover endpoint
+// @Description the request body for the discover endpoint, deprecated in favour of the query parameters
 type DiscoverPotentialMatchesRequestBody struct {
 	// PageInfo represents the filter information for the request
 	PageInfo PageInfo `json:"pageInfo"`
@@ -28,11 +65,14 @@ type DiscoverPotentialMatchesRequestBody struct {
 // @Description the filter information for the request
 type PageInfo struct {
 	// MinAge is the minimum age of any users returned in the list
-	MinAge int `json:"minAge"`
+	MinAge int `json:"minAge" binding:"omitempty,min=18"`
 	// MaxAge is the maximum age of any users returned in the list
-	MaxAge int `json:"maxAge"`
+	MaxAge int `json:"maxAge" binding:"omitempty,min=18,gtefield=MinAge"`
 	// PreferredGenders is an array of genders to include in the list
-	PreferredGenders []string `json:"preferredGenders"`
+	PreferredGenders []string `json:"preferredGenders" binding:"omitempty,dive,gender"`
+	// MinCompatibility is the minimum compatibility percentage of any users returned in the list, users without
+	// questions answered in common are left out when it is set
+	MinCompatibility int `json:"minCompatibility" binding:"omitempty,min=0,max=100"`
 }
 
 // DiscoverPotentialMatchesResponseBody represents the response of the discover endpoint
@@ -40,6 +80,58 @@ type PageInfo struct {
 type DiscoverPotentialMatchesResponseBody struct {
 	// Users is the returned list of all users matching the filter criteria
 	Users []UserResponseBody `json:"users"`
+	// NextCursor is the cursor of the next page, it is omitted on the last page
+	NextCursor *string `json:"nextCursor,omitempty"`
+	// Debug is how the users were ranked, it is only returned to admins that request it
+	Debug *DiscoveryDebugResponseBody `json:"debug,omitempty"`
+}
+
+// discoverCursor is the position in the snapshot of ranked users that a page starts from
+type discoverCursor struct {
+	SnapshotID uuid.UUID `json:"snapshotId"`
+	Position   int       `json:"position"`
+}
+
+// DiscoveryDebugResponseBody represents the ranker used for discovery
+// @Description the ranker that ordered the users and the weight of each signal
+type DiscoveryDebugResponseBody struct {
+	// Ranker is the name of the ranker
+	Ranker string `json:"ranker"`
+	// Weights is how much each signal counts towards a users score, it is omitted for rankers that don't weight signals
+	Weights *RankingSignalsResponseBody `json:"weights,omitempty"`
+}
+
+// RankingResponseBody represents how a user was ranked
+// @Description the users score and how they scored on each signal
+type RankingResponseBody struct {
+	// Score is the weighted sum of the signals, higher scores are listed first
+	Score float64 `json:"score"`
+	// Signals is how the user scored on each signal, from 0 to 1
+	Signals RankingSignalsResponseBody `json:"signals"`
+	// RecommendationRank is the users position in the recommendations from the swipe history, it is omitted if they
+	// weren't recommended
+	RecommendationRank int `json:"recommendationRank,omitempty"`
+}
+
+// RankingSignalsResponseBody represents a value for each discovery ranking signal
+// @Description a value for each of the signals users are ranked on
+type RankingSignalsResponseBody struct {
+	// Distance is how close the user is
+	Distance float64 `json:"distance"`
+	// AgeFit is how close the users age is to the middle of the requested age range
+	AgeFit float64 `json:"ageFit"`
+	// SharedInterests is how many interests the users have in common
+	SharedInterests float64 `json:"sharedInterests"`
+	// RecentActivity is how recently the user last swiped
+	RecentActivity float64 `json:"recentActivity"`
+	// Boost is whether the user has an active boost
+	Boost float64 `json:"boost"`
+	// Popularity is how often the user is liked
+	Popularity float64 `json:"popularity"`
+	// Reciprocal is how likely the user is to like the requesting user back
+	Reciprocal float64 `json:"reciprocal"`
+	// DesirabilityFit is how close the users desirability is to the requesting users
+	DesirabilityFit float64 `json:"desirabilityFit"`
 }
 
 // UserResponseBody represents a user that is returned by the discover endpoint
@@ -55,76 +147,375 @@ type UserResponseBody struct {
 	Age int `json:"age"`
 	// DistanceFromMe is the distance between the users measured in miles
 	DistanceFromMe float64 `json:"distanceFromMe"`
+	// SuperLikedMe is true when the user has super liked the requesting user
+	SuperLikedMe bool `json:"superLikedMe"`
+	// Compatibility is the percentage match of the users answers to questions, it is omitted if the users have no
+	// questions answered in common
+	Compatibility *int `json:"compatibility,omitempty"`
+	// Ranking is how the user was ranked, it is only returned to admins that request it
+	Ranking *RankingResponseBody `json:"ranking,omitempty"`
 }
 
 // NewDiscoverPotentialMatches get a filterable list of users
 // @Summary Discover new users
-// @Description Gets a filterable list of new users
+// @Description Gets a filterable list of new users ranked by the discovery ranker, users that have super liked the
+// @Description requesting user are listed first. Each user has their compatibility with the requesting user from the
+// @Description questions both have answered, set minCompatibility to only list users at least that compatible. Admins
+// @Description can set debug to see the ranker, its weights and each users score.
+// @Description The filters used to be sent as a request body, which is still accepted for now but is deprecated as many
+// @Description clients and proxies drop the body of GET requests. When a body is sent its filters are used instead of
+// @Description the filter query parameters.
 // @Security BearerAuth
 // @Tags users
 // @Accept json
 // @Produce json
-// @Param user body DiscoverPotentialMatchesRequestBody true "Discover Potential Matches Request Body"
+// @Param minAge query int false "The minimum age of the users, at least 18"
+// @Param maxAge query int false "The maximum age of the users, at least 18 and minAge"
+// @Param gender query []string false "The genders of the users, repeated for each gender" collectionFormat(multi) Enums(male, female)
+// @Param minCompatibility query int false "The minimum compatibility percentage of the users"
+// @Param maxDistance query number false "The furthest away in miles the users can be"
+// @Param limit query int false "The maximum number of users to return"
+// @Param cursor query string false "The nextCursor of the previous page"
+// @Param user body DiscoverPotentialMatchesRequestBody false "Deprecated, use the query parameters instead"
+// @Param debug query bool false "Include how the users were ranked, admins only"
 // @Success 200 {object} DiscoverPotentialMatchesResponseBody
-// @Failure 400
-// @Failure 500
+// @Failure 400 {object} ProblemResponseBody
+// @Failure 403 {object} ProblemResponseBody
+// @Failure 500 {object} ProblemResponseBody
 // @Router /user/discover [get]
-func NewDiscoverPotentialMatches(discoverer UserDiscoverer) gin.HandlerFunc {
+func NewDiscoverPotentialMatches(discoverer UserDiscoverer, ranker Ranker, roleChecker RoleChecker) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		ctx := c.Request.Context()
 		userID, ok := c.Get("userID")
 		if !ok {
-			slog.Error("unable to get userID from context")
-			c.JSON(http.StatusInternalServerError, entities.ErrorMessage{Message: "unable to get users"})
+			Logger(ctx).ErrorContext(ctx, "unable to get userID from context")
+			c.Error(errUserIDMissing)
 			return
 		}
 
-		var request DiscoverPotentialMatchesRequestBody
-		err := c.ShouldBindJSON(&request)
+		var request DiscoverPotentialMatchesRequestQuery
+		err := c.ShouldBindQuery(&request)
 		if err != nil {
-			slog.Error("validating request body", "err", err)
-			c.JSON(http.StatusBadRequest, entities.ErrorMessage{Message: "unable to validate request body"})
+			Logger(ctx).ErrorContext(ctx, "validating request query", "err", err)
+			c.Error(bindingError(err))
 			return
 		}
 
+		pageInfo := entities.PageInfo{
+			MinAge:           request.MinAge,
+			MaxAge:           request.MaxAge,
+			PreferredGenders: request.Genders,
+		}
+		minCompatibility := request.MinCompatibility
+
+		// older clients send the filters as a body, which takes the place of the query parameters
+		if c.Request.Body != nil && c.Request.Body != http.NoBody {
+			var requestBody DiscoverPotentialMatchesRequestBody
+			err = c.ShouldBindJSON(&requestBody)
+			if err != nil {
+				Logger(ctx).ErrorContext(ctx, "validating request body", "err", err)
+				c.Error(bindingError(err))
+				return
+			}
+			Logger(ctx).InfoContext(ctx, "discover filters sent in the deprecated request body")
+
+			pageInfo = entities.PageInfo{
+				MinAge:           requestBody.PageInfo.MinAge,
+				MaxAge:           requestBody.PageInfo.MaxAge,
+				PreferredGenders: requestBody.PageInfo.PreferredGenders,
+			}
+			minCompatibility = requestBody.PageInfo.MinCompatibility
+		}
+
+		cursor, err := decodeDiscoverCursor(request.Cursor)
+		if err != nil {
+			Logger(ctx).ErrorContext(ctx, "decoding cursor", "err", err)
+			c.Error(invalidField("cursor", "cursor", "must be the nextCursor of a previous page"))
+			return
+		}
+
+		limit := defaultDiscoverLimit
+		if request.Limit != 0 {
+			limit = min(request.Limit, maxDiscoverLimit)
+		}
+
 		requestingUserID := userID.(uuid.UUID)
-		users, err := discoverer.DiscoverNewUsers(requestingUserID, entities.PageInfo{
-			MinAge:           request.PageInfo.MinAge,
-			MaxAge:           request.PageInfo.MaxAge,
-			PreferredGenders: request.PageInfo.PreferredGenders,
-		})
+		debug := c.Query("debug") == "true"
+		if debug {
+			role, err := roleChecker.GetUserRole(ctx, requestingUserID)
+			if err != nil {
+				Logger(ctx).ErrorContext(ctx, "getting user role", "err", err)
+				c.Error(err)
+				return
+			}
+
+			if role != entities.RoleAdmin {
+				c.Error(entities.NewError(http.StatusForbidden, entities.CodeInsufficientPermissions, "insufficient permissions"))
+				return
+			}
+		}
+
+		var snapshot *entities.DiscoverySnapshot
+		if cursor != nil {
+			snapshot, err = discoverer.GetDiscoverySnapshot(ctx, requestingUserID, cursor.SnapshotID, discoverySnapshotMaxAge)
+			if errors.Is(err, entities.ErrSnapshotNotFound) || (err == nil && cursor.Position > len(snapshot.RankedUserIDs)) {
+				c.Error(invalidField("cursor", "cursor", "must be the nextCursor of a recent page"))
+				return
+			}
+			if err != nil {
+				Logger(ctx).ErrorContext(ctx, "getting discovery snapshot", "err", err)
+				c.Error(err)
+				return
+			}
+		}
+
+		location, err := discoverer.GetUsersLocation(ctx, requestingUserID)
 		if err != nil {
-			slog.Error("getting users", "err", err)
-			c.JSON(http.StatusInternalServerError, entities.ErrorMessage{Message: "unable to get users"})
+			Logger(ctx).ErrorContext(ctx, "getting requesting users location", "err", err)
+			c.Error(err)
 			return
 		}
 
-		location, err := discoverer.GetUsersLocation(requestingUserID)
+		desirability, err := discoverer.GetDesirability(ctx, requestingUserID)
 		if err != nil {
-			slog.Error("getting requesting users location", "err", err)
-			c.JSON(http.StatusInternalServerError, entities.ErrorMessage{Message: "an internal error occurred"})
+			Logger(ctx).ErrorContext(ctx, "getting requesting users desirability", "err", err)
+			c.Error(err)
 			return
 		}
 
+		// users out of range are left out by the query, so that only users who qualify are scored and ranked
+		pageInfo.MaxDistance = request.MaxDistance
+		pageInfo.Origin = *location
+		candidates := discoveryCandidates{
+			discoverer:       discoverer,
+			ranker:           ranker,
+			requestingUserID: requestingUserID,
+			rankingContext: entities.RankingContext{
+				Location:     *location,
+				PageInfo:     pageInfo,
+				Desirability: desirability,
+				Now:          time.Now(),
+			},
+			minCompatibility: minCompatibility,
+		}
+
+		var page []entities.RankedCandidate
+		var compatibilities map[uuid.UUID]int
+		var nextCursor *discoverCursor
+		if snapshot == nil {
+			var rankedUsers []entities.RankedCandidate
+			rankedUsers, compatibilities, err = candidates.rank(ctx, nil, limit*discoverySnapshotPages)
+			if err != nil {
+				c.Error(err)
+				return
+			}
+
+			page = rankedUsers[:min(limit, len(rankedUsers))]
+			// the query caps how many users are ranked, so the snapshot never holds more than discoverySnapshotPages pages
+			if len(rankedUsers) > limit {
+				rankedUserIDs := make([]uuid.UUID, len(rankedUsers))
+				for i, rankedUser := range rankedUsers {
+					rankedUserIDs[i] = rankedUser.User.ID
+				}
+
+				snapshot, err = discoverer.SaveDiscoverySnapshot(ctx, requestingUserID, rankedUserIDs)
+				if err != nil {
+					Logger(ctx).ErrorContext(ctx, "saving discovery snapshot", "err", err)
+					c.Error(err)
+					return
+				}
+				nextCursor = &discoverCursor{SnapshotID: snapshot.ID, Position: limit}
+			}
+		} else {
+			var position int
+			page, compatibilities, position, err = candidates.fromSnapshot(ctx, snapshot, cursor.Position, limit)
+			if err != nil {
+				c.Error(err)
+				return
+			}
+
+			if position < len(snapshot.RankedUserIDs) {
+				nextCursor = &discoverCursor{SnapshotID: snapshot.ID, Position: position}
+			}
+		}
+
 		var returnedUsers []UserResponseBody
+		shownUserIDs := make([]uuid.UUID, 0, len(page))
+		for _, rankedUser := range page {
+			returnedUser := UserResponseBody{
+				ID:             rankedUser.User.ID.String(),
+				Name:           rankedUser.User.Name,
+				Gender:         rankedUser.User.Gender,
+				Age:            rankedUser.User.Age,
+				DistanceFromMe: rankedUser.DistanceFromMe,
+				SuperLikedMe:   rankedUser.User.SuperLikedMe,
+			}
+			if compatibility, ok := compatibilities[rankedUser.User.ID]; ok {
+				returnedUser.Compatibility = &compatibility
+			}
+			if debug {
+				returnedUser.Ranking = &RankingResponseBody{
+					Score:              rankedUser.Score,
+					Signals:            RankingSignalsResponseBody(rankedUser.Signals),
+					RecommendationRank: rankedUser.User.RecommendationRank,
+				}
+			}
+
+			returnedUsers = append(returnedUsers, returnedUser)
+			shownUserIDs = append(shownUserIDs, rankedUser.User.ID)
+		}
+
+		err = discoverer.RecordProfileViews(ctx, shownUserIDs)
+		if err != nil {
+			Logger(ctx).ErrorContext(ctx, "recording profile views", "err", err)
+		}
+
+		response := DiscoverPotentialMatchesResponseBody{Users: returnedUsers}
+		if nextCursor != nil {
+			encodedCursor := encodeDiscoverCursor(*nextCursor)
+			response.NextCursor = &encodedCursor
+		}
+		if debug {
+			response.Debug = &DiscoveryDebugResponseBody{Ranker: ranker.Name()}
+			if weights := ranker.Weights(); weights != nil {
+				response.Debug.Weights = (*RankingSignalsResponseBody)(weights)
+			}
+		}
+
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+// discoveryCandidates finds and ranks the users for a discover request
+type discoveryCandidates struct {
+	discoverer       UserDiscoverer
+	ranker           Ranker
+	requestingUserID uuid.UUID
+	rankingContext   entities.RankingContext
+	minCompatibility int
+}
+
+// rank is a function that returns the users matching the filters ranked for the requesting user, along with their
+// compatibility with the requesting user. Only the users in userIDs are returned when it is set, and no more than
+// limit users are ranked when it is set.
+func (d discoveryCandidates) rank(ctx context.Context, userIDs []uuid.UUID, limit int) ([]entities.RankedCandidate, map[uuid.UUID]int, error) {
+	pageInfo := d.rankingContext.PageInfo
+	pageInfo.UserIDs = userIDs
+	pageInfo.Limit = limit
+	users, err := d.discoverer.DiscoverNewUsers(ctx, d.requestingUserID, pageInfo)
+	if err != nil {
+		Logger(ctx).ErrorContext(ctx, "getting users", "err", err)
+		return nil, nil, err
+	}
+
+	compatibilities, err := getCompatibilities(ctx, d.discoverer, d.requestingUserID, users)
+	if err != nil {
+		Logger(ctx).ErrorContext(ctx, "getting compatibilities", "err", err)
+		return nil, nil, err
+	}
+
+	if d.minCompatibility > 0 {
+		compatibleUsers := make([]entities.UserDiscovery, 0, len(users))
 		for _, user := range users {
-			requestingUserLocation := haversine.Coord{Lat: location.Latitude, Lon: location.Longitude}
-			userLocation := haversine.Coord{Lat: user.Location.Latitude, Lon: user.Location.Longitude}
+			compatibility, ok := compatibilities[user.ID]
+			if ok && compatibility >= d.minCompatibility {
+				compatibleUsers = append(compatibleUsers, user)
+			}
+		}
+		users = compatibleUsers
+	}
 
-			distanceInMiles, _ := haversine.Distance(requestingUserLocation, userLocation)
+	return d.ranker.Rank(d.rankingContext, users), compatibilities, nil
+}
+
+// fromSnapshot is a function that returns up to limit users from the snapshot starting at position, in the order they
+// were ranked when the snapshot was taken. Users that no longer match, such as those swiped on or blocked since, are
+// skipped rather than moving the users after them. It also returns the position the next page starts from.
+func (d discoveryCandidates) fromSnapshot(ctx context.Context, snapshot *entities.DiscoverySnapshot, position, limit int) ([]entities.RankedCandidate, map[uuid.UUID]int, int, error) {
+	var page []entities.RankedCandidate
+	compatibilities := map[uuid.UUID]int{}
+	for len(page) < limit && position < len(snapshot.RankedUserIDs) {
+		window := snapshot.RankedUserIDs[position:min(position+limit-len(page), len(snapshot.RankedUserIDs))]
+		position += len(window)
 
-			returnedUsers = append(returnedUsers, UserResponseBody{
-				ID:             user.ID.String(),
-				Name:           user.Name,
-				Gender:         user.Gender,
-				Age:            user.Age,
-				DistanceFromMe: distanceInMiles,
-			})
+		rankedUsers, windowCompatibilities, err := d.rank(ctx, window, 0)
+		if err != nil {
+			return nil, nil, 0, err
 		}
 
-		slices.SortFunc(returnedUsers, func(a, b UserResponseBody) int {
-			return cmp.Compare(a.DistanceFromMe, b.DistanceFromMe)
-		})
+		rankedUsersByID := make(map[uuid.UUID]entities.RankedCandidate, len(rankedUsers))
+		for _, rankedUser := range rankedUsers {
+			rankedUsersByID[rankedUser.User.ID] = rankedUser
+		}
 
-		c.JSON(http.StatusOK, DiscoverPotentialMatchesResponseBody{Users: returnedUsers})
+		for _, userID := range window {
+			if rankedUser, ok := rankedUsersByID[userID]; ok {
+				page = append(page, rankedUser)
+			}
+		}
+		maps.Copy(compatibilities, windowCompatibilities)
 	}
+
+	return page, compatibilities, position, nil
+}
+
+// getCompatibilities is a function that returns the requesting users compatibility with each of the users, keyed by user
+// id. Users with no questions answered in common with the requesting user are left out.
+func getCompatibilities(ctx context.Context, discoverer UserDiscoverer, requestingUserID uuid.UUID, users []entities.UserDiscovery) (map[uuid.UUID]int, error) {
+	compatibilities := make(map[uuid.UUID]int, len(users))
+	if len(users) == 0 {
+		return compatibilities, nil
+	}
+
+	userIDs := make([]uuid.UUID, 0, len(users)+1)
+	userIDs = append(userIDs, requestingUserID)
+	for _, user := range users {
+		userIDs = append(userIDs, user.ID)
+	}
+
+	answers, err := discoverer.GetAnswersForUsers(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, user := range users {
+		if compatibility, ok := entities.Compatibility(answers[requestingUserID], answers[user.ID]); ok {
+			compatibilities[user.ID] = compatibility
+		}
+	}
+
+	return compatibilities, nil
+}
+
+// encodeDiscoverCursor is a function that returns the cursor for the page starting at the position in the snapshot
+func encodeDiscoverCursor(cursor discoverCursor) string {
+	encoded, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(encoded)
+}
+
+// decodeDiscoverCursor is a function that returns the position in the snapshot that the cursor starts from, an empty
+// cursor is the first page and has no snapshot
+func decodeDiscoverCursor(cursor string) (*discoverCursor, error) {
+	if cursor == "" {
+		return nil, nil
+	}
+
+	decoded, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	var discoverCursor discoverCursor
+	err = json.Unmarshal(decoded, &discoverCursor)
+	if err != nil {
+		return nil, err
+	}
+	if discoverCursor.SnapshotID == uuid.Nil {
+		return nil, errors.New("cursor has no snapshot")
+	}
+	if discoverCursor.Position < 0 {
+		return nil, fmt.Errorf("cursor position %d is negative", discoverCursor.Position)
+	}
+
+	return &discoverCursor, nil
 }
@@ -2,17 +2,24 @@ package usecases_test
 
 import (
 	"bytes"
+	"context"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"github.com/AlecSmith96/dating-api/internal/adapters/memory"
 	"github.com/AlecSmith96/dating-api/internal/entities"
 	"github.com/AlecSmith96/dating-api/internal/usecases"
 	"github.com/brianvoe/gofakeit/v7"
+	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
 	"net/http"
 	"net/http/httptest"
+	"strings"
+	"time"
 )
 
 var _ = Describe("discovering potential matches", func() {
@@ -24,6 +31,7 @@ var _ = Describe("discovering potential matches", func() {
 	var validateJwtForUserErr error
 	var validateJwtForUserCallCount int
 
+	var discoverNewUsersPageInfo entities.PageInfo
 	var discoverNewUsersResponse []entities.UserDiscovery
 	var discoverNewUsersErr error
 	var discoverNewUsersCallCount int
@@ -32,6 +40,33 @@ var _ = Describe("discovering potential matches", func() {
 	var getUsersLocationErr error
 	var getUsersLocationCallCount int
 
+	var recordProfileViewsErr error
+	var recordProfileViewsCallCount int
+
+	var getDesirabilityResponse float64
+	var getDesirabilityErr error
+	var getDesirabilityCallCount int
+
+	var getAnswersForUsersResponse map[uuid.UUID][]entities.UserAnswer
+	var getAnswersForUsersErr error
+	var getAnswersForUsersCallCount int
+
+	var requestURL string
+
+	var getUserRoleResponse entities.Role
+	var getUserRoleErr error
+	var getUserRoleCallCount int
+
+	var saveDiscoverySnapshotRankedUserIDs []uuid.UUID
+	var saveDiscoverySnapshotResponse *entities.DiscoverySnapshot
+	var saveDiscoverySnapshotErr error
+	var saveDiscoverySnapshotCallCount int
+
+	var getDiscoverySnapshotID uuid.UUID
+	var getDiscoverySnapshotResponse *entities.DiscoverySnapshot
+	var getDiscoverySnapshotErr error
+	var getDiscoverySnapshotCallCount int
+
 	BeforeEach(func() {
 		requestBody = &usecases.DiscoverPotentialMatchesRequestBody{}
 		var err error
@@ -42,11 +77,11 @@ var _ = Describe("discovering potential matches", func() {
 		validateJwtForUserErr = nil
 		validateJwtForUserCallCount = 1
 
+		// the first page ranks up to ten pages of the default limit
+		discoverNewUsersPageInfo = entities.PageInfo{Limit: 200}
 		discoverNewUsersResponse = []entities.UserDiscovery{
 			{
 				ID:          uuid.New(),
-				Email:       gofakeit.Email(),
-				Password:    gofakeit.Password(true, true, true, true, true, 15),
 				Name:        gofakeit.Name(),
 				Gender:      gofakeit.Gender(),
 				DateOfBirth: gofakeit.Date(),
@@ -58,8 +93,6 @@ var _ = Describe("discovering potential matches", func() {
 			},
 			{
 				ID:          uuid.New(),
-				Email:       gofakeit.Email(),
-				Password:    gofakeit.Password(true, true, true, true, true, 15),
 				Name:        gofakeit.Name(),
 				Gender:      gofakeit.Gender(),
 				DateOfBirth: gofakeit.Date(),
@@ -80,16 +113,53 @@ var _ = Describe("discovering potential matches", func() {
 		}
 		getUsersLocationErr = nil
 		getUsersLocationCallCount = 1
+
+		recordProfileViewsErr = nil
+		recordProfileViewsCallCount = 1
+
+		getDesirabilityResponse = entities.DefaultDesirability
+		getDesirabilityErr = nil
+		getDesirabilityCallCount = 1
+
+		getAnswersForUsersResponse = map[uuid.UUID][]entities.UserAnswer{}
+		getAnswersForUsersErr = nil
+		getAnswersForUsersCallCount = 1
+
+		requestURL = "http://localhost:8080/dating-api/v1/user/discover"
+
+		getUserRoleResponse = entities.RoleAdmin
+		getUserRoleErr = nil
+		getUserRoleCallCount = 0
+
+		saveDiscoverySnapshotRankedUserIDs = nil
+		saveDiscoverySnapshotResponse = nil
+		saveDiscoverySnapshotErr = nil
+		saveDiscoverySnapshotCallCount = 0
+
+		getDiscoverySnapshotID = uuid.New()
+		getDiscoverySnapshotResponse = nil
+		getDiscoverySnapshotErr = nil
+		getDiscoverySnapshotCallCount = 0
 	})
 
 	JustBeforeEach(func() {
 		w = httptest.NewRecorder()
 
-		jwtProcessor.EXPECT().ValidateJwtForUser(mockJWT).Return(validateJwtForUserUUID, validateJwtForUserErr).Times(validateJwtForUserCallCount)
-		userDiscoverer.EXPECT().DiscoverNewUsers(validateJwtForUserUUID, entities.PageInfo{}).Return(discoverNewUsersResponse, discoverNewUsersErr).Times(discoverNewUsersCallCount)
-		userDiscoverer.EXPECT().GetUsersLocation(validateJwtForUserUUID).Return(getUsersLocationResponse, getUsersLocationErr).Times(getUsersLocationCallCount)
+		jwtProcessor.EXPECT().ValidateJwtForUser(gomock.Any(), mockJWT).Return(validateJwtForUserUUID, validateJwtForUserErr).Times(validateJwtForUserCallCount)
+		// the distance to the users is measured from the requesting users location
+		if getUsersLocationResponse != nil {
+			discoverNewUsersPageInfo.Origin = *getUsersLocationResponse
+		}
+		userDiscoverer.EXPECT().DiscoverNewUsers(gomock.Any(), validateJwtForUserUUID, discoverNewUsersPageInfo).Return(discoverNewUsersResponse, discoverNewUsersErr).Times(discoverNewUsersCallCount)
+		userDiscoverer.EXPECT().GetUsersLocation(gomock.Any(), validateJwtForUserUUID).Return(getUsersLocationResponse, getUsersLocationErr).Times(getUsersLocationCallCount)
+		userDiscoverer.EXPECT().RecordProfileViews(gomock.Any(), gomock.Any()).Return(recordProfileViewsErr).Times(recordProfileViewsCallCount)
+		userDiscoverer.EXPECT().GetDesirability(gomock.Any(), validateJwtForUserUUID).Return(getDesirabilityResponse, getDesirabilityErr).Times(getDesirabilityCallCount)
+		roleChecker.EXPECT().GetUserRole(gomock.Any(), validateJwtForUserUUID).Return(getUserRoleResponse, getUserRoleErr).Times(getUserRoleCallCount)
+		userDiscoverer.EXPECT().GetAnswersForUsers(gomock.Any(), gomock.Any()).Return(getAnswersForUsersResponse, getAnswersForUsersErr).Times(getAnswersForUsersCallCount)
+		userDiscoverer.EXPECT().SaveDiscoverySnapshot(gomock.Any(), validateJwtForUserUUID, saveDiscoverySnapshotRankedUserIDs).Return(saveDiscoverySnapshotResponse, saveDiscoverySnapshotErr).Times(saveDiscoverySnapshotCallCount)
+		userDiscoverer.EXPECT().GetDiscoverySnapshot(gomock.Any(), validateJwtForUserUUID, getDiscoverySnapshotID, time.Hour).Return(getDiscoverySnapshotResponse, getDiscoverySnapshotErr).Times(getDiscoverySnapshotCallCount)
 
-		req, err := http.NewRequest("GET", "http://localhost:8080/dating-api/v1/user/discover", bytes.NewReader(requestBodyJSON))
+		req, err := http.NewRequest("GET", requestURL, bytes.NewReader(requestBodyJSON))
 		req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", mockJWT))
 		Expect(err).ToNot(HaveOccurred())
 		r.ServeHTTP(w, req)
@@ -101,6 +171,144 @@ var _ = Describe("discovering potential matches", func() {
 		err := json.NewDecoder(w.Body).Decode(&resp)
 		Expect(err).ToNot(HaveOccurred())
 		Expect(resp.Users).To(HaveLen(2))
+		Expect(resp.Users[0].Ranking).To(BeNil())
+		Expect(resp.Debug).To(BeNil())
+	})
+
+	When("a user shares more interests with the requesting user", func() {
+		BeforeEach(func() {
+			getUsersLocationResponse = &entities.Location{Latitude: 51.4545, Longitude: -2.5879}
+			discoverNewUsersResponse[0].Location = entities.Location{Latitude: 51.4545, Longitude: -2.5879}
+			discoverNewUsersResponse[1].Location = entities.Location{Latitude: 51.4545, Longitude: -2.5879}
+			discoverNewUsersResponse[1].SharedInterests = 2
+		})
+
+		It("should list them first", func() {
+			Expect(w.Code).To(Equal(http.StatusOK))
+			var resp usecases.DiscoverPotentialMatchesResponseBody
+			err := json.NewDecoder(w.Body).Decode(&resp)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resp.Users[0].ID).To(Equal(discoverNewUsersResponse[1].ID.String()))
+		})
+	})
+
+	When("an admin requests the debug output", func() {
+		BeforeEach(func() {
+			requestURL += "?debug=true"
+			getUserRoleCallCount = 1
+		})
+
+		It("should return the ranker, its weights and each users score", func() {
+			Expect(w.Code).To(Equal(http.StatusOK))
+			var resp usecases.DiscoverPotentialMatchesResponseBody
+			err := json.NewDecoder(w.Body).Decode(&resp)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resp.Debug.Ranker).To(Equal(usecases.WeightedLinearRankerName))
+			Expect(resp.Debug.Weights.Distance).To(Equal(rankingWeights.Distance))
+			Expect(resp.Debug.Weights.Boost).To(Equal(rankingWeights.Boost))
+			Expect(resp.Users).To(HaveLen(2))
+			Expect(resp.Users[0].Ranking).ToNot(BeNil())
+			Expect(resp.Users[0].Ranking.Score).To(BeNumerically(">=", resp.Users[1].Ranking.Score))
+			Expect(resp.Users[0].Ranking.Signals.AgeFit).To(Equal(1.0))
+		})
+	})
+
+	When("a user that isn't an admin requests the debug output", func() {
+		BeforeEach(func() {
+			requestURL += "?debug=true"
+			getUserRoleResponse = entities.RoleModerator
+			getUserRoleCallCount = 1
+			discoverNewUsersCallCount = 0
+			getUsersLocationCallCount = 0
+			recordProfileViewsCallCount = 0
+			getDesirabilityCallCount = 0
+			getAnswersForUsersCallCount = 0
+		})
+
+		It("should return a 403 Forbidden", func() {
+			Expect(w.Code).To(Equal(http.StatusForbidden))
+		})
+	})
+
+	When("getting the users role for the debug output returns an error", func() {
+		BeforeEach(func() {
+			requestURL += "?debug=true"
+			getUserRoleErr = errors.New("an error occurred")
+			getUserRoleCallCount = 1
+			discoverNewUsersCallCount = 0
+			getUsersLocationCallCount = 0
+			recordProfileViewsCallCount = 0
+			getDesirabilityCallCount = 0
+			getAnswersForUsersCallCount = 0
+		})
+
+		It("should return a 500 Internal Server Error", func() {
+			Expect(w.Code).To(Equal(http.StatusInternalServerError))
+		})
+	})
+
+	When("a user has super liked the requesting user", func() {
+		BeforeEach(func() {
+			// the super liker is further away, but should still be listed first
+			getUsersLocationResponse = &entities.Location{Latitude: 51.4545, Longitude: -2.5879}
+			discoverNewUsersResponse[0].Location = entities.Location{Latitude: 51.4545, Longitude: -2.5879}
+			discoverNewUsersResponse[1].Location = entities.Location{Latitude: 55.9533, Longitude: -3.1883}
+			discoverNewUsersResponse[1].SuperLikedMe = true
+		})
+
+		It("should list the super liker first", func() {
+			Expect(w.Code).To(Equal(http.StatusOK))
+			var resp usecases.DiscoverPotentialMatchesResponseBody
+			err := json.NewDecoder(w.Body).Decode(&resp)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resp.Users).To(HaveLen(2))
+			Expect(resp.Users[0].ID).To(Equal(discoverNewUsersResponse[1].ID.String()))
+			Expect(resp.Users[0].SuperLikedMe).To(BeTrue())
+			Expect(resp.Users[1].SuperLikedMe).To(BeFalse())
+		})
+	})
+
+	When("a user has an active boost", func() {
+		BeforeEach(func() {
+			getUsersLocationResponse = &entities.Location{Latitude: 51.4545, Longitude: -2.5879}
+			discoverNewUsersResponse[0].Location = entities.Location{Latitude: 51.4545, Longitude: -2.5879}
+			discoverNewUsersResponse[1].Location = entities.Location{Latitude: 55.9533, Longitude: -3.1883}
+			discoverNewUsersResponse[1].Boosted = true
+		})
+
+		It("should list the boosted user before closer users", func() {
+			Expect(w.Code).To(Equal(http.StatusOK))
+			var resp usecases.DiscoverPotentialMatchesResponseBody
+			err := json.NewDecoder(w.Body).Decode(&resp)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resp.Users).To(HaveLen(2))
+			Expect(resp.Users[0].ID).To(Equal(discoverNewUsersResponse[1].ID.String()))
+		})
+
+		When("another user has super liked the requesting user", func() {
+			BeforeEach(func() {
+				discoverNewUsersResponse[0].SuperLikedMe = true
+			})
+
+			It("should list the super liker before the boosted user", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				var resp usecases.DiscoverPotentialMatchesResponseBody
+				err := json.NewDecoder(w.Body).Decode(&resp)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(resp.Users[0].ID).To(Equal(discoverNewUsersResponse[0].ID.String()))
+				Expect(resp.Users[1].ID).To(Equal(discoverNewUsersResponse[1].ID.String()))
+			})
+		})
+	})
+
+	When("recording the profile views returns an error", func() {
+		BeforeEach(func() {
+			recordProfileViewsErr = errors.New("an error occurred")
+		})
+
+		It("should still return the users", func() {
+			Expect(w.Code).To(Equal(http.StatusOK))
+		})
 	})
 
 	When("the request fails to validate", func() {
@@ -108,6 +316,106 @@ var _ = Describe("discovering potential matches", func() {
 			requestBodyJSON = []byte("{")
 			discoverNewUsersCallCount = 0
 			getUsersLocationCallCount = 0
+			recordProfileViewsCallCount = 0
+			getDesirabilityCallCount = 0
+			getAnswersForUsersCallCount = 0
+		})
+
+		It("should return a 400 Bad Request", func() {
+			Expect(w.Code).To(Equal(http.StatusBadRequest))
+		})
+	})
+
+	When("the filters are sent as query parameters", func() {
+		BeforeEach(func() {
+			requestBodyJSON = nil
+			requestURL += "?minAge=20&maxAge=30&gender=male&gender=female"
+			discoverNewUsersPageInfo = entities.PageInfo{
+				MinAge:           20,
+				MaxAge:           30,
+				PreferredGenders: []string{"male", "female"},
+				Limit:            200,
+			}
+		})
+
+		It("should filter the users by them", func() {
+			Expect(w.Code).To(Equal(http.StatusOK))
+		})
+	})
+
+	When("the query parameters fail to validate", func() {
+		var problem usecases.ProblemResponseBody
+
+		BeforeEach(func() {
+			requestBodyJSON = nil
+			discoverNewUsersCallCount = 0
+			getUsersLocationCallCount = 0
+			recordProfileViewsCallCount = 0
+			getDesirabilityCallCount = 0
+			getAnswersForUsersCallCount = 0
+		})
+
+		JustBeforeEach(func() {
+			Expect(w.Code).To(Equal(http.StatusBadRequest))
+			Expect(json.Unmarshal(w.Body.Bytes(), &problem)).To(Succeed())
+		})
+
+		When("the minimum age is over the maximum age", func() {
+			BeforeEach(func() {
+				requestURL += "?minAge=30&maxAge=20"
+			})
+
+			It("should return the maximum age as invalid", func() {
+				Expect(problem.Errors).To(Equal([]usecases.FieldErrorResponseBody{
+					{Field: "maxAge", Code: "gtefield", Message: "must be at least minAge"},
+				}))
+			})
+		})
+
+		When("the minimum age is under 18", func() {
+			BeforeEach(func() {
+				requestURL += "?minAge=17"
+			})
+
+			It("should return the minimum age as invalid", func() {
+				Expect(problem.Errors).To(Equal([]usecases.FieldErrorResponseBody{
+					{Field: "minAge", Code: "min", Message: "must be at least 18"},
+				}))
+			})
+		})
+
+		When("a gender isn't supported", func() {
+			BeforeEach(func() {
+				requestURL += "?gender=male&gender=unknown"
+			})
+
+			It("should return the gender as invalid", func() {
+				Expect(problem.Errors).To(Equal([]usecases.FieldErrorResponseBody{
+					{Field: "gender[1]", Code: "gender", Message: "must be one of male, female"},
+				}))
+			})
+		})
+
+		When("the cursor wasn't returned by a previous page", func() {
+			BeforeEach(func() {
+				requestURL += "?cursor=not-a-cursor"
+			})
+
+			It("should return the cursor as invalid", func() {
+				Expect(problem.Errors).To(HaveLen(1))
+				Expect(problem.Errors[0].Field).To(Equal("cursor"))
+			})
+		})
+	})
+
+	When("the legacy request body fails validation", func() {
+		BeforeEach(func() {
+			requestBodyJSON = []byte(`{"pageInfo": {"preferredGenders": ["unknown"]}}`)
+			discoverNewUsersCallCount = 0
+			getUsersLocationCallCount = 0
+			recordProfileViewsCallCount = 0
+			getDesirabilityCallCount = 0
+			getAnswersForUsersCallCount = 0
 		})
 
 		It("should return a 400 Bad Request", func() {
@@ -115,13 +423,322 @@ var _ = Describe("discovering potential matches", func() {
 		})
 	})
 
+	When("a limit is requested", func() {
+		var resp usecases.DiscoverPotentialMatchesResponseBody
+
+		BeforeEach(func() {
+			requestBodyJSON = nil
+			requestURL += "?limit=1"
+			discoverNewUsersPageInfo = entities.PageInfo{Limit: 10}
+			getUsersLocationResponse = &entities.Location{Latitude: 51.4545, Longitude: -2.5879}
+			discoverNewUsersResponse[0].Location = entities.Location{Latitude: 51.4545, Longitude: -2.5879}
+			discoverNewUsersResponse[1].Location = entities.Location{Latitude: 55.9533, Longitude: -3.1883}
+
+			saveDiscoverySnapshotRankedUserIDs = []uuid.UUID{discoverNewUsersResponse[0].ID, discoverNewUsersResponse[1].ID}
+			saveDiscoverySnapshotResponse = &entities.DiscoverySnapshot{
+				ID:            getDiscoverySnapshotID,
+				UserID:        validateJwtForUserUUID,
+				RankedUserIDs: saveDiscoverySnapshotRankedUserIDs,
+			}
+			saveDiscoverySnapshotCallCount = 1
+		})
+
+		JustBeforeEach(func() {
+			if w.Code == http.StatusOK {
+				resp = usecases.DiscoverPotentialMatchesResponseBody{}
+				Expect(json.NewDecoder(w.Body).Decode(&resp)).To(Succeed())
+			}
+		})
+
+		It("should return that many users and the cursor of the next page in the snapshot", func() {
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(resp.Users).To(HaveLen(1))
+			Expect(resp.Users[0].ID).To(Equal(discoverNewUsersResponse[0].ID.String()))
+			Expect(resp.NextCursor).To(HaveValue(Equal(discoverCursor(getDiscoverySnapshotID, 1))))
+		})
+
+		When("saving the snapshot returns an error", func() {
+			BeforeEach(func() {
+				saveDiscoverySnapshotResponse = nil
+				saveDiscoverySnapshotErr = errors.New("an error occurred")
+				recordProfileViewsCallCount = 0
+			})
+
+			It("should return a 500 Internal Server Error", func() {
+				Expect(w.Code).To(Equal(http.StatusInternalServerError))
+			})
+		})
+
+		When("all of the users fit on the page", func() {
+			BeforeEach(func() {
+				requestURL = strings.Replace(requestURL, "limit=1", "limit=2", 1)
+				discoverNewUsersPageInfo = entities.PageInfo{Limit: 20}
+				saveDiscoverySnapshotCallCount = 0
+			})
+
+			It("should return the users without saving a snapshot or a cursor", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(resp.Users).To(HaveLen(2))
+				Expect(resp.NextCursor).To(BeNil())
+			})
+		})
+	})
+
+	When("the cursor of the next page is requested", func() {
+		var rankedUserIDs []uuid.UUID
+		var resp usecases.DiscoverPotentialMatchesResponseBody
+
+		BeforeEach(func() {
+			requestBodyJSON = nil
+			// the users on the first page have been swiped on since, so they are no longer discovered. Paging by offset
+			// through the users ranked again skipped past as many users as had been swiped on.
+			rankedUserIDs = []uuid.UUID{uuid.New(), uuid.New(), discoverNewUsersResponse[0].ID, discoverNewUsersResponse[1].ID, uuid.New()}
+			requestURL += "?limit=2&cursor=" + discoverCursor(getDiscoverySnapshotID, 2)
+
+			getDiscoverySnapshotResponse = &entities.DiscoverySnapshot{
+				ID:            getDiscoverySnapshotID,
+				UserID:        validateJwtForUserUUID,
+				RankedUserIDs: rankedUserIDs,
+			}
+			getDiscoverySnapshotCallCount = 1
+
+			// the second user is now closer than the first, but they are listed in the order they were ranked when the
+			// snapshot was taken
+			getUsersLocationResponse = &entities.Location{Latitude: 51.4545, Longitude: -2.5879}
+			discoverNewUsersResponse[0].Location = entities.Location{Latitude: 55.9533, Longitude: -3.1883}
+			discoverNewUsersResponse[1].Location = entities.Location{Latitude: 51.4545, Longitude: -2.5879}
+			discoverNewUsersPageInfo = entities.PageInfo{UserIDs: rankedUserIDs[2:4]}
+		})
+
+		JustBeforeEach(func() {
+			if w.Code == http.StatusOK {
+				resp = usecases.DiscoverPotentialMatchesResponseBody{}
+				Expect(json.NewDecoder(w.Body).Decode(&resp)).To(Succeed())
+			}
+		})
+
+		It("should return the users after the cursor in the order of the snapshot without skipping any", func() {
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(resp.Users).To(HaveLen(2))
+			Expect(resp.Users[0].ID).To(Equal(discoverNewUsersResponse[0].ID.String()))
+			Expect(resp.Users[1].ID).To(Equal(discoverNewUsersResponse[1].ID.String()))
+			Expect(resp.NextCursor).To(HaveValue(Equal(discoverCursor(getDiscoverySnapshotID, 4))))
+		})
+
+		When("a user on the next page has been swiped on since the snapshot was taken", func() {
+			var lastUser entities.UserDiscovery
+
+			BeforeEach(func() {
+				lastUser = entities.UserDiscovery{ID: rankedUserIDs[4], Name: gofakeit.Name(), Age: 23, Location: *getUsersLocationResponse}
+				discoverNewUsersResponse = discoverNewUsersResponse[1:]
+				// the page is filled from the rest of the snapshot
+				userDiscoverer.EXPECT().DiscoverNewUsers(gomock.Any(), validateJwtForUserUUID, entities.PageInfo{Origin: *getUsersLocationResponse, UserIDs: rankedUserIDs[4:]}).
+					Return([]entities.UserDiscovery{lastUser}, nil)
+				userDiscoverer.EXPECT().GetAnswersForUsers(gomock.Any(), gomock.Any()).Return(getAnswersForUsersResponse, nil)
+			})
+
+			It("should skip them and return the next user in the snapshot without a cursor", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(resp.Users).To(HaveLen(2))
+				Expect(resp.Users[0].ID).To(Equal(discoverNewUsersResponse[0].ID.String()))
+				Expect(resp.Users[1].ID).To(Equal(lastUser.ID.String()))
+				Expect(resp.NextCursor).To(BeNil())
+			})
+		})
+
+		When("the snapshot has been replaced or has expired", func() {
+			BeforeEach(func() {
+				getDiscoverySnapshotResponse = nil
+				getDiscoverySnapshotErr = entities.ErrSnapshotNotFound
+				discoverNewUsersCallCount = 0
+				getUsersLocationCallCount = 0
+				recordProfileViewsCallCount = 0
+				getDesirabilityCallCount = 0
+				getAnswersForUsersCallCount = 0
+			})
+
+			It("should return the cursor as invalid", func() {
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+				var problem usecases.ProblemResponseBody
+				Expect(json.Unmarshal(w.Body.Bytes(), &problem)).To(Succeed())
+				Expect(problem.Errors).To(HaveLen(1))
+				Expect(problem.Errors[0].Field).To(Equal("cursor"))
+			})
+		})
+
+		When("getting the snapshot returns an error", func() {
+			BeforeEach(func() {
+				getDiscoverySnapshotResponse = nil
+				getDiscoverySnapshotErr = errors.New("an error occurred")
+				discoverNewUsersCallCount = 0
+				getUsersLocationCallCount = 0
+				recordProfileViewsCallCount = 0
+				getDesirabilityCallCount = 0
+				getAnswersForUsersCallCount = 0
+			})
+
+			It("should return a 500 Internal Server Error", func() {
+				Expect(w.Code).To(Equal(http.StatusInternalServerError))
+			})
+		})
+	})
+
+	When("a maximum distance is requested", func() {
+		BeforeEach(func() {
+			requestBodyJSON = nil
+			requestURL += "?maxDistance=50"
+			getUsersLocationResponse = &entities.Location{Latitude: 51.5, Longitude: -0.1}
+			discoverNewUsersPageInfo = entities.PageInfo{MaxDistance: 50, Limit: 200}
+			discoverNewUsersResponse = discoverNewUsersResponse[:1]
+			discoverNewUsersResponse[0].Location = entities.Location{Latitude: 51.6, Longitude: -0.2}
+		})
+
+		It("should only get the users within the distance of the requesting user", func() {
+			Expect(w.Code).To(Equal(http.StatusOK))
+			var resp usecases.DiscoverPotentialMatchesResponseBody
+			Expect(json.NewDecoder(w.Body).Decode(&resp)).To(Succeed())
+			Expect(resp.Users).To(HaveLen(1))
+			Expect(resp.Users[0].ID).To(Equal(discoverNewUsersResponse[0].ID.String()))
+		})
+	})
+
 	When("getting new users returns an error", func() {
 		BeforeEach(func() {
 			discoverNewUsersResponse = nil
 			discoverNewUsersErr = errors.New("an error occurred")
 			discoverNewUsersCallCount = 1
 
+			recordProfileViewsCallCount = 0
+			getAnswersForUsersCallCount = 0
+		})
+
+		It("should return a 500 Internal Server Error", func() {
+			Expect(w.Code).To(Equal(http.StatusInternalServerError))
+		})
+	})
+
+	When("the users have answered questions in common", func() {
+		BeforeEach(func() {
+			questionIDs := []uuid.UUID{uuid.New(), uuid.New(), uuid.New(), uuid.New()}
+			yes, no := uuid.New(), uuid.New()
+			answerAll := func(userID, answerID, acceptedAnswerID uuid.UUID) []entities.UserAnswer {
+				var answers []entities.UserAnswer
+				for _, questionID := range questionIDs {
+					answers = append(answers, entities.UserAnswer{
+						UserID:            userID,
+						QuestionID:        questionID,
+						AnswerID:          answerID,
+						AcceptedAnswerIDs: []uuid.UUID{acceptedAnswerID},
+						Importance:        entities.ImportanceVery,
+					})
+				}
+				return answers
+			}
+
+			getAnswersForUsersResponse = map[uuid.UUID][]entities.UserAnswer{
+				validateJwtForUserUUID:         answerAll(validateJwtForUserUUID, yes, yes),
+				discoverNewUsersResponse[0].ID: answerAll(discoverNewUsersResponse[0].ID, yes, yes),
+				discoverNewUsersResponse[1].ID: answerAll(discoverNewUsersResponse[1].ID, no, no),
+			}
+		})
+
+		It("should return each users compatibility", func() {
+			Expect(w.Code).To(Equal(http.StatusOK))
+			var resp usecases.DiscoverPotentialMatchesResponseBody
+			err := json.NewDecoder(w.Body).Decode(&resp)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resp.Users).To(HaveLen(2))
+
+			compatibilities := map[string]*int{}
+			for _, user := range resp.Users {
+				compatibilities[user.ID] = user.Compatibility
+			}
+			Expect(compatibilities[discoverNewUsersResponse[0].ID.String()]).To(HaveValue(Equal(75)))
+			Expect(compatibilities[discoverNewUsersResponse[1].ID.String()]).To(HaveValue(Equal(0)))
+		})
+
+		When("a minimum compatibility is requested", func() {
+			BeforeEach(func() {
+				requestBodyJSON = []byte(`{"pageInfo": {"minCompatibility": 50}}`)
+			})
+
+			It("should only return users at least that compatible", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				var resp usecases.DiscoverPotentialMatchesResponseBody
+				err := json.NewDecoder(w.Body).Decode(&resp)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(resp.Users).To(HaveLen(1))
+				Expect(resp.Users[0].ID).To(Equal(discoverNewUsersResponse[0].ID.String()))
+			})
+		})
+	})
+
+	When("a minimum compatibility is requested and the users have no questions answered in common", func() {
+		BeforeEach(func() {
+			requestBodyJSON = []byte(`{"pageInfo": {"minCompatibility": 1}}`)
+		})
+
+		It("should leave the users out", func() {
+			Expect(w.Code).To(Equal(http.StatusOK))
+			var resp usecases.DiscoverPotentialMatchesResponseBody
+			err := json.NewDecoder(w.Body).Decode(&resp)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resp.Users).To(BeEmpty())
+		})
+	})
+
+	When("the minimum compatibility is over 100", func() {
+		BeforeEach(func() {
+			requestBodyJSON = []byte(`{"pageInfo": {"minCompatibility": 101}}`)
+			discoverNewUsersCallCount = 0
 			getUsersLocationCallCount = 0
+			recordProfileViewsCallCount = 0
+			getDesirabilityCallCount = 0
+			getAnswersForUsersCallCount = 0
+		})
+
+		It("should return a 400 Bad Request", func() {
+			Expect(w.Code).To(Equal(http.StatusBadRequest))
+		})
+	})
+
+	When("getting the users answers returns an error", func() {
+		BeforeEach(func() {
+			getAnswersForUsersErr = errors.New("an error occurred")
+			recordProfileViewsCallCount = 0
+		})
+
+		It("should return a 500 Internal Server Error", func() {
+			Expect(w.Code).To(Equal(http.StatusInternalServerError))
+		})
+	})
+
+	When("a user has a similar desirability to the requesting user", func() {
+		BeforeEach(func() {
+			getUsersLocationResponse = &entities.Location{Latitude: 51.4545, Longitude: -2.5879}
+			discoverNewUsersResponse[0].Location = entities.Location{Latitude: 51.4545, Longitude: -2.5879}
+			discoverNewUsersResponse[1].Location = entities.Location{Latitude: 51.4545, Longitude: -2.5879}
+			getDesirabilityResponse = 1800
+			discoverNewUsersResponse[0].Desirability = 1300
+			discoverNewUsersResponse[1].Desirability = 1750
+		})
+
+		It("should list them first without returning their desirability", func() {
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(w.Body.String()).ToNot(ContainSubstring("1750"))
+			var resp usecases.DiscoverPotentialMatchesResponseBody
+			err := json.NewDecoder(w.Body).Decode(&resp)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resp.Users[0].ID).To(Equal(discoverNewUsersResponse[1].ID.String()))
+		})
+	})
+
+	When("getting the requesting users desirability returns an error", func() {
+		BeforeEach(func() {
+			getDesirabilityErr = errors.New("an error occurred")
+			discoverNewUsersCallCount = 0
+			recordProfileViewsCallCount = 0
+			getAnswersForUsersCallCount = 0
 		})
 
 		It("should return a 500 Internal Server Error", func() {
@@ -134,6 +751,10 @@ var _ = Describe("discovering potential matches", func() {
 			getUsersLocationResponse = nil
 			getUsersLocationErr = errors.New("an error occurred")
 			getUsersLocationCallCount = 1
+			discoverNewUsersCallCount = 0
+			recordProfileViewsCallCount = 0
+			getDesirabilityCallCount = 0
+			getAnswersForUsersCallCount = 0
 		})
 
 		It("should return a 500 Internal Server Error", func() {
@@ -141,3 +762,75 @@ var _ = Describe("discovering potential matches", func() {
 		})
 	})
 })
+
+var _ = Describe("paging through discovered users while swiping on them", func() {
+	It("should return every user once", func() {
+		ctx := context.Background()
+		storage := memory.NewAdapter(3000000, "something-secret")
+		newUser := func(name string, latitude float64) *entities.User {
+			user, err := storage.CreateUser(ctx, &entities.User{
+				Email:       name,
+				Password:    "password",
+				Name:        name,
+				Gender:      "female",
+				DateOfBirth: time.Date(1995, time.March, 14, 0, 0, 0, 0, time.UTC),
+				Location:    entities.Location{Latitude: latitude, Longitude: -0.1276},
+				Timezone:    "Europe/London",
+			})
+			Expect(err).ToNot(HaveOccurred())
+			return user
+		}
+
+		owner := newUser("owner", 51.5)
+		// the adapter is seeded with the admin user, which is passed on so that only the candidates are discovered
+		seeded, err := storage.DiscoverNewUsers(ctx, owner.ID, entities.PageInfo{})
+		Expect(err).ToNot(HaveOccurred())
+		for _, user := range seeded {
+			_, err = storage.RegisterSwipe(ctx, owner.ID, user.ID, entities.SwipeTypePass)
+			Expect(err).ToNot(HaveOccurred())
+		}
+
+		var candidateIDs []string
+		for i := range 5 {
+			candidateIDs = append(candidateIDs, newUser(fmt.Sprintf("candidate-%d", i), 51.5+float64(i)/10).ID.String())
+		}
+
+		engine := gin.New()
+		engine.GET("/discover", func(c *gin.Context) {
+			c.Set("userID", owner.ID)
+		}, usecases.NewDiscoverPotentialMatches(storage, ranker, storage))
+
+		var shownIDs []string
+		cursor := ""
+		for pages := 0; pages < len(candidateIDs); pages++ {
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/discover?limit=2&cursor="+cursor, nil))
+			Expect(w.Code).To(Equal(http.StatusOK))
+
+			var resp usecases.DiscoverPotentialMatchesResponseBody
+			Expect(json.NewDecoder(w.Body).Decode(&resp)).To(Succeed())
+			for _, user := range resp.Users {
+				shownIDs = append(shownIDs, user.ID)
+				_, err := storage.RegisterSwipe(ctx, owner.ID, uuid.MustParse(user.ID), entities.SwipeTypePass)
+				Expect(err).ToNot(HaveOccurred())
+			}
+
+			if resp.NextCursor == nil {
+				break
+			}
+			cursor = *resp.NextCursor
+		}
+
+		Expect(shownIDs).To(ConsistOf(candidateIDs))
+	})
+})
+
+// discoverCursor is a function that returns the cursor of the page starting at position in the snapshot
+func discoverCursor(snapshotID uuid.UUID, position int) string {
+	cursor, err := json.Marshal(struct {
+		SnapshotID uuid.UUID `json:"snapshotId"`
+		Position   int       `json:"position"`
+	}{SnapshotID: snapshotID, Position: position})
+	Expect(err).ToNot(HaveOccurred())
+	return base64.RawURLEncoding.EncodeToString(cursor)
+}
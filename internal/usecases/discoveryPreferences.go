@@ -0,0 +1,71 @@
+package usecases
+
+import (
+	"context"
+	"github.com/AlecSmith96/dating-api/internal/entities"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+)
+
+//go:generate mockgen --build_flags=--mod=mod -destination=../../mocks/discoveryPreferencesSaver.go  . "DiscoveryPreferencesSaver"
+type DiscoveryPreferencesSaver interface {
+	SaveDiscoveryPreferences(ctx context.Context, userID uuid.UUID, preferences entities.PageInfo) error
+}
+
+// DiscoveryPreferencesRequestBody represents the filters a user wants their daily picks to match
+// @Description the users discovery preferences, an age of 0 has no limit and no genders includes every gender
+type DiscoveryPreferencesRequestBody struct {
+	// MinAge is the minimum age of picked users
+	MinAge int `json:"minAge" binding:"omitempty,min=18"`
+	// MaxAge is the maximum age of picked users
+	MaxAge int `json:"maxAge" binding:"omitempty,min=18,gtefield=MinAge"`
+	// PreferredGenders is an array of genders to pick users from
+	PreferredGenders []string `json:"preferredGenders"`
+}
+
+// NewSaveDiscoveryPreferences saves the requesting users discovery preferences
+// @Summary Save discovery preferences
+// @Description Saves the filters the requesting users daily picks are generated with, replacing any saved before.
+// @Description Discovery requests still use the filters sent with them.
+// @Security BearerAuth
+// @Tags users
+// @Accept json
+// @Param preferences body DiscoveryPreferencesRequestBody true "Discovery Preferences Request Body"
+// @Success 204
+// @Failure 400 {object} ProblemResponseBody
+// @Failure 500 {object} ProblemResponseBody
+// @Router /user/preferences [put]
+func NewSaveDiscoveryPreferences(discoveryPreferencesSaver DiscoveryPreferencesSaver) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		userID, ok := c.Get("userID")
+		if !ok {
+			Logger(ctx).ErrorContext(ctx, "unable to get userID from context")
+			c.Error(errUserIDMissing)
+			return
+		}
+		requestingUserID := userID.(uuid.UUID)
+
+		var request DiscoveryPreferencesRequestBody
+		err := c.ShouldBindJSON(&request)
+		if err != nil {
+			Logger(ctx).ErrorContext(ctx, "validating request body", "err", err)
+			c.Error(bindingError(err))
+			return
+		}
+
+		err = discoveryPreferencesSaver.SaveDiscoveryPreferences(ctx, requestingUserID, entities.PageInfo{
+			MinAge:           request.MinAge,
+			MaxAge:           request.MaxAge,
+			PreferredGenders: request.PreferredGenders,
+		})
+		if err != nil {
+			Logger(ctx).ErrorContext(ctx, "saving discovery preferences", "err", err)
+			c.Error(err)
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,95 @@
+package usecases_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/AlecSmith96/dating-api/internal/entities"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+)
+
+var _ = Describe("saving discovery preferences", func() {
+	var w *httptest.ResponseRecorder
+	var requestBodyJSON []byte
+
+	var validateJwtForUserUUID uuid.UUID
+
+	var expectedPreferences entities.PageInfo
+	var saveDiscoveryPreferencesErr error
+	var saveDiscoveryPreferencesCallCount int
+
+	BeforeEach(func() {
+		requestBodyJSON = []byte(`{"minAge": 25, "maxAge": 35, "preferredGenders": ["female"]}`)
+
+		validateJwtForUserUUID = uuid.New()
+
+		expectedPreferences = entities.PageInfo{MinAge: 25, MaxAge: 35, PreferredGenders: []string{"female"}}
+		saveDiscoveryPreferencesErr = nil
+		saveDiscoveryPreferencesCallCount = 1
+	})
+
+	JustBeforeEach(func() {
+		w = httptest.NewRecorder()
+
+		jwtProcessor.EXPECT().ValidateJwtForUser(gomock.Any(), mockJWT).Return(validateJwtForUserUUID, nil).Times(1)
+		preferencesSaver.EXPECT().SaveDiscoveryPreferences(gomock.Any(), validateJwtForUserUUID, expectedPreferences).
+			Return(saveDiscoveryPreferencesErr).Times(saveDiscoveryPreferencesCallCount)
+
+		req, err := http.NewRequest("PUT", "http://localhost:8080/dating-api/v1/user/preferences", bytes.NewReader(requestBodyJSON))
+		req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", mockJWT))
+		Expect(err).ToNot(HaveOccurred())
+		r.ServeHTTP(w, req)
+	})
+
+	It("should save the preferences", func() {
+		Expect(w.Code).To(Equal(http.StatusNoContent))
+	})
+
+	When("no age limits are set", func() {
+		BeforeEach(func() {
+			requestBodyJSON = []byte(`{"preferredGenders": ["female", "male"]}`)
+			expectedPreferences = entities.PageInfo{PreferredGenders: []string{"female", "male"}}
+		})
+
+		It("should save the preferences without limits", func() {
+			Expect(w.Code).To(Equal(http.StatusNoContent))
+		})
+	})
+
+	When("the maximum age is below the minimum age", func() {
+		BeforeEach(func() {
+			requestBodyJSON = []byte(`{"minAge": 35, "maxAge": 25}`)
+			saveDiscoveryPreferencesCallCount = 0
+		})
+
+		It("should return a 400 Bad Request", func() {
+			Expect(w.Code).To(Equal(http.StatusBadRequest))
+		})
+	})
+
+	When("the minimum age is under 18", func() {
+		BeforeEach(func() {
+			requestBodyJSON = []byte(`{"minAge": 16}`)
+			saveDiscoveryPreferencesCallCount = 0
+		})
+
+		It("should return a 400 Bad Request", func() {
+			Expect(w.Code).To(Equal(http.StatusBadRequest))
+		})
+	})
+
+	When("the adapter returns an error", func() {
+		BeforeEach(func() {
+			saveDiscoveryPreferencesErr = errors.New("an error occurred")
+		})
+
+		It("should return a 500 Internal Server Error", func() {
+			Expect(w.Code).To(Equal(http.StatusInternalServerError))
+		})
+	})
+})
@@ -0,0 +1,150 @@
+package usecases
+
+import (
+	"context"
+	"github.com/AlecSmith96/dating-api/internal/entities"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"time"
+)
+
+// Entitlements answers what a user can do on their current tier. Handlers should ask it rather than checking tiers.
+//
+//go:generate mockgen --build_flags=--mod=mod -destination=../../mocks/entitlements.go  . "Entitlements"
+type Entitlements interface {
+	GetEntitlements(ctx context.Context, userID uuid.UUID) (*entities.Entitlements, error)
+}
+
+// EntitlementsResponseBody represents what the requesting user can do on their current tier
+// @Description the tier of the requesting user, the features it includes and their remaining swipes
+type EntitlementsResponseBody struct {
+	// Tier is the tier the user is on, one of free, plus or premium
+	Tier string `json:"tier"`
+	// Subscription is the users latest subscription, it is omitted if they have never subscribed
+	Subscription *SubscriptionResponseBody `json:"subscription,omitempty"`
+	// CanRewind is true when the user can rewind their last swipe
+	CanRewind bool `json:"canRewind"`
+	// CanSeeLikes is true when the user can see the profiles of the users that liked them
+	CanSeeLikes bool `json:"canSeeLikes"`
+	// Likes is the users daily like quota
+	Likes SwipeQuotaResponseBody `json:"likes"`
+	// SuperLikes is the users weekly super like quota
+	SuperLikes SwipeQuotaResponseBody `json:"superLikes"`
+	// Boosts is the users boost allowance
+	Boosts BoostQuotaResponseBody `json:"boosts"`
+}
+
+// BoostQuotaResponseBody represents a users boost allowance
+// @Description the boosts the user can start in any 30 days
+type BoostQuotaResponseBody struct {
+	// Limit is the number of boosts the users plan includes in any 30 days
+	Limit int `json:"limit"`
+	// Remaining is the number of boosts the user can start now
+	Remaining int `json:"remaining"`
+}
+
+// SubscriptionResponseBody represents a users subscription
+// @Description a subscription to a paid tier
+type SubscriptionResponseBody struct {
+	// Tier is the tier subscribed to
+	Tier string `json:"tier"`
+	// Status is the status of the subscription, one of trialing, active, past_due, cancelled or expired
+	Status string `json:"status"`
+	// CurrentPeriodEnd is when the current billing period ends
+	CurrentPeriodEnd time.Time `json:"currentPeriodEnd"`
+}
+
+// SwipeQuotaResponseBody represents a users swipe quota
+// @Description the swipes the user has left, limit and remaining are omitted when the quota is unlimited
+type SwipeQuotaResponseBody struct {
+	// Unlimited is true when the users tier has no limit
+	Unlimited bool `json:"unlimited"`
+	// Limit is the number of swipes allowed in the quota period
+	Limit *int `json:"limit,omitempty"`
+	// Remaining is the number of swipes left in the quota period
+	Remaining *int `json:"remaining,omitempty"`
+	// ResetsAt is when the quota resets
+	ResetsAt time.Time `json:"resetsAt"`
+}
+
+func toSwipeQuotaResponseBody(quota *entities.SwipeQuota) SwipeQuotaResponseBody {
+	response := SwipeQuotaResponseBody{
+		Unlimited: quota.Unlimited,
+		ResetsAt:  quota.ResetsAt,
+	}
+
+	if !quota.Unlimited {
+		limit := quota.Limit
+		remaining := quota.Remaining()
+		response.Limit = &limit
+		response.Remaining = &remaining
+	}
+
+	return response
+}
+
+// NewGetEntitlements gets what the requesting user can do
+// @Summary Get entitlements
+// @Description Gets the tier of the requesting user, the features it includes and how many likes and super likes they
+// @Description have left. The tier is the highest of the users paid up subscriptions, or free.
+// @Security BearerAuth
+// @Tags users
+// @Produce json
+// @Success 200 {object} EntitlementsResponseBody
+// @Failure 500 {object} ProblemResponseBody
+// @Router /user/entitlements [get]
+func NewGetEntitlements(entitlements Entitlements) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		userID, ok := c.Get("userID")
+		if !ok {
+			Logger(ctx).ErrorContext(ctx, "unable to get userID from context")
+			c.Error(errUserIDMissing)
+			return
+		}
+		requestingUserID := userID.(uuid.UUID)
+
+		userEntitlements, err := entitlements.GetEntitlements(ctx, requestingUserID)
+		if err != nil {
+			Logger(ctx).ErrorContext(ctx, "getting entitlements", "err", err)
+			c.Error(err)
+			return
+		}
+
+		response := EntitlementsResponseBody{
+			Tier:        string(userEntitlements.Tier),
+			CanRewind:   userEntitlements.CanRewind,
+			CanSeeLikes: userEntitlements.CanSeeLikes,
+			Likes:       toSwipeQuotaResponseBody(&userEntitlements.LikeQuota),
+			SuperLikes:  toSwipeQuotaResponseBody(&userEntitlements.SuperLikeQuota),
+			Boosts: BoostQuotaResponseBody{
+				Limit:     userEntitlements.BoostLimit,
+				Remaining: userEntitlements.BoostsRemaining(),
+			},
+		}
+
+		if userEntitlements.Subscription != nil {
+			response.Subscription = &SubscriptionResponseBody{
+				Tier:             string(userEntitlements.Subscription.Tier),
+				Status:           string(userEntitlements.Subscription.Status),
+				CurrentPeriodEnd: userEntitlements.Subscription.CurrentPeriodEnd,
+			}
+		}
+
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+// getEntitlements is a function that gets the requesting users entitlements, writing the error response if it fails
+func getEntitlements(c *gin.Context, entitlements Entitlements, userID uuid.UUID) (*entities.Entitlements, bool) {
+	ctx := c.Request.Context()
+	userEntitlements, err := entitlements.GetEntitlements(ctx, userID)
+	if err != nil {
+		Logger(ctx).ErrorContext(ctx, "getting entitlements", "err", err)
+		c.Error(err)
+		return nil, false
+	}
+
+	return userEntitlements, true
+}
@@ -0,0 +1,116 @@
+package usecases_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/AlecSmith96/dating-api/internal/entities"
+	"github.com/AlecSmith96/dating-api/internal/usecases"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"time"
+)
+
+var _ = Describe("getting entitlements", func() {
+	var w *httptest.ResponseRecorder
+
+	var validateJwtForUserUUID uuid.UUID
+
+	var getEntitlementsResponse *entities.Entitlements
+	var getEntitlementsErr error
+
+	BeforeEach(func() {
+		validateJwtForUserUUID = uuid.New()
+
+		getEntitlementsResponse = &entities.Entitlements{
+			Tier: entities.TierPlus,
+			Subscription: &entities.Subscription{
+				ID:               uuid.New(),
+				UserID:           validateJwtForUserUUID,
+				Tier:             entities.TierPlus,
+				Status:           entities.SubscriptionStatusTrialing,
+				CurrentPeriodEnd: time.Now().Add(7 * 24 * time.Hour).UTC().Truncate(time.Second),
+			},
+			CanRewind:   true,
+			CanSeeLikes: false,
+			LikeQuota: entities.SwipeQuota{
+				Tier:      entities.TierPlus,
+				SwipeType: entities.SwipeTypeLike,
+				Unlimited: true,
+				Used:      40,
+			},
+			SuperLikeQuota: entities.SwipeQuota{
+				Tier:      entities.TierPlus,
+				SwipeType: entities.SwipeTypeSuperLike,
+				Limit:     3,
+				Used:      1,
+				ResetsAt:  time.Now().Add(48 * time.Hour).UTC().Truncate(time.Second),
+			},
+			BoostLimit: 1,
+			BoostsUsed: 0,
+		}
+		getEntitlementsErr = nil
+	})
+
+	JustBeforeEach(func() {
+		w = httptest.NewRecorder()
+
+		jwtProcessor.EXPECT().ValidateJwtForUser(gomock.Any(), mockJWT).Return(validateJwtForUserUUID, nil).Times(1)
+		entitlements.EXPECT().GetEntitlements(gomock.Any(), validateJwtForUserUUID).Return(getEntitlementsResponse, getEntitlementsErr).Times(1)
+
+		req, err := http.NewRequest("GET", "http://localhost:8080/dating-api/v1/user/entitlements", nil)
+		req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", mockJWT))
+		Expect(err).ToNot(HaveOccurred())
+		r.ServeHTTP(w, req)
+	})
+
+	It("should return the tier, features and remaining swipes of the user", func() {
+		Expect(w.Code).To(Equal(http.StatusOK))
+		var resp usecases.EntitlementsResponseBody
+		err := json.NewDecoder(w.Body).Decode(&resp)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(resp.Tier).To(Equal("plus"))
+		Expect(resp.Subscription.Status).To(Equal("trialing"))
+		Expect(resp.Subscription.CurrentPeriodEnd).To(BeTemporally("==", getEntitlementsResponse.Subscription.CurrentPeriodEnd))
+		Expect(resp.CanRewind).To(BeTrue())
+		Expect(resp.CanSeeLikes).To(BeFalse())
+		Expect(resp.Likes.Unlimited).To(BeTrue())
+		Expect(resp.Likes.Remaining).To(BeNil())
+		Expect(*resp.SuperLikes.Limit).To(Equal(3))
+		Expect(*resp.SuperLikes.Remaining).To(Equal(2))
+		Expect(resp.SuperLikes.ResetsAt).To(BeTemporally("==", getEntitlementsResponse.SuperLikeQuota.ResetsAt))
+		Expect(resp.Boosts.Limit).To(Equal(1))
+		Expect(resp.Boosts.Remaining).To(Equal(1))
+	})
+
+	When("the user has never subscribed", func() {
+		BeforeEach(func() {
+			getEntitlementsResponse.Tier = entities.TierFree
+			getEntitlementsResponse.Subscription = nil
+		})
+
+		It("should not return a subscription", func() {
+			Expect(w.Code).To(Equal(http.StatusOK))
+			var resp usecases.EntitlementsResponseBody
+			err := json.NewDecoder(w.Body).Decode(&resp)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resp.Tier).To(Equal("free"))
+			Expect(resp.Subscription).To(BeNil())
+		})
+	})
+
+	When("the adapter returns an error", func() {
+		BeforeEach(func() {
+			getEntitlementsResponse = nil
+			getEntitlementsErr = errors.New("an error occurred")
+		})
+
+		It("should return a 500 Internal Server Error", func() {
+			Expect(w.Code).To(Equal(http.StatusInternalServerError))
+		})
+	})
+})
@@ -0,0 +1,62 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/AlecSmith96/dating-api/internal/entities"
+	"github.com/google/uuid"
+	"time"
+)
+
+//go:generate mockgen --build_flags=--mod=mod -destination=../../mocks/eventRecorder.go  . "EventRecorder"
+type EventRecorder interface {
+	RecordEvent(ctx context.Context, event *entities.Event) error
+}
+
+//go:generate mockgen --build_flags=--mod=mod -destination=../../mocks/eventPruner.go  . "EventPruner"
+type EventPruner interface {
+	PruneEvents(ctx context.Context, retention time.Duration) (int64, error)
+}
+
+// recordEvent is a function that writes an event to the event log for the user. Failing to record an event should
+// never fail the request that caused it, so errors are only logged, and the event is still recorded if the request is
+// cancelled after the change that caused it.
+func recordEvent(ctx context.Context, eventRecorder EventRecorder, userID, actorUserID uuid.UUID, eventType entities.EventType, payload any) {
+	payloadJSON, err := json.Marshal(payload)
+	if err != nil {
+		Logger(ctx).ErrorContext(ctx, "marshalling event payload", "err", err, "eventType", eventType)
+		return
+	}
+
+	err = eventRecorder.RecordEvent(context.WithoutCancel(ctx), &entities.Event{
+		UserID:      userID,
+		ActorUserID: actorUserID,
+		Type:        eventType,
+		Payload:     payloadJSON,
+	})
+	if err != nil {
+		Logger(ctx).ErrorContext(ctx, "recording event", "err", err, "eventType", eventType)
+	}
+}
+
+// RunEventLogPruner is a background job that deletes events older than the retention period from the event log every
+// interval, until the context is cancelled.
+func RunEventLogPruner(ctx context.Context, eventPruner EventPruner, retention, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		deleted, err := eventPruner.PruneEvents(ctx, retention)
+		if err != nil {
+			Logger(ctx).ErrorContext(ctx, "pruning event log", "err", err)
+		} else {
+			Logger(ctx).DebugContext(ctx, "pruned event log", "deleted", deleted)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
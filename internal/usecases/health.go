@@ -0,0 +1,118 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+const readinessCheckTimeout = 2 * time.Second
+
+const (
+	checkStatusOK      = "ok"
+	checkStatusFailing = "failing"
+
+	readinessStatusReady    = "ready"
+	readinessStatusNotReady = "not ready"
+	readinessStatusDraining = "draining"
+)
+
+//go:generate mockgen --build_flags=--mod=mod -destination=../../mocks/readinessChecker.go  . "ReadinessChecker"
+type ReadinessChecker interface {
+	PingDatabase(ctx context.Context) error
+	GetMigrationVersion(ctx context.Context) (int64, error)
+}
+
+// HealthResponseBody represents the liveness of the service
+// @Description the service is alive whenever it is able to respond
+type HealthResponseBody struct {
+	// Status is always ok
+	Status string `json:"status"`
+}
+
+// ReadinessResponseBody represents whether the service is ready to take requests
+// @Description the readiness of the service and the status of each of its dependencies
+type ReadinessResponseBody struct {
+	// Status is one of ready, not ready or draining
+	Status string `json:"status"`
+	// Checks is the status of each dependency, it is empty while the service is draining
+	Checks map[string]CheckResponseBody `json:"checks,omitempty"`
+}
+
+// CheckResponseBody represents the status of a dependency
+// @Description the status of a dependency and why it is failing
+type CheckResponseBody struct {
+	// Status is either ok or failing
+	Status string `json:"status"`
+	// Error is why the check is failing
+	Error string `json:"error,omitempty"`
+}
+
+// NewHealthz reports that the service is alive, it has no dependencies so that a database outage doesn't get the
+// service restarted
+func NewHealthz() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, HealthResponseBody{Status: checkStatusOK})
+	}
+}
+
+// NewReadyz reports whether the service is ready to take requests. It is ready when the database responds and has
+// had at least the migrations this version ships with applied. Once draining is closed the service reports that it
+// isn't ready, so that no new requests are sent to it while it shuts down.
+func NewReadyz(readinessChecker ReadinessChecker, migrationVersion int64, draining <-chan struct{}) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		select {
+		case <-draining:
+			c.JSON(http.StatusServiceUnavailable, ReadinessResponseBody{Status: readinessStatusDraining})
+			return
+		default:
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), readinessCheckTimeout)
+		defer cancel()
+
+		response := ReadinessResponseBody{
+			Status: readinessStatusReady,
+			Checks: map[string]CheckResponseBody{
+				"database":   toCheckResponseBody(readinessChecker.PingDatabase(ctx)),
+				"migrations": toCheckResponseBody(checkMigrations(ctx, readinessChecker, migrationVersion)),
+			},
+		}
+
+		status := http.StatusOK
+		for name, check := range response.Checks {
+			if check.Status != checkStatusOK {
+				Logger(ctx).WarnContext(ctx, "readiness check failing", "check", name, "err", check.Error)
+				response.Status = readinessStatusNotReady
+				status = http.StatusServiceUnavailable
+			}
+		}
+
+		c.JSON(status, response)
+	}
+}
+
+// checkMigrations is a function that returns an error if the database is missing any of the migrations this version of
+// the service needs, a database that is ahead is fine as a newer version may have already been deployed
+func checkMigrations(ctx context.Context, readinessChecker ReadinessChecker, migrationVersion int64) error {
+	version, err := readinessChecker.GetMigrationVersion(ctx)
+	if err != nil {
+		return err
+	}
+
+	if version < migrationVersion {
+		return fmt.Errorf("database is at migration %d, expected %d", version, migrationVersion)
+	}
+
+	return nil
+}
+
+func toCheckResponseBody(err error) CheckResponseBody {
+	if err != nil {
+		return CheckResponseBody{Status: checkStatusFailing, Error: err.Error()}
+	}
+
+	return CheckResponseBody{Status: checkStatusOK}
+}
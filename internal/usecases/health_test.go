@@ -0,0 +1,114 @@
+package usecases_test
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/AlecSmith96/dating-api/internal/usecases"
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+)
+
+var _ = Describe("checking liveness", func() {
+	It("should return a 200 OK without checking any dependencies", func() {
+		w := httptest.NewRecorder()
+		req, err := http.NewRequest("GET", "http://localhost:8080/healthz", nil)
+		Expect(err).ToNot(HaveOccurred())
+		r.ServeHTTP(w, req)
+
+		Expect(w.Code).To(Equal(http.StatusOK))
+		Expect(w.Body.String()).To(MatchJSON(`{"status": "ok"}`))
+	})
+})
+
+var _ = Describe("checking readiness", func() {
+	var w *httptest.ResponseRecorder
+
+	var pingDatabaseErr error
+	var getMigrationVersionResponse int64
+	var getMigrationVersionErr error
+
+	BeforeEach(func() {
+		pingDatabaseErr = nil
+		getMigrationVersionResponse = migrationVersion
+		getMigrationVersionErr = nil
+	})
+
+	JustBeforeEach(func() {
+		w = httptest.NewRecorder()
+
+		readinessChecker.EXPECT().PingDatabase(gomock.Any()).Return(pingDatabaseErr).Times(1)
+		readinessChecker.EXPECT().GetMigrationVersion(gomock.Any()).Return(getMigrationVersionResponse, getMigrationVersionErr).Times(1)
+
+		req, err := http.NewRequest("GET", "http://localhost:8080/readyz", nil)
+		Expect(err).ToNot(HaveOccurred())
+		r.ServeHTTP(w, req)
+	})
+
+	It("should return a 200 OK with the status of each dependency", func() {
+		Expect(w.Code).To(Equal(http.StatusOK))
+		Expect(w.Body.String()).To(MatchJSON(`{"status": "ready", "checks": {"database": {"status": "ok"}, "migrations": {"status": "ok"}}}`))
+	})
+
+	When("the database is ahead of the service", func() {
+		BeforeEach(func() {
+			getMigrationVersionResponse = migrationVersion + 1
+		})
+
+		It("should return a 200 OK", func() {
+			Expect(w.Code).To(Equal(http.StatusOK))
+		})
+	})
+
+	When("the database can't be reached", func() {
+		BeforeEach(func() {
+			pingDatabaseErr = errors.New("connection refused")
+			getMigrationVersionErr = errors.New("connection refused")
+		})
+
+		It("should return a 503 Service Unavailable with the failing checks", func() {
+			Expect(w.Code).To(Equal(http.StatusServiceUnavailable))
+			var resp usecases.ReadinessResponseBody
+			err := json.NewDecoder(w.Body).Decode(&resp)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resp.Status).To(Equal("not ready"))
+			Expect(resp.Checks["database"]).To(Equal(usecases.CheckResponseBody{Status: "failing", Error: "connection refused"}))
+		})
+	})
+
+	When("the database is missing migrations", func() {
+		BeforeEach(func() {
+			getMigrationVersionResponse = migrationVersion - 1
+		})
+
+		It("should return a 503 Service Unavailable", func() {
+			Expect(w.Code).To(Equal(http.StatusServiceUnavailable))
+			var resp usecases.ReadinessResponseBody
+			err := json.NewDecoder(w.Body).Decode(&resp)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resp.Checks["database"].Status).To(Equal("ok"))
+			Expect(resp.Checks["migrations"].Status).To(Equal("failing"))
+		})
+	})
+})
+
+var _ = Describe("checking readiness while draining", func() {
+	It("should return a 503 Service Unavailable without checking any dependencies", func() {
+		draining := make(chan struct{})
+		close(draining)
+
+		drainingRouter := gin.New()
+		drainingRouter.GET("/readyz", usecases.NewReadyz(readinessChecker, migrationVersion, draining))
+
+		w := httptest.NewRecorder()
+		req, err := http.NewRequest("GET", "http://localhost:8080/readyz", nil)
+		Expect(err).ToNot(HaveOccurred())
+		drainingRouter.ServeHTTP(w, req)
+
+		Expect(w.Code).To(Equal(http.StatusServiceUnavailable))
+		Expect(w.Body.String()).To(MatchJSON(`{"status": "draining"}`))
+	})
+})
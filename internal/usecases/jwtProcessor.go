@@ -1,8 +1,11 @@
 package usecases
 
-import "github.com/google/uuid"
+import (
+	"context"
+	"github.com/google/uuid"
+)
 
 //go:generate mockgen --build_flags=--mod=mod -destination=../../mocks/jwtProcessor.go  . "JwtProcessor"
 type JwtProcessor interface {
-	ValidateJwtForUser(tokenValue string) (uuid.UUID, error)
+	ValidateJwtForUser(ctx context.Context, tokenValue string) (uuid.UUID, error)
 }
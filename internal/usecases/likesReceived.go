@@ -0,0 +1,155 @@
+package usecases
+
+import (
+	"context"
+	"github.com/AlecSmith96/dating-api/internal/entities"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"time"
+)
+
+const (
+	defaultLikesReceivedLimit = 20
+	maxLikesReceivedLimit     = 100
+)
+
+//go:generate mockgen --build_flags=--mod=mod -destination=../../mocks/likesReceivedLister.go  . "LikesReceivedLister"
+type LikesReceivedLister interface {
+	CountLikesReceived(ctx context.Context, userID uuid.UUID) (int, error)
+	GetLikesReceived(ctx context.Context, userID uuid.UUID, limit, offset int) ([]entities.ReceivedLike, error)
+}
+
+// GetLikesReceivedRequestQuery represents the page of likes to return
+type GetLikesReceivedRequestQuery struct {
+	// Limit is the maximum number of likes to return
+	Limit int `form:"limit" binding:"omitempty,min=1"`
+	// Offset is the number of likes to skip
+	Offset int `form:"offset" binding:"omitempty,min=0"`
+}
+
+// LikesReceivedResponseBody represents a page of the users that have liked the requesting user
+// @Description the users that have liked the requesting user that they haven't swiped on yet, super likes first
+type LikesReceivedResponseBody struct {
+	// Total is the number of users that have liked the requesting user
+	Total int `json:"total"`
+	// Likes is the page of likes, they are blurred unless the requesting users plan includes seeing who liked them
+	Likes []ReceivedLikeResponseBody `json:"likes"`
+	// NextOffset is the offset of the next page, it is omitted on the last page
+	NextOffset *int `json:"nextOffset,omitempty"`
+}
+
+// ReceivedLikeResponseBody represents a user that has liked the requesting user
+// @Description a user that has liked the requesting user, only blurred is set for blurred likes
+type ReceivedLikeResponseBody struct {
+	// Blurred is true when the profile of the user is hidden
+	Blurred bool `json:"blurred"`
+	// UserID is the id of the user, swipe on it through /user/swipe to like back or pass
+	UserID string `json:"userId,omitempty"`
+	// Name is the name of the user
+	Name string `json:"name,omitempty"`
+	// Gender is the gender of the user
+	Gender string `json:"gender,omitempty"`
+	// Age is the age of the user
+	Age int `json:"age,omitempty"`
+	// SuperLike is true when the user super liked the requesting user
+	SuperLike bool `json:"superLike,omitempty"`
+	// LikedAt is when the user liked the requesting user, it is omitted for older likes
+	LikedAt *time.Time `json:"likedAt,omitempty"`
+}
+
+// NewGetLikesReceived lists the users that have liked the requesting user
+// @Summary Get likes received
+// @Description Lists the users that have liked or super liked the requesting user that they haven't swiped on yet.
+// @Description Users on a plan that includes it get the profiles of those users, other users get the total and blurred
+// @Description placeholders.
+// @Description Swiping on a user from the list through /user/swipe matches as normal and removes them from the list.
+// @Security BearerAuth
+// @Tags users
+// @Produce json
+// @Param limit query int false "The maximum number of likes to return"
+// @Param offset query int false "The number of likes to skip"
+// @Success 200 {object} LikesReceivedResponseBody
+// @Failure 400 {object} ProblemResponseBody
+// @Failure 500 {object} ProblemResponseBody
+// @Router /user/likes/received [get]
+func NewGetLikesReceived(entitlements Entitlements, likesReceivedLister LikesReceivedLister) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		userID, ok := c.Get("userID")
+		if !ok {
+			Logger(ctx).ErrorContext(ctx, "unable to get userID from context")
+			c.Error(errUserIDMissing)
+			return
+		}
+		requestingUserID := userID.(uuid.UUID)
+
+		var request GetLikesReceivedRequestQuery
+		err := c.ShouldBindQuery(&request)
+		if err != nil {
+			Logger(ctx).ErrorContext(ctx, "validating request query", "err", err)
+			c.Error(bindingError(err))
+			return
+		}
+
+		limit := defaultLikesReceivedLimit
+		if request.Limit != 0 {
+			limit = min(request.Limit, maxLikesReceivedLimit)
+		}
+
+		userEntitlements, ok := getEntitlements(c, entitlements, requestingUserID)
+		if !ok {
+			return
+		}
+
+		total, err := likesReceivedLister.CountLikesReceived(ctx, requestingUserID)
+		if err != nil {
+			Logger(ctx).ErrorContext(ctx, "counting likes received", "err", err)
+			c.Error(err)
+			return
+		}
+
+		response := LikesReceivedResponseBody{
+			Total: total,
+			Likes: []ReceivedLikeResponseBody{},
+		}
+
+		if !userEntitlements.CanSeeLikes {
+			for range min(total, limit) {
+				response.Likes = append(response.Likes, ReceivedLikeResponseBody{Blurred: true})
+			}
+
+			c.JSON(http.StatusOK, response)
+			return
+		}
+
+		likes, err := likesReceivedLister.GetLikesReceived(ctx, requestingUserID, limit, request.Offset)
+		if err != nil {
+			Logger(ctx).ErrorContext(ctx, "getting likes received", "err", err)
+			c.Error(err)
+			return
+		}
+
+		for _, like := range likes {
+			likeResponse := ReceivedLikeResponseBody{
+				UserID:    like.UserID.String(),
+				Name:      like.Name,
+				Gender:    like.Gender,
+				Age:       like.Age,
+				SuperLike: like.SwipeType == entities.SwipeTypeSuperLike,
+			}
+			if !like.LikedAt.IsZero() {
+				likedAt := like.LikedAt
+				likeResponse.LikedAt = &likedAt
+			}
+
+			response.Likes = append(response.Likes, likeResponse)
+		}
+
+		if nextOffset := request.Offset + len(likes); len(likes) != 0 && nextOffset < total {
+			response.NextOffset = &nextOffset
+		}
+
+		c.JSON(http.StatusOK, response)
+	}
+}
@@ -0,0 +1,189 @@
+package usecases_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/AlecSmith96/dating-api/internal/entities"
+	"github.com/AlecSmith96/dating-api/internal/usecases"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"time"
+)
+
+var _ = Describe("getting likes received", func() {
+	var w *httptest.ResponseRecorder
+
+	var validateJwtForUserUUID uuid.UUID
+	var query string
+
+	var getEntitlementsResponse *entities.Entitlements
+	var getEntitlementsErr error
+	var getEntitlementsCallCount int
+
+	var countLikesReceivedResponse int
+	var countLikesReceivedErr error
+	var countLikesReceivedCallCount int
+
+	var getLikesReceivedLimit int
+	var getLikesReceivedOffset int
+	var getLikesReceivedResponse []entities.ReceivedLike
+	var getLikesReceivedErr error
+	var getLikesReceivedCallCount int
+
+	BeforeEach(func() {
+		validateJwtForUserUUID = uuid.New()
+		query = ""
+
+		getEntitlementsResponse = &entities.Entitlements{Tier: entities.TierPremium, CanSeeLikes: true}
+		getEntitlementsErr = nil
+		getEntitlementsCallCount = 1
+
+		countLikesReceivedResponse = 3
+		countLikesReceivedErr = nil
+		countLikesReceivedCallCount = 1
+
+		getLikesReceivedLimit = 20
+		getLikesReceivedOffset = 0
+		getLikesReceivedResponse = []entities.ReceivedLike{
+			{
+				UserID:    uuid.New(),
+				Name:      "Alex",
+				Gender:    "female",
+				Age:       27,
+				SwipeType: entities.SwipeTypeSuperLike,
+				LikedAt:   time.Now().Add(-time.Hour).UTC().Truncate(time.Second),
+			},
+			{
+				UserID:    uuid.New(),
+				Name:      "Sam",
+				Gender:    "male",
+				Age:       31,
+				SwipeType: entities.SwipeTypeLike,
+			},
+		}
+		getLikesReceivedErr = nil
+		getLikesReceivedCallCount = 1
+	})
+
+	JustBeforeEach(func() {
+		w = httptest.NewRecorder()
+
+		jwtProcessor.EXPECT().ValidateJwtForUser(gomock.Any(), mockJWT).Return(validateJwtForUserUUID, nil).Times(1)
+		entitlements.EXPECT().GetEntitlements(gomock.Any(), validateJwtForUserUUID).Return(getEntitlementsResponse, getEntitlementsErr).Times(getEntitlementsCallCount)
+		likesReceived.EXPECT().CountLikesReceived(gomock.Any(), validateJwtForUserUUID).Return(countLikesReceivedResponse, countLikesReceivedErr).Times(countLikesReceivedCallCount)
+		likesReceived.EXPECT().GetLikesReceived(gomock.Any(), validateJwtForUserUUID, getLikesReceivedLimit, getLikesReceivedOffset).
+			Return(getLikesReceivedResponse, getLikesReceivedErr).Times(getLikesReceivedCallCount)
+
+		req, err := http.NewRequest("GET", "http://localhost:8080/dating-api/v1/user/likes/received"+query, nil)
+		req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", mockJWT))
+		Expect(err).ToNot(HaveOccurred())
+		r.ServeHTTP(w, req)
+	})
+
+	It("should return the profiles of the users that liked the requesting user", func() {
+		Expect(w.Code).To(Equal(http.StatusOK))
+		var resp usecases.LikesReceivedResponseBody
+		err := json.NewDecoder(w.Body).Decode(&resp)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(resp.Total).To(Equal(3))
+		Expect(resp.Likes).To(HaveLen(2))
+		Expect(resp.Likes[0].Blurred).To(BeFalse())
+		Expect(resp.Likes[0].UserID).To(Equal(getLikesReceivedResponse[0].UserID.String()))
+		Expect(resp.Likes[0].Name).To(Equal("Alex"))
+		Expect(resp.Likes[0].SuperLike).To(BeTrue())
+		Expect(*resp.Likes[0].LikedAt).To(BeTemporally("==", getLikesReceivedResponse[0].LikedAt))
+		Expect(resp.Likes[1].SuperLike).To(BeFalse())
+		Expect(resp.Likes[1].LikedAt).To(BeNil())
+		Expect(resp.NextOffset).ToNot(BeNil())
+		Expect(*resp.NextOffset).To(Equal(2))
+	})
+
+	When("the last page is requested", func() {
+		BeforeEach(func() {
+			query = "?limit=2&offset=1"
+			getLikesReceivedLimit = 2
+			getLikesReceivedOffset = 1
+		})
+
+		It("should not return a next offset", func() {
+			Expect(w.Code).To(Equal(http.StatusOK))
+			var resp usecases.LikesReceivedResponseBody
+			err := json.NewDecoder(w.Body).Decode(&resp)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resp.NextOffset).To(BeNil())
+		})
+	})
+
+	When("the limit is above the maximum", func() {
+		BeforeEach(func() {
+			query = "?limit=1000"
+			getLikesReceivedLimit = 100
+		})
+
+		It("should cap the limit", func() {
+			Expect(w.Code).To(Equal(http.StatusOK))
+		})
+	})
+
+	When("the users plan doesn't include seeing who liked them", func() {
+		BeforeEach(func() {
+			getEntitlementsResponse = &entities.Entitlements{Tier: entities.TierPlus, CanSeeLikes: false}
+			getLikesReceivedCallCount = 0
+		})
+
+		It("should return the total and blurred placeholders", func() {
+			Expect(w.Code).To(Equal(http.StatusOK))
+			var resp usecases.LikesReceivedResponseBody
+			err := json.NewDecoder(w.Body).Decode(&resp)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resp.Total).To(Equal(3))
+			Expect(resp.Likes).To(HaveLen(3))
+			for _, like := range resp.Likes {
+				Expect(like).To(Equal(usecases.ReceivedLikeResponseBody{Blurred: true}))
+			}
+			Expect(resp.NextOffset).To(BeNil())
+		})
+	})
+
+	When("the offset is negative", func() {
+		BeforeEach(func() {
+			query = "?offset=-1"
+			getEntitlementsCallCount = 0
+			countLikesReceivedCallCount = 0
+			getLikesReceivedCallCount = 0
+		})
+
+		It("should return a 400 Bad Request", func() {
+			Expect(w.Code).To(Equal(http.StatusBadRequest))
+		})
+	})
+
+	When("getting the entitlements of the user returns an error", func() {
+		BeforeEach(func() {
+			getEntitlementsResponse = nil
+			getEntitlementsErr = errors.New("an error occurred")
+			countLikesReceivedCallCount = 0
+			getLikesReceivedCallCount = 0
+		})
+
+		It("should return a 500 Internal Server Error", func() {
+			Expect(w.Code).To(Equal(http.StatusInternalServerError))
+		})
+	})
+
+	When("the adapter returns an error", func() {
+		BeforeEach(func() {
+			getLikesReceivedResponse = nil
+			getLikesReceivedErr = errors.New("an error occurred")
+		})
+
+		It("should return a 500 Internal Server Error", func() {
+			Expect(w.Code).To(Equal(http.StatusInternalServerError))
+		})
+	})
+})
@@ -0,0 +1,24 @@
+package usecases
+
+import (
+	"context"
+	"log/slog"
+)
+
+type loggerKey struct{}
+
+// WithLogger is a function that returns a copy of the context carrying the logger
+func WithLogger(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// Logger is a function that returns the logger of the context, which for requests carries the request id, route and
+// user id. It returns the default logger for contexts without one, such as those of the background workers.
+func Logger(ctx context.Context) *slog.Logger {
+	logger, ok := ctx.Value(loggerKey{}).(*slog.Logger)
+	if !ok {
+		return slog.Default()
+	}
+
+	return logger
+}
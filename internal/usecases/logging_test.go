@@ -0,0 +1,111 @@
+package usecases_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/AlecSmith96/dating-api/internal/entities"
+	"github.com/AlecSmith96/dating-api/internal/usecases"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+)
+
+var _ = Describe("logging a request", func() {
+	var w *httptest.ResponseRecorder
+	var logs bytes.Buffer
+	var defaultLogger *slog.Logger
+
+	var requestID string
+	var validateJwtForUserUUID uuid.UUID
+
+	BeforeEach(func() {
+		logs.Reset()
+		defaultLogger = slog.Default()
+		slog.SetDefault(slog.New(slog.NewJSONHandler(&logs, nil)))
+
+		requestID = "client-request-id"
+		validateJwtForUserUUID = uuid.New()
+	})
+
+	AfterEach(func() {
+		slog.SetDefault(defaultLogger)
+	})
+
+	JustBeforeEach(func() {
+		w = httptest.NewRecorder()
+
+		jwtProcessor.EXPECT().ValidateJwtForUser(gomock.Any(), mockJWT).Return(validateJwtForUserUUID, nil).Times(1)
+		entitlements.EXPECT().GetEntitlements(gomock.Any(), validateJwtForUserUUID).DoAndReturn(func(ctx context.Context, _ uuid.UUID) (*entities.Entitlements, error) {
+			usecases.Logger(ctx).InfoContext(ctx, "getting entitlements")
+			return &entities.Entitlements{Tier: entities.TierFree}, nil
+		}).Times(1)
+
+		req, err := http.NewRequest("GET", "http://localhost:8080/dating-api/v1/user/entitlements", nil)
+		req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", mockJWT))
+		if requestID != "" {
+			req.Header.Add("X-Request-ID", requestID)
+		}
+		Expect(err).ToNot(HaveOccurred())
+		r.ServeHTTP(w, req)
+	})
+
+	logEntries := func() []map[string]any {
+		var entries []map[string]any
+		for _, line := range strings.Split(strings.TrimSpace(logs.String()), "\n") {
+			var entry map[string]any
+			Expect(json.Unmarshal([]byte(line), &entry)).To(Succeed())
+			entries = append(entries, entry)
+		}
+		return entries
+	}
+
+	It("should return the request id it was given", func() {
+		Expect(w.Code).To(Equal(http.StatusOK))
+		Expect(w.Header().Get("X-Request-ID")).To(Equal(requestID))
+	})
+
+	It("should log with the request id, route and user id", func() {
+		entries := logEntries()
+		Expect(entries).To(ContainElement(And(
+			HaveKeyWithValue("msg", "getting entitlements"),
+			HaveKeyWithValue("request_id", requestID),
+			HaveKeyWithValue("route", "/dating-api/v1/user/entitlements"),
+			HaveKeyWithValue("user_id", validateJwtForUserUUID.String()),
+		)))
+		Expect(entries).To(ContainElement(And(
+			HaveKeyWithValue("msg", "handled request"),
+			HaveKeyWithValue("request_id", requestID),
+			HaveKeyWithValue("status", BeEquivalentTo(http.StatusOK)),
+		)))
+	})
+
+	When("no request id is given", func() {
+		BeforeEach(func() {
+			requestID = ""
+		})
+
+		It("should generate one", func() {
+			_, err := uuid.Parse(w.Header().Get("X-Request-ID"))
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+
+	When("the request id isn't valid", func() {
+		BeforeEach(func() {
+			requestID = "not valid\nrequest id"
+		})
+
+		It("should replace it", func() {
+			Expect(w.Header().Get("X-Request-ID")).ToNot(Equal(requestID))
+			_, err := uuid.Parse(w.Header().Get("X-Request-ID"))
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+})
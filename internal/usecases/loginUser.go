@@ -1,18 +1,18 @@
 package usecases
 
 import (
+	"context"
 	"errors"
 	"github.com/AlecSmith96/dating-api/internal/entities"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
-	"log/slog"
 	"net/http"
 )
 
 //go:generate mockgen --build_flags=--mod=mod -destination=../../mocks/userAuthenticator.go  . "UserAuthenticator"
 type UserAuthenticator interface {
-	LoginUser(email string, password string) (*entities.User, error)
-	IssueJWT(userID uuid.UUID) (*entities.Token, error)
+	LoginUser(ctx context.Context, email string, password string) (*entities.User, error)
+	IssueJWT(ctx context.Context, userID uuid.UUID) (*entities.Token, error)
 }
 
 // LoginUserRequestBody represents the login credentials for the user
@@ -39,38 +39,53 @@ type LoginUserResponseBody struct {
 // @Produce json
 // @Param user body LoginUserRequestBody true "Login User Request Body"
 // @Success 200 {object} LoginUserResponseBody
-// @Failure 400
-// @Failure 500
+// @Failure 400 {object} ProblemResponseBody
+// @Failure 401 {object} ProblemResponseBody
+// @Failure 403 {object} ProblemResponseBody
+// @Failure 500 {object} ProblemResponseBody
 // @Router /login [post]
-func NewLoginUser(userAuthenticator UserAuthenticator) gin.HandlerFunc {
+func NewLoginUser(userAuthenticator UserAuthenticator, metricsRecorder MetricsRecorder) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		ctx := c.Request.Context()
 		var request LoginUserRequestBody
 		err := c.ShouldBindJSON(&request)
 		if err != nil {
-			slog.Error("binding request body", "err", err)
-			c.JSON(http.StatusBadRequest, entities.ErrorMessage{Message: err.Error()})
+			Logger(ctx).ErrorContext(ctx, "binding request body", "err", err)
+			c.Error(bindingError(err))
 			return
 		}
 
-		user, err := userAuthenticator.LoginUser(request.Email, request.Password)
+		user, err := userAuthenticator.LoginUser(ctx, request.Email, request.Password)
 		if err != nil {
 			if errors.Is(err, entities.ErrUserNotFound) {
-				slog.Error("user not found for parsed details")
-				c.JSON(http.StatusUnauthorized, entities.ErrorMessage{Message: "incorrect email or password"})
+				Logger(ctx).ErrorContext(ctx, "user not found for parsed details")
+				metricsRecorder.RecordLogin(false)
+				c.Error(entities.NewError(http.StatusUnauthorized, entities.CodeIncorrectCredentials, "incorrect email or password"))
 				return
 			}
-			slog.Error("authenticating user login", "err", err)
-			c.JSON(http.StatusInternalServerError, entities.ErrorMessage{Message: "unable to login user"})
+			if errors.Is(err, entities.ErrUserBanned) {
+				metricsRecorder.RecordLogin(false)
+				c.Error(entities.NewError(http.StatusForbidden, entities.CodeAccountBanned, "account is banned"))
+				return
+			}
+			if errors.Is(err, entities.ErrUserSuspended) {
+				metricsRecorder.RecordLogin(false)
+				c.Error(entities.NewError(http.StatusForbidden, entities.CodeAccountSuspended, "account is suspended"))
+				return
+			}
+			Logger(ctx).ErrorContext(ctx, "authenticating user login", "err", err)
+			c.Error(err)
 			return
 		}
 
-		token, err := userAuthenticator.IssueJWT(user.ID)
+		token, err := userAuthenticator.IssueJWT(ctx, user.ID)
 		if err != nil {
-			slog.Error("issuing user JWT", "err", err)
-			c.JSON(http.StatusInternalServerError, entities.ErrorMessage{Message: "unable to login user"})
+			Logger(ctx).ErrorContext(ctx, "issuing user JWT", "err", err)
+			c.Error(err)
 			return
 		}
 
+		metricsRecorder.RecordLogin(true)
 		c.JSON(http.StatusOK, LoginUserResponseBody{
 			Token: token.Value,
 		})
@@ -0,0 +1,26 @@
+package usecases
+
+import (
+	"github.com/AlecSmith96/dating-api/internal/entities"
+	"time"
+)
+
+// the reasons a jwt fails validation, they are kept to a fixed set so that the metric stays small
+const (
+	JwtFailureMissing   = "missing"
+	JwtFailureMalformed = "malformed"
+	JwtFailureExpired   = "expired"
+	JwtFailureRevoked   = "revoked"
+	JwtFailureBanned    = "banned"
+	JwtFailureSuspended = "suspended"
+	JwtFailureInvalid   = "invalid"
+)
+
+//go:generate mockgen --build_flags=--mod=mod -destination=../../mocks/metricsRecorder.go  . "MetricsRecorder"
+type MetricsRecorder interface {
+	ObserveRequest(route, method string, status int, duration time.Duration)
+	RecordLogin(success bool)
+	RecordSwipe(swipeType entities.SwipeType)
+	RecordMatch()
+	RecordJwtValidationFailure(reason string)
+}
@@ -0,0 +1,55 @@
+package usecases_test
+
+import (
+	"fmt"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"io"
+	"net/http"
+	"net/http/httptest"
+)
+
+var _ = Describe("scraping metrics", func() {
+	var body string
+
+	JustBeforeEach(func() {
+		w := httptest.NewRecorder()
+		req, err := http.NewRequest("GET", "http://localhost:8080/metrics", nil)
+		Expect(err).ToNot(HaveOccurred())
+		r.ServeHTTP(w, req)
+
+		Expect(w.Code).To(Equal(http.StatusOK))
+		b, err := io.ReadAll(w.Body)
+		Expect(err).ToNot(HaveOccurred())
+		body = string(b)
+	})
+
+	When("a request is rejected by jwt validation", func() {
+		BeforeEach(func() {
+			w := httptest.NewRecorder()
+			req, err := http.NewRequest("GET", fmt.Sprintf("http://localhost:8080/dating-api/v1/admin/questions/%s", "some-id"), nil)
+			Expect(err).ToNot(HaveOccurred())
+			r.ServeHTTP(w, req)
+			Expect(w.Code).To(Equal(http.StatusUnauthorized))
+		})
+
+		It("should record the request against its route template and the reason it was rejected", func() {
+			Expect(body).To(ContainSubstring(`dating_api_http_request_duration_seconds_count{method="GET",route="/dating-api/v1/admin/questions/:id",status="401"}`))
+			Expect(body).To(MatchRegexp(`dating_api_jwt_validation_failures_total\{reason="malformed"\} [1-9]`))
+		})
+	})
+
+	When("a request doesn't match a route", func() {
+		BeforeEach(func() {
+			w := httptest.NewRecorder()
+			req, err := http.NewRequest("GET", "http://localhost:8080/not-a-route/123", nil)
+			Expect(err).ToNot(HaveOccurred())
+			r.ServeHTTP(w, req)
+		})
+
+		It("should record the request without its path", func() {
+			Expect(body).To(ContainSubstring(`route="unmatched"`))
+			Expect(body).ToNot(ContainSubstring("not-a-route"))
+		})
+	})
+})
@@ -0,0 +1,415 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/AlecSmith96/dating-api/internal/entities"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"time"
+)
+
+const (
+	defaultModerationQueueLimit = 50
+	maxModerationQueueLimit     = 200
+)
+
+//go:generate mockgen --build_flags=--mod=mod -destination=../../mocks/moderationQueue.go  . "ModerationQueue"
+type ModerationQueue interface {
+	GetModerationCases(ctx context.Context, status entities.ModerationCaseStatus, limit int) ([]entities.ModerationCase, error)
+	GetModerationCase(ctx context.Context, caseID uuid.UUID) (*entities.ModerationCase, error)
+	GetModerationAudit(ctx context.Context, caseID uuid.UUID) ([]entities.ModerationAuditEntry, error)
+	AssignModerationCase(ctx context.Context, caseID, moderatorUserID, actorUserID uuid.UUID) (*entities.ModerationCase, error)
+	UpdateModerationCaseStatus(ctx context.Context, caseID uuid.UUID, status entities.ModerationCaseStatus, actorUserID uuid.UUID) (*entities.ModerationCase, error)
+	ApplyModerationAction(ctx context.Context, action *entities.ModerationAction) (*entities.ModerationCase, error)
+}
+
+// GetModerationCasesRequestQuery represents the filters for the moderation queue
+type GetModerationCasesRequestQuery struct {
+	// Status is the status of the cases to return, defaults to open
+	Status string `form:"status" binding:"omitempty,oneof=open in_review actioned dismissed"`
+	// Limit is the maximum number of cases to return
+	Limit int `form:"limit" binding:"omitempty,min=1"`
+}
+
+// ModerationCasesResponseBody represents a page of the moderation queue
+// @Description the cases in the moderation queue, oldest first
+type ModerationCasesResponseBody struct {
+	// Cases is the list of cases matching the filter
+	Cases []ModerationCaseResponseBody `json:"cases"`
+}
+
+// ModerationCaseResponseBody represents a moderation case
+// @Description a case in the moderation queue
+type ModerationCaseResponseBody struct {
+	// ID is the id of the case
+	ID string `json:"id"`
+	// SubjectUserID is the id of the user the case is about
+	SubjectUserID string `json:"subjectUserId"`
+	// ReportID is the id of the report that opened the case
+	ReportID *string `json:"reportId,omitempty"`
+	// Reason is the reason given in the report
+	Reason string `json:"reason,omitempty"`
+	// Details is the free text given in the report
+	Details string `json:"details,omitempty"`
+	// Status is the status of the case
+	Status string `json:"status"`
+	// AssignedModeratorID is the id of the moderator working the case
+	AssignedModeratorID *string `json:"assignedModeratorId,omitempty"`
+	// CreatedAt is when the case was opened
+	CreatedAt time.Time `json:"createdAt"`
+	// UpdatedAt is when the case was last changed
+	UpdatedAt time.Time `json:"updatedAt"`
+	// Audit is the history of the case, it is only returned when getting a single case
+	Audit []ModerationAuditEntryResponseBody `json:"audit,omitempty"`
+}
+
+// ModerationAuditEntryResponseBody represents an entry in the audit history of a case
+// @Description an immutable record of a change made to a case
+type ModerationAuditEntryResponseBody struct {
+	// ActorUserID is the id of the moderator that made the change
+	ActorUserID string `json:"actorUserId"`
+	// Action is the change that was made
+	Action string `json:"action"`
+	// Details is the data of the change
+	Details json.RawMessage `json:"details" swaggertype:"object"`
+	// CreatedAt is when the change was made
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// AssignModerationCaseRequestBody represents the moderator to assign a case to
+// @Description the moderator to assign the case to
+type AssignModerationCaseRequestBody struct {
+	// ModeratorID is the id of the moderator to assign, defaults to the requesting moderator
+	ModeratorID *uuid.UUID `json:"moderatorId"`
+}
+
+// UpdateModerationCaseStatusRequestBody represents the new status of a case
+// @Description the new status of the case, cases are marked as actioned by taking an action
+type UpdateModerationCaseStatusRequestBody struct {
+	// Status is the new status of the case
+	Status string `json:"status" binding:"required,oneof=open in_review dismissed" enums:"open,in_review,dismissed"`
+}
+
+// ModerationActionRequestBody represents an action to take against the subject of a case
+// @Description the action to take against the user the case is about
+type ModerationActionRequestBody struct {
+	// Action is the action to take
+	Action string `json:"action" binding:"required,oneof=warn suspend ban" enums:"warn,suspend,ban"`
+	// DurationHours is how long to suspend the user for, required when suspending
+	DurationHours int `json:"durationHours" binding:"required_if=Action suspend,max=8760"`
+	// Note is the reasoning of the moderator, it is written to the audit history
+	Note string `json:"note" binding:"max=2000"`
+}
+
+func toModerationCaseResponseBody(moderationCase *entities.ModerationCase) ModerationCaseResponseBody {
+	response := ModerationCaseResponseBody{
+		ID:            moderationCase.ID.String(),
+		SubjectUserID: moderationCase.SubjectUserID.String(),
+		Reason:        string(moderationCase.ReportReason),
+		Details:       moderationCase.ReportDetails,
+		Status:        string(moderationCase.Status),
+		CreatedAt:     moderationCase.CreatedAt,
+		UpdatedAt:     moderationCase.UpdatedAt,
+	}
+
+	if moderationCase.ReportID.Valid {
+		reportID := moderationCase.ReportID.UUID.String()
+		response.ReportID = &reportID
+	}
+
+	if moderationCase.AssignedModeratorID.Valid {
+		moderatorID := moderationCase.AssignedModeratorID.UUID.String()
+		response.AssignedModeratorID = &moderatorID
+	}
+
+	return response
+}
+
+// writeModerationError is a function that writes the response for errors returned when changing a case
+func writeModerationError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, entities.ErrCaseNotFound):
+		c.Error(entities.NewError(http.StatusNotFound, entities.CodeCaseNotFound, "case not found"))
+	case errors.Is(err, entities.ErrInvalidTransition):
+		c.Error(entities.NewError(http.StatusConflict, entities.CodeInvalidTransition, "case can not be moved to that status"))
+	case errors.Is(err, entities.ErrNotAModerator):
+		c.Error(entities.NewError(http.StatusBadRequest, entities.CodeNotAModerator, "user is not a moderator"))
+	default:
+		ctx := c.Request.Context()
+		Logger(ctx).ErrorContext(ctx, "updating moderation case", "err", err)
+		c.Error(err)
+	}
+}
+
+// NewGetModerationCases gets the moderation queue
+// @Summary Get the moderation queue
+// @Description Gets the moderation cases with the provided status, oldest first
+// @Security BearerAuth
+// @Tags moderation
+// @Produce json
+// @Param status query string false "The status of the cases to return" Enums(open, in_review, actioned, dismissed)
+// @Param limit query int false "The maximum number of cases to return"
+// @Success 200 {object} ModerationCasesResponseBody
+// @Failure 400 {object} ProblemResponseBody
+// @Failure 403 {object} ProblemResponseBody
+// @Failure 500 {object} ProblemResponseBody
+// @Router /admin/moderation/cases [get]
+func NewGetModerationCases(moderationQueue ModerationQueue) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		var request GetModerationCasesRequestQuery
+		err := c.ShouldBindQuery(&request)
+		if err != nil {
+			Logger(ctx).ErrorContext(ctx, "validating request query", "err", err)
+			c.Error(bindingError(err))
+			return
+		}
+
+		status := entities.ModerationCaseStatusOpen
+		if request.Status != "" {
+			status = entities.ModerationCaseStatus(request.Status)
+		}
+
+		limit := defaultModerationQueueLimit
+		if request.Limit != 0 {
+			limit = min(request.Limit, maxModerationQueueLimit)
+		}
+
+		moderationCases, err := moderationQueue.GetModerationCases(ctx, status, limit)
+		if err != nil {
+			Logger(ctx).ErrorContext(ctx, "getting moderation cases", "err", err)
+			c.Error(err)
+			return
+		}
+
+		response := ModerationCasesResponseBody{Cases: []ModerationCaseResponseBody{}}
+		for _, moderationCase := range moderationCases {
+			response.Cases = append(response.Cases, toModerationCaseResponseBody(&moderationCase))
+		}
+
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+// NewGetModerationCase gets a moderation case
+// @Summary Get a moderation case
+// @Description Gets a moderation case and its audit history
+// @Security BearerAuth
+// @Tags moderation
+// @Produce json
+// @Param id path string true "The id of the case"
+// @Success 200 {object} ModerationCaseResponseBody
+// @Failure 400 {object} ProblemResponseBody
+// @Failure 403 {object} ProblemResponseBody
+// @Failure 404 {object} ProblemResponseBody
+// @Failure 500 {object} ProblemResponseBody
+// @Router /admin/moderation/cases/{id} [get]
+func NewGetModerationCase(moderationQueue ModerationQueue) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		caseID, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			c.Error(invalidField("id", "uuid", "must be a uuid"))
+			return
+		}
+
+		moderationCase, err := moderationQueue.GetModerationCase(ctx, caseID)
+		if err != nil {
+			if errors.Is(err, entities.ErrCaseNotFound) {
+				c.Error(entities.NewError(http.StatusNotFound, entities.CodeCaseNotFound, "case not found"))
+				return
+			}
+			Logger(ctx).ErrorContext(ctx, "getting moderation case", "err", err)
+			c.Error(err)
+			return
+		}
+
+		auditEntries, err := moderationQueue.GetModerationAudit(ctx, caseID)
+		if err != nil {
+			Logger(ctx).ErrorContext(ctx, "getting moderation audit", "err", err)
+			c.Error(err)
+			return
+		}
+
+		response := toModerationCaseResponseBody(moderationCase)
+		response.Audit = []ModerationAuditEntryResponseBody{}
+		for _, auditEntry := range auditEntries {
+			response.Audit = append(response.Audit, ModerationAuditEntryResponseBody{
+				ActorUserID: auditEntry.ActorUserID.String(),
+				Action:      auditEntry.Action,
+				Details:     auditEntry.Details,
+				CreatedAt:   auditEntry.CreatedAt,
+			})
+		}
+
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+// NewAssignModerationCase assigns a moderation case to a moderator
+// @Summary Assign a moderation case
+// @Description Assigns a moderation case to a moderator, moving open cases into review
+// @Security BearerAuth
+// @Tags moderation
+// @Accept json
+// @Produce json
+// @Param id path string true "The id of the case"
+// @Param assignment body AssignModerationCaseRequestBody true "Assign Moderation Case Request Body"
+// @Success 200 {object} ModerationCaseResponseBody
+// @Failure 400 {object} ProblemResponseBody
+// @Failure 403 {object} ProblemResponseBody
+// @Failure 404 {object} ProblemResponseBody
+// @Failure 409 {object} ProblemResponseBody
+// @Failure 500 {object} ProblemResponseBody
+// @Router /admin/moderation/cases/{id}/assign [post]
+func NewAssignModerationCase(moderationQueue ModerationQueue) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		userID, ok := c.Get("userID")
+		if !ok {
+			Logger(ctx).ErrorContext(ctx, "unable to get userID from context")
+			c.Error(errUserIDMissing)
+			return
+		}
+		requestingUserID := userID.(uuid.UUID)
+
+		caseID, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			c.Error(invalidField("id", "uuid", "must be a uuid"))
+			return
+		}
+
+		var request AssignModerationCaseRequestBody
+		err = c.ShouldBindJSON(&request)
+		if err != nil {
+			Logger(ctx).ErrorContext(ctx, "validating request body", "err", err)
+			c.Error(bindingError(err))
+			return
+		}
+
+		moderatorID := requestingUserID
+		if request.ModeratorID != nil {
+			moderatorID = *request.ModeratorID
+		}
+
+		moderationCase, err := moderationQueue.AssignModerationCase(ctx, caseID, moderatorID, requestingUserID)
+		if err != nil {
+			writeModerationError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, toModerationCaseResponseBody(moderationCase))
+	}
+}
+
+// NewUpdateModerationCaseStatus changes the status of a moderation case
+// @Summary Update the status of a moderation case
+// @Description Moves a moderation case between open, in_review and dismissed
+// @Security BearerAuth
+// @Tags moderation
+// @Accept json
+// @Produce json
+// @Param id path string true "The id of the case"
+// @Param status body UpdateModerationCaseStatusRequestBody true "Update Moderation Case Status Request Body"
+// @Success 200 {object} ModerationCaseResponseBody
+// @Failure 400 {object} ProblemResponseBody
+// @Failure 403 {object} ProblemResponseBody
+// @Failure 404 {object} ProblemResponseBody
+// @Failure 409 {object} ProblemResponseBody
+// @Failure 500 {object} ProblemResponseBody
+// @Router /admin/moderation/cases/{id}/status [post]
+func NewUpdateModerationCaseStatus(moderationQueue ModerationQueue) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		userID, ok := c.Get("userID")
+		if !ok {
+			Logger(ctx).ErrorContext(ctx, "unable to get userID from context")
+			c.Error(errUserIDMissing)
+			return
+		}
+		requestingUserID := userID.(uuid.UUID)
+
+		caseID, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			c.Error(invalidField("id", "uuid", "must be a uuid"))
+			return
+		}
+
+		var request UpdateModerationCaseStatusRequestBody
+		err = c.ShouldBindJSON(&request)
+		if err != nil {
+			Logger(ctx).ErrorContext(ctx, "validating request body", "err", err)
+			c.Error(bindingError(err))
+			return
+		}
+
+		moderationCase, err := moderationQueue.UpdateModerationCaseStatus(ctx, caseID, entities.ModerationCaseStatus(request.Status), requestingUserID)
+		if err != nil {
+			writeModerationError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, toModerationCaseResponseBody(moderationCase))
+	}
+}
+
+// NewApplyModerationAction takes an action against the subject of a moderation case
+// @Summary Take a moderation action
+// @Description Warns, suspends or bans the user a case is about and marks the case as actioned. Suspending or banning
+// @Description a user revokes all of their tokens.
+// @Security BearerAuth
+// @Tags moderation
+// @Accept json
+// @Produce json
+// @Param id path string true "The id of the case"
+// @Param action body ModerationActionRequestBody true "Moderation Action Request Body"
+// @Success 200 {object} ModerationCaseResponseBody
+// @Failure 400 {object} ProblemResponseBody
+// @Failure 403 {object} ProblemResponseBody
+// @Failure 404 {object} ProblemResponseBody
+// @Failure 409 {object} ProblemResponseBody
+// @Failure 500 {object} ProblemResponseBody
+// @Router /admin/moderation/cases/{id}/actions [post]
+func NewApplyModerationAction(moderationQueue ModerationQueue) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		userID, ok := c.Get("userID")
+		if !ok {
+			Logger(ctx).ErrorContext(ctx, "unable to get userID from context")
+			c.Error(errUserIDMissing)
+			return
+		}
+		requestingUserID := userID.(uuid.UUID)
+
+		caseID, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			c.Error(invalidField("id", "uuid", "must be a uuid"))
+			return
+		}
+
+		var request ModerationActionRequestBody
+		err = c.ShouldBindJSON(&request)
+		if err != nil {
+			Logger(ctx).ErrorContext(ctx, "validating request body", "err", err)
+			c.Error(bindingError(err))
+			return
+		}
+
+		moderationCase, err := moderationQueue.ApplyModerationAction(ctx, &entities.ModerationAction{
+			CaseID:          caseID,
+			ModeratorUserID: requestingUserID,
+			Action:          entities.ModerationActionType(request.Action),
+			Duration:        time.Duration(request.DurationHours) * time.Hour,
+			Note:            request.Note,
+		})
+		if err != nil {
+			writeModerationError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, toModerationCaseResponseBody(moderationCase))
+	}
+}
@@ -0,0 +1,221 @@
+package usecases_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/AlecSmith96/dating-api/internal/entities"
+	"github.com/AlecSmith96/dating-api/internal/usecases"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"time"
+)
+
+var _ = Describe("getting the moderation queue", func() {
+	var w *httptest.ResponseRecorder
+
+	var validateJwtForUserUUID uuid.UUID
+	var validateJwtForUserErr error
+	var validateJwtForUserCallCount int
+
+	var getUserRoleResponse entities.Role
+	var getUserRoleErr error
+	var getUserRoleCallCount int
+
+	var getModerationCasesResponse []entities.ModerationCase
+	var getModerationCasesErr error
+	var getModerationCasesCallCount int
+
+	BeforeEach(func() {
+		validateJwtForUserUUID = uuid.New()
+		validateJwtForUserErr = nil
+		validateJwtForUserCallCount = 1
+
+		getUserRoleResponse = entities.RoleModerator
+		getUserRoleErr = nil
+		getUserRoleCallCount = 1
+
+		getModerationCasesResponse = []entities.ModerationCase{
+			{
+				ID:            uuid.New(),
+				SubjectUserID: uuid.New(),
+				ReportID:      uuid.NullUUID{UUID: uuid.New(), Valid: true},
+				Status:        entities.ModerationCaseStatusOpen,
+				CreatedAt:     time.Now(),
+				UpdatedAt:     time.Now(),
+				ReportReason:  entities.ReportReasonSpam,
+			},
+		}
+		getModerationCasesErr = nil
+		getModerationCasesCallCount = 1
+	})
+
+	JustBeforeEach(func() {
+		w = httptest.NewRecorder()
+
+		jwtProcessor.EXPECT().ValidateJwtForUser(gomock.Any(), mockJWT).Return(validateJwtForUserUUID, validateJwtForUserErr).Times(validateJwtForUserCallCount)
+		roleChecker.EXPECT().GetUserRole(gomock.Any(), validateJwtForUserUUID).Return(getUserRoleResponse, getUserRoleErr).Times(getUserRoleCallCount)
+		moderationQueue.EXPECT().GetModerationCases(gomock.Any(), entities.ModerationCaseStatusOpen, 50).Return(getModerationCasesResponse, getModerationCasesErr).Times(getModerationCasesCallCount)
+
+		req, err := http.NewRequest("GET", "http://localhost:8080/dating-api/v1/admin/moderation/cases", nil)
+		req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", mockJWT))
+		Expect(err).ToNot(HaveOccurred())
+		r.ServeHTTP(w, req)
+	})
+
+	It("should return the open cases", func() {
+		Expect(w.Code).To(Equal(http.StatusOK))
+		var resp usecases.ModerationCasesResponseBody
+		err := json.NewDecoder(w.Body).Decode(&resp)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(resp.Cases).To(HaveLen(1))
+		Expect(resp.Cases[0].Reason).To(Equal("spam"))
+		Expect(resp.Cases[0].AssignedModeratorID).To(BeNil())
+	})
+
+	When("the user is not a moderator", func() {
+		BeforeEach(func() {
+			getUserRoleResponse = entities.RoleUser
+			getModerationCasesCallCount = 0
+		})
+
+		It("should return a 403 Forbidden", func() {
+			Expect(w.Code).To(Equal(http.StatusForbidden))
+		})
+	})
+
+	When("the user is suspended", func() {
+		BeforeEach(func() {
+			validateJwtForUserUUID = uuid.UUID{}
+			validateJwtForUserErr = entities.ErrUserSuspended
+			getUserRoleCallCount = 0
+			getModerationCasesCallCount = 0
+		})
+
+		It("should return a 403 Forbidden", func() {
+			Expect(w.Code).To(Equal(http.StatusForbidden))
+		})
+	})
+
+	When("the users jwt has been revoked", func() {
+		BeforeEach(func() {
+			validateJwtForUserUUID = uuid.UUID{}
+			validateJwtForUserErr = entities.ErrJwtRevoked
+			getUserRoleCallCount = 0
+			getModerationCasesCallCount = 0
+		})
+
+		It("should return a 401 Unauthorized", func() {
+			Expect(w.Code).To(Equal(http.StatusUnauthorized))
+		})
+	})
+
+	When("the adapter returns an error", func() {
+		BeforeEach(func() {
+			getModerationCasesResponse = nil
+			getModerationCasesErr = errors.New("an error occurred")
+		})
+
+		It("should return a 500 Internal Server Error", func() {
+			Expect(w.Code).To(Equal(http.StatusInternalServerError))
+		})
+	})
+})
+
+var _ = Describe("taking a moderation action", func() {
+	var w *httptest.ResponseRecorder
+	var caseID uuid.UUID
+	var requestBodyJSON []byte
+
+	var validateJwtForUserUUID uuid.UUID
+
+	var applyModerationActionResponse *entities.ModerationCase
+	var applyModerationActionErr error
+	var applyModerationActionCallCount int
+
+	BeforeEach(func() {
+		caseID = uuid.New()
+		var err error
+		requestBodyJSON, err = json.Marshal(usecases.ModerationActionRequestBody{
+			Action:        "suspend",
+			DurationHours: 48,
+			Note:          "repeated harassment",
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		validateJwtForUserUUID = uuid.New()
+
+		applyModerationActionResponse = &entities.ModerationCase{
+			ID:            caseID,
+			SubjectUserID: uuid.New(),
+			Status:        entities.ModerationCaseStatusActioned,
+		}
+		applyModerationActionErr = nil
+		applyModerationActionCallCount = 1
+	})
+
+	JustBeforeEach(func() {
+		w = httptest.NewRecorder()
+
+		jwtProcessor.EXPECT().ValidateJwtForUser(gomock.Any(), mockJWT).Return(validateJwtForUserUUID, nil).Times(1)
+		roleChecker.EXPECT().GetUserRole(gomock.Any(), validateJwtForUserUUID).Return(entities.RoleAdmin, nil).Times(1)
+		moderationQueue.EXPECT().ApplyModerationAction(gomock.Any(), &entities.ModerationAction{
+			CaseID:          caseID,
+			ModeratorUserID: validateJwtForUserUUID,
+			Action:          entities.ModerationActionSuspend,
+			Duration:        48 * time.Hour,
+			Note:            "repeated harassment",
+		}).Return(applyModerationActionResponse, applyModerationActionErr).Times(applyModerationActionCallCount)
+
+		req, err := http.NewRequest("POST", fmt.Sprintf("http://localhost:8080/dating-api/v1/admin/moderation/cases/%s/actions", caseID), bytes.NewReader(requestBodyJSON))
+		req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", mockJWT))
+		Expect(err).ToNot(HaveOccurred())
+		r.ServeHTTP(w, req)
+	})
+
+	It("should return the actioned case", func() {
+		Expect(w.Code).To(Equal(http.StatusOK))
+		var resp usecases.ModerationCaseResponseBody
+		err := json.NewDecoder(w.Body).Decode(&resp)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(resp.Status).To(Equal("actioned"))
+	})
+
+	When("a suspension has no duration", func() {
+		BeforeEach(func() {
+			requestBodyJSON = []byte(`{"action": "suspend"}`)
+			applyModerationActionCallCount = 0
+		})
+
+		It("should return a 400 Bad Request", func() {
+			Expect(w.Code).To(Equal(http.StatusBadRequest))
+		})
+	})
+
+	When("the case is already closed", func() {
+		BeforeEach(func() {
+			applyModerationActionResponse = nil
+			applyModerationActionErr = entities.ErrInvalidTransition
+		})
+
+		It("should return a 409 Conflict", func() {
+			Expect(w.Code).To(Equal(http.StatusConflict))
+		})
+	})
+
+	When("the case does not exist", func() {
+		BeforeEach(func() {
+			applyModerationActionResponse = nil
+			applyModerationActionErr = entities.ErrCaseNotFound
+		})
+
+		It("should return a 404 Not Found", func() {
+			Expect(w.Code).To(Equal(http.StatusNotFound))
+		})
+	})
+})
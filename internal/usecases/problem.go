@@ -0,0 +1,255 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/AlecSmith96/dating-api/internal/entities"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"io"
+	"net/http"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// ProblemContentType is the content type of error responses, as described by RFC 7807
+const ProblemContentType = "application/problem+json"
+
+// problemTypeBlank is the problem type for problems that are only described by their status, the code tells them apart
+const problemTypeBlank = "about:blank"
+
+// errUserIDMissing is returned when a usecase runs without TokenAuthMiddleware having set the userID
+var errUserIDMissing = errors.New("userID missing from request context")
+
+// ProblemResponseBody represents why a request failed, following RFC 7807
+// @Description a problem details object describing why the request failed
+type ProblemResponseBody struct {
+	// Type is always about:blank, the code identifies the problem instead
+	Type string `json:"type"`
+	// Title is the description of the status
+	Title string `json:"title"`
+	// Status is the http status of the response
+	Status int `json:"status"`
+	// Detail is a description of the problem that is safe to show to users
+	Detail string `json:"detail"`
+	// Code is a stable identifier for the problem that clients can rely on
+	Code string `json:"code"`
+	// Instance is the path of the request that failed
+	Instance string `json:"instance"`
+	// Errors are the reasons each of the invalid fields of the request is invalid
+	Errors []FieldErrorResponseBody `json:"errors,omitempty"`
+}
+
+// FieldErrorResponseBody represents why a field of the request is invalid
+// @Description the field of the request that is invalid and the rule it broke
+type FieldErrorResponseBody struct {
+	// Field is the name of the field, such as pageInfo.minAge
+	Field string `json:"field"`
+	// Code is the rule the field broke, such as required or max
+	Code string `json:"code"`
+	// Message describes the rule the field broke
+	Message string `json:"message"`
+}
+
+// ToError is a function that converts an error returned by a usecase into one that is safe to return to clients. Errors
+// that aren't already client errors are reported as cancelled, timed out or internal errors so that nothing about
+// their cause reaches the client.
+func ToError(ctx context.Context, err error) *entities.Error {
+	var clientErr *entities.Error
+	if errors.As(err, &clientErr) {
+		return clientErr
+	}
+
+	if status, ok := CancellationStatus(ctx, err); ok {
+		if status == http.StatusGatewayTimeout {
+			return entities.NewError(status, entities.CodeRequestTimedOut, "request timed out")
+		}
+
+		return entities.NewError(status, entities.CodeRequestCancelled, "request was cancelled")
+	}
+
+	return entities.NewError(http.StatusInternalServerError, entities.CodeInternalError, "an internal error occurred")
+}
+
+// WriteProblem is a function that writes err as a problem details response
+func WriteProblem(c *gin.Context, err error) {
+	clientErr := ToError(c.Request.Context(), err)
+
+	title := http.StatusText(clientErr.Status)
+	if clientErr.Status == StatusClientClosedRequest {
+		title = "Client Closed Request"
+	}
+
+	problem := ProblemResponseBody{
+		Type:     problemTypeBlank,
+		Title:    title,
+		Status:   clientErr.Status,
+		Detail:   clientErr.Message,
+		Code:     clientErr.Code,
+		Instance: c.Request.URL.Path,
+	}
+	for _, field := range clientErr.Fields {
+		problem.Errors = append(problem.Errors, FieldErrorResponseBody{
+			Field:   field.Field,
+			Code:    field.Code,
+			Message: field.Message,
+		})
+	}
+
+	c.Header("Content-Type", ProblemContentType)
+	c.JSON(clientErr.Status, problem)
+}
+
+// FieldName is a function that returns the name clients use for a field of a request, which is its json name, or its
+// form name for query parameters
+func FieldName(field reflect.StructField) string {
+	for _, tag := range []string{"json", "form"} {
+		name, _, _ := strings.Cut(field.Tag.Get(tag), ",")
+		if name == "-" {
+			return ""
+		}
+		if name != "" {
+			return name
+		}
+	}
+
+	return field.Name
+}
+
+// ValidateGender is a validation rule for fields that must be one of the supported genders
+func ValidateGender(fieldLevel validator.FieldLevel) bool {
+	return slices.Contains(entities.Genders, fieldLevel.Field().String())
+}
+
+// invalidField is a function that returns the error for a single invalid field of the request
+func invalidField(field, code, message string) *entities.Error {
+	err := entities.NewError(http.StatusBadRequest, entities.CodeInvalidRequest, "request is invalid")
+	err.Fields = []entities.FieldError{{Field: field, Code: code, Message: message}}
+
+	return err
+}
+
+// bindingError is a function that translates the error from binding a request into the fields that are invalid and
+// why, without returning the decoders own message
+func bindingError(err error) *entities.Error {
+	clientErr := entities.NewError(http.StatusBadRequest, entities.CodeInvalidRequest, "request is invalid")
+
+	var validationErrs validator.ValidationErrors
+	var typeErr *json.UnmarshalTypeError
+	var syntaxErr *json.SyntaxError
+	switch {
+	case errors.As(err, &validationErrs):
+		for _, fieldErr := range validationErrs {
+			clientErr.Fields = append(clientErr.Fields, entities.FieldError{
+				Field:   fieldPath(fieldErr.Namespace()),
+				Code:    fieldErr.Tag(),
+				Message: validationMessage(fieldErr),
+			})
+		}
+	case errors.As(err, &typeErr):
+		clientErr.Fields = []entities.FieldError{{
+			Field:   typeErr.Field,
+			Code:    "type",
+			Message: fmt.Sprintf("must be %s", jsonTypeName(typeErr.Type)),
+		}}
+	case errors.As(err, &syntaxErr), errors.Is(err, io.ErrUnexpectedEOF):
+		clientErr.Message = "request body is not valid json"
+	case errors.Is(err, io.EOF):
+		clientErr.Message = "request body is empty"
+	}
+
+	return clientErr
+}
+
+// fieldPath is a function that removes the name of the request type from the start of a validation namespace, leaving
+// the path of the field as the client sent it
+func fieldPath(namespace string) string {
+	_, path, found := strings.Cut(namespace, ".")
+	if !found {
+		return namespace
+	}
+
+	return path
+}
+
+// validationMessage is a function that describes the rule a field broke
+func validationMessage(fieldErr validator.FieldError) string {
+	param := fieldErr.Param()
+	switch fieldErr.Tag() {
+	case "required":
+		return "is required"
+	case "oneof":
+		return fmt.Sprintf("must be one of %s", strings.ReplaceAll(param, " ", ", "))
+	case "uuid", "uuid4":
+		return "must be a uuid"
+	case "email":
+		return "must be an email address"
+	case "min", "gte":
+		return fmt.Sprintf("must be at least %s%s", param, lengthUnit(fieldErr.Kind()))
+	case "max", "lte":
+		return fmt.Sprintf("must be at most %s%s", param, lengthUnit(fieldErr.Kind()))
+	case "gt":
+		return fmt.Sprintf("must be greater than %s%s", param, lengthUnit(fieldErr.Kind()))
+	case "lt":
+		return fmt.Sprintf("must be less than %s%s", param, lengthUnit(fieldErr.Kind()))
+	case "gtefield":
+		return fmt.Sprintf("must be at least %s", lowerFirst(param))
+	case "gender":
+		return fmt.Sprintf("must be one of %s", strings.Join(entities.Genders, ", "))
+	case "len":
+		return fmt.Sprintf("must be exactly %s%s", param, lengthUnit(fieldErr.Kind()))
+	default:
+		return fmt.Sprintf("must pass the %s rule", fieldErr.Tag())
+	}
+}
+
+// lowerFirst is a function that turns the name of a struct field into the name clients send it with
+func lowerFirst(name string) string {
+	if name == "" {
+		return name
+	}
+
+	return strings.ToLower(name[:1]) + name[1:]
+}
+
+// lengthUnit is a function that returns what the size rules count for a kind of field, as they limit the length of
+// strings and lists rather than their value
+func lengthUnit(kind reflect.Kind) string {
+	switch kind {
+	case reflect.String:
+		return " characters long"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return " items"
+	default:
+		return ""
+	}
+}
+
+// jsonTypeName is a function that describes a go type in the terms of json
+func jsonTypeName(t reflect.Type) string {
+	if t == reflect.TypeOf(uuid.UUID{}) {
+		return "a uuid"
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return "a string"
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "an integer"
+	case reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.Slice, reflect.Array:
+		return "an array"
+	case reflect.Map, reflect.Struct:
+		return "an object"
+	default:
+		return "a " + t.String()
+	}
+}
@@ -0,0 +1,131 @@
+package usecases_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/AlecSmith96/dating-api/internal/entities"
+	"github.com/AlecSmith96/dating-api/internal/usecases"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+)
+
+var _ = Describe("returning errors", func() {
+	var w *httptest.ResponseRecorder
+	var problem usecases.ProblemResponseBody
+
+	JustBeforeEach(func() {
+		Expect(w.Header().Get("Content-Type")).To(Equal(usecases.ProblemContentType))
+		Expect(json.Unmarshal(w.Body.Bytes(), &problem)).To(Succeed())
+	})
+
+	When("the request body fails validation", func() {
+		BeforeEach(func() {
+			w = httptest.NewRecorder()
+			req, err := http.NewRequest("POST", "http://localhost:8080/dating-api/v1/login", bytes.NewBufferString(`{"email":"someone@example.com"}`))
+			Expect(err).ToNot(HaveOccurred())
+			r.ServeHTTP(w, req)
+		})
+
+		It("should return each invalid field by its json name", func() {
+			Expect(w.Code).To(Equal(http.StatusBadRequest))
+			Expect(problem).To(Equal(usecases.ProblemResponseBody{
+				Type:     "about:blank",
+				Title:    "Bad Request",
+				Status:   http.StatusBadRequest,
+				Detail:   "request is invalid",
+				Code:     entities.CodeInvalidRequest,
+				Instance: "/dating-api/v1/login",
+				Errors: []usecases.FieldErrorResponseBody{
+					{Field: "password", Code: "required", Message: "is required"},
+				},
+			}))
+		})
+	})
+
+	When("a field of the request body has the wrong type", func() {
+		BeforeEach(func() {
+			w = httptest.NewRecorder()
+			req, err := http.NewRequest("POST", "http://localhost:8080/dating-api/v1/login", bytes.NewBufferString(`{"email":7,"password":"password"}`))
+			Expect(err).ToNot(HaveOccurred())
+			r.ServeHTTP(w, req)
+		})
+
+		It("should return the field and the type it should be", func() {
+			Expect(w.Code).To(Equal(http.StatusBadRequest))
+			Expect(problem.Errors).To(Equal([]usecases.FieldErrorResponseBody{
+				{Field: "email", Code: "type", Message: "must be a string"},
+			}))
+		})
+	})
+
+	When("the request body isn't json", func() {
+		BeforeEach(func() {
+			w = httptest.NewRecorder()
+			req, err := http.NewRequest("POST", "http://localhost:8080/dating-api/v1/login", bytes.NewBufferString(`{"email":`))
+			Expect(err).ToNot(HaveOccurred())
+			r.ServeHTTP(w, req)
+		})
+
+		It("should not return the decoders error", func() {
+			Expect(w.Code).To(Equal(http.StatusBadRequest))
+			Expect(problem.Code).To(Equal(entities.CodeInvalidRequest))
+			Expect(problem.Detail).To(Equal("request body is not valid json"))
+		})
+	})
+
+	When("an unexpected error occurs", func() {
+		BeforeEach(func() {
+			userID := uuid.New()
+			jwtProcessor.EXPECT().ValidateJwtForUser(gomock.Any(), mockJWT).Return(userID, nil).Times(1)
+			entitlements.EXPECT().GetEntitlements(gomock.Any(), userID).Return(nil, errors.New("pq: relation \"subscriptions\" does not exist")).Times(1)
+
+			w = httptest.NewRecorder()
+			req, err := http.NewRequest("GET", "http://localhost:8080/dating-api/v1/user/entitlements", nil)
+			Expect(err).ToNot(HaveOccurred())
+			req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", mockJWT))
+			r.ServeHTTP(w, req)
+		})
+
+		It("should return an internal error without its cause", func() {
+			Expect(w.Code).To(Equal(http.StatusInternalServerError))
+			Expect(problem.Code).To(Equal(entities.CodeInternalError))
+			Expect(problem.Detail).To(Equal("an internal error occurred"))
+			Expect(w.Body.String()).ToNot(ContainSubstring("subscriptions"))
+		})
+	})
+
+	When("the jwt is rejected", func() {
+		BeforeEach(func() {
+			w = httptest.NewRecorder()
+			req, err := http.NewRequest("GET", "http://localhost:8080/dating-api/v1/user/entitlements", nil)
+			Expect(err).ToNot(HaveOccurred())
+			req.Header.Add("Authorization", "Bearer ")
+			r.ServeHTTP(w, req)
+		})
+
+		It("should return the reason from the middleware", func() {
+			Expect(w.Code).To(Equal(http.StatusUnauthorized))
+			Expect(problem.Code).To(Equal(entities.CodeJwtMissing))
+		})
+	})
+
+	When("the route doesn't exist", func() {
+		BeforeEach(func() {
+			w = httptest.NewRecorder()
+			req, err := http.NewRequest("GET", "http://localhost:8080/dating-api/v1/not-a-route", nil)
+			Expect(err).ToNot(HaveOccurred())
+			r.ServeHTTP(w, req)
+		})
+
+		It("should return a 404 Not Found", func() {
+			Expect(w.Code).To(Equal(http.StatusNotFound))
+			Expect(problem.Code).To(Equal(entities.CodeNotFound))
+		})
+	})
+})
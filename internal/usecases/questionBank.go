@@ -0,0 +1,275 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"github.com/AlecSmith96/dating-api/internal/entities"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"time"
+)
+
+//go:generate mockgen --build_flags=--mod=mod -destination=../../mocks/questionBank.go  . "QuestionBank"
+type QuestionBank interface {
+	CreateQuestion(ctx context.Context, question *entities.Question) (*entities.Question, error)
+	GetQuestions(ctx context.Context) ([]entities.Question, error)
+	GetQuestion(ctx context.Context, questionID uuid.UUID) (*entities.Question, error)
+	UpdateQuestion(ctx context.Context, question *entities.Question) (*entities.Question, error)
+	DeleteQuestion(ctx context.Context, questionID uuid.UUID) error
+}
+
+// QuestionRequestBody represents a question in the question bank
+// @Description the question and its possible answers, in the order they are shown
+type QuestionRequestBody struct {
+	// Text is the question
+	Text string `json:"text" binding:"required,max=500"`
+	// Answers are the possible answers to the question
+	Answers []string `json:"answers" binding:"required,min=2,max=6,dive,required,max=200"`
+}
+
+// QuestionsResponseBody represents the question bank
+// @Description every question in the question bank, oldest first
+type QuestionsResponseBody struct {
+	// Questions are the questions in the question bank
+	Questions []QuestionResponseBody `json:"questions"`
+}
+
+// QuestionResponseBody represents a question in the question bank
+// @Description a question and its possible answers
+type QuestionResponseBody struct {
+	// ID is the id of the question
+	ID string `json:"id"`
+	// Text is the question
+	Text string `json:"text"`
+	// Answers are the possible answers to the question, in the order they are shown
+	Answers []AnswerResponseBody `json:"answers"`
+	// CreatedAt is when the question was added
+	CreatedAt time.Time `json:"createdAt"`
+	// UpdatedAt is when the question was last changed
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// AnswerResponseBody represents a possible answer to a question
+// @Description a possible answer to a question
+type AnswerResponseBody struct {
+	// ID is the id of the answer
+	ID string `json:"id"`
+	// Text is the answer
+	Text string `json:"text"`
+}
+
+func toQuestionResponseBody(question *entities.Question) QuestionResponseBody {
+	response := QuestionResponseBody{
+		ID:        question.ID.String(),
+		Text:      question.Text,
+		Answers:   make([]AnswerResponseBody, 0, len(question.Answers)),
+		CreatedAt: question.CreatedAt,
+		UpdatedAt: question.UpdatedAt,
+	}
+
+	for _, answer := range question.Answers {
+		response.Answers = append(response.Answers, AnswerResponseBody{ID: answer.ID.String(), Text: answer.Text})
+	}
+
+	return response
+}
+
+func toQuestion(request QuestionRequestBody) *entities.Question {
+	question := &entities.Question{Text: request.Text}
+	for _, answer := range request.Answers {
+		question.Answers = append(question.Answers, entities.Answer{Text: answer})
+	}
+
+	return question
+}
+
+// writeQuestionError is a function that writes the response for errors returned when reading or changing a question
+func writeQuestionError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, entities.ErrQuestionNotFound):
+		c.Error(entities.NewError(http.StatusNotFound, entities.CodeQuestionNotFound, "question not found"))
+	case errors.Is(err, entities.ErrQuestionAnswered):
+		c.Error(entities.NewError(http.StatusConflict, entities.CodeQuestionAnswered, "answers can not be added or removed once the question has been answered"))
+	default:
+		ctx := c.Request.Context()
+		Logger(ctx).ErrorContext(ctx, "changing question", "err", err)
+		c.Error(err)
+	}
+}
+
+// NewCreateQuestion adds a question to the question bank
+// @Summary Create a question
+// @Description Adds a question with between 2 and 6 possible answers to the question bank
+// @Security BearerAuth
+// @Tags questions
+// @Accept json
+// @Produce json
+// @Param question body QuestionRequestBody true "Question Request Body"
+// @Success 201 {object} QuestionResponseBody
+// @Failure 400 {object} ProblemResponseBody
+// @Failure 403 {object} ProblemResponseBody
+// @Failure 500 {object} ProblemResponseBody
+// @Router /admin/questions [post]
+func NewCreateQuestion(questionBank QuestionBank) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		var request QuestionRequestBody
+		err := c.ShouldBindJSON(&request)
+		if err != nil {
+			Logger(ctx).ErrorContext(ctx, "validating request body", "err", err)
+			c.Error(bindingError(err))
+			return
+		}
+
+		question, err := questionBank.CreateQuestion(ctx, toQuestion(request))
+		if err != nil {
+			Logger(ctx).ErrorContext(ctx, "creating question", "err", err)
+			c.Error(err)
+			return
+		}
+
+		c.JSON(http.StatusCreated, toQuestionResponseBody(question))
+	}
+}
+
+// NewGetQuestions gets the question bank
+// @Summary Get the question bank
+// @Description Gets every question in the question bank, oldest first
+// @Security BearerAuth
+// @Tags questions
+// @Produce json
+// @Success 200 {object} QuestionsResponseBody
+// @Failure 403 {object} ProblemResponseBody
+// @Failure 500 {object} ProblemResponseBody
+// @Router /admin/questions [get]
+func NewGetQuestions(questionBank QuestionBank) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		questions, err := questionBank.GetQuestions(ctx)
+		if err != nil {
+			Logger(ctx).ErrorContext(ctx, "getting questions", "err", err)
+			c.Error(err)
+			return
+		}
+
+		response := QuestionsResponseBody{Questions: make([]QuestionResponseBody, 0, len(questions))}
+		for _, question := range questions {
+			response.Questions = append(response.Questions, toQuestionResponseBody(&question))
+		}
+
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+// NewGetQuestion gets a question
+// @Summary Get a question
+// @Description Gets a question from the question bank
+// @Security BearerAuth
+// @Tags questions
+// @Produce json
+// @Param id path string true "The id of the question"
+// @Success 200 {object} QuestionResponseBody
+// @Failure 400 {object} ProblemResponseBody
+// @Failure 403 {object} ProblemResponseBody
+// @Failure 404 {object} ProblemResponseBody
+// @Failure 500 {object} ProblemResponseBody
+// @Router /admin/questions/{id} [get]
+func NewGetQuestion(questionBank QuestionBank) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		questionID, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			Logger(ctx).ErrorContext(ctx, "parsing question id", "err", err)
+			c.Error(invalidField("id", "uuid", "must be a uuid"))
+			return
+		}
+
+		question, err := questionBank.GetQuestion(ctx, questionID)
+		if err != nil {
+			writeQuestionError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, toQuestionResponseBody(question))
+	}
+}
+
+// NewUpdateQuestion updates a question
+// @Summary Update a question
+// @Description Replaces the text and answers of a question. Once users have answered the question its answers can
+// @Description only be reworded, keeping the same number of answers in the same order.
+// @Security BearerAuth
+// @Tags questions
+// @Accept json
+// @Produce json
+// @Param id path string true "The id of the question"
+// @Param question body QuestionRequestBody true "Question Request Body"
+// @Success 200 {object} QuestionResponseBody
+// @Failure 400 {object} ProblemResponseBody
+// @Failure 403 {object} ProblemResponseBody
+// @Failure 404 {object} ProblemResponseBody
+// @Failure 409 {object} ProblemResponseBody
+// @Failure 500 {object} ProblemResponseBody
+// @Router /admin/questions/{id} [put]
+func NewUpdateQuestion(questionBank QuestionBank) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		questionID, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			Logger(ctx).ErrorContext(ctx, "parsing question id", "err", err)
+			c.Error(invalidField("id", "uuid", "must be a uuid"))
+			return
+		}
+
+		var request QuestionRequestBody
+		err = c.ShouldBindJSON(&request)
+		if err != nil {
+			Logger(ctx).ErrorContext(ctx, "validating request body", "err", err)
+			c.Error(bindingError(err))
+			return
+		}
+
+		question := toQuestion(request)
+		question.ID = questionID
+		question, err = questionBank.UpdateQuestion(ctx, question)
+		if err != nil {
+			writeQuestionError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, toQuestionResponseBody(question))
+	}
+}
+
+// NewDeleteQuestion deletes a question
+// @Summary Delete a question
+// @Description Deletes a question from the question bank along with every users answer to it
+// @Security BearerAuth
+// @Tags questions
+// @Param id path string true "The id of the question"
+// @Success 204
+// @Failure 400 {object} ProblemResponseBody
+// @Failure 403 {object} ProblemResponseBody
+// @Failure 404 {object} ProblemResponseBody
+// @Failure 500 {object} ProblemResponseBody
+// @Router /admin/questions/{id} [delete]
+func NewDeleteQuestion(questionBank QuestionBank) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		questionID, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			Logger(ctx).ErrorContext(ctx, "parsing question id", "err", err)
+			c.Error(invalidField("id", "uuid", "must be a uuid"))
+			return
+		}
+
+		err = questionBank.DeleteQuestion(ctx, questionID)
+		if err != nil {
+			writeQuestionError(c, err)
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,386 @@
+package usecases_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/AlecSmith96/dating-api/internal/entities"
+	"github.com/AlecSmith96/dating-api/internal/usecases"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"time"
+)
+
+var _ = Describe("creating a question", func() {
+	var w *httptest.ResponseRecorder
+	var requestBodyJSON []byte
+
+	var validateJwtForUserUUID uuid.UUID
+
+	var getUserRoleResponse entities.Role
+	var getUserRoleCallCount int
+
+	var expectedQuestion *entities.Question
+	var createQuestionResponse *entities.Question
+	var createQuestionErr error
+	var createQuestionCallCount int
+
+	BeforeEach(func() {
+		requestBodyJSON = []byte(`{"text": "Do you like dogs?", "answers": ["Yes", "No"]}`)
+
+		validateJwtForUserUUID = uuid.New()
+
+		getUserRoleResponse = entities.RoleAdmin
+		getUserRoleCallCount = 1
+
+		expectedQuestion = &entities.Question{
+			Text:    "Do you like dogs?",
+			Answers: []entities.Answer{{Text: "Yes"}, {Text: "No"}},
+		}
+		createQuestionResponse = &entities.Question{
+			ID:        uuid.New(),
+			Text:      "Do you like dogs?",
+			Answers:   []entities.Answer{{ID: uuid.New(), Text: "Yes"}, {ID: uuid.New(), Text: "No"}},
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		}
+		createQuestionErr = nil
+		createQuestionCallCount = 1
+	})
+
+	JustBeforeEach(func() {
+		w = httptest.NewRecorder()
+
+		jwtProcessor.EXPECT().ValidateJwtForUser(gomock.Any(), mockJWT).Return(validateJwtForUserUUID, nil).Times(1)
+		roleChecker.EXPECT().GetUserRole(gomock.Any(), validateJwtForUserUUID).Return(getUserRoleResponse, nil).Times(getUserRoleCallCount)
+		questionBank.EXPECT().CreateQuestion(gomock.Any(), expectedQuestion).Return(createQuestionResponse, createQuestionErr).Times(createQuestionCallCount)
+
+		req, err := http.NewRequest("POST", "http://localhost:8080/dating-api/v1/admin/questions", bytes.NewReader(requestBodyJSON))
+		req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", mockJWT))
+		Expect(err).ToNot(HaveOccurred())
+		r.ServeHTTP(w, req)
+	})
+
+	It("should return the created question", func() {
+		Expect(w.Code).To(Equal(http.StatusCreated))
+		var resp usecases.QuestionResponseBody
+		err := json.NewDecoder(w.Body).Decode(&resp)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(resp.ID).To(Equal(createQuestionResponse.ID.String()))
+		Expect(resp.Answers).To(HaveLen(2))
+		Expect(resp.Answers[1].ID).To(Equal(createQuestionResponse.Answers[1].ID.String()))
+		Expect(resp.Answers[1].Text).To(Equal("No"))
+	})
+
+	When("the user is a moderator", func() {
+		BeforeEach(func() {
+			getUserRoleResponse = entities.RoleModerator
+			createQuestionCallCount = 0
+		})
+
+		It("should return a 403 Forbidden", func() {
+			Expect(w.Code).To(Equal(http.StatusForbidden))
+		})
+	})
+
+	When("the question has fewer than two answers", func() {
+		BeforeEach(func() {
+			requestBodyJSON = []byte(`{"text": "Do you like dogs?", "answers": ["Yes"]}`)
+			createQuestionCallCount = 0
+		})
+
+		It("should return a 400 Bad Request", func() {
+			Expect(w.Code).To(Equal(http.StatusBadRequest))
+		})
+	})
+
+	When("an answer is empty", func() {
+		BeforeEach(func() {
+			requestBodyJSON = []byte(`{"text": "Do you like dogs?", "answers": ["Yes", ""]}`)
+			createQuestionCallCount = 0
+		})
+
+		It("should return a 400 Bad Request", func() {
+			Expect(w.Code).To(Equal(http.StatusBadRequest))
+		})
+	})
+
+	When("the adapter returns an error", func() {
+		BeforeEach(func() {
+			createQuestionResponse = nil
+			createQuestionErr = errors.New("an error occurred")
+		})
+
+		It("should return a 500 Internal Server Error", func() {
+			Expect(w.Code).To(Equal(http.StatusInternalServerError))
+		})
+	})
+})
+
+var _ = Describe("getting the question bank", func() {
+	var w *httptest.ResponseRecorder
+
+	var validateJwtForUserUUID uuid.UUID
+
+	var getQuestionsResponse []entities.Question
+	var getQuestionsErr error
+
+	BeforeEach(func() {
+		validateJwtForUserUUID = uuid.New()
+
+		getQuestionsResponse = []entities.Question{
+			{ID: uuid.New(), Text: "Do you like dogs?", Answers: []entities.Answer{{ID: uuid.New(), Text: "Yes"}, {ID: uuid.New(), Text: "No"}}},
+			{ID: uuid.New(), Text: "Do you like cats?", Answers: []entities.Answer{{ID: uuid.New(), Text: "Yes"}, {ID: uuid.New(), Text: "No"}}},
+		}
+		getQuestionsErr = nil
+	})
+
+	JustBeforeEach(func() {
+		w = httptest.NewRecorder()
+
+		jwtProcessor.EXPECT().ValidateJwtForUser(gomock.Any(), mockJWT).Return(validateJwtForUserUUID, nil).Times(1)
+		roleChecker.EXPECT().GetUserRole(gomock.Any(), validateJwtForUserUUID).Return(entities.RoleAdmin, nil).Times(1)
+		questionBank.EXPECT().GetQuestions(gomock.Any()).Return(getQuestionsResponse, getQuestionsErr).Times(1)
+
+		req, err := http.NewRequest("GET", "http://localhost:8080/dating-api/v1/admin/questions", nil)
+		req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", mockJWT))
+		Expect(err).ToNot(HaveOccurred())
+		r.ServeHTTP(w, req)
+	})
+
+	It("should return every question", func() {
+		Expect(w.Code).To(Equal(http.StatusOK))
+		var resp usecases.QuestionsResponseBody
+		err := json.NewDecoder(w.Body).Decode(&resp)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(resp.Questions).To(HaveLen(2))
+		Expect(resp.Questions[1].Text).To(Equal("Do you like cats?"))
+	})
+
+	When("the adapter returns an error", func() {
+		BeforeEach(func() {
+			getQuestionsResponse = nil
+			getQuestionsErr = errors.New("an error occurred")
+		})
+
+		It("should return a 500 Internal Server Error", func() {
+			Expect(w.Code).To(Equal(http.StatusInternalServerError))
+		})
+	})
+})
+
+var _ = Describe("getting a question", func() {
+	var w *httptest.ResponseRecorder
+
+	var validateJwtForUserUUID uuid.UUID
+	var questionID string
+
+	var getQuestionResponse *entities.Question
+	var getQuestionErr error
+	var getQuestionCallCount int
+
+	BeforeEach(func() {
+		validateJwtForUserUUID = uuid.New()
+		questionUUID := uuid.New()
+		questionID = questionUUID.String()
+
+		getQuestionResponse = &entities.Question{
+			ID:      questionUUID,
+			Text:    "Do you like dogs?",
+			Answers: []entities.Answer{{ID: uuid.New(), Text: "Yes"}, {ID: uuid.New(), Text: "No"}},
+		}
+		getQuestionErr = nil
+		getQuestionCallCount = 1
+	})
+
+	JustBeforeEach(func() {
+		w = httptest.NewRecorder()
+
+		jwtProcessor.EXPECT().ValidateJwtForUser(gomock.Any(), mockJWT).Return(validateJwtForUserUUID, nil).Times(1)
+		roleChecker.EXPECT().GetUserRole(gomock.Any(), validateJwtForUserUUID).Return(entities.RoleAdmin, nil).Times(1)
+		questionBank.EXPECT().GetQuestion(gomock.Any(), gomock.Any()).Return(getQuestionResponse, getQuestionErr).Times(getQuestionCallCount)
+
+		req, err := http.NewRequest("GET", fmt.Sprintf("http://localhost:8080/dating-api/v1/admin/questions/%s", questionID), nil)
+		req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", mockJWT))
+		Expect(err).ToNot(HaveOccurred())
+		r.ServeHTTP(w, req)
+	})
+
+	It("should return the question", func() {
+		Expect(w.Code).To(Equal(http.StatusOK))
+		var resp usecases.QuestionResponseBody
+		err := json.NewDecoder(w.Body).Decode(&resp)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(resp.ID).To(Equal(questionID))
+		Expect(resp.Answers).To(HaveLen(2))
+	})
+
+	When("the question doesn't exist", func() {
+		BeforeEach(func() {
+			getQuestionResponse = nil
+			getQuestionErr = entities.ErrQuestionNotFound
+		})
+
+		It("should return a 404 Not Found", func() {
+			Expect(w.Code).To(Equal(http.StatusNotFound))
+		})
+	})
+
+	When("the question id is invalid", func() {
+		BeforeEach(func() {
+			questionID = "not-a-uuid"
+			getQuestionCallCount = 0
+		})
+
+		It("should return a 400 Bad Request", func() {
+			Expect(w.Code).To(Equal(http.StatusBadRequest))
+		})
+	})
+})
+
+var _ = Describe("updating a question", func() {
+	var w *httptest.ResponseRecorder
+	var requestBodyJSON []byte
+
+	var validateJwtForUserUUID uuid.UUID
+	var questionID uuid.UUID
+
+	var expectedQuestion *entities.Question
+	var updateQuestionResponse *entities.Question
+	var updateQuestionErr error
+	var updateQuestionCallCount int
+
+	BeforeEach(func() {
+		requestBodyJSON = []byte(`{"text": "Do you like dogs?", "answers": ["Yes", "No", "Sometimes"]}`)
+
+		validateJwtForUserUUID = uuid.New()
+		questionID = uuid.New()
+
+		expectedQuestion = &entities.Question{
+			ID:      questionID,
+			Text:    "Do you like dogs?",
+			Answers: []entities.Answer{{Text: "Yes"}, {Text: "No"}, {Text: "Sometimes"}},
+		}
+		updateQuestionResponse = &entities.Question{
+			ID:      questionID,
+			Text:    "Do you like dogs?",
+			Answers: []entities.Answer{{ID: uuid.New(), Text: "Yes"}, {ID: uuid.New(), Text: "No"}, {ID: uuid.New(), Text: "Sometimes"}},
+		}
+		updateQuestionErr = nil
+		updateQuestionCallCount = 1
+	})
+
+	JustBeforeEach(func() {
+		w = httptest.NewRecorder()
+
+		jwtProcessor.EXPECT().ValidateJwtForUser(gomock.Any(), mockJWT).Return(validateJwtForUserUUID, nil).Times(1)
+		roleChecker.EXPECT().GetUserRole(gomock.Any(), validateJwtForUserUUID).Return(entities.RoleAdmin, nil).Times(1)
+		questionBank.EXPECT().UpdateQuestion(gomock.Any(), expectedQuestion).Return(updateQuestionResponse, updateQuestionErr).Times(updateQuestionCallCount)
+
+		req, err := http.NewRequest("PUT", fmt.Sprintf("http://localhost:8080/dating-api/v1/admin/questions/%s", questionID), bytes.NewReader(requestBodyJSON))
+		req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", mockJWT))
+		Expect(err).ToNot(HaveOccurred())
+		r.ServeHTTP(w, req)
+	})
+
+	It("should return the updated question", func() {
+		Expect(w.Code).To(Equal(http.StatusOK))
+		var resp usecases.QuestionResponseBody
+		err := json.NewDecoder(w.Body).Decode(&resp)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(resp.Answers).To(HaveLen(3))
+		Expect(resp.Answers[2].Text).To(Equal("Sometimes"))
+	})
+
+	When("the question has been answered and the number of answers changes", func() {
+		BeforeEach(func() {
+			updateQuestionResponse = nil
+			updateQuestionErr = entities.ErrQuestionAnswered
+		})
+
+		It("should return a 409 Conflict", func() {
+			Expect(w.Code).To(Equal(http.StatusConflict))
+		})
+	})
+
+	When("the question doesn't exist", func() {
+		BeforeEach(func() {
+			updateQuestionResponse = nil
+			updateQuestionErr = entities.ErrQuestionNotFound
+		})
+
+		It("should return a 404 Not Found", func() {
+			Expect(w.Code).To(Equal(http.StatusNotFound))
+		})
+	})
+
+	When("the question has more than six answers", func() {
+		BeforeEach(func() {
+			requestBodyJSON = []byte(`{"text": "Pick a number", "answers": ["1", "2", "3", "4", "5", "6", "7"]}`)
+			updateQuestionCallCount = 0
+		})
+
+		It("should return a 400 Bad Request", func() {
+			Expect(w.Code).To(Equal(http.StatusBadRequest))
+		})
+	})
+
+	When("the adapter returns an error", func() {
+		BeforeEach(func() {
+			updateQuestionResponse = nil
+			updateQuestionErr = errors.New("an error occurred")
+		})
+
+		It("should return a 500 Internal Server Error", func() {
+			Expect(w.Code).To(Equal(http.StatusInternalServerError))
+		})
+	})
+})
+
+var _ = Describe("deleting a question", func() {
+	var w *httptest.ResponseRecorder
+
+	var validateJwtForUserUUID uuid.UUID
+	var questionID uuid.UUID
+
+	var deleteQuestionErr error
+
+	BeforeEach(func() {
+		validateJwtForUserUUID = uuid.New()
+		questionID = uuid.New()
+
+		deleteQuestionErr = nil
+	})
+
+	JustBeforeEach(func() {
+		w = httptest.NewRecorder()
+
+		jwtProcessor.EXPECT().ValidateJwtForUser(gomock.Any(), mockJWT).Return(validateJwtForUserUUID, nil).Times(1)
+		roleChecker.EXPECT().GetUserRole(gomock.Any(), validateJwtForUserUUID).Return(entities.RoleAdmin, nil).Times(1)
+		questionBank.EXPECT().DeleteQuestion(gomock.Any(), questionID).Return(deleteQuestionErr).Times(1)
+
+		req, err := http.NewRequest("DELETE", fmt.Sprintf("http://localhost:8080/dating-api/v1/admin/questions/%s", questionID), nil)
+		req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", mockJWT))
+		Expect(err).ToNot(HaveOccurred())
+		r.ServeHTTP(w, req)
+	})
+
+	It("should delete the question", func() {
+		Expect(w.Code).To(Equal(http.StatusNoContent))
+	})
+
+	When("the question doesn't exist", func() {
+		BeforeEach(func() {
+			deleteQuestionErr = entities.ErrQuestionNotFound
+		})
+
+		It("should return a 404 Not Found", func() {
+			Expect(w.Code).To(Equal(http.StatusNotFound))
+		})
+	})
+})
@@ -0,0 +1,261 @@
+package usecases
+
+import (
+	"cmp"
+	"fmt"
+	"github.com/AlecSmith96/dating-api/internal/entities"
+	"github.com/google/uuid"
+	"github.com/umahmood/haversine"
+	"math"
+	"slices"
+	"time"
+)
+
+const (
+	WeightedLinearRankerName = "weighted-linear"
+	DistanceRankerName       = "distance"
+
+	// distanceScale is the distance in miles at which a candidates distance signal halves
+	distanceScale = 25.0
+	// activityScale is how long after a candidates last swipe their recent activity signal halves
+	activityScale = 72 * time.Hour
+	// desirabilityGapScale is the difference in desirability scores at which a candidates desirability fit signal halves
+	desirabilityGapScale = 200.0
+)
+
+//go:generate mockgen --build_flags=--mod=mod -destination=../../mocks/ranker.go  . "Ranker"
+type Ranker interface {
+	// Name is the name the ranker is chosen by
+	Name() string
+	// Weights returns how much each signal counts towards a candidates score, it is nil if the ranker doesn't weight
+	// signals
+	Weights() *entities.RankingWeights
+	// Rank scores the candidates for the requesting user and returns them best first
+	Rank(rankingContext entities.RankingContext, candidates []entities.UserDiscovery) []entities.RankedCandidate
+}
+
+// NewRanker is a function that returns the ranker with the provided name, the weights are only used by rankers that
+// weight signals
+func NewRanker(name string, weights entities.RankingWeights) (Ranker, error) {
+	switch name {
+	case WeightedLinearRankerName:
+		return NewWeightedLinearRanker(weights), nil
+	case DistanceRankerName:
+		return NewDistanceRanker(), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", entities.ErrUnknownRanker, name)
+	}
+}
+
+// WeightedLinearRanker scores each candidate as the weighted sum of their signals. Users that have super liked the
+// requesting user are always listed first.
+type WeightedLinearRanker struct {
+	weights entities.RankingWeights
+}
+
+func NewWeightedLinearRanker(weights entities.RankingWeights) *WeightedLinearRanker {
+	return &WeightedLinearRanker{weights: weights}
+}
+
+func (r *WeightedLinearRanker) Name() string {
+	return WeightedLinearRankerName
+}
+
+func (r *WeightedLinearRanker) Weights() *entities.RankingWeights {
+	weights := r.weights
+	return &weights
+}
+
+func (r *WeightedLinearRanker) Rank(rankingContext entities.RankingContext, candidates []entities.UserDiscovery) []entities.RankedCandidate {
+	ranked := make([]entities.RankedCandidate, 0, len(candidates))
+	for _, candidate := range candidates {
+		distanceFromMe := distanceInMiles(rankingContext.Location, candidate.Location)
+		signals := rankingSignals(rankingContext, candidate, distanceFromMe)
+
+		ranked = append(ranked, entities.RankedCandidate{
+			User:           candidate,
+			DistanceFromMe: distanceFromMe,
+			Signals:        signals,
+			Score:          signals.Score(r.weights),
+		})
+	}
+
+	slices.SortStableFunc(ranked, func(a, b entities.RankedCandidate) int {
+		if a.User.SuperLikedMe != b.User.SuperLikedMe {
+			if a.User.SuperLikedMe {
+				return -1
+			}
+			return 1
+		}
+		if a.Score != b.Score {
+			return cmp.Compare(b.Score, a.Score)
+		}
+		return cmp.Compare(a.DistanceFromMe, b.DistanceFromMe)
+	})
+
+	return ranked
+}
+
+// DistanceRanker lists users that have super liked the requesting user first, followed by users with an active boost,
+// and otherwise the closest users first
+type DistanceRanker struct{}
+
+func NewDistanceRanker() *DistanceRanker {
+	return &DistanceRanker{}
+}
+
+func (r *DistanceRanker) Name() string {
+	return DistanceRankerName
+}
+
+func (r *DistanceRanker) Weights() *entities.RankingWeights {
+	return nil
+}
+
+func (r *DistanceRanker) Rank(rankingContext entities.RankingContext, candidates []entities.UserDiscovery) []entities.RankedCandidate {
+	ranked := make([]entities.RankedCandidate, 0, len(candidates))
+	for _, candidate := range candidates {
+		distanceFromMe := distanceInMiles(rankingContext.Location, candidate.Location)
+		ranked = append(ranked, entities.RankedCandidate{
+			User:           candidate,
+			DistanceFromMe: distanceFromMe,
+			Signals:        entities.RankingSignals{Distance: distanceSignal(distanceFromMe)},
+		})
+	}
+
+	slices.SortStableFunc(ranked, func(a, b entities.RankedCandidate) int {
+		if a.User.SuperLikedMe != b.User.SuperLikedMe {
+			if a.User.SuperLikedMe {
+				return -1
+			}
+			return 1
+		}
+		if a.User.Boosted != b.User.Boosted {
+			if a.User.Boosted {
+				return -1
+			}
+			return 1
+		}
+		return cmp.Compare(a.DistanceFromMe, b.DistanceFromMe)
+	})
+
+	return ranked
+}
+
+func distanceInMiles(from, to entities.Location) float64 {
+	distance, _ := haversine.Distance(
+		haversine.Coord{Lat: from.Latitude, Lon: from.Longitude},
+		haversine.Coord{Lat: to.Latitude, Lon: to.Longitude},
+	)
+
+	return distance
+}
+
+// rankingSignals is a function that scores the candidate on each signal between 0 and 1
+func rankingSignals(rankingContext entities.RankingContext, candidate entities.UserDiscovery, distanceFromMe float64) entities.RankingSignals {
+	signals := entities.RankingSignals{
+		Distance:        distanceSignal(distanceFromMe),
+		AgeFit:          ageFitSignal(rankingContext.PageInfo, candidate.Age),
+		SharedInterests: float64(candidate.SharedInterests) / float64(candidate.SharedInterests+1),
+		// the like rates are smoothed so candidates with few swipes start in the middle
+		Popularity:      float64(candidate.LikesReceived+1) / float64(candidate.SwipesReceived+2),
+		Reciprocal:      float64(candidate.LikesGiven+1) / float64(candidate.SwipesGiven+2),
+		DesirabilityFit: 1 / (1 + math.Abs(candidate.Desirability-rankingContext.Desirability)/desirabilityGapScale),
+	}
+
+	if candidate.LastActiveAt != nil {
+		sinceActive := max(0, rankingContext.Now.Sub(*candidate.LastActiveAt))
+		signals.RecentActivity = 1 / (1 + float64(sinceActive)/float64(activityScale))
+	}
+
+	if candidate.Boosted {
+		signals.Boost = 1
+	}
+
+	if candidate.SuperLikedMe {
+		signals.Reciprocal = 1
+	}
+
+	return signals
+}
+
+func distanceSignal(distanceFromMe float64) float64 {
+	return 1 / (1 + distanceFromMe/distanceScale)
+}
+
+// ageFitSignal is a function that scores candidates highest in the middle of the requested age range, falling to 0
+// just outside it. Every candidate fits when no range is requested.
+func ageFitSignal(pageInfo entities.PageInfo, age int) float64 {
+	if pageInfo.MinAge == 0 || pageInfo.MaxAge == 0 || pageInfo.MaxAge < pageInfo.MinAge {
+		return 1
+	}
+
+	middle := float64(pageInfo.MinAge+pageInfo.MaxAge) / 2
+	halfRange := float64(pageInfo.MaxAge-pageInfo.MinAge)/2 + 1
+
+	return math.Max(0, 1-math.Abs(float64(age)-middle)/halfRange)
+}
+
+// RecommendationMixer wraps another ranker and mixes the candidates recommended from the swipe history into its
+// ranking, giving every interval-th place after the users that super liked the requesting user to the best recommended
+// candidate that hasn't been listed yet. The remaining places keep the wrapped rankers order.
+type RecommendationMixer struct {
+	ranker   Ranker
+	interval int
+}
+
+func NewRecommendationMixer(ranker Ranker, interval int) *RecommendationMixer {
+	return &RecommendationMixer{ranker: ranker, interval: max(1, interval)}
+}
+
+func (m *RecommendationMixer) Name() string {
+	return m.ranker.Name() + "+recommendations"
+}
+
+func (m *RecommendationMixer) Weights() *entities.RankingWeights {
+	return m.ranker.Weights()
+}
+
+func (m *RecommendationMixer) Rank(rankingContext entities.RankingContext, candidates []entities.UserDiscovery) []entities.RankedCandidate {
+	ranked := m.ranker.Rank(rankingContext, candidates)
+
+	mixed := make([]entities.RankedCandidate, 0, len(ranked))
+	for len(mixed) < len(ranked) && ranked[len(mixed)].User.SuperLikedMe {
+		mixed = append(mixed, ranked[len(mixed)])
+	}
+	pinned := len(mixed)
+	rest := ranked[pinned:]
+
+	var recommended []entities.RankedCandidate
+	for _, candidate := range rest {
+		if candidate.User.RecommendationRank > 0 {
+			recommended = append(recommended, candidate)
+		}
+	}
+	slices.SortStableFunc(recommended, func(a, b entities.RankedCandidate) int {
+		return cmp.Compare(a.User.RecommendationRank, b.User.RecommendationRank)
+	})
+
+	listed := make(map[uuid.UUID]bool, len(rest))
+	nextRanked, nextRecommended := 0, 0
+	for len(mixed) < len(ranked) {
+		if (len(mixed)-pinned+1)%m.interval == 0 {
+			for nextRecommended < len(recommended) && listed[recommended[nextRecommended].User.ID] {
+				nextRecommended++
+			}
+			if nextRecommended < len(recommended) {
+				mixed = append(mixed, recommended[nextRecommended])
+				listed[recommended[nextRecommended].User.ID] = true
+				continue
+			}
+		}
+
+		for listed[rest[nextRanked].User.ID] {
+			nextRanked++
+		}
+		mixed = append(mixed, rest[nextRanked])
+		listed[rest[nextRanked].User.ID] = true
+	}
+
+	return mixed
+}
@@ -0,0 +1,180 @@
+package usecases_test
+
+import (
+	"github.com/AlecSmith96/dating-api/internal/entities"
+	"github.com/AlecSmith96/dating-api/internal/usecases"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"time"
+)
+
+var _ = Describe("ranking discovery candidates", func() {
+	var rankingContext entities.RankingContext
+	var candidates []entities.UserDiscovery
+
+	BeforeEach(func() {
+		rankingContext = entities.RankingContext{
+			Location: entities.Location{Latitude: 51.4545, Longitude: -2.5879},
+			Now:      time.Now(),
+		}
+
+		candidates = []entities.UserDiscovery{
+			{ID: uuid.New(), Age: 30, Location: entities.Location{Latitude: 51.4545, Longitude: -2.5879}},
+			{ID: uuid.New(), Age: 30, Location: entities.Location{Latitude: 51.4545, Longitude: -2.5879}},
+		}
+	})
+
+	rankedIDs := func(ranked []entities.RankedCandidate) []uuid.UUID {
+		var ids []uuid.UUID
+		for _, candidate := range ranked {
+			ids = append(ids, candidate.User.ID)
+		}
+		return ids
+	}
+
+	Describe("choosing a ranker", func() {
+		It("should return the ranker with the provided name", func() {
+			ranker, err := usecases.NewRanker(usecases.WeightedLinearRankerName, rankingWeights)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ranker.Name()).To(Equal(usecases.WeightedLinearRankerName))
+			Expect(*ranker.Weights()).To(Equal(rankingWeights))
+
+			ranker, err = usecases.NewRanker(usecases.DistanceRankerName, rankingWeights)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ranker.Name()).To(Equal(usecases.DistanceRankerName))
+			Expect(ranker.Weights()).To(BeNil())
+		})
+
+		It("should return an error for an unknown ranker", func() {
+			_, err := usecases.NewRanker("random", rankingWeights)
+			Expect(err).To(MatchError(entities.ErrUnknownRanker))
+		})
+	})
+
+	Describe("the weighted linear ranker", func() {
+		var ranker *usecases.WeightedLinearRanker
+
+		BeforeEach(func() {
+			ranker = usecases.NewWeightedLinearRanker(rankingWeights)
+		})
+
+		It("should score each candidate as the weighted sum of their signals", func() {
+			ranked := ranker.Rank(rankingContext, candidates)
+			Expect(ranked).To(HaveLen(2))
+			Expect(ranked[0].DistanceFromMe).To(BeNumerically("~", 0, 0.001))
+			Expect(ranked[0].Signals.Distance).To(BeNumerically("~", 1, 0.001))
+			// candidates without swipes start in the middle of the like rates
+			Expect(ranked[0].Signals.Popularity).To(Equal(0.5))
+			Expect(ranked[0].Signals.Reciprocal).To(Equal(0.5))
+			Expect(ranked[0].Signals.RecentActivity).To(Equal(0.0))
+			Expect(ranked[0].Score).To(BeNumerically("~", ranked[0].Signals.Score(rankingWeights), 0.001))
+		})
+
+		It("should list closer candidates first", func() {
+			candidates[0].Location = entities.Location{Latitude: 55.9533, Longitude: -3.1883}
+			Expect(rankedIDs(ranker.Rank(rankingContext, candidates))).To(Equal([]uuid.UUID{candidates[1].ID, candidates[0].ID}))
+		})
+
+		It("should list recently active candidates first", func() {
+			lastActiveAt := rankingContext.Now.Add(-time.Hour)
+			candidates[1].LastActiveAt = &lastActiveAt
+			Expect(rankedIDs(ranker.Rank(rankingContext, candidates))).To(Equal([]uuid.UUID{candidates[1].ID, candidates[0].ID}))
+		})
+
+		It("should list candidates that are liked more often first", func() {
+			candidates[0].SwipesReceived, candidates[0].LikesReceived = 10, 1
+			candidates[1].SwipesReceived, candidates[1].LikesReceived = 10, 8
+			Expect(rankedIDs(ranker.Rank(rankingContext, candidates))).To(Equal([]uuid.UUID{candidates[1].ID, candidates[0].ID}))
+		})
+
+		It("should list candidates that are more likely to like back first", func() {
+			candidates[0].SwipesGiven, candidates[0].LikesGiven = 10, 1
+			candidates[1].SwipesGiven, candidates[1].LikesGiven = 10, 8
+			Expect(rankedIDs(ranker.Rank(rankingContext, candidates))).To(Equal([]uuid.UUID{candidates[1].ID, candidates[0].ID}))
+		})
+
+		It("should list candidates in the middle of the requested age range first", func() {
+			rankingContext.PageInfo = entities.PageInfo{MinAge: 25, MaxAge: 35}
+			candidates[0].Age = 34
+			ranked := ranker.Rank(rankingContext, candidates)
+			Expect(rankedIDs(ranked)).To(Equal([]uuid.UUID{candidates[1].ID, candidates[0].ID}))
+			Expect(ranked[0].Signals.AgeFit).To(Equal(1.0))
+			Expect(ranked[1].Signals.AgeFit).To(BeNumerically("~", 1.0/3, 0.001))
+		})
+
+		It("should list boosted candidates above closer candidates", func() {
+			candidates[1].Location = entities.Location{Latitude: 55.9533, Longitude: -3.1883}
+			candidates[1].Boosted = true
+			Expect(rankedIDs(ranker.Rank(rankingContext, candidates))).To(Equal([]uuid.UUID{candidates[1].ID, candidates[0].ID}))
+		})
+
+		It("should always list super likers first", func() {
+			candidates[0].Boosted = true
+			candidates[0].SharedInterests = 5
+			candidates[1].Location = entities.Location{Latitude: 55.9533, Longitude: -3.1883}
+			candidates[1].SuperLikedMe = true
+			ranked := ranker.Rank(rankingContext, candidates)
+			Expect(rankedIDs(ranked)).To(Equal([]uuid.UUID{candidates[1].ID, candidates[0].ID}))
+			Expect(ranked[0].Signals.Reciprocal).To(Equal(1.0))
+		})
+	})
+
+	Describe("the distance ranker", func() {
+		It("should list super likers, then boosted candidates, then the closest candidates", func() {
+			candidates[0].Location = entities.Location{Latitude: 55.9533, Longitude: -3.1883}
+			candidates = append(candidates,
+				entities.UserDiscovery{ID: uuid.New(), Location: entities.Location{Latitude: 55.9533, Longitude: -3.1883}, Boosted: true},
+				entities.UserDiscovery{ID: uuid.New(), Location: entities.Location{Latitude: 55.9533, Longitude: -3.1883}, SuperLikedMe: true},
+			)
+
+			ranked := usecases.NewDistanceRanker().Rank(rankingContext, candidates)
+			Expect(rankedIDs(ranked)).To(Equal([]uuid.UUID{candidates[3].ID, candidates[2].ID, candidates[1].ID, candidates[0].ID}))
+		})
+	})
+
+	Describe("mixing in recommendations", func() {
+		BeforeEach(func() {
+			// the distance ranker lists these candidates in order, each further away than the last
+			candidates = nil
+			for i := 0; i < 6; i++ {
+				candidates = append(candidates, entities.UserDiscovery{
+					ID:       uuid.New(),
+					Location: entities.Location{Latitude: 51.4545 + float64(i)/10, Longitude: -2.5879},
+				})
+			}
+		})
+
+		It("should wrap the name and weights of the ranker", func() {
+			mixer := usecases.NewRecommendationMixer(usecases.NewWeightedLinearRanker(rankingWeights), 3)
+			Expect(mixer.Name()).To(Equal("weighted-linear+recommendations"))
+			Expect(*mixer.Weights()).To(Equal(rankingWeights))
+		})
+
+		It("should give every interval-th place to the best recommended candidate not yet listed", func() {
+			candidates[4].RecommendationRank = 1
+			candidates[5].RecommendationRank = 2
+			candidates[1].RecommendationRank = 3
+
+			ranked := usecases.NewRecommendationMixer(usecases.NewDistanceRanker(), 3).Rank(rankingContext, candidates)
+			Expect(rankedIDs(ranked)).To(Equal([]uuid.UUID{
+				candidates[0].ID, candidates[1].ID, candidates[4].ID, candidates[2].ID, candidates[3].ID, candidates[5].ID,
+			}))
+		})
+
+		It("should keep super likers first", func() {
+			candidates[5].SuperLikedMe = true
+			candidates[4].RecommendationRank = 1
+
+			ranked := usecases.NewRecommendationMixer(usecases.NewDistanceRanker(), 2).Rank(rankingContext, candidates)
+			Expect(rankedIDs(ranked)).To(Equal([]uuid.UUID{
+				candidates[5].ID, candidates[0].ID, candidates[4].ID, candidates[1].ID, candidates[2].ID, candidates[3].ID,
+			}))
+		})
+
+		It("should keep the rankers order when nobody was recommended", func() {
+			ranked := usecases.NewRecommendationMixer(usecases.NewDistanceRanker(), 3).Rank(rankingContext, candidates)
+			Expect(ranked).To(Equal(usecases.NewDistanceRanker().Rank(rankingContext, candidates)))
+		})
+	})
+})
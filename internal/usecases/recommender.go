@@ -0,0 +1,220 @@
+package usecases
+
+import (
+	"cmp"
+	"context"
+	"github.com/AlecSmith96/dating-api/internal/entities"
+	"github.com/google/uuid"
+	"math"
+	"slices"
+	"time"
+)
+
+//go:generate mockgen --build_flags=--mod=mod -destination=../../mocks/recommendationStore.go  . "RecommendationStore"
+type RecommendationStore interface {
+	// GetSwipeHistory returns every swipe, oldest first
+	GetSwipeHistory(ctx context.Context) ([]entities.Swipe, error)
+	// ReplaceRecommendations replaces every users recommendations with the provided ones
+	ReplaceRecommendations(ctx context.Context, recommendations []entities.Recommendation) error
+}
+
+// ItemItemRecommender recommends users that are liked by the same people as the users someone has already liked. Two
+// users are similar when the sets of people that liked them overlap, measured with cosine similarity, and a candidate
+// scores the sum of their similarity to each user the person liked.
+type ItemItemRecommender struct {
+	// likers is the set of people that liked each user
+	likers map[uuid.UUID]map[uuid.UUID]bool
+	// liked is the users each person liked, in the order they liked them
+	liked map[uuid.UUID][]uuid.UUID
+	// swiped is the set of users each person has swiped on either way
+	swiped map[uuid.UUID]map[uuid.UUID]bool
+}
+
+// NewItemItemRecommender is a function that builds a recommender from the swipe history
+func NewItemItemRecommender(swipes []entities.Swipe) *ItemItemRecommender {
+	recommender := &ItemItemRecommender{
+		likers: make(map[uuid.UUID]map[uuid.UUID]bool),
+		liked:  make(map[uuid.UUID][]uuid.UUID),
+		swiped: make(map[uuid.UUID]map[uuid.UUID]bool),
+	}
+
+	for _, swipe := range swipes {
+		if recommender.swiped[swipe.OwnerUserID] == nil {
+			recommender.swiped[swipe.OwnerUserID] = make(map[uuid.UUID]bool)
+		}
+		if recommender.swiped[swipe.OwnerUserID][swipe.SwipedUserID] {
+			continue
+		}
+		recommender.swiped[swipe.OwnerUserID][swipe.SwipedUserID] = true
+
+		if !swipe.Type.IsPositive() {
+			continue
+		}
+
+		if recommender.likers[swipe.SwipedUserID] == nil {
+			recommender.likers[swipe.SwipedUserID] = make(map[uuid.UUID]bool)
+		}
+		recommender.likers[swipe.SwipedUserID][swipe.OwnerUserID] = true
+		recommender.liked[swipe.OwnerUserID] = append(recommender.liked[swipe.OwnerUserID], swipe.SwipedUserID)
+	}
+
+	return recommender
+}
+
+// Users is a function that returns the users that can be given recommendations, which is everyone that has liked
+// someone
+func (r *ItemItemRecommender) Users() []uuid.UUID {
+	users := make([]uuid.UUID, 0, len(r.liked))
+	for userID := range r.liked {
+		users = append(users, userID)
+	}
+	slices.SortFunc(users, func(a, b uuid.UUID) int {
+		return cmp.Compare(a.String(), b.String())
+	})
+
+	return users
+}
+
+// Recommend is a function that returns up to limit users the user hasn't swiped on yet, best first
+func (r *ItemItemRecommender) Recommend(userID uuid.UUID, limit int) []entities.Recommendation {
+	scores := make(map[uuid.UUID]float64)
+	for _, likedUserID := range r.liked[userID] {
+		// overlap counts the people that liked both the liked user and each candidate
+		overlap := make(map[uuid.UUID]int)
+		for likerID := range r.likers[likedUserID] {
+			if likerID == userID {
+				continue
+			}
+			for _, candidateID := range r.liked[likerID] {
+				if candidateID == userID || candidateID == likedUserID || r.swiped[userID][candidateID] {
+					continue
+				}
+				overlap[candidateID]++
+			}
+		}
+
+		for candidateID, count := range overlap {
+			scores[candidateID] += float64(count) / math.Sqrt(float64(len(r.likers[likedUserID])*len(r.likers[candidateID])))
+		}
+	}
+
+	recommendations := make([]entities.Recommendation, 0, len(scores))
+	for candidateID, score := range scores {
+		recommendations = append(recommendations, entities.Recommendation{
+			UserID:          userID,
+			CandidateUserID: candidateID,
+			Score:           score,
+		})
+	}
+
+	slices.SortFunc(recommendations, func(a, b entities.Recommendation) int {
+		if a.Score != b.Score {
+			return cmp.Compare(b.Score, a.Score)
+		}
+		return cmp.Compare(a.CandidateUserID.String(), b.CandidateUserID.String())
+	})
+
+	if len(recommendations) > limit {
+		recommendations = recommendations[:limit]
+	}
+	for i := range recommendations {
+		recommendations[i].Rank = i + 1
+	}
+
+	return recommendations
+}
+
+// RefreshRecommendations is a function that rebuilds the recommender from the swipe history and replaces every users
+// recommendations with up to perUser new ones. It returns the number of recommendations saved.
+func RefreshRecommendations(ctx context.Context, recommendationStore RecommendationStore, perUser int) (int, error) {
+	swipes, err := recommendationStore.GetSwipeHistory(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	recommender := NewItemItemRecommender(swipes)
+	var recommendations []entities.Recommendation
+	for _, userID := range recommender.Users() {
+		recommendations = append(recommendations, recommender.Recommend(userID, perUser)...)
+	}
+
+	err = recommendationStore.ReplaceRecommendations(ctx, recommendations)
+	if err != nil {
+		return 0, err
+	}
+
+	return len(recommendations), nil
+}
+
+// RunRecommender is a background job that refreshes every users recommendations every interval, until the context is
+// cancelled
+func RunRecommender(ctx context.Context, recommendationStore RecommendationStore, perUser int, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		saved, err := RefreshRecommendations(ctx, recommendationStore, perUser)
+		if err != nil {
+			Logger(ctx).ErrorContext(ctx, "refreshing recommendations", "err", err)
+		} else {
+			Logger(ctx).DebugContext(ctx, "refreshed recommendations", "recommendations", saved)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// EvaluateRecommendations is a function that measures how well the recommender predicts likes. The latest
+// holdoutFraction of each users swipes are held out, the recommender is built from the rest, and a users precision is
+// the fraction of their top k recommendations they went on to like. It returns the mean precision@k over the users
+// that liked someone in their held out swipes, and the number of those users.
+func EvaluateRecommendations(swipes []entities.Swipe, k int, holdoutFraction float64) (float64, int) {
+	swipesByUser := make(map[uuid.UUID][]entities.Swipe)
+	for _, swipe := range swipes {
+		swipesByUser[swipe.OwnerUserID] = append(swipesByUser[swipe.OwnerUserID], swipe)
+	}
+
+	var training []entities.Swipe
+	heldOutLikes := make(map[uuid.UUID]map[uuid.UUID]bool)
+	for userID, userSwipes := range swipesByUser {
+		slices.SortStableFunc(userSwipes, func(a, b entities.Swipe) int {
+			return a.CreatedAt.Compare(b.CreatedAt)
+		})
+
+		holdout := int(math.Ceil(float64(len(userSwipes)) * holdoutFraction))
+		split := len(userSwipes) - holdout
+		training = append(training, userSwipes[:split]...)
+
+		for _, swipe := range userSwipes[split:] {
+			if !swipe.Type.IsPositive() {
+				continue
+			}
+			if heldOutLikes[userID] == nil {
+				heldOutLikes[userID] = make(map[uuid.UUID]bool)
+			}
+			heldOutLikes[userID][swipe.SwipedUserID] = true
+		}
+	}
+
+	if len(heldOutLikes) == 0 || k < 1 {
+		return 0, 0
+	}
+
+	recommender := NewItemItemRecommender(training)
+	total := 0.0
+	for userID, liked := range heldOutLikes {
+		hits := 0
+		for _, recommendation := range recommender.Recommend(userID, k) {
+			if liked[recommendation.CandidateUserID] {
+				hits++
+			}
+		}
+		total += float64(hits) / float64(k)
+	}
+
+	return total / float64(len(heldOutLikes)), len(heldOutLikes)
+}
@@ -0,0 +1,141 @@
+package usecases_test
+
+import (
+	"context"
+	"errors"
+	"github.com/AlecSmith96/dating-api/internal/entities"
+	"github.com/AlecSmith96/dating-api/internal/usecases"
+	mock_usecases "github.com/AlecSmith96/dating-api/mocks"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	"time"
+)
+
+var _ = Describe("recommending users from the swipe history", func() {
+	var users []uuid.UUID
+	var swipes []entities.Swipe
+	var now time.Time
+
+	swipe := func(owner, swiped int, swipeType entities.SwipeType) {
+		swipes = append(swipes, entities.Swipe{
+			ID:           uuid.New(),
+			OwnerUserID:  users[owner],
+			SwipedUserID: users[swiped],
+			Type:         swipeType,
+			CreatedAt:    now.Add(time.Duration(len(swipes)) * time.Minute),
+		})
+	}
+
+	candidateIDs := func(recommendations []entities.Recommendation) []uuid.UUID {
+		var ids []uuid.UUID
+		for _, recommendation := range recommendations {
+			ids = append(ids, recommendation.CandidateUserID)
+		}
+		return ids
+	}
+
+	BeforeEach(func() {
+		now = time.Now()
+		users = nil
+		for i := 0; i < 6; i++ {
+			users = append(users, uuid.New())
+		}
+
+		// users 0 and 1 both like user 3, user 1 also likes users 4 and 5, and user 2 likes user 5
+		swipes = nil
+		swipe(0, 3, entities.SwipeTypeLike)
+		swipe(1, 3, entities.SwipeTypeSuperLike)
+		swipe(1, 4, entities.SwipeTypeLike)
+		swipe(1, 5, entities.SwipeTypeLike)
+		swipe(2, 5, entities.SwipeTypeLike)
+	})
+
+	It("should recommend the users liked by people with the same taste, most similar first", func() {
+		recommendations := usecases.NewItemItemRecommender(swipes).Recommend(users[0], 10)
+		Expect(candidateIDs(recommendations)).To(Equal([]uuid.UUID{users[4], users[5]}))
+		Expect(recommendations[0].Rank).To(Equal(1))
+		Expect(recommendations[0].Score).To(BeNumerically("~", 1/1.4142, 0.001))
+		Expect(recommendations[1].Rank).To(Equal(2))
+		Expect(recommendations[1].Score).To(BeNumerically("~", 0.5, 0.001))
+	})
+
+	It("should not recommend users that have already been swiped on", func() {
+		swipe(0, 4, entities.SwipeTypePass)
+		recommendations := usecases.NewItemItemRecommender(swipes).Recommend(users[0], 10)
+		Expect(candidateIDs(recommendations)).To(Equal([]uuid.UUID{users[5]}))
+	})
+
+	It("should return at most limit recommendations", func() {
+		recommendations := usecases.NewItemItemRecommender(swipes).Recommend(users[0], 1)
+		Expect(candidateIDs(recommendations)).To(Equal([]uuid.UUID{users[4]}))
+	})
+
+	It("should not recommend anyone to users that haven't liked anyone", func() {
+		recommender := usecases.NewItemItemRecommender(swipes)
+		Expect(recommender.Recommend(users[3], 10)).To(BeEmpty())
+		Expect(recommender.Users()).To(ConsistOf(users[0], users[1], users[2]))
+	})
+
+	Describe("refreshing recommendations", func() {
+		var recommendationStore *mock_usecases.MockRecommendationStore
+
+		BeforeEach(func() {
+			recommendationStore = mock_usecases.NewMockRecommendationStore(gomock.NewController(GinkgoT()))
+		})
+
+		It("should replace every users recommendations", func() {
+			recommendationStore.EXPECT().GetSwipeHistory(gomock.Any()).Return(swipes, nil)
+			recommendationStore.EXPECT().ReplaceRecommendations(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, recommendations []entities.Recommendation) error {
+				var userIDs []uuid.UUID
+				for _, recommendation := range recommendations {
+					userIDs = append(userIDs, recommendation.UserID)
+				}
+				Expect(userIDs).To(ConsistOf(users[0], users[0], users[2], users[2]))
+				return nil
+			})
+
+			saved, err := usecases.RefreshRecommendations(context.Background(), recommendationStore, 10)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(saved).To(Equal(4))
+		})
+
+		It("should not replace the recommendations if the swipe history can't be read", func() {
+			recommendationStore.EXPECT().GetSwipeHistory(gomock.Any()).Return(nil, errors.New("an error occurred"))
+
+			_, err := usecases.RefreshRecommendations(context.Background(), recommendationStore, 10)
+			Expect(err).To(MatchError("an error occurred"))
+		})
+
+		It("should return an error if the recommendations can't be saved", func() {
+			recommendationStore.EXPECT().GetSwipeHistory(gomock.Any()).Return(swipes, nil)
+			recommendationStore.EXPECT().ReplaceRecommendations(gomock.Any(), gomock.Any()).Return(errors.New("an error occurred"))
+
+			_, err := usecases.RefreshRecommendations(context.Background(), recommendationStore, 10)
+			Expect(err).To(MatchError("an error occurred"))
+		})
+	})
+
+	Describe("evaluating recommendations", func() {
+		It("should report the precision@k of the latest swipes", func() {
+			// each users last swipe is held out, user 0 goes on to like user 4 who is recommended to them, while users 1
+			// and 2 go on to like users that can't be recommended to them without user 1s like of user 5
+			swipe(0, 4, entities.SwipeTypeLike)
+			swipe(2, 3, entities.SwipeTypeLike)
+
+			precision, evaluated := usecases.EvaluateRecommendations(swipes, 2, 0.3)
+			Expect(evaluated).To(Equal(3))
+			Expect(precision).To(BeNumerically("~", 0.5/3, 0.001))
+		})
+
+		It("should not evaluate users whose held out swipes are all passes", func() {
+			swipe(0, 4, entities.SwipeTypePass)
+			swipe(2, 4, entities.SwipeTypePass)
+
+			precision, evaluated := usecases.EvaluateRecommendations(swipes, 2, 0.3)
+			Expect(evaluated).To(Equal(1))
+			Expect(precision).To(Equal(0.0))
+		})
+	})
+})
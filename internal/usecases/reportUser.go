@@ -0,0 +1,101 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"github.com/AlecSmith96/dating-api/internal/entities"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+)
+
+//go:generate mockgen --build_flags=--mod=mod -destination=../../mocks/userReporter.go  . "UserReporter"
+type UserReporter interface {
+	ReportUser(ctx context.Context, report *entities.Report) (*entities.Report, error)
+}
+
+// ReportUserRequestBody represents the report made against a user
+// @Description the reason for reporting a user
+type ReportUserRequestBody struct {
+	// Reason is the category of the report
+	Reason string `json:"reason" binding:"required,oneof=spam harassment inappropriate_content fake_profile scam underage other" enums:"spam,harassment,inappropriate_content,fake_profile,scam,underage,other"`
+	// Details is an optional free text description of the problem
+	Details string `json:"details" binding:"max=2000"`
+}
+
+// ReportUserResponseBody represents the newly created report
+// @Description the report that has been added to the moderation queue
+type ReportUserResponseBody struct {
+	// ReportID is the id of the report
+	ReportID string `json:"reportId"`
+	// Status is the moderation status of the report
+	Status string `json:"status"`
+}
+
+// NewReportUser reports a user
+// @Summary Report a user
+// @Description Reports a user for review by a moderator
+// @Security BearerAuth
+// @Tags safety
+// @Accept json
+// @Produce json
+// @Param id path string true "The id of the user to report"
+// @Param report body ReportUserRequestBody true "Report User Request Body"
+// @Success 201 {object} ReportUserResponseBody
+// @Failure 400 {object} ProblemResponseBody
+// @Failure 404 {object} ProblemResponseBody
+// @Failure 500 {object} ProblemResponseBody
+// @Router /user/report/{id} [post]
+func NewReportUser(userReporter UserReporter) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		userID, ok := c.Get("userID")
+		if !ok {
+			Logger(ctx).ErrorContext(ctx, "unable to get userID from context")
+			c.Error(errUserIDMissing)
+			return
+		}
+		requestingUserID := userID.(uuid.UUID)
+
+		reportedUserID, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			Logger(ctx).ErrorContext(ctx, "parsing reported user id", "err", err)
+			c.Error(invalidField("id", "uuid", "must be a uuid"))
+			return
+		}
+
+		if reportedUserID == requestingUserID {
+			c.Error(entities.NewError(http.StatusBadRequest, entities.CodeSelfAction, "unable to report yourself"))
+			return
+		}
+
+		var request ReportUserRequestBody
+		err = c.ShouldBindJSON(&request)
+		if err != nil {
+			Logger(ctx).ErrorContext(ctx, "validating request body", "err", err)
+			c.Error(bindingError(err))
+			return
+		}
+
+		report, err := userReporter.ReportUser(ctx, &entities.Report{
+			ReporterUserID: requestingUserID,
+			ReportedUserID: reportedUserID,
+			Reason:         entities.ReportReason(request.Reason),
+			Details:        request.Details,
+		})
+		if err != nil {
+			if errors.Is(err, entities.ErrTargetUserNotFound) {
+				c.Error(entities.NewError(http.StatusNotFound, entities.CodeUserNotFound, "user not found"))
+				return
+			}
+			Logger(ctx).ErrorContext(ctx, "reporting user", "err", err)
+			c.Error(err)
+			return
+		}
+
+		c.JSON(http.StatusCreated, ReportUserResponseBody{
+			ReportID: report.ID.String(),
+			Status:   string(report.Status),
+		})
+	}
+}
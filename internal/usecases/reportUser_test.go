@@ -0,0 +1,117 @@
+package usecases_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/AlecSmith96/dating-api/internal/entities"
+	"github.com/AlecSmith96/dating-api/internal/usecases"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"time"
+)
+
+var _ = Describe("reporting a user", func() {
+	var w *httptest.ResponseRecorder
+	var reportedUserID uuid.UUID
+	var requestBodyJSON []byte
+
+	var validateJwtForUserUUID uuid.UUID
+	var validateJwtForUserErr error
+	var validateJwtForUserCallCount int
+
+	var reportUserResponse *entities.Report
+	var reportUserErr error
+	var reportUserCallCount int
+
+	BeforeEach(func() {
+		reportedUserID = uuid.New()
+		var err error
+		requestBodyJSON, err = json.Marshal(usecases.ReportUserRequestBody{
+			Reason:  "harassment",
+			Details: "sent abusive messages",
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		validateJwtForUserUUID = uuid.New()
+		validateJwtForUserErr = nil
+		validateJwtForUserCallCount = 1
+
+		reportUserResponse = &entities.Report{
+			ID:             uuid.New(),
+			ReporterUserID: validateJwtForUserUUID,
+			ReportedUserID: reportedUserID,
+			Reason:         entities.ReportReasonHarassment,
+			Details:        "sent abusive messages",
+			CaseID:         uuid.New(),
+			Status:         entities.ModerationCaseStatusOpen,
+			CreatedAt:      time.Now(),
+		}
+		reportUserErr = nil
+		reportUserCallCount = 1
+	})
+
+	JustBeforeEach(func() {
+		w = httptest.NewRecorder()
+
+		jwtProcessor.EXPECT().ValidateJwtForUser(gomock.Any(), mockJWT).Return(validateJwtForUserUUID, validateJwtForUserErr).Times(validateJwtForUserCallCount)
+		userReporter.EXPECT().ReportUser(gomock.Any(), &entities.Report{
+			ReporterUserID: validateJwtForUserUUID,
+			ReportedUserID: reportedUserID,
+			Reason:         entities.ReportReasonHarassment,
+			Details:        "sent abusive messages",
+		}).Return(reportUserResponse, reportUserErr).Times(reportUserCallCount)
+
+		req, err := http.NewRequest("POST", fmt.Sprintf("http://localhost:8080/dating-api/v1/user/report/%s", reportedUserID), bytes.NewReader(requestBodyJSON))
+		req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", mockJWT))
+		Expect(err).ToNot(HaveOccurred())
+		r.ServeHTTP(w, req)
+	})
+
+	It("should add the report to the moderation queue", func() {
+		Expect(w.Code).To(Equal(http.StatusCreated))
+		var resp usecases.ReportUserResponseBody
+		err := json.NewDecoder(w.Body).Decode(&resp)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(resp.ReportID).To(Equal(reportUserResponse.ID.String()))
+		Expect(resp.Status).To(Equal("open"))
+	})
+
+	When("the reason is not part of the taxonomy", func() {
+		BeforeEach(func() {
+			requestBodyJSON = []byte(`{"reason": "did not like them"}`)
+			reportUserCallCount = 0
+		})
+
+		It("should return a 400 Bad Request", func() {
+			Expect(w.Code).To(Equal(http.StatusBadRequest))
+		})
+	})
+
+	When("the user to report does not exist", func() {
+		BeforeEach(func() {
+			reportUserResponse = nil
+			reportUserErr = entities.ErrTargetUserNotFound
+		})
+
+		It("should return a 404 Not Found", func() {
+			Expect(w.Code).To(Equal(http.StatusNotFound))
+		})
+	})
+
+	When("the adapter returns an error", func() {
+		BeforeEach(func() {
+			reportUserResponse = nil
+			reportUserErr = errors.New("an error occurred")
+		})
+
+		It("should return a 500 Internal Server Error", func() {
+			Expect(w.Code).To(Equal(http.StatusInternalServerError))
+		})
+	})
+})
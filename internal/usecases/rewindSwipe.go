@@ -0,0 +1,84 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"github.com/AlecSmith96/dating-api/internal/entities"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"time"
+)
+
+//go:generate mockgen --build_flags=--mod=mod -destination=../../mocks/swipeRewinder.go  . "SwipeRewinder"
+type SwipeRewinder interface {
+	RewindLastSwipe(ctx context.Context, userID uuid.UUID, window time.Duration) (*entities.Swipe, error)
+}
+
+// RewindSwipeResponseBody represents the swipe that was undone
+// @Description the swipe that was undone, the swiped user will be shown in discovery again
+type RewindSwipeResponseBody struct {
+	// UserID the id of the user that was swiped on
+	UserID uuid.UUID `json:"userId"`
+	// SwipeType the type of the swipe that was undone, one of like, pass or superlike
+	SwipeType string `json:"swipeType"`
+	// SwipedAt the time the swipe was made
+	SwipedAt time.Time `json:"swipedAt"`
+}
+
+// NewRewindSwipe undoes the users last swipe
+// @Summary Rewind the last swipe
+// @Description Undoes the users most recent swipe if it was made within the rewind window and hasn't resulted in a
+// @Description match, returning the swiped user to discovery. Rewinding is only available on plans that include it and doesn't
+// @Description return likes or super likes to the users quota.
+// @Security BearerAuth
+// @Tags users
+// @Produce json
+// @Success 200 {object} RewindSwipeResponseBody
+// @Failure 403 {object} ProblemResponseBody
+// @Failure 404 {object} ProblemResponseBody
+// @Failure 409 {object} ProblemResponseBody
+// @Failure 500 {object} ProblemResponseBody
+// @Router /user/swipe/rewind [post]
+func NewRewindSwipe(entitlements Entitlements, swipeRewinder SwipeRewinder, rewindWindow time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		userID, ok := c.Get("userID")
+		if !ok {
+			Logger(ctx).ErrorContext(ctx, "unable to get userID from context")
+			c.Error(errUserIDMissing)
+			return
+		}
+		requestingUserID := userID.(uuid.UUID)
+
+		userEntitlements, ok := getEntitlements(c, entitlements, requestingUserID)
+		if !ok {
+			return
+		}
+
+		if !userEntitlements.CanRewind {
+			c.Error(entities.NewError(http.StatusForbidden, entities.CodeNotInPlan, "rewinding swipes is not included in your plan"))
+			return
+		}
+
+		swipe, err := swipeRewinder.RewindLastSwipe(ctx, requestingUserID, rewindWindow)
+		if err != nil {
+			switch {
+			case errors.Is(err, entities.ErrNoSwipeToRewind):
+				c.Error(entities.NewError(http.StatusNotFound, entities.CodeNoSwipeToRewind, "no recent swipe to rewind"))
+			case errors.Is(err, entities.ErrSwipeMatched):
+				c.Error(entities.NewError(http.StatusConflict, entities.CodeSwipeMatched, "unable to rewind a swipe that resulted in a match"))
+			default:
+				Logger(ctx).ErrorContext(ctx, "rewinding swipe", "err", err)
+				c.Error(err)
+			}
+			return
+		}
+
+		c.JSON(http.StatusOK, RewindSwipeResponseBody{
+			UserID:    swipe.SwipedUserID,
+			SwipeType: string(swipe.Type),
+			SwipedAt:  swipe.CreatedAt,
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package usecases_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/AlecSmith96/dating-api/internal/entities"
+	"github.com/AlecSmith96/dating-api/internal/usecases"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"time"
+)
+
+var _ = Describe("rewinding a swipe", func() {
+	var w *httptest.ResponseRecorder
+
+	var validateJwtForUserUUID uuid.UUID
+
+	var getEntitlementsResponse *entities.Entitlements
+	var getEntitlementsErr error
+
+	var rewindLastSwipeResponse *entities.Swipe
+	var rewindLastSwipeErr error
+	var rewindLastSwipeCallCount int
+
+	BeforeEach(func() {
+		validateJwtForUserUUID = uuid.New()
+
+		getEntitlementsResponse = &entities.Entitlements{Tier: entities.TierPlus, CanRewind: true}
+		getEntitlementsErr = nil
+
+		rewindLastSwipeResponse = &entities.Swipe{
+			ID:           uuid.New(),
+			OwnerUserID:  validateJwtForUserUUID,
+			SwipedUserID: uuid.New(),
+			Type:         entities.SwipeTypePass,
+			CreatedAt:    time.Now().Add(-time.Minute).UTC().Truncate(time.Second),
+		}
+		rewindLastSwipeErr = nil
+		rewindLastSwipeCallCount = 1
+	})
+
+	JustBeforeEach(func() {
+		w = httptest.NewRecorder()
+
+		jwtProcessor.EXPECT().ValidateJwtForUser(gomock.Any(), mockJWT).Return(validateJwtForUserUUID, nil).Times(1)
+		entitlements.EXPECT().GetEntitlements(gomock.Any(), validateJwtForUserUUID).Return(getEntitlementsResponse, getEntitlementsErr).Times(1)
+		swipeRewinder.EXPECT().RewindLastSwipe(gomock.Any(), validateJwtForUserUUID, swipeRewindWindow).Return(rewindLastSwipeResponse, rewindLastSwipeErr).Times(rewindLastSwipeCallCount)
+
+		req, err := http.NewRequest("POST", "http://localhost:8080/dating-api/v1/user/swipe/rewind", nil)
+		req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", mockJWT))
+		Expect(err).ToNot(HaveOccurred())
+		r.ServeHTTP(w, req)
+	})
+
+	It("should return the undone swipe", func() {
+		Expect(w.Code).To(Equal(http.StatusOK))
+		var resp usecases.RewindSwipeResponseBody
+		err := json.NewDecoder(w.Body).Decode(&resp)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(resp.UserID).To(Equal(rewindLastSwipeResponse.SwipedUserID))
+		Expect(resp.SwipeType).To(Equal("pass"))
+		Expect(resp.SwipedAt).To(BeTemporally("==", rewindLastSwipeResponse.CreatedAt))
+	})
+
+	When("the users plan doesn't include rewinds", func() {
+		BeforeEach(func() {
+			getEntitlementsResponse = &entities.Entitlements{Tier: entities.TierFree, CanRewind: false}
+			rewindLastSwipeCallCount = 0
+		})
+
+		It("should return a 403 Forbidden", func() {
+			Expect(w.Code).To(Equal(http.StatusForbidden))
+		})
+	})
+
+	When("getting the entitlements of the user returns an error", func() {
+		BeforeEach(func() {
+			getEntitlementsResponse = nil
+			getEntitlementsErr = errors.New("an error occurred")
+			rewindLastSwipeCallCount = 0
+		})
+
+		It("should return a 500 Internal Server Error", func() {
+			Expect(w.Code).To(Equal(http.StatusInternalServerError))
+		})
+	})
+
+	When("there is no swipe within the rewind window", func() {
+		BeforeEach(func() {
+			rewindLastSwipeResponse = nil
+			rewindLastSwipeErr = entities.ErrNoSwipeToRewind
+		})
+
+		It("should return a 404 Not Found", func() {
+			Expect(w.Code).To(Equal(http.StatusNotFound))
+		})
+	})
+
+	When("the swipe has resulted in a match", func() {
+		BeforeEach(func() {
+			rewindLastSwipeResponse = nil
+			rewindLastSwipeErr = entities.ErrSwipeMatched
+		})
+
+		It("should return a 409 Conflict", func() {
+			Expect(w.Code).To(Equal(http.StatusConflict))
+		})
+	})
+
+	When("the adapter returns an error", func() {
+		BeforeEach(func() {
+			rewindLastSwipeResponse = nil
+			rewindLastSwipeErr = errors.New("an error occurred")
+		})
+
+		It("should return a 500 Internal Server Error", func() {
+			Expect(w.Code).To(Equal(http.StatusInternalServerError))
+		})
+	})
+})
@@ -0,0 +1,12 @@
+package usecases
+
+import (
+	"context"
+	"github.com/AlecSmith96/dating-api/internal/entities"
+	"github.com/google/uuid"
+)
+
+//go:generate mockgen --build_flags=--mod=mod -destination=../../mocks/roleChecker.go  . "RoleChecker"
+type RoleChecker interface {
+	GetUserRole(ctx context.Context, userID uuid.UUID) (entities.Role, error)
+}
@@ -1,49 +1,147 @@
 package usecases_test
 
 import (
+	"github.com/AlecSmith96/dating-api/internal/adapters"
 	"github.com/AlecSmith96/dating-api/internal/drivers"
+	"github.com/AlecSmith96/dating-api/internal/entities"
+	"github.com/AlecSmith96/dating-api/internal/usecases"
 	mock_usecases "github.com/AlecSmith96/dating-api/mocks"
 	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	"go.uber.org/mock/gomock"
 	"net/http"
 	"testing"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 )
 
+const (
+	swipeRewindWindow    = 5 * time.Minute
+	billingWebhookSecret = "local-billing-secret"
+	boostDuration        = 30 * time.Minute
+	queryTimeout         = 5 * time.Second
+	migrationVersion     = 20261019233000
+)
+
 func TestHandleUsers(t *testing.T) {
 	RegisterFailHandler(Fail)
 	RunSpecs(t, "Users Test Suite")
 }
 
 var (
-	r                 *gin.Engine
-	userCreator       *mock_usecases.MockUserCreator
-	userDiscoverer    *mock_usecases.MockUserDiscoverer
-	jwtProcessor      *mock_usecases.MockJwtProcessor
-	userAuthenticator *mock_usecases.MockUserAuthenticator
-	swipeRegister     *mock_usecases.MockSwipeRegister
+	r                   *gin.Engine
+	userCreator         *mock_usecases.MockUserCreator
+	userDiscoverer      *mock_usecases.MockUserDiscoverer
+	jwtProcessor        *mock_usecases.MockJwtProcessor
+	userAuthenticator   *mock_usecases.MockUserAuthenticator
+	swipeRegister       *mock_usecases.MockSwipeRegister
+	txManager           *mock_usecases.MockTxManager
+	eventStreamer       *mock_usecases.MockEventStreamer
+	eventRecorder       *mock_usecases.MockEventRecorder
+	userBlocker         *mock_usecases.MockUserBlocker
+	userReporter        *mock_usecases.MockUserReporter
+	roleChecker         *mock_usecases.MockRoleChecker
+	moderationQueue     *mock_usecases.MockModerationQueue
+	swipeRewinder       *mock_usecases.MockSwipeRewinder
+	likesReceived       *mock_usecases.MockLikesReceivedLister
+	entitlements        *mock_usecases.MockEntitlements
+	subscriptionManager *mock_usecases.MockSubscriptionManager
+	billingProvider     *adapters.LocalBillingProvider
+	boostManager        *mock_usecases.MockBoostManager
+	ranker              *usecases.WeightedLinearRanker
+	dailyPicksLister    *mock_usecases.MockDailyPicksLister
+	preferencesSaver    *mock_usecases.MockDiscoveryPreferencesSaver
+	questionBank        *mock_usecases.MockQuestionBank
+	questionAnswerer    *mock_usecases.MockQuestionAnswerer
+	readinessChecker    *mock_usecases.MockReadinessChecker
+	metrics             *adapters.PrometheusMetrics
+	spanRecorder        *tracetest.SpanRecorder
 )
 
+var rankingWeights = entities.RankingWeights{
+	Distance:        1,
+	AgeFit:          0.5,
+	SharedInterests: 0.75,
+	RecentActivity:  0.5,
+	Boost:           2,
+	Popularity:      0.5,
+	Reciprocal:      0.75,
+	DesirabilityFit: 0.75,
+}
+
 var _ = BeforeSuite(func() {
 	// Put gin in test mode
 	gin.SetMode(gin.TestMode)
 
+	// record spans so that the tests can check what was traced
+	spanRecorder = tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spanRecorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
 	ctrl := gomock.NewController(GinkgoT())
 	userCreator = mock_usecases.NewMockUserCreator(ctrl)
 	userDiscoverer = mock_usecases.NewMockUserDiscoverer(ctrl)
 	jwtProcessor = mock_usecases.NewMockJwtProcessor(ctrl)
 	userAuthenticator = mock_usecases.NewMockUserAuthenticator(ctrl)
 	swipeRegister = mock_usecases.NewMockSwipeRegister(ctrl)
+	txManager = mock_usecases.NewMockTxManager(ctrl)
+	eventStreamer = mock_usecases.NewMockEventStreamer(ctrl)
+	eventRecorder = mock_usecases.NewMockEventRecorder(ctrl)
+	userBlocker = mock_usecases.NewMockUserBlocker(ctrl)
+	userReporter = mock_usecases.NewMockUserReporter(ctrl)
+	roleChecker = mock_usecases.NewMockRoleChecker(ctrl)
+	moderationQueue = mock_usecases.NewMockModerationQueue(ctrl)
+	swipeRewinder = mock_usecases.NewMockSwipeRewinder(ctrl)
+	likesReceived = mock_usecases.NewMockLikesReceivedLister(ctrl)
+	entitlements = mock_usecases.NewMockEntitlements(ctrl)
+	subscriptionManager = mock_usecases.NewMockSubscriptionManager(ctrl)
+	billingProvider = adapters.NewLocalBillingProvider(billingWebhookSecret)
+	boostManager = mock_usecases.NewMockBoostManager(ctrl)
+	ranker = usecases.NewWeightedLinearRanker(rankingWeights)
+	dailyPicksLister = mock_usecases.NewMockDailyPicksLister(ctrl)
+	preferencesSaver = mock_usecases.NewMockDiscoveryPreferencesSaver(ctrl)
+	questionBank = mock_usecases.NewMockQuestionBank(ctrl)
+	questionAnswerer = mock_usecases.NewMockQuestionAnswerer(ctrl)
+	readinessChecker = mock_usecases.NewMockReadinessChecker(ctrl)
+	metrics = adapters.NewPrometheusMetrics()
 
-	r = drivers.NewRouter(
-		userCreator,
-		userAuthenticator,
-		jwtProcessor,
-		userDiscoverer,
-		swipeRegister,
-	)
+	r = drivers.NewRouter(drivers.RouterDeps{
+		UserCreator:               userCreator,
+		UserAuthenticator:         userAuthenticator,
+		JwtProcessor:              jwtProcessor,
+		UserDiscoverer:            userDiscoverer,
+		SwipeRegister:             swipeRegister,
+		TxManager:                 txManager,
+		EventStreamer:             eventStreamer,
+		EventRecorder:             eventRecorder,
+		UserBlocker:               userBlocker,
+		UserReporter:              userReporter,
+		RoleChecker:               roleChecker,
+		ModerationQueue:           moderationQueue,
+		SwipeRewinder:             swipeRewinder,
+		SwipeRewindWindow:         swipeRewindWindow,
+		LikesReceivedLister:       likesReceived,
+		Entitlements:              entitlements,
+		SubscriptionManager:       subscriptionManager,
+		BillingProviders:          []usecases.BillingProvider{billingProvider},
+		BoostManager:              boostManager,
+		BoostDuration:             boostDuration,
+		Ranker:                    ranker,
+		DailyPicksLister:          dailyPicksLister,
+		DiscoveryPreferencesSaver: preferencesSaver,
+		QuestionBank:              questionBank,
+		QuestionAnswerer:          questionAnswerer,
+		QueryTimeout:              queryTimeout,
+		ReadinessChecker:          readinessChecker,
+		MigrationVersion:          migrationVersion,
+		MetricsRecorder:           metrics,
+		MetricsHandler:            metrics.Handler(),
+	})
 
 	go func() {
 		defer GinkgoRecover()
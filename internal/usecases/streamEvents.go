@@ -0,0 +1,146 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/AlecSmith96/dating-api/internal/entities"
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	eventPollInterval      = 2 * time.Second
+	eventHeartbeatInterval = 15 * time.Second
+	eventRetryMillis       = 3000
+)
+
+//go:generate mockgen --build_flags=--mod=mod -destination=../../mocks/eventStreamer.go  . "EventStreamer"
+type EventStreamer interface {
+	GetLatestEventID(ctx context.Context, userID uuid.UUID) (int64, error)
+	GetEventsSince(ctx context.Context, userID uuid.UUID, lastEventID int64) ([]entities.Event, error)
+}
+
+// EventResponseBody represents a single event written to the event stream
+// @Description the data of an event written to the event stream, the event id and type are sent as the SSE id and event fields
+type EventResponseBody struct {
+	// Type is the type of the event, one of new_match, profile_liked, super_liked or moderation_warning
+	Type string `json:"type"`
+	// Payload is the event specific data
+	Payload json.RawMessage `json:"payload" swaggertype:"object"`
+	// CreatedAt is the time the event occurred
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// NewStreamEvents streams real-time events for the user
+// @Summary Stream real-time events
+// @Description Opens a Server-Sent Events stream of the users events (new matches, profile likes, super likes and
+// @Description moderation warnings). Reconnecting clients can resume from the last event they received by providing the
+// @Description Last-Event-ID header, or the lastEventId query parameter for clients that are unable to set headers.
+// @Security BearerAuth
+// @Tags events
+// @Produce text/event-stream
+// @Param Last-Event-ID header string false "The id of the last event received"
+// @Param lastEventId query string false "The id of the last event received"
+// @Success 200 {object} EventResponseBody
+// @Failure 400 {object} ProblemResponseBody
+// @Failure 500 {object} ProblemResponseBody
+// @Router /user/events [get]
+func NewStreamEvents(eventStreamer EventStreamer, queryTimeout time.Duration, shutdown <-chan struct{}) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		userID, ok := c.Get("userID")
+		if !ok {
+			Logger(ctx).ErrorContext(ctx, "unable to get userID from context")
+			c.Error(errUserIDMissing)
+			return
+		}
+		requestingUserID := userID.(uuid.UUID)
+
+		lastEventIDValue := c.GetHeader("Last-Event-ID")
+		if lastEventIDValue == "" {
+			lastEventIDValue = c.Query("lastEventId")
+		}
+
+		var lastEventID int64
+		var err error
+		if lastEventIDValue != "" {
+			lastEventID, err = strconv.ParseInt(lastEventIDValue, 10, 64)
+			if err != nil || lastEventID < 0 {
+				Logger(ctx).ErrorContext(ctx, "parsing last event id", "lastEventID", lastEventIDValue)
+				c.Error(invalidField("lastEventId", "type", "must be a non-negative integer"))
+				return
+			}
+		} else {
+			// without a last event id the client is only interested in events from now on
+			queryCtx, cancel := context.WithTimeout(ctx, queryTimeout)
+			lastEventID, err = eventStreamer.GetLatestEventID(queryCtx, requestingUserID)
+			cancel()
+			if err != nil {
+				Logger(ctx).ErrorContext(ctx, "getting latest event id", "err", err)
+				c.Error(err)
+				return
+			}
+		}
+
+		// the stream outlives the servers write timeout, so it is removed for this connection
+		_ = http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{})
+
+		c.Header("Content-Type", sse.ContentType)
+		c.Header("Cache-Control", "no-cache")
+		c.Header("Connection", "keep-alive")
+		c.Header("X-Accel-Buffering", "no")
+		c.Status(http.StatusOK)
+		_, _ = c.Writer.WriteString(fmt.Sprintf("retry: %d\n\n", eventRetryMillis))
+		c.Writer.Flush()
+
+		pollTicker := time.NewTicker(eventPollInterval)
+		defer pollTicker.Stop()
+		heartbeatTicker := time.NewTicker(eventHeartbeatInterval)
+		defer heartbeatTicker.Stop()
+
+		for {
+			// the stream is long lived, so each poll gets its own deadline rather than the request
+			queryCtx, cancel := context.WithTimeout(ctx, queryTimeout)
+			events, err := eventStreamer.GetEventsSince(queryCtx, requestingUserID, lastEventID)
+			cancel()
+			if err != nil {
+				// the headers have already been sent, so close the stream and let the client reconnect from the
+				// last event it received
+				Logger(ctx).ErrorContext(ctx, "getting events", "err", err)
+				return
+			}
+
+			for _, event := range events {
+				c.Render(-1, sse.Event{
+					Id:    strconv.FormatInt(event.ID, 10),
+					Event: string(event.Type),
+					Data: EventResponseBody{
+						Type:      string(event.Type),
+						Payload:   event.Payload,
+						CreatedAt: event.CreatedAt,
+					},
+				})
+				lastEventID = event.ID
+			}
+			c.Writer.Flush()
+
+			select {
+			case <-c.Request.Context().Done():
+				return
+			case <-shutdown:
+				// the client reconnects to another instance from the last event it received
+				return
+			case <-heartbeatTicker.C:
+				// comments are ignored by clients but stop proxies from closing an idle connection
+				_, _ = c.Writer.WriteString(": heartbeat\n\n")
+				c.Writer.Flush()
+			case <-pollTicker.C:
+			}
+		}
+	}
+}
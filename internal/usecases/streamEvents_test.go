@@ -0,0 +1,146 @@
+package usecases_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/AlecSmith96/dating-api/internal/entities"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"time"
+)
+
+var _ = Describe("streaming events", func() {
+	var w *httptest.ResponseRecorder
+	var lastEventIDHeader string
+
+	var validateJwtForUserUUID uuid.UUID
+	var validateJwtForUserErr error
+	var validateJwtForUserCallCount int
+
+	var getLatestEventIDResponse int64
+	var getLatestEventIDErr error
+	var getLatestEventIDCallCount int
+
+	var expectedLastEventID int64
+	var getEventsSinceResponse []entities.Event
+	var getEventsSinceErr error
+	var getEventsSinceCallCount int
+
+	BeforeEach(func() {
+		lastEventIDHeader = ""
+
+		validateJwtForUserUUID = uuid.New()
+		validateJwtForUserErr = nil
+		validateJwtForUserCallCount = 1
+
+		getLatestEventIDResponse = 41
+		getLatestEventIDErr = nil
+		getLatestEventIDCallCount = 1
+
+		expectedLastEventID = 41
+		getEventsSinceResponse = []entities.Event{
+			{
+				ID:        42,
+				UserID:    validateJwtForUserUUID,
+				Type:      entities.EventTypeNewMatch,
+				Payload:   json.RawMessage(`{"matchId":"a8e5b5b4-7f7e-4a0c-9d0c-8b9f0f4a6a53"}`),
+				CreatedAt: time.Now(),
+			},
+		}
+		getEventsSinceErr = nil
+		getEventsSinceCallCount = 1
+	})
+
+	JustBeforeEach(func() {
+		w = httptest.NewRecorder()
+
+		jwtProcessor.EXPECT().ValidateJwtForUser(gomock.Any(), mockJWT).Return(validateJwtForUserUUID, validateJwtForUserErr).Times(validateJwtForUserCallCount)
+		eventStreamer.EXPECT().GetLatestEventID(gomock.Any(), validateJwtForUserUUID).Return(getLatestEventIDResponse, getLatestEventIDErr).Times(getLatestEventIDCallCount)
+		eventStreamer.EXPECT().GetEventsSince(gomock.Any(), validateJwtForUserUUID, expectedLastEventID).Return(getEventsSinceResponse, getEventsSinceErr).Times(getEventsSinceCallCount)
+
+		// the stream stays open until the client disconnects, so cancel the request before the next poll
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+
+		req, err := http.NewRequestWithContext(ctx, "GET", "http://localhost:8080/dating-api/v1/user/events", nil)
+		req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", mockJWT))
+		if lastEventIDHeader != "" {
+			req.Header.Add("Last-Event-ID", lastEventIDHeader)
+		}
+		Expect(err).ToNot(HaveOccurred())
+		r.ServeHTTP(w, req)
+	})
+
+	It("should stream new events to the user", func() {
+		Expect(w.Code).To(Equal(http.StatusOK))
+		Expect(w.Header().Get("Content-Type")).To(Equal("text/event-stream"))
+		Expect(w.Body.String()).To(ContainSubstring("id:42\nevent:new_match\ndata:"))
+		Expect(w.Body.String()).To(ContainSubstring(`"matchId":"a8e5b5b4-7f7e-4a0c-9d0c-8b9f0f4a6a53"`))
+	})
+
+	When("the client resumes with a Last-Event-ID", func() {
+		BeforeEach(func() {
+			lastEventIDHeader = "12"
+			getLatestEventIDCallCount = 0
+			expectedLastEventID = 12
+		})
+
+		It("should stream the events since the last event received", func() {
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(w.Body.String()).To(ContainSubstring("id:42\n"))
+		})
+	})
+
+	When("the Last-Event-ID is invalid", func() {
+		BeforeEach(func() {
+			lastEventIDHeader = "not-a-number"
+			getLatestEventIDCallCount = 0
+			getEventsSinceCallCount = 0
+		})
+
+		It("should return a 400 Bad Request", func() {
+			Expect(w.Code).To(Equal(http.StatusBadRequest))
+		})
+	})
+
+	When("getting the latest event id returns an error", func() {
+		BeforeEach(func() {
+			getLatestEventIDErr = errors.New("an error occurred")
+			getEventsSinceCallCount = 0
+		})
+
+		It("should return a 500 Internal Server Error", func() {
+			Expect(w.Code).To(Equal(http.StatusInternalServerError))
+		})
+	})
+
+	When("getting events returns an error", func() {
+		BeforeEach(func() {
+			getEventsSinceResponse = nil
+			getEventsSinceErr = errors.New("an error occurred")
+		})
+
+		It("should close the stream without writing any events", func() {
+			Expect(w.Body.String()).ToNot(ContainSubstring("event:"))
+		})
+	})
+
+	When("the jwt cannot be validated", func() {
+		BeforeEach(func() {
+			validateJwtForUserUUID = uuid.UUID{}
+			validateJwtForUserErr = errors.New("unable to validate jwt")
+			getLatestEventIDCallCount = 0
+			getEventsSinceCallCount = 0
+		})
+
+		It("should return a 401 Unauthorized error", func() {
+			Expect(w.Code).To(Equal(http.StatusUnauthorized))
+		})
+	})
+})
@@ -1,17 +1,21 @@
 package usecases
 
 import (
+	"context"
+	"errors"
 	"github.com/AlecSmith96/dating-api/internal/entities"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
-	"log/slog"
+	"math"
 	"net/http"
+	"strconv"
+	"time"
 )
 
 //go:generate mockgen --build_flags=--mod=mod -destination=../../mocks/swipeRegister.go  . "SwipeRegister"
 type SwipeRegister interface {
-	RegisterSwipe(ownerUserID, swipedUserID uuid.UUID, isPositivePreference bool) error
-	IsMatch(ownerUserID, swipedUserID uuid.UUID) (*entities.Match, error)
+	RegisterSwipe(ctx context.Context, ownerUserID, swipedUserID uuid.UUID, swipeType entities.SwipeType) (*entities.SwipeQuota, error)
+	IsMatch(ctx context.Context, ownerUserID, swipedUserID uuid.UUID) (*entities.Match, error)
 }
 
 // SwipeUserRequestBody represents the swipe result on a user
@@ -19,8 +23,8 @@ type SwipeRegister interface {
 type SwipeUserRequestBody struct {
 	// UserID the id of the user that is swiped on
 	UserID uuid.UUID `json:"userId" binding:"required"`
-	// Preference the preference for the user
-	Preference string `json:"preference" binding:"required,oneof=YES yes NO no"`
+	// Preference the type of swipe, one of like, pass or superlike. YES and NO are still accepted as like and pass
+	Preference string `json:"preference" binding:"required,oneof=like pass superlike YES yes NO no"`
 }
 
 // SwipeUserResponseBody represents the result of the swipe
@@ -41,22 +45,32 @@ type Result struct {
 
 // NewSwipeUser swipe on a user
 // @Summary Swipe on a user
-// @Description Provides a swipe result on a user
+// @Description Provides a swipe result on a user. Likes use one swipe from the users daily quota, which resets at midnight
+// @Description in the users timezone, and super likes use one from their weekly quota, which resets at midnight on monday.
+// @Description The quota the swipe used (the daily quota for passes) is returned in the X-RateLimit headers unless the
+// @Description users tier is unlimited. Super liked users are notified and shown the sender first when discovering.
 // @Security BearerAuth
 // @Tags users
 // @Accept json
 // @Produce json
 // @Param user body SwipeUserRequestBody true "Swipe User Request Body"
 // @Success 200 {object} SwipeUserResponseBody
-// @Failure 400
-// @Failure 500
+// @Header 200,429 {integer} X-RateLimit-Limit "The number of swipes allowed in the quota period"
+// @Header 200,429 {integer} X-RateLimit-Remaining "The number of swipes left in the quota period"
+// @Header 200,429 {integer} X-RateLimit-Reset "The unix time the quota resets"
+// @Header 429 {integer} Retry-After "The number of seconds until the quota resets"
+// @Failure 400 {object} ProblemResponseBody
+// @Failure 404 {object} ProblemResponseBody
+// @Failure 429 {object} ProblemResponseBody
+// @Failure 500 {object} ProblemResponseBody
 // @Router /user/swipe [post]
-func NewSwipeUser(swipeRegister SwipeRegister) gin.HandlerFunc {
+func NewSwipeUser(swipeRegister SwipeRegister, txManager TxManager, eventRecorder EventRecorder, metricsRecorder MetricsRecorder) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		ctx := c.Request.Context()
 		userID, ok := c.Get("userID")
 		if !ok {
-			slog.Error("unable to get userID from context")
-			c.JSON(http.StatusInternalServerError, entities.ErrorMessage{Message: "unable to get users"})
+			Logger(ctx).ErrorContext(ctx, "unable to get userID from context")
+			c.Error(errUserIDMissing)
 			return
 		}
 		requestingUserID := userID.(uuid.UUID)
@@ -64,43 +78,85 @@ func NewSwipeUser(swipeRegister SwipeRegister) gin.HandlerFunc {
 		var request SwipeUserRequestBody
 		err := c.ShouldBindJSON(&request)
 		if err != nil {
-			slog.Error("validating request", "err", err)
-			c.JSON(http.StatusBadRequest, entities.ErrorMessage{Message: "invalid request body"})
+			Logger(ctx).ErrorContext(ctx, "validating request", "err", err)
+			c.Error(bindingError(err))
 			return
 		}
 
-		var isPositivePreference bool
+		var swipeType entities.SwipeType
 		switch request.Preference {
-		case "YES":
-			isPositivePreference = true
-		case "yes":
-			isPositivePreference = true
-		case "NO":
-			isPositivePreference = false
-		case "no":
-			isPositivePreference = false
+		case "like", "YES", "yes":
+			swipeType = entities.SwipeTypeLike
+		case "pass", "NO", "no":
+			swipeType = entities.SwipeTypePass
+		case "superlike":
+			swipeType = entities.SwipeTypeSuperLike
 		default:
-			c.JSON(http.StatusBadRequest, entities.ErrorMessage{
-				Message: "invalid preference",
-			})
+			c.Error(invalidField("preference", "oneof", "must be one of like, pass or superlike"))
 			return
 		}
 
-		err = swipeRegister.RegisterSwipe(requestingUserID, request.UserID, isPositivePreference)
+		// the swipe and the match it makes are saved together, so a swipe is never kept without the match it should have
+		// created
+		var quota *entities.SwipeQuota
+		var match *entities.Match
+		err = txManager.WithinTx(ctx, func(ctx context.Context) error {
+			var err error
+			quota, err = swipeRegister.RegisterSwipe(ctx, requestingUserID, request.UserID, swipeType)
+			if err != nil {
+				return err
+			}
+
+			match, err = swipeRegister.IsMatch(ctx, requestingUserID, request.UserID)
+			return err
+		})
 		if err != nil {
-			slog.Error("registering swipe", "err", err)
-			c.JSON(http.StatusInternalServerError, entities.ErrorMessage{Message: "an internal server error occurred"})
+			if errors.Is(err, entities.ErrSwipeQuotaExceeded) {
+				setSwipeQuotaHeaders(c, quota)
+				if quota != nil {
+					c.Header("Retry-After", strconv.Itoa(int(math.Ceil(time.Until(quota.ResetsAt).Seconds()))))
+				}
+				message := "daily swipe limit reached"
+				if swipeType == entities.SwipeTypeSuperLike {
+					message = "weekly super like limit reached"
+				}
+				c.Error(entities.NewError(http.StatusTooManyRequests, entities.CodeSwipeQuotaExceeded, message))
+				return
+			}
+			if errors.Is(err, entities.ErrUserBlocked) || errors.Is(err, entities.ErrTargetUserNotFound) {
+				c.Error(entities.NewError(http.StatusNotFound, entities.CodeUserNotFound, "user not found"))
+				return
+			}
+			Logger(ctx).ErrorContext(ctx, "registering swipe", "err", err)
+			c.Error(err)
 			return
 		}
 
-		match, err := swipeRegister.IsMatch(requestingUserID, request.UserID)
-		if err != nil {
-			slog.Error("checking for match", "err", err)
-			c.JSON(http.StatusInternalServerError, entities.ErrorMessage{Message: "an internal server error occurred"})
-			return
+		setSwipeQuotaHeaders(c, quota)
+		metricsRecorder.RecordSwipe(swipeType)
+
+		switch swipeType {
+		case entities.SwipeTypeLike:
+			recordEvent(ctx, eventRecorder, request.UserID, requestingUserID, entities.EventTypeProfileLiked, map[string]interface{}{})
+		case entities.SwipeTypeSuperLike:
+			recordEvent(ctx, eventRecorder, request.UserID, requestingUserID, entities.EventTypeSuperLiked, map[string]interface{}{
+				"userId": requestingUserID,
+			})
 		}
 
 		isMatch := match != nil
+		if isMatch {
+			metricsRecorder.RecordMatch()
+			recordEvent(ctx, eventRecorder, requestingUserID, request.UserID, entities.EventTypeNewMatch, map[string]interface{}{
+				"matchId": match.ID,
+				"userId":  request.UserID,
+			})
+			recordEvent(ctx, eventRecorder, request.UserID, requestingUserID, entities.EventTypeNewMatch, map[string]interface{}{
+				"matchId": match.ID,
+				"userId":  requestingUserID,
+			})
+		}
+
 		results := map[string]interface{}{
 			"matched": isMatch,
 		}
@@ -117,3 +173,14 @@ func NewSwipeUser(swipeRegister SwipeRegister) gin.HandlerFunc {
 		})
 	}
 }
+
+// setSwipeQuotaHeaders is a function that writes the users remaining swipe quota to the response headers
+func setSwipeQuotaHeaders(c *gin.Context, quota *entities.SwipeQuota) {
+	if quota == nil || quota.Unlimited {
+		return
+	}
+
+	c.Header("X-RateLimit-Limit", strconv.Itoa(quota.Limit))
+	c.Header("X-RateLimit-Remaining", strconv.Itoa(quota.Remaining()))
+	c.Header("X-RateLimit-Reset", strconv.FormatInt(quota.ResetsAt.Unix(), 10))
+}
@@ -0,0 +1,214 @@
+package usecases_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/AlecSmith96/dating-api/internal/entities"
+	"github.com/AlecSmith96/dating-api/internal/usecases"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"time"
+)
+
+var _ = Describe("swiping on a user", func() {
+	var w *httptest.ResponseRecorder
+	var swipedUserID uuid.UUID
+	var preference string
+	var swipeType entities.SwipeType
+	var recordedEventType entities.EventType
+
+	var validateJwtForUserUUID uuid.UUID
+
+	var registerSwipeResponse *entities.SwipeQuota
+	var registerSwipeErr error
+
+	var isMatchResponse *entities.Match
+	var isMatchErr error
+	var isMatchCallCount int
+
+	var commitErr error
+
+	BeforeEach(func() {
+		swipedUserID = uuid.New()
+		preference = "YES"
+		swipeType = entities.SwipeTypeLike
+		recordedEventType = ""
+
+		validateJwtForUserUUID = uuid.New()
+
+		registerSwipeResponse = &entities.SwipeQuota{
+			Tier:     entities.TierFree,
+			Limit:    100,
+			Used:     42,
+			ResetsAt: time.Now().Add(time.Hour).Truncate(time.Second),
+		}
+		registerSwipeErr = nil
+
+		isMatchResponse = nil
+		isMatchErr = nil
+		isMatchCallCount = 1
+
+		commitErr = nil
+	})
+
+	JustBeforeEach(func() {
+		w = httptest.NewRecorder()
+
+		jwtProcessor.EXPECT().ValidateJwtForUser(gomock.Any(), mockJWT).Return(validateJwtForUserUUID, nil).Times(1)
+		swipeRegister.EXPECT().RegisterSwipe(gomock.Any(), validateJwtForUserUUID, swipedUserID, swipeType).Return(registerSwipeResponse, registerSwipeErr).Times(1)
+		txManager.EXPECT().WithinTx(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			err := fn(ctx)
+			if err != nil {
+				return err
+			}
+			return commitErr
+		}).Times(1)
+		swipeRegister.EXPECT().IsMatch(gomock.Any(), validateJwtForUserUUID, swipedUserID).Return(isMatchResponse, isMatchErr).Times(isMatchCallCount)
+		eventRecorder.EXPECT().RecordEvent(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, event *entities.Event) error {
+			recordedEventType = event.Type
+			return nil
+		}).MaxTimes(1)
+
+		requestBodyJSON, err := json.Marshal(usecases.SwipeUserRequestBody{UserID: swipedUserID, Preference: preference})
+		Expect(err).ToNot(HaveOccurred())
+
+		req, err := http.NewRequest("POST", "http://localhost:8080/dating-api/v1/user/swipe", bytes.NewReader(requestBodyJSON))
+		req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", mockJWT))
+		Expect(err).ToNot(HaveOccurred())
+		r.ServeHTTP(w, req)
+	})
+
+	It("should return the remaining quota in the headers", func() {
+		Expect(w.Code).To(Equal(http.StatusOK))
+		Expect(recordedEventType).To(Equal(entities.EventTypeProfileLiked))
+		Expect(w.Header().Get("X-RateLimit-Limit")).To(Equal("100"))
+		Expect(w.Header().Get("X-RateLimit-Remaining")).To(Equal("58"))
+		Expect(w.Header().Get("X-RateLimit-Reset")).To(Equal(strconv.FormatInt(registerSwipeResponse.ResetsAt.Unix(), 10)))
+	})
+
+	When("the user likes with the new swipe type", func() {
+		BeforeEach(func() {
+			preference = "like"
+		})
+
+		It("should register a like", func() {
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(recordedEventType).To(Equal(entities.EventTypeProfileLiked))
+		})
+	})
+
+	When("the user passes", func() {
+		BeforeEach(func() {
+			preference = "NO"
+			swipeType = entities.SwipeTypePass
+		})
+
+		It("should not notify the swiped user", func() {
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(recordedEventType).To(BeEmpty())
+		})
+	})
+
+	When("the user super likes", func() {
+		BeforeEach(func() {
+			preference = "superlike"
+			swipeType = entities.SwipeTypeSuperLike
+			registerSwipeResponse = &entities.SwipeQuota{
+				Tier:      entities.TierFree,
+				SwipeType: entities.SwipeTypeSuperLike,
+				Limit:     1,
+				Used:      1,
+				ResetsAt:  time.Now().Add(72 * time.Hour),
+			}
+		})
+
+		It("should notify the swiped user that they were super liked", func() {
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(recordedEventType).To(Equal(entities.EventTypeSuperLiked))
+			Expect(w.Header().Get("X-RateLimit-Remaining")).To(Equal("0"))
+		})
+
+		When("the user has used their weekly super likes", func() {
+			BeforeEach(func() {
+				registerSwipeErr = entities.ErrSwipeQuotaExceeded
+				isMatchCallCount = 0
+			})
+
+			It("should return a 429 Too Many Requests", func() {
+				Expect(w.Code).To(Equal(http.StatusTooManyRequests))
+				Expect(w.Body.String()).To(ContainSubstring("weekly super like limit reached"))
+			})
+		})
+	})
+
+	When("the user has unlimited swipes", func() {
+		BeforeEach(func() {
+			registerSwipeResponse = &entities.SwipeQuota{Tier: entities.TierPremium, Unlimited: true, Used: 1000}
+		})
+
+		It("should not return quota headers", func() {
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(w.Header().Get("X-RateLimit-Limit")).To(BeEmpty())
+			Expect(w.Header().Get("X-RateLimit-Remaining")).To(BeEmpty())
+		})
+	})
+
+	When("the user has used their quota", func() {
+		BeforeEach(func() {
+			registerSwipeResponse.Used = 100
+			registerSwipeErr = entities.ErrSwipeQuotaExceeded
+			isMatchCallCount = 0
+		})
+
+		It("should return a 429 Too Many Requests", func() {
+			Expect(w.Code).To(Equal(http.StatusTooManyRequests))
+			Expect(w.Header().Get("X-RateLimit-Remaining")).To(Equal("0"))
+			retryAfter, err := strconv.Atoi(w.Header().Get("Retry-After"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(retryAfter).To(BeNumerically("~", 3600, 5))
+		})
+	})
+
+	When("the swiped user does not exist", func() {
+		BeforeEach(func() {
+			registerSwipeResponse = nil
+			registerSwipeErr = entities.ErrTargetUserNotFound
+			isMatchCallCount = 0
+		})
+
+		It("should return a 404 Not Found", func() {
+			Expect(w.Code).To(Equal(http.StatusNotFound))
+		})
+	})
+
+	When("checking for a match fails", func() {
+		BeforeEach(func() {
+			isMatchErr = errors.New("an error occurred")
+		})
+
+		It("should return a 500 Internal Server Error without recording the swipe", func() {
+			Expect(w.Code).To(Equal(http.StatusInternalServerError))
+			Expect(recordedEventType).To(BeEmpty())
+			Expect(w.Header().Get("X-RateLimit-Remaining")).To(BeEmpty())
+		})
+	})
+
+	When("the swipe fails to commit", func() {
+		BeforeEach(func() {
+			commitErr = errors.New("an error occurred")
+		})
+
+		It("should return a 500 Internal Server Error without notifying the swiped user", func() {
+			Expect(w.Code).To(Equal(http.StatusInternalServerError))
+			Expect(recordedEventType).To(BeEmpty())
+		})
+	})
+})
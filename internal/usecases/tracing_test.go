@@ -0,0 +1,73 @@
+package usecases_test
+
+import (
+	"context"
+	"fmt"
+	"github.com/AlecSmith96/dating-api/internal/entities"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+)
+
+var _ = Describe("tracing a request", func() {
+	const traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+
+	var w *httptest.ResponseRecorder
+
+	var validateJwtForUserUUID uuid.UUID
+	var getEntitlementsSpanContext trace.SpanContext
+
+	BeforeEach(func() {
+		validateJwtForUserUUID = uuid.New()
+		getEntitlementsSpanContext = trace.SpanContext{}
+	})
+
+	JustBeforeEach(func() {
+		w = httptest.NewRecorder()
+
+		jwtProcessor.EXPECT().ValidateJwtForUser(gomock.Any(), mockJWT).Return(validateJwtForUserUUID, nil).Times(1)
+		entitlements.EXPECT().GetEntitlements(gomock.Any(), validateJwtForUserUUID).DoAndReturn(func(ctx context.Context, _ uuid.UUID) (*entities.Entitlements, error) {
+			getEntitlementsSpanContext = trace.SpanContextFromContext(ctx)
+			return &entities.Entitlements{Tier: entities.TierFree}, nil
+		}).Times(1)
+
+		req, err := http.NewRequest("GET", "http://localhost:8080/dating-api/v1/user/entitlements", nil)
+		req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", mockJWT))
+		req.Header.Add("traceparent", fmt.Sprintf("00-%s-00f067aa0ba902b7-01", traceID))
+		Expect(err).ToNot(HaveOccurred())
+		r.ServeHTTP(w, req)
+	})
+
+	It("should continue the trace from the incoming headers", func() {
+		Expect(w.Code).To(Equal(http.StatusOK))
+		Expect(getEntitlementsSpanContext.TraceID().String()).To(Equal(traceID))
+	})
+
+	It("should record a span for the request and for the usecase", func() {
+		var names []string
+		for _, span := range spanRecorder.Ended() {
+			if span.SpanContext().TraceID().String() == traceID {
+				names = append(names, span.Name())
+			}
+		}
+
+		Expect(names).To(ContainElements("/dating-api/v1/user/entitlements", "GetEntitlements"))
+	})
+
+	It("should make the usecase span a child of the request span", func() {
+		spans := map[string]sdktrace.ReadOnlySpan{}
+		for _, span := range spanRecorder.Ended() {
+			if span.SpanContext().TraceID().String() == traceID {
+				spans[span.Name()] = span
+			}
+		}
+
+		Expect(spans["GetEntitlements"].Parent().SpanID()).To(Equal(spans["/dating-api/v1/user/entitlements"].SpanContext().SpanID()))
+		Expect(getEntitlementsSpanContext.SpanID()).To(Equal(spans["GetEntitlements"].SpanContext().SpanID()))
+	})
+})
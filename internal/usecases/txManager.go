@@ -0,0 +1,11 @@
+package usecases
+
+import "context"
+
+// TxManager runs several adapter calls as one unit of work. The calls fn makes with the context it is given take part
+// in a transaction that is committed when fn returns nil and rolled back when it returns an error.
+//
+//go:generate mockgen --build_flags=--mod=mod -destination=../../mocks/txManager.go  . "TxManager"
+type TxManager interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
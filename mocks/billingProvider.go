@@ -0,0 +1,69 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/AlecSmith96/dating-api/internal/usecases (interfaces: BillingProvider)
+//
+// Generated by this command:
+//
+//	mockgen --build_flags=--mod=mod -destination=../../mocks/billingProvider.go . BillingProvider
+//
+// Package mock_usecases is a generated GoMock package.
+package mock_usecases
+
+import (
+	http "net/http"
+	reflect "reflect"
+
+	entities "github.com/AlecSmith96/dating-api/internal/entities"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockBillingProvider is a mock of BillingProvider interface.
+type MockBillingProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockBillingProviderMockRecorder
+}
+
+// MockBillingProviderMockRecorder is the mock recorder for MockBillingProvider.
+type MockBillingProviderMockRecorder struct {
+	mock *MockBillingProvider
+}
+
+// NewMockBillingProvider creates a new mock instance.
+func NewMockBillingProvider(ctrl *gomock.Controller) *MockBillingProvider {
+	mock := &MockBillingProvider{ctrl: ctrl}
+	mock.recorder = &MockBillingProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBillingProvider) EXPECT() *MockBillingProviderMockRecorder {
+	return m.recorder
+}
+
+// Name mocks base method.
+func (m *MockBillingProvider) Name() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Name")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Name indicates an expected call of Name.
+func (mr *MockBillingProviderMockRecorder) Name() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Name", reflect.TypeOf((*MockBillingProvider)(nil).Name))
+}
+
+// ParseWebhook mocks base method.
+func (m *MockBillingProvider) ParseWebhook(arg0 http.Header, arg1 []byte) (*entities.BillingEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ParseWebhook", arg0, arg1)
+	ret0, _ := ret[0].(*entities.BillingEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ParseWebhook indicates an expected call of ParseWebhook.
+func (mr *MockBillingProviderMockRecorder) ParseWebhook(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseWebhook", reflect.TypeOf((*MockBillingProvider)(nil).ParseWebhook), arg0, arg1)
+}
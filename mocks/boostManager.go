@@ -0,0 +1,72 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/AlecSmith96/dating-api/internal/usecases (interfaces: BoostManager)
+//
+// Generated by this command:
+//
+//	mockgen --build_flags=--mod=mod -destination=../../mocks/boostManager.go . BoostManager
+//
+// Package mock_usecases is a generated GoMock package.
+package mock_usecases
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	entities "github.com/AlecSmith96/dating-api/internal/entities"
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockBoostManager is a mock of BoostManager interface.
+type MockBoostManager struct {
+	ctrl     *gomock.Controller
+	recorder *MockBoostManagerMockRecorder
+}
+
+// MockBoostManagerMockRecorder is the mock recorder for MockBoostManager.
+type MockBoostManagerMockRecorder struct {
+	mock *MockBoostManager
+}
+
+// NewMockBoostManager creates a new mock instance.
+func NewMockBoostManager(ctrl *gomock.Controller) *MockBoostManager {
+	mock := &MockBoostManager{ctrl: ctrl}
+	mock.recorder = &MockBoostManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBoostManager) EXPECT() *MockBoostManagerMockRecorder {
+	return m.recorder
+}
+
+// GetBoostReports mocks base method.
+func (m *MockBoostManager) GetBoostReports(arg0 context.Context, arg1 uuid.UUID) ([]entities.BoostReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoostReports", arg0, arg1)
+	ret0, _ := ret[0].([]entities.BoostReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoostReports indicates an expected call of GetBoostReports.
+func (mr *MockBoostManagerMockRecorder) GetBoostReports(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoostReports", reflect.TypeOf((*MockBoostManager)(nil).GetBoostReports), arg0, arg1)
+}
+
+// StartBoost mocks base method.
+func (m *MockBoostManager) StartBoost(arg0 context.Context, arg1 uuid.UUID, arg2 time.Duration) (*entities.Boost, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartBoost", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*entities.Boost)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartBoost indicates an expected call of StartBoost.
+func (mr *MockBoostManagerMockRecorder) StartBoost(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartBoost", reflect.TypeOf((*MockBoostManager)(nil).StartBoost), arg0, arg1, arg2)
+}
@@ -0,0 +1,69 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/AlecSmith96/dating-api/internal/usecases (interfaces: DailyPicksGenerator)
+//
+// Generated by this command:
+//
+//	mockgen --build_flags=--mod=mod -destination=../../mocks/dailyPicksGenerator.go . DailyPicksGenerator
+//
+// Package mock_usecases is a generated GoMock package.
+package mock_usecases
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entities "github.com/AlecSmith96/dating-api/internal/entities"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockDailyPicksGenerator is a mock of DailyPicksGenerator interface.
+type MockDailyPicksGenerator struct {
+	ctrl     *gomock.Controller
+	recorder *MockDailyPicksGeneratorMockRecorder
+}
+
+// MockDailyPicksGeneratorMockRecorder is the mock recorder for MockDailyPicksGenerator.
+type MockDailyPicksGeneratorMockRecorder struct {
+	mock *MockDailyPicksGenerator
+}
+
+// NewMockDailyPicksGenerator creates a new mock instance.
+func NewMockDailyPicksGenerator(ctrl *gomock.Controller) *MockDailyPicksGenerator {
+	mock := &MockDailyPicksGenerator{ctrl: ctrl}
+	mock.recorder = &MockDailyPicksGeneratorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDailyPicksGenerator) EXPECT() *MockDailyPicksGeneratorMockRecorder {
+	return m.recorder
+}
+
+// GetUsersDueDailyPicks mocks base method.
+func (m *MockDailyPicksGenerator) GetUsersDueDailyPicks(arg0 context.Context, arg1 int) ([]entities.DailyPicksUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsersDueDailyPicks", arg0, arg1)
+	ret0, _ := ret[0].([]entities.DailyPicksUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsersDueDailyPicks indicates an expected call of GetUsersDueDailyPicks.
+func (mr *MockDailyPicksGeneratorMockRecorder) GetUsersDueDailyPicks(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersDueDailyPicks", reflect.TypeOf((*MockDailyPicksGenerator)(nil).GetUsersDueDailyPicks), arg0, arg1)
+}
+
+// SaveDailyPicks mocks base method.
+func (m *MockDailyPicksGenerator) SaveDailyPicks(arg0 context.Context, arg1 entities.DailyPicksUser, arg2 []entities.DailyPick) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveDailyPicks", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveDailyPicks indicates an expected call of SaveDailyPicks.
+func (mr *MockDailyPicksGeneratorMockRecorder) SaveDailyPicks(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveDailyPicks", reflect.TypeOf((*MockDailyPicksGenerator)(nil).SaveDailyPicks), arg0, arg1, arg2)
+}
@@ -0,0 +1,71 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/AlecSmith96/dating-api/internal/usecases (interfaces: DailyPicksLister)
+//
+// Generated by this command:
+//
+//	mockgen --build_flags=--mod=mod -destination=../../mocks/dailyPicksLister.go . DailyPicksLister
+//
+// Package mock_usecases is a generated GoMock package.
+package mock_usecases
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entities "github.com/AlecSmith96/dating-api/internal/entities"
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockDailyPicksLister is a mock of DailyPicksLister interface.
+type MockDailyPicksLister struct {
+	ctrl     *gomock.Controller
+	recorder *MockDailyPicksListerMockRecorder
+}
+
+// MockDailyPicksListerMockRecorder is the mock recorder for MockDailyPicksLister.
+type MockDailyPicksListerMockRecorder struct {
+	mock *MockDailyPicksLister
+}
+
+// NewMockDailyPicksLister creates a new mock instance.
+func NewMockDailyPicksLister(ctrl *gomock.Controller) *MockDailyPicksLister {
+	mock := &MockDailyPicksLister{ctrl: ctrl}
+	mock.recorder = &MockDailyPicksListerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDailyPicksLister) EXPECT() *MockDailyPicksListerMockRecorder {
+	return m.recorder
+}
+
+// GetTodaysPicks mocks base method.
+func (m *MockDailyPicksLister) GetTodaysPicks(arg0 context.Context, arg1 uuid.UUID) ([]entities.DailyPickCandidate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTodaysPicks", arg0, arg1)
+	ret0, _ := ret[0].([]entities.DailyPickCandidate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTodaysPicks indicates an expected call of GetTodaysPicks.
+func (mr *MockDailyPicksListerMockRecorder) GetTodaysPicks(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTodaysPicks", reflect.TypeOf((*MockDailyPicksLister)(nil).GetTodaysPicks), arg0, arg1)
+}
+
+// GetUsersLocation mocks base method.
+func (m *MockDailyPicksLister) GetUsersLocation(arg0 context.Context, arg1 uuid.UUID) (*entities.Location, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsersLocation", arg0, arg1)
+	ret0, _ := ret[0].(*entities.Location)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsersLocation indicates an expected call of GetUsersLocation.
+func (mr *MockDailyPicksListerMockRecorder) GetUsersLocation(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersLocation", reflect.TypeOf((*MockDailyPicksLister)(nil).GetUsersLocation), arg0, arg1)
+}
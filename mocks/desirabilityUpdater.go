@@ -0,0 +1,68 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/AlecSmith96/dating-api/internal/usecases (interfaces: DesirabilityUpdater)
+//
+// Generated by this command:
+//
+//	mockgen --build_flags=--mod=mod -destination=../../mocks/desirabilityUpdater.go . DesirabilityUpdater
+//
+// Package mock_usecases is a generated GoMock package.
+package mock_usecases
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockDesirabilityUpdater is a mock of DesirabilityUpdater interface.
+type MockDesirabilityUpdater struct {
+	ctrl     *gomock.Controller
+	recorder *MockDesirabilityUpdaterMockRecorder
+}
+
+// MockDesirabilityUpdaterMockRecorder is the mock recorder for MockDesirabilityUpdater.
+type MockDesirabilityUpdaterMockRecorder struct {
+	mock *MockDesirabilityUpdater
+}
+
+// NewMockDesirabilityUpdater creates a new mock instance.
+func NewMockDesirabilityUpdater(ctrl *gomock.Controller) *MockDesirabilityUpdater {
+	mock := &MockDesirabilityUpdater{ctrl: ctrl}
+	mock.recorder = &MockDesirabilityUpdaterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDesirabilityUpdater) EXPECT() *MockDesirabilityUpdaterMockRecorder {
+	return m.recorder
+}
+
+// ApplyDesirabilitySwipes mocks base method.
+func (m *MockDesirabilityUpdater) ApplyDesirabilitySwipes(arg0 context.Context, arg1 int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyDesirabilitySwipes", arg0, arg1)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplyDesirabilitySwipes indicates an expected call of ApplyDesirabilitySwipes.
+func (mr *MockDesirabilityUpdaterMockRecorder) ApplyDesirabilitySwipes(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyDesirabilitySwipes", reflect.TypeOf((*MockDesirabilityUpdater)(nil).ApplyDesirabilitySwipes), arg0, arg1)
+}
+
+// ResetDesirability mocks base method.
+func (m *MockDesirabilityUpdater) ResetDesirability(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetDesirability", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetDesirability indicates an expected call of ResetDesirability.
+func (mr *MockDesirabilityUpdaterMockRecorder) ResetDesirability(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetDesirability", reflect.TypeOf((*MockDesirabilityUpdater)(nil).ResetDesirability), arg0)
+}
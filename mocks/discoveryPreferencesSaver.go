@@ -0,0 +1,55 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/AlecSmith96/dating-api/internal/usecases (interfaces: DiscoveryPreferencesSaver)
+//
+// Generated by this command:
+//
+//	mockgen --build_flags=--mod=mod -destination=../../mocks/discoveryPreferencesSaver.go . DiscoveryPreferencesSaver
+//
+// Package mock_usecases is a generated GoMock package.
+package mock_usecases
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entities "github.com/AlecSmith96/dating-api/internal/entities"
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockDiscoveryPreferencesSaver is a mock of DiscoveryPreferencesSaver interface.
+type MockDiscoveryPreferencesSaver struct {
+	ctrl     *gomock.Controller
+	recorder *MockDiscoveryPreferencesSaverMockRecorder
+}
+
+// MockDiscoveryPreferencesSaverMockRecorder is the mock recorder for MockDiscoveryPreferencesSaver.
+type MockDiscoveryPreferencesSaverMockRecorder struct {
+	mock *MockDiscoveryPreferencesSaver
+}
+
+// NewMockDiscoveryPreferencesSaver creates a new mock instance.
+func NewMockDiscoveryPreferencesSaver(ctrl *gomock.Controller) *MockDiscoveryPreferencesSaver {
+	mock := &MockDiscoveryPreferencesSaver{ctrl: ctrl}
+	mock.recorder = &MockDiscoveryPreferencesSaverMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDiscoveryPreferencesSaver) EXPECT() *MockDiscoveryPreferencesSaverMockRecorder {
+	return m.recorder
+}
+
+// SaveDiscoveryPreferences mocks base method.
+func (m *MockDiscoveryPreferencesSaver) SaveDiscoveryPreferences(arg0 context.Context, arg1 uuid.UUID, arg2 entities.PageInfo) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveDiscoveryPreferences", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveDiscoveryPreferences indicates an expected call of SaveDiscoveryPreferences.
+func (mr *MockDiscoveryPreferencesSaverMockRecorder) SaveDiscoveryPreferences(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveDiscoveryPreferences", reflect.TypeOf((*MockDiscoveryPreferencesSaver)(nil).SaveDiscoveryPreferences), arg0, arg1, arg2)
+}
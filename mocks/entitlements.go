@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/AlecSmith96/dating-api/internal/usecases (interfaces: Entitlements)
+//
+// Generated by this command:
+//
+//	mockgen --build_flags=--mod=mod -destination=../../mocks/entitlements.go . Entitlements
+//
+// Package mock_usecases is a generated GoMock package.
+package mock_usecases
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entities "github.com/AlecSmith96/dating-api/internal/entities"
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockEntitlements is a mock of Entitlements interface.
+type MockEntitlements struct {
+	ctrl     *gomock.Controller
+	recorder *MockEntitlementsMockRecorder
+}
+
+// MockEntitlementsMockRecorder is the mock recorder for MockEntitlements.
+type MockEntitlementsMockRecorder struct {
+	mock *MockEntitlements
+}
+
+// NewMockEntitlements creates a new mock instance.
+func NewMockEntitlements(ctrl *gomock.Controller) *MockEntitlements {
+	mock := &MockEntitlements{ctrl: ctrl}
+	mock.recorder = &MockEntitlementsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEntitlements) EXPECT() *MockEntitlementsMockRecorder {
+	return m.recorder
+}
+
+// GetEntitlements mocks base method.
+func (m *MockEntitlements) GetEntitlements(arg0 context.Context, arg1 uuid.UUID) (*entities.Entitlements, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEntitlements", arg0, arg1)
+	ret0, _ := ret[0].(*entities.Entitlements)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEntitlements indicates an expected call of GetEntitlements.
+func (mr *MockEntitlementsMockRecorder) GetEntitlements(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEntitlements", reflect.TypeOf((*MockEntitlements)(nil).GetEntitlements), arg0, arg1)
+}
@@ -0,0 +1,55 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/AlecSmith96/dating-api/internal/usecases (interfaces: EventPruner)
+//
+// Generated by this command:
+//
+//	mockgen --build_flags=--mod=mod -destination=../../mocks/eventPruner.go . EventPruner
+//
+// Package mock_usecases is a generated GoMock package.
+package mock_usecases
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockEventPruner is a mock of EventPruner interface.
+type MockEventPruner struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventPrunerMockRecorder
+}
+
+// MockEventPrunerMockRecorder is the mock recorder for MockEventPruner.
+type MockEventPrunerMockRecorder struct {
+	mock *MockEventPruner
+}
+
+// NewMockEventPruner creates a new mock instance.
+func NewMockEventPruner(ctrl *gomock.Controller) *MockEventPruner {
+	mock := &MockEventPruner{ctrl: ctrl}
+	mock.recorder = &MockEventPrunerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventPruner) EXPECT() *MockEventPrunerMockRecorder {
+	return m.recorder
+}
+
+// PruneEvents mocks base method.
+func (m *MockEventPruner) PruneEvents(arg0 context.Context, arg1 time.Duration) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PruneEvents", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PruneEvents indicates an expected call of PruneEvents.
+func (mr *MockEventPrunerMockRecorder) PruneEvents(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PruneEvents", reflect.TypeOf((*MockEventPruner)(nil).PruneEvents), arg0, arg1)
+}
@@ -0,0 +1,71 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/AlecSmith96/dating-api/internal/usecases (interfaces: EventStreamer)
+//
+// Generated by this command:
+//
+//	mockgen --build_flags=--mod=mod -destination=../../mocks/eventStreamer.go . EventStreamer
+//
+// Package mock_usecases is a generated GoMock package.
+package mock_usecases
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entities "github.com/AlecSmith96/dating-api/internal/entities"
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockEventStreamer is a mock of EventStreamer interface.
+type MockEventStreamer struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventStreamerMockRecorder
+}
+
+// MockEventStreamerMockRecorder is the mock recorder for MockEventStreamer.
+type MockEventStreamerMockRecorder struct {
+	mock *MockEventStreamer
+}
+
+// NewMockEventStreamer creates a new mock instance.
+func NewMockEventStreamer(ctrl *gomock.Controller) *MockEventStreamer {
+	mock := &MockEventStreamer{ctrl: ctrl}
+	mock.recorder = &MockEventStreamerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventStreamer) EXPECT() *MockEventStreamerMockRecorder {
+	return m.recorder
+}
+
+// GetEventsSince mocks base method.
+func (m *MockEventStreamer) GetEventsSince(arg0 context.Context, arg1 uuid.UUID, arg2 int64) ([]entities.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEventsSince", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]entities.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEventsSince indicates an expected call of GetEventsSince.
+func (mr *MockEventStreamerMockRecorder) GetEventsSince(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEventsSince", reflect.TypeOf((*MockEventStreamer)(nil).GetEventsSince), arg0, arg1, arg2)
+}
+
+// GetLatestEventID mocks base method.
+func (m *MockEventStreamer) GetLatestEventID(arg0 context.Context, arg1 uuid.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestEventID", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestEventID indicates an expected call of GetLatestEventID.
+func (mr *MockEventStreamerMockRecorder) GetLatestEventID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestEventID", reflect.TypeOf((*MockEventStreamer)(nil).GetLatestEventID), arg0, arg1)
+}
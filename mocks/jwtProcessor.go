@@ -9,6 +9,7 @@
 package mock_usecases
 
 import (
+	context "context"
 	reflect "reflect"
 
 	uuid "github.com/google/uuid"
@@ -39,16 +40,16 @@ func (m *MockJwtProcessor) EXPECT() *MockJwtProcessorMockRecorder {
 }
 
 // ValidateJwtForUser mocks base method.
-func (m *MockJwtProcessor) ValidateJwtForUser(arg0 string) (uuid.UUID, error) {
+func (m *MockJwtProcessor) ValidateJwtForUser(arg0 context.Context, arg1 string) (uuid.UUID, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ValidateJwtForUser", arg0)
+	ret := m.ctrl.Call(m, "ValidateJwtForUser", arg0, arg1)
 	ret0, _ := ret[0].(uuid.UUID)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ValidateJwtForUser indicates an expected call of ValidateJwtForUser.
-func (mr *MockJwtProcessorMockRecorder) ValidateJwtForUser(arg0 any) *gomock.Call {
+func (mr *MockJwtProcessorMockRecorder) ValidateJwtForUser(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateJwtForUser", reflect.TypeOf((*MockJwtProcessor)(nil).ValidateJwtForUser), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateJwtForUser", reflect.TypeOf((*MockJwtProcessor)(nil).ValidateJwtForUser), arg0, arg1)
 }
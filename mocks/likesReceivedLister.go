@@ -0,0 +1,71 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/AlecSmith96/dating-api/internal/usecases (interfaces: LikesReceivedLister)
+//
+// Generated by this command:
+//
+//	mockgen --build_flags=--mod=mod -destination=../../mocks/likesReceivedLister.go . LikesReceivedLister
+//
+// Package mock_usecases is a generated GoMock package.
+package mock_usecases
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entities "github.com/AlecSmith96/dating-api/internal/entities"
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockLikesReceivedLister is a mock of LikesReceivedLister interface.
+type MockLikesReceivedLister struct {
+	ctrl     *gomock.Controller
+	recorder *MockLikesReceivedListerMockRecorder
+}
+
+// MockLikesReceivedListerMockRecorder is the mock recorder for MockLikesReceivedLister.
+type MockLikesReceivedListerMockRecorder struct {
+	mock *MockLikesReceivedLister
+}
+
+// NewMockLikesReceivedLister creates a new mock instance.
+func NewMockLikesReceivedLister(ctrl *gomock.Controller) *MockLikesReceivedLister {
+	mock := &MockLikesReceivedLister{ctrl: ctrl}
+	mock.recorder = &MockLikesReceivedListerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLikesReceivedLister) EXPECT() *MockLikesReceivedListerMockRecorder {
+	return m.recorder
+}
+
+// CountLikesReceived mocks base method.
+func (m *MockLikesReceivedLister) CountLikesReceived(arg0 context.Context, arg1 uuid.UUID) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountLikesReceived", arg0, arg1)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountLikesReceived indicates an expected call of CountLikesReceived.
+func (mr *MockLikesReceivedListerMockRecorder) CountLikesReceived(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountLikesReceived", reflect.TypeOf((*MockLikesReceivedLister)(nil).CountLikesReceived), arg0, arg1)
+}
+
+// GetLikesReceived mocks base method.
+func (m *MockLikesReceivedLister) GetLikesReceived(arg0 context.Context, arg1 uuid.UUID, arg2, arg3 int) ([]entities.ReceivedLike, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLikesReceived", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]entities.ReceivedLike)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLikesReceived indicates an expected call of GetLikesReceived.
+func (mr *MockLikesReceivedListerMockRecorder) GetLikesReceived(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLikesReceived", reflect.TypeOf((*MockLikesReceivedLister)(nil).GetLikesReceived), arg0, arg1, arg2, arg3)
+}
@@ -0,0 +1,100 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/AlecSmith96/dating-api/internal/usecases (interfaces: MetricsRecorder)
+//
+// Generated by this command:
+//
+//	mockgen --build_flags=--mod=mod -destination=../../mocks/metricsRecorder.go . MetricsRecorder
+//
+// Package mock_usecases is a generated GoMock package.
+package mock_usecases
+
+import (
+	reflect "reflect"
+	time "time"
+
+	entities "github.com/AlecSmith96/dating-api/internal/entities"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockMetricsRecorder is a mock of MetricsRecorder interface.
+type MockMetricsRecorder struct {
+	ctrl     *gomock.Controller
+	recorder *MockMetricsRecorderMockRecorder
+}
+
+// MockMetricsRecorderMockRecorder is the mock recorder for MockMetricsRecorder.
+type MockMetricsRecorderMockRecorder struct {
+	mock *MockMetricsRecorder
+}
+
+// NewMockMetricsRecorder creates a new mock instance.
+func NewMockMetricsRecorder(ctrl *gomock.Controller) *MockMetricsRecorder {
+	mock := &MockMetricsRecorder{ctrl: ctrl}
+	mock.recorder = &MockMetricsRecorderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMetricsRecorder) EXPECT() *MockMetricsRecorderMockRecorder {
+	return m.recorder
+}
+
+// ObserveRequest mocks base method.
+func (m *MockMetricsRecorder) ObserveRequest(arg0, arg1 string, arg2 int, arg3 time.Duration) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ObserveRequest", arg0, arg1, arg2, arg3)
+}
+
+// ObserveRequest indicates an expected call of ObserveRequest.
+func (mr *MockMetricsRecorderMockRecorder) ObserveRequest(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ObserveRequest", reflect.TypeOf((*MockMetricsRecorder)(nil).ObserveRequest), arg0, arg1, arg2, arg3)
+}
+
+// RecordJwtValidationFailure mocks base method.
+func (m *MockMetricsRecorder) RecordJwtValidationFailure(arg0 string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RecordJwtValidationFailure", arg0)
+}
+
+// RecordJwtValidationFailure indicates an expected call of RecordJwtValidationFailure.
+func (mr *MockMetricsRecorderMockRecorder) RecordJwtValidationFailure(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordJwtValidationFailure", reflect.TypeOf((*MockMetricsRecorder)(nil).RecordJwtValidationFailure), arg0)
+}
+
+// RecordLogin mocks base method.
+func (m *MockMetricsRecorder) RecordLogin(arg0 bool) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RecordLogin", arg0)
+}
+
+// RecordLogin indicates an expected call of RecordLogin.
+func (mr *MockMetricsRecorderMockRecorder) RecordLogin(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordLogin", reflect.TypeOf((*MockMetricsRecorder)(nil).RecordLogin), arg0)
+}
+
+// RecordMatch mocks base method.
+func (m *MockMetricsRecorder) RecordMatch() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RecordMatch")
+}
+
+// RecordMatch indicates an expected call of RecordMatch.
+func (mr *MockMetricsRecorderMockRecorder) RecordMatch() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordMatch", reflect.TypeOf((*MockMetricsRecorder)(nil).RecordMatch))
+}
+
+// RecordSwipe mocks base method.
+func (m *MockMetricsRecorder) RecordSwipe(arg0 entities.SwipeType) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RecordSwipe", arg0)
+}
+
+// RecordSwipe indicates an expected call of RecordSwipe.
+func (mr *MockMetricsRecorderMockRecorder) RecordSwipe(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordSwipe", reflect.TypeOf((*MockMetricsRecorder)(nil).RecordSwipe), arg0)
+}
@@ -0,0 +1,131 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/AlecSmith96/dating-api/internal/usecases (interfaces: ModerationQueue)
+//
+// Generated by this command:
+//
+//	mockgen --build_flags=--mod=mod -destination=../../mocks/moderationQueue.go . ModerationQueue
+//
+// Package mock_usecases is a generated GoMock package.
+package mock_usecases
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entities "github.com/AlecSmith96/dating-api/internal/entities"
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockModerationQueue is a mock of ModerationQueue interface.
+type MockModerationQueue struct {
+	ctrl     *gomock.Controller
+	recorder *MockModerationQueueMockRecorder
+}
+
+// MockModerationQueueMockRecorder is the mock recorder for MockModerationQueue.
+type MockModerationQueueMockRecorder struct {
+	mock *MockModerationQueue
+}
+
+// NewMockModerationQueue creates a new mock instance.
+func NewMockModerationQueue(ctrl *gomock.Controller) *MockModerationQueue {
+	mock := &MockModerationQueue{ctrl: ctrl}
+	mock.recorder = &MockModerationQueueMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockModerationQueue) EXPECT() *MockModerationQueueMockRecorder {
+	return m.recorder
+}
+
+// ApplyModerationAction mocks base method.
+func (m *MockModerationQueue) ApplyModerationAction(arg0 context.Context, arg1 *entities.ModerationAction) (*entities.ModerationCase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyModerationAction", arg0, arg1)
+	ret0, _ := ret[0].(*entities.ModerationCase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplyModerationAction indicates an expected call of ApplyModerationAction.
+func (mr *MockModerationQueueMockRecorder) ApplyModerationAction(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyModerationAction", reflect.TypeOf((*MockModerationQueue)(nil).ApplyModerationAction), arg0, arg1)
+}
+
+// AssignModerationCase mocks base method.
+func (m *MockModerationQueue) AssignModerationCase(arg0 context.Context, arg1, arg2, arg3 uuid.UUID) (*entities.ModerationCase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssignModerationCase", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*entities.ModerationCase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AssignModerationCase indicates an expected call of AssignModerationCase.
+func (mr *MockModerationQueueMockRecorder) AssignModerationCase(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignModerationCase", reflect.TypeOf((*MockModerationQueue)(nil).AssignModerationCase), arg0, arg1, arg2, arg3)
+}
+
+// GetModerationAudit mocks base method.
+func (m *MockModerationQueue) GetModerationAudit(arg0 context.Context, arg1 uuid.UUID) ([]entities.ModerationAuditEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetModerationAudit", arg0, arg1)
+	ret0, _ := ret[0].([]entities.ModerationAuditEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetModerationAudit indicates an expected call of GetModerationAudit.
+func (mr *MockModerationQueueMockRecorder) GetModerationAudit(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetModerationAudit", reflect.TypeOf((*MockModerationQueue)(nil).GetModerationAudit), arg0, arg1)
+}
+
+// GetModerationCase mocks base method.
+func (m *MockModerationQueue) GetModerationCase(arg0 context.Context, arg1 uuid.UUID) (*entities.ModerationCase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetModerationCase", arg0, arg1)
+	ret0, _ := ret[0].(*entities.ModerationCase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetModerationCase indicates an expected call of GetModerationCase.
+func (mr *MockModerationQueueMockRecorder) GetModerationCase(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetModerationCase", reflect.TypeOf((*MockModerationQueue)(nil).GetModerationCase), arg0, arg1)
+}
+
+// GetModerationCases mocks base method.
+func (m *MockModerationQueue) GetModerationCases(arg0 context.Context, arg1 entities.ModerationCaseStatus, arg2 int) ([]entities.ModerationCase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetModerationCases", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]entities.ModerationCase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetModerationCases indicates an expected call of GetModerationCases.
+func (mr *MockModerationQueueMockRecorder) GetModerationCases(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetModerationCases", reflect.TypeOf((*MockModerationQueue)(nil).GetModerationCases), arg0, arg1, arg2)
+}
+
+// UpdateModerationCaseStatus mocks base method.
+func (m *MockModerationQueue) UpdateModerationCaseStatus(arg0 context.Context, arg1 uuid.UUID, arg2 entities.ModerationCaseStatus, arg3 uuid.UUID) (*entities.ModerationCase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateModerationCaseStatus", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*entities.ModerationCase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateModerationCaseStatus indicates an expected call of UpdateModerationCaseStatus.
+func (mr *MockModerationQueueMockRecorder) UpdateModerationCaseStatus(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateModerationCaseStatus", reflect.TypeOf((*MockModerationQueue)(nil).UpdateModerationCaseStatus), arg0, arg1, arg2, arg3)
+}
@@ -0,0 +1,85 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/AlecSmith96/dating-api/internal/usecases (interfaces: QuestionAnswerer)
+//
+// Generated by this command:
+//
+//	mockgen --build_flags=--mod=mod -destination=../../mocks/questionAnswerer.go . QuestionAnswerer
+//
+// Package mock_usecases is a generated GoMock package.
+package mock_usecases
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entities "github.com/AlecSmith96/dating-api/internal/entities"
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockQuestionAnswerer is a mock of QuestionAnswerer interface.
+type MockQuestionAnswerer struct {
+	ctrl     *gomock.Controller
+	recorder *MockQuestionAnswererMockRecorder
+}
+
+// MockQuestionAnswererMockRecorder is the mock recorder for MockQuestionAnswerer.
+type MockQuestionAnswererMockRecorder struct {
+	mock *MockQuestionAnswerer
+}
+
+// NewMockQuestionAnswerer creates a new mock instance.
+func NewMockQuestionAnswerer(ctrl *gomock.Controller) *MockQuestionAnswerer {
+	mock := &MockQuestionAnswerer{ctrl: ctrl}
+	mock.recorder = &MockQuestionAnswererMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockQuestionAnswerer) EXPECT() *MockQuestionAnswererMockRecorder {
+	return m.recorder
+}
+
+// AnswerQuestion mocks base method.
+func (m *MockQuestionAnswerer) AnswerQuestion(arg0 context.Context, arg1 *entities.UserAnswer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AnswerQuestion", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AnswerQuestion indicates an expected call of AnswerQuestion.
+func (mr *MockQuestionAnswererMockRecorder) AnswerQuestion(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AnswerQuestion", reflect.TypeOf((*MockQuestionAnswerer)(nil).AnswerQuestion), arg0, arg1)
+}
+
+// GetQuestions mocks base method.
+func (m *MockQuestionAnswerer) GetQuestions(arg0 context.Context) ([]entities.Question, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQuestions", arg0)
+	ret0, _ := ret[0].([]entities.Question)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetQuestions indicates an expected call of GetQuestions.
+func (mr *MockQuestionAnswererMockRecorder) GetQuestions(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQuestions", reflect.TypeOf((*MockQuestionAnswerer)(nil).GetQuestions), arg0)
+}
+
+// GetUserAnswers mocks base method.
+func (m *MockQuestionAnswerer) GetUserAnswers(arg0 context.Context, arg1 uuid.UUID) ([]entities.UserAnswer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserAnswers", arg0, arg1)
+	ret0, _ := ret[0].([]entities.UserAnswer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserAnswers indicates an expected call of GetUserAnswers.
+func (mr *MockQuestionAnswererMockRecorder) GetUserAnswers(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserAnswers", reflect.TypeOf((*MockQuestionAnswerer)(nil).GetUserAnswers), arg0, arg1)
+}
@@ -0,0 +1,115 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/AlecSmith96/dating-api/internal/usecases (interfaces: QuestionBank)
+//
+// Generated by this command:
+//
+//	mockgen --build_flags=--mod=mod -destination=../../mocks/questionBank.go . QuestionBank
+//
+// Package mock_usecases is a generated GoMock package.
+package mock_usecases
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entities "github.com/AlecSmith96/dating-api/internal/entities"
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockQuestionBank is a mock of QuestionBank interface.
+type MockQuestionBank struct {
+	ctrl     *gomock.Controller
+	recorder *MockQuestionBankMockRecorder
+}
+
+// MockQuestionBankMockRecorder is the mock recorder for MockQuestionBank.
+type MockQuestionBankMockRecorder struct {
+	mock *MockQuestionBank
+}
+
+// NewMockQuestionBank creates a new mock instance.
+func NewMockQuestionBank(ctrl *gomock.Controller) *MockQuestionBank {
+	mock := &MockQuestionBank{ctrl: ctrl}
+	mock.recorder = &MockQuestionBankMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockQuestionBank) EXPECT() *MockQuestionBankMockRecorder {
+	return m.recorder
+}
+
+// CreateQuestion mocks base method.
+func (m *MockQuestionBank) CreateQuestion(arg0 context.Context, arg1 *entities.Question) (*entities.Question, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateQuestion", arg0, arg1)
+	ret0, _ := ret[0].(*entities.Question)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateQuestion indicates an expected call of CreateQuestion.
+func (mr *MockQuestionBankMockRecorder) CreateQuestion(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateQuestion", reflect.TypeOf((*MockQuestionBank)(nil).CreateQuestion), arg0, arg1)
+}
+
+// DeleteQuestion mocks base method.
+func (m *MockQuestionBank) DeleteQuestion(arg0 context.Context, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteQuestion", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteQuestion indicates an expected call of DeleteQuestion.
+func (mr *MockQuestionBankMockRecorder) DeleteQuestion(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteQuestion", reflect.TypeOf((*MockQuestionBank)(nil).DeleteQuestion), arg0, arg1)
+}
+
+// GetQuestion mocks base method.
+func (m *MockQuestionBank) GetQuestion(arg0 context.Context, arg1 uuid.UUID) (*entities.Question, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQuestion", arg0, arg1)
+	ret0, _ := ret[0].(*entities.Question)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetQuestion indicates an expected call of GetQuestion.
+func (mr *MockQuestionBankMockRecorder) GetQuestion(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQuestion", reflect.TypeOf((*MockQuestionBank)(nil).GetQuestion), arg0, arg1)
+}
+
+// GetQuestions mocks base method.
+func (m *MockQuestionBank) GetQuestions(arg0 context.Context) ([]entities.Question, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQuestions", arg0)
+	ret0, _ := ret[0].([]entities.Question)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetQuestions indicates an expected call of GetQuestions.
+func (mr *MockQuestionBankMockRecorder) GetQuestions(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQuestions", reflect.TypeOf((*MockQuestionBank)(nil).GetQuestions), arg0)
+}
+
+// UpdateQuestion mocks base method.
+func (m *MockQuestionBank) UpdateQuestion(arg0 context.Context, arg1 *entities.Question) (*entities.Question, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateQuestion", arg0, arg1)
+	ret0, _ := ret[0].(*entities.Question)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateQuestion indicates an expected call of UpdateQuestion.
+func (mr *MockQuestionBankMockRecorder) UpdateQuestion(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateQuestion", reflect.TypeOf((*MockQuestionBank)(nil).UpdateQuestion), arg0, arg1)
+}
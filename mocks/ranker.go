@@ -0,0 +1,81 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/AlecSmith96/dating-api/internal/usecases (interfaces: Ranker)
+//
+// Generated by this command:
+//
+//	mockgen --build_flags=--mod=mod -destination=../../mocks/ranker.go . Ranker
+//
+// Package mock_usecases is a generated GoMock package.
+package mock_usecases
+
+import (
+	reflect "reflect"
+
+	entities "github.com/AlecSmith96/dating-api/internal/entities"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRanker is a mock of Ranker interface.
+type MockRanker struct {
+	ctrl     *gomock.Controller
+	recorder *MockRankerMockRecorder
+}
+
+// MockRankerMockRecorder is the mock recorder for MockRanker.
+type MockRankerMockRecorder struct {
+	mock *MockRanker
+}
+
+// NewMockRanker creates a new mock instance.
+func NewMockRanker(ctrl *gomock.Controller) *MockRanker {
+	mock := &MockRanker{ctrl: ctrl}
+	mock.recorder = &MockRankerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRanker) EXPECT() *MockRankerMockRecorder {
+	return m.recorder
+}
+
+// Name mocks base method.
+func (m *MockRanker) Name() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Name")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Name indicates an expected call of Name.
+func (mr *MockRankerMockRecorder) Name() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Name", reflect.TypeOf((*MockRanker)(nil).Name))
+}
+
+// Rank mocks base method.
+func (m *MockRanker) Rank(arg0 entities.RankingContext, arg1 []entities.UserDiscovery) []entities.RankedCandidate {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rank", arg0, arg1)
+	ret0, _ := ret[0].([]entities.RankedCandidate)
+	return ret0
+}
+
+// Rank indicates an expected call of Rank.
+func (mr *MockRankerMockRecorder) Rank(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rank", reflect.TypeOf((*MockRanker)(nil).Rank), arg0, arg1)
+}
+
+// Weights mocks base method.
+func (m *MockRanker) Weights() *entities.RankingWeights {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Weights")
+	ret0, _ := ret[0].(*entities.RankingWeights)
+	return ret0
+}
+
+// Weights indicates an expected call of Weights.
+func (mr *MockRankerMockRecorder) Weights() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Weights", reflect.TypeOf((*MockRanker)(nil).Weights))
+}
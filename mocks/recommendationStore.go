@@ -0,0 +1,69 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/AlecSmith96/dating-api/internal/usecases (interfaces: RecommendationStore)
+//
+// Generated by this command:
+//
+//	mockgen --build_flags=--mod=mod -destination=../../mocks/recommendationStore.go . RecommendationStore
+//
+// Package mock_usecases is a generated GoMock package.
+package mock_usecases
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entities "github.com/AlecSmith96/dating-api/internal/entities"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRecommendationStore is a mock of RecommendationStore interface.
+type MockRecommendationStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockRecommendationStoreMockRecorder
+}
+
+// MockRecommendationStoreMockRecorder is the mock recorder for MockRecommendationStore.
+type MockRecommendationStoreMockRecorder struct {
+	mock *MockRecommendationStore
+}
+
+// NewMockRecommendationStore creates a new mock instance.
+func NewMockRecommendationStore(ctrl *gomock.Controller) *MockRecommendationStore {
+	mock := &MockRecommendationStore{ctrl: ctrl}
+	mock.recorder = &MockRecommendationStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRecommendationStore) EXPECT() *MockRecommendationStoreMockRecorder {
+	return m.recorder
+}
+
+// GetSwipeHistory mocks base method.
+func (m *MockRecommendationStore) GetSwipeHistory(arg0 context.Context) ([]entities.Swipe, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSwipeHistory", arg0)
+	ret0, _ := ret[0].([]entities.Swipe)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSwipeHistory indicates an expected call of GetSwipeHistory.
+func (mr *MockRecommendationStoreMockRecorder) GetSwipeHistory(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSwipeHistory", reflect.TypeOf((*MockRecommendationStore)(nil).GetSwipeHistory), arg0)
+}
+
+// ReplaceRecommendations mocks base method.
+func (m *MockRecommendationStore) ReplaceRecommendations(arg0 context.Context, arg1 []entities.Recommendation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceRecommendations", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceRecommendations indicates an expected call of ReplaceRecommendations.
+func (mr *MockRecommendationStoreMockRecorder) ReplaceRecommendations(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceRecommendations", reflect.TypeOf((*MockRecommendationStore)(nil).ReplaceRecommendations), arg0, arg1)
+}
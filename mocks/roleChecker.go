@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/AlecSmith96/dating-api/internal/usecases (interfaces: RoleChecker)
+//
+// Generated by this command:
+//
+//	mockgen --build_flags=--mod=mod -destination=../../mocks/roleChecker.go . RoleChecker
+//
+// Package mock_usecases is a generated GoMock package.
+package mock_usecases
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entities "github.com/AlecSmith96/dating-api/internal/entities"
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRoleChecker is a mock of RoleChecker interface.
+type MockRoleChecker struct {
+	ctrl     *gomock.Controller
+	recorder *MockRoleCheckerMockRecorder
+}
+
+// MockRoleCheckerMockRecorder is the mock recorder for MockRoleChecker.
+type MockRoleCheckerMockRecorder struct {
+	mock *MockRoleChecker
+}
+
+// NewMockRoleChecker creates a new mock instance.
+func NewMockRoleChecker(ctrl *gomock.Controller) *MockRoleChecker {
+	mock := &MockRoleChecker{ctrl: ctrl}
+	mock.recorder = &MockRoleCheckerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRoleChecker) EXPECT() *MockRoleCheckerMockRecorder {
+	return m.recorder
+}
+
+// GetUserRole mocks base method.
+func (m *MockRoleChecker) GetUserRole(arg0 context.Context, arg1 uuid.UUID) (entities.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserRole", arg0, arg1)
+	ret0, _ := ret[0].(entities.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserRole indicates an expected call of GetUserRole.
+func (mr *MockRoleCheckerMockRecorder) GetUserRole(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserRole", reflect.TypeOf((*MockRoleChecker)(nil).GetUserRole), arg0, arg1)
+}
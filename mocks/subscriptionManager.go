@@ -0,0 +1,55 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/AlecSmith96/dating-api/internal/usecases (interfaces: SubscriptionManager)
+//
+// Generated by this command:
+//
+//	mockgen --build_flags=--mod=mod -destination=../../mocks/subscriptionManager.go . SubscriptionManager
+//
+// Package mock_usecases is a generated GoMock package.
+package mock_usecases
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entities "github.com/AlecSmith96/dating-api/internal/entities"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSubscriptionManager is a mock of SubscriptionManager interface.
+type MockSubscriptionManager struct {
+	ctrl     *gomock.Controller
+	recorder *MockSubscriptionManagerMockRecorder
+}
+
+// MockSubscriptionManagerMockRecorder is the mock recorder for MockSubscriptionManager.
+type MockSubscriptionManagerMockRecorder struct {
+	mock *MockSubscriptionManager
+}
+
+// NewMockSubscriptionManager creates a new mock instance.
+func NewMockSubscriptionManager(ctrl *gomock.Controller) *MockSubscriptionManager {
+	mock := &MockSubscriptionManager{ctrl: ctrl}
+	mock.recorder = &MockSubscriptionManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSubscriptionManager) EXPECT() *MockSubscriptionManagerMockRecorder {
+	return m.recorder
+}
+
+// ApplyBillingEvent mocks base method.
+func (m *MockSubscriptionManager) ApplyBillingEvent(arg0 context.Context, arg1 *entities.BillingEvent) (*entities.Subscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyBillingEvent", arg0, arg1)
+	ret0, _ := ret[0].(*entities.Subscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplyBillingEvent indicates an expected call of ApplyBillingEvent.
+func (mr *MockSubscriptionManagerMockRecorder) ApplyBillingEvent(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyBillingEvent", reflect.TypeOf((*MockSubscriptionManager)(nil).ApplyBillingEvent), arg0, arg1)
+}
@@ -9,6 +9,7 @@
 package mock_usecases
 
 import (
+	context "context"
 	reflect "reflect"
 
 	entities "github.com/AlecSmith96/dating-api/internal/entities"
@@ -40,30 +41,31 @@ func (m *MockSwipeRegister) EXPECT() *MockSwipeRegisterMockRecorder {
 }
 
 // IsMatch mocks base method.
-func (m *MockSwipeRegister) IsMatch(arg0, arg1 uuid.UUID) (*entities.Match, error) {
+func (m *MockSwipeRegister) IsMatch(arg0 context.Context, arg1, arg2 uuid.UUID) (*entities.Match, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "IsMatch", arg0, arg1)
+	ret := m.ctrl.Call(m, "IsMatch", arg0, arg1, arg2)
 	ret0, _ := ret[0].(*entities.Match)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // IsMatch indicates an expected call of IsMatch.
-func (mr *MockSwipeRegisterMockRecorder) IsMatch(arg0, arg1 any) *gomock.Call {
+func (mr *MockSwipeRegisterMockRecorder) IsMatch(arg0, arg1, arg2 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsMatch", reflect.TypeOf((*MockSwipeRegister)(nil).IsMatch), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsMatch", reflect.TypeOf((*MockSwipeRegister)(nil).IsMatch), arg0, arg1, arg2)
 }
 
 // RegisterSwipe mocks base method.
-func (m *MockSwipeRegister) RegisterSwipe(arg0, arg1 uuid.UUID, arg2 bool) error {
+func (m *MockSwipeRegister) RegisterSwipe(arg0 context.Context, arg1, arg2 uuid.UUID, arg3 entities.SwipeType) (*entities.SwipeQuota, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RegisterSwipe", arg0, arg1, arg2)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "RegisterSwipe", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*entities.SwipeQuota)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // RegisterSwipe indicates an expected call of RegisterSwipe.
-func (mr *MockSwipeRegisterMockRecorder) RegisterSwipe(arg0, arg1, arg2 any) *gomock.Call {
+func (mr *MockSwipeRegisterMockRecorder) RegisterSwipe(arg0, arg1, arg2, arg3 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterSwipe", reflect.TypeOf((*MockSwipeRegister)(nil).RegisterSwipe), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterSwipe", reflect.TypeOf((*MockSwipeRegister)(nil).RegisterSwipe), arg0, arg1, arg2, arg3)
 }
@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/AlecSmith96/dating-api/internal/usecases (interfaces: SwipeRewinder)
+//
+// Generated by this command:
+//
+//	mockgen --build_flags=--mod=mod -destination=../../mocks/swipeRewinder.go . SwipeRewinder
+//
+// Package mock_usecases is a generated GoMock package.
+package mock_usecases
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	entities "github.com/AlecSmith96/dating-api/internal/entities"
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSwipeRewinder is a mock of SwipeRewinder interface.
+type MockSwipeRewinder struct {
+	ctrl     *gomock.Controller
+	recorder *MockSwipeRewinderMockRecorder
+}
+
+// MockSwipeRewinderMockRecorder is the mock recorder for MockSwipeRewinder.
+type MockSwipeRewinderMockRecorder struct {
+	mock *MockSwipeRewinder
+}
+
+// NewMockSwipeRewinder creates a new mock instance.
+func NewMockSwipeRewinder(ctrl *gomock.Controller) *MockSwipeRewinder {
+	mock := &MockSwipeRewinder{ctrl: ctrl}
+	mock.recorder = &MockSwipeRewinderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSwipeRewinder) EXPECT() *MockSwipeRewinderMockRecorder {
+	return m.recorder
+}
+
+// RewindLastSwipe mocks base method.
+func (m *MockSwipeRewinder) RewindLastSwipe(arg0 context.Context, arg1 uuid.UUID, arg2 time.Duration) (*entities.Swipe, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RewindLastSwipe", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*entities.Swipe)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RewindLastSwipe indicates an expected call of RewindLastSwipe.
+func (mr *MockSwipeRewinderMockRecorder) RewindLastSwipe(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RewindLastSwipe", reflect.TypeOf((*MockSwipeRewinder)(nil).RewindLastSwipe), arg0, arg1, arg2)
+}
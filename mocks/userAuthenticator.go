@@ -9,6 +9,7 @@
 package mock_usecases
 
 import (
+	context "context"
 	reflect "reflect"
 
 	entities "github.com/AlecSmith96/dating-api/internal/entities"
@@ -40,31 +41,31 @@ func (m *MockUserAuthenticator) EXPECT() *MockUserAuthenticatorMockRecorder {
 }
 
 // IssueJWT mocks base method.
-func (m *MockUserAuthenticator) IssueJWT(arg0 uuid.UUID) (*entities.Token, error) {
+func (m *MockUserAuthenticator) IssueJWT(arg0 context.Context, arg1 uuid.UUID) (*entities.Token, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "IssueJWT", arg0)
+	ret := m.ctrl.Call(m, "IssueJWT", arg0, arg1)
 	ret0, _ := ret[0].(*entities.Token)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // IssueJWT indicates an expected call of IssueJWT.
-func (mr *MockUserAuthenticatorMockRecorder) IssueJWT(arg0 any) *gomock.Call {
+func (mr *MockUserAuthenticatorMockRecorder) IssueJWT(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IssueJWT", reflect.TypeOf((*MockUserAuthenticator)(nil).IssueJWT), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IssueJWT", reflect.TypeOf((*MockUserAuthenticator)(nil).IssueJWT), arg0, arg1)
 }
 
 // LoginUser mocks base method.
-func (m *MockUserAuthenticator) LoginUser(arg0, arg1 string) (*entities.User, error) {
+func (m *MockUserAuthenticator) LoginUser(arg0 context.Context, arg1, arg2 string) (*entities.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "LoginUser", arg0, arg1)
+	ret := m.ctrl.Call(m, "LoginUser", arg0, arg1, arg2)
 	ret0, _ := ret[0].(*entities.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // LoginUser indicates an expected call of LoginUser.
-func (mr *MockUserAuthenticatorMockRecorder) LoginUser(arg0, arg1 any) *gomock.Call {
+func (mr *MockUserAuthenticatorMockRecorder) LoginUser(arg0, arg1, arg2 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoginUser", reflect.TypeOf((*MockUserAuthenticator)(nil).LoginUser), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoginUser", reflect.TypeOf((*MockUserAuthenticator)(nil).LoginUser), arg0, arg1, arg2)
 }
@@ -0,0 +1,54 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/AlecSmith96/dating-api/internal/usecases (interfaces: UserBlocker)
+//
+// Generated by this command:
+//
+//	mockgen --build_flags=--mod=mod -destination=../../mocks/userBlocker.go . UserBlocker
+//
+// Package mock_usecases is a generated GoMock package.
+package mock_usecases
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockUserBlocker is a mock of UserBlocker interface.
+type MockUserBlocker struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserBlockerMockRecorder
+}
+
+// MockUserBlockerMockRecorder is the mock recorder for MockUserBlocker.
+type MockUserBlockerMockRecorder struct {
+	mock *MockUserBlocker
+}
+
+// NewMockUserBlocker creates a new mock instance.
+func NewMockUserBlocker(ctrl *gomock.Controller) *MockUserBlocker {
+	mock := &MockUserBlocker{ctrl: ctrl}
+	mock.recorder = &MockUserBlockerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserBlocker) EXPECT() *MockUserBlockerMockRecorder {
+	return m.recorder
+}
+
+// BlockUser mocks base method.
+func (m *MockUserBlocker) BlockUser(arg0 context.Context, arg1, arg2 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BlockUser", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BlockUser indicates an expected call of BlockUser.
+func (mr *MockUserBlockerMockRecorder) BlockUser(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockUser", reflect.TypeOf((*MockUserBlocker)(nil).BlockUser), arg0, arg1, arg2)
+}
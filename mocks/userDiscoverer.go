@@ -9,7 +9,9 @@
 package mock_usecases
 
 import (
+	context "context"
 	reflect "reflect"
+	time "time"
 
 	entities "github.com/AlecSmith96/dating-api/internal/entities"
 	uuid "github.com/google/uuid"
@@ -40,31 +42,105 @@ func (m *MockUserDiscoverer) EXPECT() *MockUserDiscovererMockRecorder {
 }
 
 // DiscoverNewUsers mocks base method.
-func (m *MockUserDiscoverer) DiscoverNewUsers(arg0 uuid.UUID, arg1 entities.PageInfo) ([]entities.UserDiscovery, error) {
+func (m *MockUserDiscoverer) DiscoverNewUsers(arg0 context.Context, arg1 uuid.UUID, arg2 entities.PageInfo) ([]entities.UserDiscovery, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DiscoverNewUsers", arg0, arg1)
+	ret := m.ctrl.Call(m, "DiscoverNewUsers", arg0, arg1, arg2)
 	ret0, _ := ret[0].([]entities.UserDiscovery)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // DiscoverNewUsers indicates an expected call of DiscoverNewUsers.
-func (mr *MockUserDiscovererMockRecorder) DiscoverNewUsers(arg0, arg1 any) *gomock.Call {
+func (mr *MockUserDiscovererMockRecorder) DiscoverNewUsers(arg0, arg1, arg2 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiscoverNewUsers", reflect.TypeOf((*MockUserDiscoverer)(nil).DiscoverNewUsers), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiscoverNewUsers", reflect.TypeOf((*MockUserDiscoverer)(nil).DiscoverNewUsers), arg0, arg1, arg2)
+}
+
+// GetAnswersForUsers mocks base method.
+func (m *MockUserDiscoverer) GetAnswersForUsers(arg0 context.Context, arg1 []uuid.UUID) (map[uuid.UUID][]entities.UserAnswer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAnswersForUsers", arg0, arg1)
+	ret0, _ := ret[0].(map[uuid.UUID][]entities.UserAnswer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAnswersForUsers indicates an expected call of GetAnswersForUsers.
+func (mr *MockUserDiscovererMockRecorder) GetAnswersForUsers(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAnswersForUsers", reflect.TypeOf((*MockUserDiscoverer)(nil).GetAnswersForUsers), arg0, arg1)
+}
+
+// GetDesirability mocks base method.
+func (m *MockUserDiscoverer) GetDesirability(arg0 context.Context, arg1 uuid.UUID) (float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDesirability", arg0, arg1)
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDesirability indicates an expected call of GetDesirability.
+func (mr *MockUserDiscovererMockRecorder) GetDesirability(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDesirability", reflect.TypeOf((*MockUserDiscoverer)(nil).GetDesirability), arg0, arg1)
+}
+
+// GetDiscoverySnapshot mocks base method.
+func (m *MockUserDiscoverer) GetDiscoverySnapshot(arg0 context.Context, arg1, arg2 uuid.UUID, arg3 time.Duration) (*entities.DiscoverySnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDiscoverySnapshot", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*entities.DiscoverySnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDiscoverySnapshot indicates an expected call of GetDiscoverySnapshot.
+func (mr *MockUserDiscovererMockRecorder) GetDiscoverySnapshot(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDiscoverySnapshot", reflect.TypeOf((*MockUserDiscoverer)(nil).GetDiscoverySnapshot), arg0, arg1, arg2, arg3)
 }
 
 // GetUsersLocation mocks base method.
-func (m *MockUserDiscoverer) GetUsersLocation(arg0 uuid.UUID) (*entities.Location, error) {
+func (m *MockUserDiscoverer) GetUsersLocation(arg0 context.Context, arg1 uuid.UUID) (*entities.Location, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUsersLocation", arg0)
+	ret := m.ctrl.Call(m, "GetUsersLocation", arg0, arg1)
 	ret0, _ := ret[0].(*entities.Location)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetUsersLocation indicates an expected call of GetUsersLocation.
-func (mr *MockUserDiscovererMockRecorder) GetUsersLocation(arg0 any) *gomock.Call {
+func (mr *MockUserDiscovererMockRecorder) GetUsersLocation(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersLocation", reflect.TypeOf((*MockUserDiscoverer)(nil).GetUsersLocation), arg0, arg1)
+}
+
+// RecordProfileViews mocks base method.
+func (m *MockUserDiscoverer) RecordProfileViews(arg0 context.Context, arg1 []uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordProfileViews", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordProfileViews indicates an expected call of RecordProfileViews.
+func (mr *MockUserDiscovererMockRecorder) RecordProfileViews(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordProfileViews", reflect.TypeOf((*MockUserDiscoverer)(nil).RecordProfileViews), arg0, arg1)
+}
+
+// SaveDiscoverySnapshot mocks base method.
+func (m *MockUserDiscoverer) SaveDiscoverySnapshot(arg0 context.Context, arg1 uuid.UUID, arg2 []uuid.UUID) (*entities.DiscoverySnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveDiscoverySnapshot", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*entities.DiscoverySnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveDiscoverySnapshot indicates an expected call of SaveDiscoverySnapshot.
+func (mr *MockUserDiscovererMockRecorder) SaveDiscoverySnapshot(arg0, arg1, arg2 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersLocation", reflect.TypeOf((*MockUserDiscoverer)(nil).GetUsersLocation), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveDiscoverySnapshot", reflect.TypeOf((*MockUserDiscoverer)(nil).SaveDiscoverySnapshot), arg0, arg1, arg2)
 }
@@ -0,0 +1,55 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/AlecSmith96/dating-api/internal/usecases (interfaces: UserReporter)
+//
+// Generated by this command:
+//
+//	mockgen --build_flags=--mod=mod -destination=../../mocks/userReporter.go . UserReporter
+//
+// Package mock_usecases is a generated GoMock package.
+package mock_usecases
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entities "github.com/AlecSmith96/dating-api/internal/entities"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockUserReporter is a mock of UserReporter interface.
+type MockUserReporter struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserReporterMockRecorder
+}
+
+// MockUserReporterMockRecorder is the mock recorder for MockUserReporter.
+type MockUserReporterMockRecorder struct {
+	mock *MockUserReporter
+}
+
+// NewMockUserReporter creates a new mock instance.
+func NewMockUserReporter(ctrl *gomock.Controller) *MockUserReporter {
+	mock := &MockUserReporter{ctrl: ctrl}
+	mock.recorder = &MockUserReporterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserReporter) EXPECT() *MockUserReporterMockRecorder {
+	return m.recorder
+}
+
+// ReportUser mocks base method.
+func (m *MockUserReporter) ReportUser(arg0 context.Context, arg1 *entities.Report) (*entities.Report, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReportUser", arg0, arg1)
+	ret0, _ := ret[0].(*entities.Report)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReportUser indicates an expected call of ReportUser.
+func (mr *MockUserReporterMockRecorder) ReportUser(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReportUser", reflect.TypeOf((*MockUserReporter)(nil).ReportUser), arg0, arg1)
+}